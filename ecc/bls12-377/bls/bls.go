@@ -0,0 +1,225 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls
+
+import (
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+const (
+	sizeFr         = fr.Bytes
+	sizePublicKey  = bls12377.SizeOfG1AffineCompressed
+	sizePrivateKey = sizeFr + sizePublicKey
+	sizeSignature  = bls12377.SizeOfG2AffineCompressed
+)
+
+// DST is the domain separation tag used to hash messages to G2.
+const DST = "BLS_SIG_BLS12377G2_XMD:SHA-256_SSWU_RO_NUL_"
+
+var (
+	errNoSignatures      = errors.New("nothing to aggregate")
+	errInvalidPublicKey  = errors.New("public key is the point at infinity")
+	errDuplicatedMessage = errors.New("aggregate signatures require distinct messages")
+)
+
+var order = fr.Modulus()
+
+// PublicKey represents a BLS public key
+type PublicKey struct {
+	A bls12377.G1Affine
+}
+
+// PrivateKey represents a BLS private key
+type PrivateKey struct {
+	PublicKey PublicKey
+	scalar    [sizeFr]byte // secret scalar, in big Endian
+}
+
+// Signature represents a BLS signature
+type Signature struct {
+	S bls12377.G2Affine
+}
+
+var one = new(big.Int).SetInt64(1)
+
+// randFieldElement returns a random element of the order of the given
+// curve using the procedure given in FIPS 186-4, Appendix B.5.1.
+func randFieldElement(rand io.Reader) (k *big.Int, err error) {
+	b := make([]byte, fr.Bits/8+8)
+	_, err = io.ReadFull(rand, b)
+	if err != nil {
+		return
+	}
+
+	k = new(big.Int).SetBytes(b)
+	n := new(big.Int).Sub(order, one)
+	k.Mod(k, n)
+	k.Add(k, one)
+	return
+}
+
+// GenerateKey generates a public and private key pair.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+
+	k, err := randFieldElement(rand)
+	if err != nil {
+		return nil, err
+
+	}
+
+	privateKey := new(PrivateKey)
+	k.FillBytes(privateKey.scalar[:sizeFr])
+	privateKey.PublicKey.A.ScalarMultiplicationBase(k)
+	return privateKey, nil
+}
+
+// Equal compares 2 public keys
+func (pub *PublicKey) Equal(x signature.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+	bpk := pub.Bytes()
+	bxx := xx.Bytes()
+	return subtle.ConstantTimeCompare(bpk, bxx) == 1
+}
+
+// Public returns the public key associated to the private key.
+func (privKey *PrivateKey) Public() signature.PublicKey {
+	var pub PublicKey
+	pub.A.Set(&privKey.PublicKey.A)
+	return &pub
+}
+
+// hashToG2 maps the message to G2. If hFunc is provided, the message is
+// hashed with hFunc first.
+func hashToG2(message []byte, hFunc hash.Hash) (bls12377.G2Affine, error) {
+	if hFunc != nil {
+		hFunc.Reset()
+		if _, err := hFunc.Write(message); err != nil {
+			return bls12377.G2Affine{}, err
+		}
+		message = hFunc.Sum(nil)
+	}
+	return bls12377.HashToG2(message, []byte(DST))
+}
+
+// Sign performs the BLS signature
+//
+// H = HashToG2(m)
+// S = sk ⋅ H
+//
+// IETF draft, section 2.6
+func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	H, err := hashToG2(message, hFunc)
+	if err != nil {
+		return nil, err
+	}
+	var sig Signature
+	sig.S.ScalarMultiplication(&H, new(big.Int).SetBytes(privKey.scalar[:sizeFr]))
+
+	return sig.Bytes(), nil
+}
+
+// Verify validates the BLS signature
+//
+// e(publicKey, H(m)) ?= e(g1Gen, S)
+//
+// IETF draft, section 2.7
+func (publicKey *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
+
+	// Deserialize the signature
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+	if publicKey.A.IsInfinity() {
+		return false, errInvalidPublicKey
+	}
+
+	H, err := hashToG2(message, hFunc)
+	if err != nil {
+		return false, err
+	}
+
+	_, _, g1Gen, _ := bls12377.Generators()
+	g1Gen.Neg(&g1Gen)
+
+	return bls12377.PairingCheck(
+		[]bls12377.G1Affine{publicKey.A, g1Gen},
+		[]bls12377.G2Affine{H, sig.S},
+	)
+}
+
+// Aggregate aggregates signatures into a single one.
+//
+// IETF draft, section 2.8
+func Aggregate(sigsBin [][]byte) ([]byte, error) {
+	if len(sigsBin) == 0 {
+		return nil, errNoSignatures
+	}
+	var acc bls12377.G2Jac
+	for i := range sigsBin {
+		var sig Signature
+		if _, err := sig.SetBytes(sigsBin[i]); err != nil {
+			return nil, err
+		}
+		acc.AddMixed(&sig.S)
+	}
+	var res Signature
+	res.S.FromJacobian(&acc)
+	return res.Bytes(), nil
+}
+
+// AggregateVerify validates an aggregate signature of the messages
+// messages[i] signed by publicKeys[i]. The messages must be distinct.
+//
+// ∏ᵢ e(publicKeyᵢ, H(mᵢ)) ?= e(g1Gen, S)
+//
+// IETF draft, sections 2.9 and 3.1.1
+func AggregateVerify(publicKeys []*PublicKey, messages [][]byte, sigBin []byte, hFunc hash.Hash) (bool, error) {
+	if len(publicKeys) == 0 || len(publicKeys) != len(messages) {
+		return false, errors.New("public keys and messages must have the same non-zero length")
+	}
+
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+
+	seen := make(map[string]struct{}, len(messages))
+	P := make([]bls12377.G1Affine, len(publicKeys)+1)
+	Q := make([]bls12377.G2Affine, len(publicKeys)+1)
+	for i := range publicKeys {
+		if _, ok := seen[string(messages[i])]; ok {
+			return false, errDuplicatedMessage
+		}
+		seen[string(messages[i])] = struct{}{}
+
+		if publicKeys[i].A.IsInfinity() {
+			return false, errInvalidPublicKey
+		}
+		P[i].Set(&publicKeys[i].A)
+		H, err := hashToG2(messages[i], hFunc)
+		if err != nil {
+			return false, err
+		}
+		Q[i] = H
+	}
+	_, _, g1Gen, _ := bls12377.Generators()
+	P[len(publicKeys)].Neg(&g1Gen)
+	Q[len(publicKeys)] = sig.S
+
+	return bls12377.PairingCheck(P, Q)
+}
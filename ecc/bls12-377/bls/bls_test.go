@@ -0,0 +1,135 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestBLS(t *testing.T) {
+
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-377] test the signing and verification", prop.ForAll(
+		func() bool {
+
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing BLS")
+			hFunc := sha256.New()
+			sig, _ := privKey.Sign(msg, hFunc)
+			flag, _ := publicKey.Verify(sig, msg, hFunc)
+
+			return flag
+		},
+	))
+
+	properties.Property("[BLS12-377] test the signing and verification (pre-hashed)", prop.ForAll(
+		func() bool {
+
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing BLS")
+			sig, _ := privKey.Sign(msg, nil)
+			flag, _ := publicKey.Verify(sig, msg, nil)
+
+			return flag
+		},
+	))
+
+	properties.Property("[BLS12-377] a signature should not verify for another message", prop.ForAll(
+		func() bool {
+
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			sig, _ := privKey.Sign([]byte("testing BLS"), nil)
+			flag, _ := publicKey.Verify(sig, []byte("testing BLS!"), nil)
+
+			return !flag
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+
+	const n = 3
+	publicKeys := make([]*PublicKey, n)
+	messages := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		privKey, err := GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		publicKeys[i] = &privKey.PublicKey
+		messages[i] = []byte{byte(i)}
+		if sigs[i], err = privKey.Sign(messages[i], nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	agg, err := Aggregate(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := AggregateVerify(publicKeys, messages, agg, nil); err != nil || !ok {
+		t.Fatal("valid aggregate signature rejected")
+	}
+	if ok, _ := AggregateVerify(publicKeys[1:], messages[1:], agg, nil); ok {
+		t.Fatal("aggregate signature accepted without a signer")
+	}
+	messages[1] = messages[0]
+	if _, err := AggregateVerify(publicKeys, messages, agg, nil); err != errDuplicatedMessage {
+		t.Fatal("aggregate signature accepted over duplicated messages")
+	}
+	if _, err := Aggregate(nil); err != errNoSignatures {
+		t.Fatal("empty aggregate accepted")
+	}
+}
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkSignBLS(b *testing.B) {
+
+	privKey, _ := GenerateKey(rand.Reader)
+
+	msg := []byte("benchmarking BLS sign()")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.Sign(msg, nil)
+	}
+}
+
+func BenchmarkVerifyBLS(b *testing.B) {
+
+	privKey, _ := GenerateKey(rand.Reader)
+	msg := []byte("benchmarking BLS sign()")
+	sig, _ := privKey.Sign(msg, nil)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.PublicKey.Verify(sig, msg, nil)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+const (
+	nbFuzzShort = 2
+	nbFuzz      = 10
+)
+
+func TestSerialization(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-377] BLS serialization: SetBytes(Bytes()) should stay the same", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+
+			var end PrivateKey
+			buf := privKey.Bytes()
+			n, err := end.SetBytes(buf[:])
+			if err != nil {
+				return false
+			}
+			if n != sizePrivateKey {
+				return false
+			}
+
+			return end.PublicKey.Equal(&privKey.PublicKey) && subtle.ConstantTimeCompare(end.scalar[:], privKey.scalar[:]) == 1
+
+		},
+	))
+
+	properties.Property("[BLS12-377] BLS serialization: signature SetBytes(Bytes()) should stay the same", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			sigBin, _ := privKey.Sign([]byte("testing BLS"), nil)
+
+			var sig Signature
+			n, err := sig.SetBytes(sigBin)
+			if err != nil || n != sizeSignature {
+				return false
+			}
+			return subtle.ConstantTimeCompare(sig.Bytes(), sigBin) == 1
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestPrivateKeyMismatch(t *testing.T) {
+	privKey1, _ := GenerateKey(rand.Reader)
+	privKey2, _ := GenerateKey(rand.Reader)
+
+	buf := privKey1.Bytes()
+	copy(buf[:sizePublicKey], privKey2.PublicKey.Bytes())
+
+	var end PrivateKey
+	if _, err := end.SetBytes(buf); err != errPublicKeyMismatch {
+		t.Fatal("private key accepted with another public key")
+	}
+}
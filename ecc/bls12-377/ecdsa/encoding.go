@@ -0,0 +1,352 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"golang.org/x/crypto/cryptobyte"
+	cbasn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// SEC 1 point encodings
+const (
+	sec1Compressed       = 0x02
+	sec1CompressedOdd    = 0x03
+	sec1Uncompressed     = 0x04
+	sizeSEC1Compressed   = 1 + sizeFp
+	sizeSEC1Uncompressed = 1 + 2*sizeFp
+
+	pemTypePrivateKey = "PRIVATE KEY"
+)
+
+var (
+	errInvalidASN1       = errors.New("invalid ASN.1 signature")
+	errInvalidSEC1       = errors.New("invalid SEC 1 point encoding")
+	errInvalidPKCS8      = errors.New("invalid PKCS #8 private key")
+	errCurveMismatch     = errors.New("private key is not on the bls12-377 curve")
+	errInvalidPEM        = errors.New("no PEM private key found")
+	errInvalidPrivateKey = errors.New("private key must be a non-zero scalar smaller than the group order")
+)
+
+var (
+	// id-ecPublicKey, RFC 5480
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	// prime-field, SEC 1 Version 2.0, C.1
+	oidPrimeField = asn1.ObjectIdentifier{1, 2, 840, 10045, 1, 1}
+)
+
+// MarshalASN1 returns the ASN.1 DER encoding of the signature
+//
+//	Ecdsa-Sig-Value ::= SEQUENCE { r INTEGER, s INTEGER }
+//
+// SEC 1, Version 2.0, Section C.8
+func (sig *Signature) MarshalASN1() ([]byte, error) {
+	var b cryptobyte.Builder
+	b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1BigInt(new(big.Int).SetBytes(sig.R[:sizeFr]))
+		b.AddASN1BigInt(new(big.Int).SetBytes(sig.S[:sizeFr]))
+	})
+	return b.Bytes()
+}
+
+// UnmarshalASN1 sets sig from its ASN.1 DER encoding. It rejects non
+// canonical encodings, and checks that r and s are in [1, order-1].
+func (sig *Signature) UnmarshalASN1(der []byte) error {
+	var r, s big.Int
+	input := cryptobyte.String(der)
+	var inner cryptobyte.String
+	if !input.ReadASN1(&inner, cbasn1.SEQUENCE) ||
+		!input.Empty() ||
+		!inner.ReadASN1Integer(&r) ||
+		!inner.ReadASN1Integer(&s) ||
+		!inner.Empty() {
+		return errInvalidASN1
+	}
+	order := fr.Modulus()
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(order) >= 0 || s.Cmp(order) >= 0 {
+		return errInvalidASN1
+	}
+	var buf [sizeSignature]byte
+	r.FillBytes(buf[:sizeFr])
+	s.FillBytes(buf[sizeFr:])
+	_, err := sig.SetBytes(buf[:])
+	return err
+}
+
+// MarshalSEC1 returns the SEC 1 encoding of the public key: 0x02 or 0x03
+// followed by x if compressed, 0x04 followed by x and y otherwise.
+//
+// SEC 1, Version 2.0, Section 2.3.3
+func (pk *PublicKey) MarshalSEC1(compressed bool) []byte {
+	x := pk.A.X.Bytes()
+	if compressed {
+		res := make([]byte, sizeSEC1Compressed)
+		res[0] = sec1Compressed
+		if pk.A.Y.BigInt(new(big.Int)).Bit(0) == 1 {
+			res[0] = sec1CompressedOdd
+		}
+		copy(res[1:], x[:])
+		return res
+	}
+	y := pk.A.Y.Bytes()
+	res := make([]byte, sizeSEC1Uncompressed)
+	res[0] = sec1Uncompressed
+	copy(res[1:], x[:])
+	copy(res[1+sizeFp:], y[:])
+	return res
+}
+
+// UnmarshalSEC1 sets pk from its compressed or uncompressed SEC 1 encoding.
+// It checks that the point is on the curve, in the prime order subgroup and
+// is not the point at infinity.
+//
+// SEC 1, Version 2.0, Section 2.3.4
+func (pk *PublicKey) UnmarshalSEC1(buf []byte) error {
+	A, err := decodeSEC1(buf)
+	if err != nil {
+		return err
+	}
+	pk.A = A
+	return nil
+}
+
+func decodeSEC1(buf []byte) (bls12377.G1Affine, error) {
+	var p bls12377.G1Affine
+	if len(buf) == 0 {
+		return p, errInvalidSEC1
+	}
+	switch buf[0] {
+	case sec1Compressed, sec1CompressedOdd:
+		if len(buf) != sizeSEC1Compressed {
+			return p, errInvalidSEC1
+		}
+		if err := p.X.SetBytesCanonical(buf[1:]); err != nil {
+			return p, errInvalidSEC1
+		}
+		// y² = x³ + ax + b
+		a, b := bls12377.CurveCoefficients()
+		var y2, ax fp.Element
+		y2.Square(&p.X).Mul(&y2, &p.X).Add(&y2, &b)
+		ax.Mul(&a, &p.X)
+		y2.Add(&y2, &ax)
+		if p.Y.Sqrt(&y2) == nil {
+			return p, errInvalidSEC1
+		}
+		if p.Y.BigInt(new(big.Int)).Bit(0) != uint(buf[0]&1) {
+			p.Y.Neg(&p.Y)
+		}
+	case sec1Uncompressed:
+		if len(buf) != sizeSEC1Uncompressed {
+			return p, errInvalidSEC1
+		}
+		if err := p.X.SetBytesCanonical(buf[1 : 1+sizeFp]); err != nil {
+			return p, errInvalidSEC1
+		}
+		if err := p.Y.SetBytesCanonical(buf[1+sizeFp:]); err != nil {
+			return p, errInvalidSEC1
+		}
+	default:
+		return p, errInvalidSEC1
+	}
+	if p.IsInfinity() || !p.IsOnCurve() || !p.IsInSubGroup() {
+		return p, errInvalidSEC1
+	}
+	return p, nil
+}
+
+// ecPrivateKey is the SEC 1 private key structure, RFC 5915.
+type ecPrivateKey struct {
+	Version    int
+	PrivateKey []byte
+	Parameters asn1.RawValue  `asn1:"optional,explicit,tag:0"`
+	PublicKey  asn1.BitString `asn1:"optional,explicit,tag:1"`
+}
+
+// pkcs8 is the PKCS #8 private key structure, RFC 5208.
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// ecParameters are the explicit parameters of a curve over a prime field,
+// SEC 1 Version 2.0, Section C.2.
+type ecParameters struct {
+	Version  int
+	FieldID  fieldID
+	Curve    curve
+	Base     []byte
+	Order    *big.Int
+	Cofactor *big.Int `asn1:"optional"`
+}
+
+type fieldID struct {
+	FieldType asn1.ObjectIdentifier
+	Prime     *big.Int
+}
+
+type curve struct {
+	A, B []byte
+	Seed asn1.BitString `asn1:"optional"`
+}
+
+// curveParameters returns the DER encoding of the parameters of the curve
+// in the AlgorithmIdentifier of the keys. There is no standard object
+// identifier for the curve, the parameters are explicit (specifiedCurve).
+func curveParameters() ([]byte, error) {
+	return asn1.Marshal(explicitParameters())
+}
+
+// explicitParameters returns the explicit parameters of the curve.
+func explicitParameters() ecParameters {
+	_, _, g, _ := bls12377.Generators()
+	a, b := bls12377.CurveCoefficients()
+	aBin, bBin := a.Bytes(), b.Bytes()
+	return ecParameters{
+		Version: 1,
+		FieldID: fieldID{FieldType: oidPrimeField, Prime: fp.Modulus()},
+		Curve:   curve{A: aBin[:], B: bBin[:]},
+		Base:    (&PublicKey{A: g}).MarshalSEC1(false),
+		Order:   fr.Modulus(),
+	}
+}
+
+// checkCurveParameters checks that the DER encoded parameters of an
+// AlgorithmIdentifier or of a SEC 1 private key designate the curve.
+func checkCurveParameters(der []byte) error {
+	var params ecParameters
+	if rest, err := asn1.Unmarshal(der, &params); err != nil || len(rest) != 0 {
+		return errCurveMismatch
+	}
+	expected := explicitParameters()
+	if !params.FieldID.FieldType.Equal(oidPrimeField) ||
+		params.FieldID.Prime.Cmp(expected.FieldID.Prime) != 0 ||
+		params.Order.Cmp(expected.Order) != 0 ||
+		new(big.Int).SetBytes(params.Curve.A).Cmp(new(big.Int).SetBytes(expected.Curve.A)) != 0 ||
+		new(big.Int).SetBytes(params.Curve.B).Cmp(new(big.Int).SetBytes(expected.Curve.B)) != 0 {
+		return errCurveMismatch
+	}
+	base, err := decodeSEC1(params.Base)
+	if err != nil {
+		return errCurveMismatch
+	}
+	var g PublicKey
+	if err := g.UnmarshalSEC1(expected.Base); err != nil || !base.Equal(&g.A) {
+		return errCurveMismatch
+	}
+	return nil
+}
+
+// MarshalPKCS8 returns the PKCS #8 DER encoding of the private key, which
+// embeds the SEC 1 ECPrivateKey structure with the public key.
+//
+// RFC 5208 and RFC 5915
+func (privKey *PrivateKey) MarshalPKCS8() ([]byte, error) {
+	params, err := curveParameters()
+	if err != nil {
+		return nil, err
+	}
+	pub := privKey.PublicKey.MarshalSEC1(false)
+	ecKey, err := asn1.Marshal(ecPrivateKey{
+		Version:    1,
+		PrivateKey: privKey.scalar[sizeFr-sizeRFC6979 : sizeFr],
+		PublicKey:  asn1.BitString{Bytes: pub, BitLength: 8 * len(pub)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs8{
+		Algo: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		PrivateKey: ecKey,
+	})
+}
+
+// ParsePKCS8PrivateKey parses a PKCS #8 DER encoded private key. The curve
+// parameters must designate the bls12-377 curve. If the encoding contains
+// the public key, it must match the private scalar.
+func ParsePKCS8PrivateKey(der []byte) (*PrivateKey, error) {
+	var key pkcs8
+	if rest, err := asn1.Unmarshal(der, &key); err != nil || len(rest) != 0 {
+		return nil, errInvalidPKCS8
+	}
+	if !key.Algo.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, errInvalidPKCS8
+	}
+	if err := checkCurveParameters(key.Algo.Parameters.FullBytes); err != nil {
+		return nil, err
+	}
+
+	var ecKey ecPrivateKey
+	if rest, err := asn1.Unmarshal(key.PrivateKey, &ecKey); err != nil || len(rest) != 0 || ecKey.Version != 1 {
+		return nil, errInvalidPKCS8
+	}
+	if len(ecKey.Parameters.FullBytes) != 0 {
+		if err := checkCurveParameters(ecKey.Parameters.Bytes); err != nil {
+			return nil, err
+		}
+	}
+	if len(ecKey.PrivateKey) > sizeFr {
+		return nil, errInvalidPrivateKey
+	}
+	scalar := new(big.Int).SetBytes(ecKey.PrivateKey)
+	if scalar.Sign() == 0 || scalar.Cmp(order) >= 0 {
+		return nil, errInvalidPrivateKey
+	}
+
+	privKey := new(PrivateKey)
+	scalar.FillBytes(privKey.scalar[:sizeFr])
+	privKey.PublicKey.A.ScalarMultiplicationBase(scalar)
+	if len(ecKey.PublicKey.Bytes) != 0 {
+		var pub PublicKey
+		if err := pub.UnmarshalSEC1(ecKey.PublicKey.RightAlign()); err != nil {
+			return nil, err
+		}
+		if !pub.A.Equal(&privKey.PublicKey.A) {
+			return nil, errors.New("public key does not match the private key")
+		}
+	}
+	return privKey, nil
+}
+
+// MarshalPEM returns the PEM encoding of the PKCS #8 private key, with the
+// "PRIVATE KEY" type.
+func (privKey *PrivateKey) MarshalPEM() ([]byte, error) {
+	der, err := privKey.MarshalPKCS8()
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: der}), nil
+}
+
+// ParsePEMPrivateKey parses the first "PRIVATE KEY" PEM block of data as a
+// PKCS #8 private key.
+func ParsePEMPrivateKey(data []byte) (*PrivateKey, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, errInvalidPEM
+		}
+		if block.Type == pemTypePrivateKey {
+			return ParsePKCS8PrivateKey(block.Bytes)
+		}
+		if len(bytes.TrimSpace(data)) == 0 {
+			return nil, errInvalidPEM
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"errors"
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/internal/rfc6979"
+)
+
+// size in bytes of the integers of RFC 6979, rlen/8 = ceil(qlen/8)
+const sizeRFC6979 = (sizeFrBits + 7) / 8
+
+// SignDeterministic performs the ECDSA signature with the deterministic
+// nonce of RFC 6979. The message is hashed with newHash, which also
+// instantiates the HMAC_DRBG deriving the nonce from the private key and the
+// message hash.
+//
+// Unlike Sign, two signatures of the same message are identical, and can be
+// reproduced by other RFC 6979 implementations.
+//
+// RFC 6979, Section 3.2
+func (privKey *PrivateKey) SignDeterministic(message []byte, newHash func() hash.Hash) ([]byte, error) {
+	if newHash == nil {
+		return nil, errors.New("RFC 6979 requires a hash function")
+	}
+	h := newHash()
+	if _, err := h.Write(message); err != nil {
+		return nil, err
+	}
+	h1 := h.Sum(nil)
+	// the message is mapped to an integer as in Sign and Verify
+	m := HashToInt(h1)
+
+	scalar, r, s, kInv := new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+	scalar.SetBytes(privKey.scalar[:sizeFr])
+	drbg := rfc6979.New(newHash, order, scalar, h1)
+	for {
+		k := drbg.Next()
+
+		var P bls12377.G1Affine
+		P.ScalarMultiplicationBase(k)
+		P.X.BigInt(r)
+		r.Mod(r, order)
+		if r.Sign() == 0 {
+			continue
+		}
+		kInv.ModInverse(k, order)
+		s.Mul(r, scalar).
+			Add(m, s).
+			Mul(kInv, s).
+			Mod(s, order)
+		if s.Sign() != 0 {
+			break
+		}
+	}
+
+	var sig Signature
+	r.FillBytes(sig.R[:sizeFr])
+	s.FillBytes(sig.S[:sizeFr])
+	return sig.Bytes(), nil
+}
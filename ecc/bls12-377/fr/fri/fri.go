@@ -7,6 +7,7 @@ package fri
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"hash"
@@ -18,32 +19,109 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
 	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
 var (
-	ErrLowDegree            = errors.New("the fully folded polynomial in not of degree 1")
+	ErrLowDegree            = errors.New("the polynomial is not of the expected degree")
 	ErrProximityTestFolding = errors.New("one round of interaction failed")
 	ErrOddSize              = errors.New("the size should be even")
 	ErrMerkleRoot           = errors.New("merkle roots of the opening and the proof of proximity don't coincide")
 	ErrMerklePath           = errors.New("merkle path proof is wrong")
 	ErrRangePosition        = errors.New("the asked opening position is out of range")
+	ErrInvalidParameters    = errors.New("invalid FRI parameters")
+	ErrProofShape           = errors.New("the proof does not have the expected number of layers or queries")
+	ErrProofOfWork          = errors.New("the proof of work is invalid")
+	ErrClaimedValue         = errors.New("the claimed value does not match the opened leaf")
 )
 
+// rho is the default blow-up factor
 const rho = 8
 
-const nbRounds = 1
-
-// 2^{-1}, used several times
-var twoInv fr.Element
-
 // Digest commitment of a polynomial.
 type Digest []byte
 
+// Parameters are the parameters of the FRI protocol, trading the size of the
+// proofs against the time of the prover.
+type Parameters struct {
+
+	// Rate is the blow-up factor ρ = size_code_word/size_polynomial, a power
+	// of 2 larger than 1.
+	Rate int
+
+	// NbQueries is the number of queries of the verifier, see
+	// NbQueriesForSecurity.
+	NbQueries int
+
+	// FoldingArity is the number of evaluations folded into one at each
+	// step, 2, 4, 8 or 16. The oracles are committed to with one Merkle leaf
+	// per fiber of x ↦ x^FoldingArity, so that a query opens a single Merkle
+	// path per step.
+	FoldingArity int
+
+	// FinalPolynomialSize is a power of 2: the folding stops as soon as the
+	// folded polynomial has at most FinalPolynomialSize coefficients, which
+	// are sent in the clear instead of being committed to.
+	FinalPolynomialSize int
+
+	// GrindingBits is the number of leading zero bits of the proof of work
+	// computed by the prover before the queries are sampled. Each bit of
+	// grinding adds a bit of security for the same number of queries.
+	GrindingBits int
+}
+
+// DefaultParameters returns the parameters of IOPP.New: ρ = 8, folding by 2
+// down to a constant polynomial, no grinding, and a single query. The number
+// of queries should be set to the target security level, with
+// NbQueriesForSecurity.
+func DefaultParameters() Parameters {
+	return Parameters{
+		Rate:                rho,
+		NbQueries:           1,
+		FoldingArity:        2,
+		FinalPolynomialSize: 1,
+	}
+}
+
+// NbQueriesForSecurity returns the number of queries achieving securityBits
+// bits of security with the blow-up factor rate and grindingBits bits of
+// proof of work, ⌈(securityBits - grindingBits)/log₂(rate)⌉, under the
+// conjecture that each query adds log₂(rate) bits of security.
+func NbQueriesForSecurity(securityBits, rate, grindingBits int) int {
+	logRate := bits.TrailingZeros(uint(rate))
+	if logRate == 0 || securityBits <= grindingBits {
+		return 1
+	}
+	return (securityBits - grindingBits + logRate - 1) / logRate
+}
+
+// check returns an error if the parameters are not supported.
+func (p Parameters) check() error {
+	if p.Rate < 2 || bits.OnesCount(uint(p.Rate)) != 1 {
+		return fmt.Errorf("%w: rate %d is not a power of 2 larger than 1", ErrInvalidParameters, p.Rate)
+	}
+	if p.NbQueries < 1 {
+		return fmt.Errorf("%w: %d queries", ErrInvalidParameters, p.NbQueries)
+	}
+	switch p.FoldingArity {
+	case 2, 4, 8, 16:
+	default:
+		return fmt.Errorf("%w: folding arity %d", ErrInvalidParameters, p.FoldingArity)
+	}
+	if p.FinalPolynomialSize < 1 || bits.OnesCount(uint(p.FinalPolynomialSize)) != 1 {
+		return fmt.Errorf("%w: final polynomial size %d is not a power of 2", ErrInvalidParameters, p.FinalPolynomialSize)
+	}
+	if p.GrindingBits < 0 || p.GrindingBits > 32 {
+		return fmt.Errorf("%w: %d grinding bits", ErrInvalidParameters, p.GrindingBits)
+	}
+	return nil
+}
+
 // merkleProof helper structure to build the merkle proof
-// At each round, two contiguous values from the evaluated polynomial
-// are queried. For one value, the full Merkle path will be provided.
-// For the neighbor value, only the leaf is provided (so ProofSet will
-// be empty), since the Merkle path is the same as for the first value.
+// At each step, the leaf containing the query is opened. The leaf is the
+// concatenation of the evaluations of the oracle on the fiber of the query
+// for the folding map, so that a single Merkle path opens all the values
+// needed to fold.
 type MerkleProof struct {
 
 	// Merkle root
@@ -78,38 +156,26 @@ type OpeningProof struct {
 type IOPP uint
 
 const (
-	// Multiplicative version of FRI, using the map x->x², on a
-	// power of 2 subgroup of Fr^{*}.
+	// Multiplicative version of FRI, using the map x->xᵏ, on a
+	// power of 2 subgroup of Fr^{*}, where k is the folding arity.
 	RADIX_2_FRI IOPP = iota
 )
 
-// round contains the data corresponding to a single round
-// of fri.
-// It consists of a list of Interactions between the prover and the verifier,
-// where each interaction contains a challenge provided by the verifier, as
-// well as MerkleProofs for the queries of the verifier. The Merkle proofs
-// correspond to the openings of the i-th folded polynomial at 2 points that
-// belong to the same fiber of x -> x².
+// Round contains the openings of the oracles for a single query of the
+// verifier.
 type Round struct {
 
-	// stores the Interactions between the prover and the verifier.
-	// Each interaction results in a set or merkle proofs, corresponding
-	// to the queries of the verifier.
-	Interactions [][2]MerkleProof
-
-	// evaluation stores the evaluation of the fully folded polynomial.
-	// The fully folded polynomial is constant, and is evaluated on a
-	// a set of size \rho. Since the polynomial is supposed to be constant,
-	// only one evaluation, corresponding to the polynomial, is given. Since
-	// the prover cannot know in advance which entry the verifier will query,
-	// providing a single evaluation
-	Evaluation fr.Element
+	// Interactions[i] is the Merkle proof of the leaf of the i-th oracle
+	// containing the query, whose data is the fiber of the query.
+	Interactions []MerkleProof
 }
 
 // ProofOfProximity proof of proximity, attesting that
 // a function is d-close to a low degree polynomial.
 //
 // It is composed of a series of Interactions, emulated with Fiat Shamir,
+//
+// implements io.ReaderFrom and io.WriterTo
 type ProofOfProximity struct {
 
 	// ID unique ID attached to the proof of proximity. It's needed for
@@ -117,9 +183,18 @@ type ProofOfProximity struct {
 	// from the proof of proximity.
 	ID []byte
 
-	// round contains the data corresponding to a single round
-	// of fri. There are nbRounds rounds of Interactions.
+	// Roots[i] is the Merkle root of the i-th oracle, the first one being the
+	// evaluations of the polynomial on the domain.
+	Roots [][]byte
+
+	// Rounds[q] contains the openings of the q-th query.
 	Rounds []Round
+
+	// FinalPolynomial is the fully folded polynomial, in canonical form.
+	FinalPolynomial []fr.Element
+
+	// Nonce is the proof of work of the prover.
+	Nonce uint64
 }
 
 // Iopp interface that an iopp should implement
@@ -138,24 +213,42 @@ type Iopp interface {
 
 	// Verifies the opening of a polynomial at gⁱ where i = position.
 	VerifyOpening(position uint64, openingProof OpeningProof, pp ProofOfProximity) error
+
+	// CommitMatrix commits to polynomials of possibly different sizes with a
+	// single Merkle tree.
+	CommitMatrix(polynomials [][]fr.Element) (*Matrix, error)
+
+	// BatchOpen proves the degree bounds and the evaluations at out of domain
+	// points of the polynomials of several matrices, with a single FRI.
+	BatchOpen(matrices []*Matrix, shifts []fr.Element, dataTranscript ...[]byte) (BatchOpeningProof, error)
+
+	// VerifyBatchOpening verifies a proof of BatchOpen.
+	VerifyBatchOpening(commitments []MatrixCommitment, shifts []fr.Element, proof BatchOpeningProof, dataTranscript ...[]byte) error
 }
 
-// GetRho returns the factor ρ = size_code_word/size_polynomial
+// GetRho returns the default factor ρ = size_code_word/size_polynomial
 func GetRho() int {
 	return rho
 }
 
-func init() {
-	twoInv.SetUint64(2).Inverse(&twoInv)
+// New creates a new IOPP capable to handle degree(size) polynomials, with the
+// DefaultParameters.
+func (iopp IOPP) New(size uint64, h hash.Hash) Iopp {
+	res, err := iopp.NewWithParameters(size, h, DefaultParameters())
+	if err != nil {
+		panic(err)
+	}
+	return res
 }
 
-// New creates a new IOPP capable to handle degree(size) polynomials.
-func (iopp IOPP) New(size uint64, h hash.Hash) Iopp {
+// NewWithParameters creates a new IOPP capable to handle degree(size)
+// polynomials, with the given parameters.
+func (iopp IOPP) NewWithParameters(size uint64, h hash.Hash, params Parameters) (Iopp, error) {
 	switch iopp {
 	case RADIX_2_FRI:
-		return newRadixTwoFri(size, h)
+		return newRadixTwoFri(size, h, params)
 	default:
-		panic("iopp name is not recognized")
+		return nil, errors.New("iopp name is not recognized")
 	}
 }
 
@@ -167,82 +260,267 @@ type radixTwoFri struct {
 	// the oracles.
 	h hash.Hash
 
-	// nbSteps number of Interactions between the prover and the verifier
-	nbSteps int
+	params Parameters
+
+	// arities[i] is the folding arity of the i-th step. The last arity may be
+	// smaller than params.FoldingArity to stop at the final size, and it is 1
+	// when the polynomial is not folded at all, its evaluations being still
+	// committed to.
+	arities []int
+
+	// finalSize size of the final polynomial
+	finalSize int
 
 	// domain used to build the Reed Solomon code from the given polynomial.
 	// The size of the domain is ρ*size_polynomial.
 	domain *fft.Domain
 }
 
-func newRadixTwoFri(size uint64, h hash.Hash) radixTwoFri {
+func newRadixTwoFri(size uint64, h hash.Hash, params Parameters) (radixTwoFri, error) {
+	if err := params.check(); err != nil {
+		return radixTwoFri{}, err
+	}
 
-	var res radixTwoFri
+	res := radixTwoFri{h: h, params: params}
 
-	// computing the number of steps
-	n := ecc.NextPowerOfTwo(size)
-	nbSteps := bits.TrailingZeros(uint(n))
-	res.nbSteps = nbSteps
+	// computing the arities of the steps
+	n := int(ecc.NextPowerOfTwo(size))
+	d := n
+	for d > params.FinalPolynomialSize {
+		k := min(params.FoldingArity, d/params.FinalPolynomialSize)
+		res.arities = append(res.arities, k)
+		d /= k
+	}
+	if len(res.arities) == 0 {
+		res.arities = []int{1}
+	}
+	res.finalSize = d
 
-	// extending the domain
-	n = n * rho
+	// building the domain
+	res.domain = fft.NewDomain(uint64(n * params.Rate))
 
-	// building the domains
-	res.domain = fft.NewDomain(n)
+	return res, nil
+}
 
-	// hash function
-	res.h = h
+// fiberLeaves returns the leaves of the Merkle tree of the evaluations of
+// the columns on a domain of size n, the leaf j being the concatenation of
+// the rows of evaluations on the fiber {gʲ⁺ᵗⁿᐟᵏ, t < k} of gʲᵏ for x ↦ xᵏ.
+func fiberLeaves(columns [][]fr.Element, k int) [][]byte {
+	m := len(columns[0]) / k
+	leaves := make([][]byte, m)
+	parallel.Execute(m, func(start, end int) {
+		for j := start; j < end; j++ {
+			leaves[j] = make([]byte, 0, k*len(columns)*fr.Bytes)
+			for t := 0; t < k; t++ {
+				for _, c := range columns {
+					b := c[j+t*m].Bytes()
+					leaves[j] = append(leaves[j], b[:]...)
+				}
+			}
+		}
+	})
+	return leaves
+}
 
-	return res
+// parseLeaf returns the size elements of a leaf, the evaluation of the c-th
+// column at the t-th point of the fiber being at index t⋅nbColumns + c.
+func parseLeaf(leaf []byte, size int) ([]fr.Element, error) {
+	if len(leaf) != size*fr.Bytes {
+		return nil, ErrMerklePath
+	}
+	res := make([]fr.Element, size)
+	for t := range res {
+		if err := res[t].SetBytesCanonical(leaf[t*fr.Bytes : (t+1)*fr.Bytes]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
 }
 
-// convertCanonicalSorted convert the index i, an entry in a
-// sorted polynomial, to the corresponding entry in canonical
-// representation. n is the size of the polynomial.
-func convertCanonicalSorted(i, n int) int {
+// merkleTree is a complete Merkle tree keeping all its nodes, to prove many
+// leaves. The proofs are verified with merkletree.VerifyProof.
+type merkleTree struct {
+	leaves [][]byte
 
-	if i < n/2 {
-		return 2 * i
-	} else {
-		l := n - (i + 1)
-		l = 2 * l
-		return n - l - 1
-	}
+	// nodes[1] is the root, and the children of nodes[i] are nodes[2i] and
+	// nodes[2i+1], the hashes of the leaves being the last len(leaves) nodes
+	nodes [][]byte
+}
 
+// newMerkleTree returns the Merkle tree of the leaves, whose number must be a
+// power of 2.
+func newMerkleTree(h hash.Hash, leaves [][]byte) *merkleTree {
+	m := len(leaves)
+	t := &merkleTree{leaves: leaves, nodes: make([][]byte, 2*m)}
+	for j := range leaves {
+		h.Reset()
+		h.Write(leaves[j])
+		t.nodes[m+j] = h.Sum(nil)
+	}
+	for i := m - 1; i > 0; i-- {
+		h.Reset()
+		h.Write(t.nodes[2*i])
+		h.Write(t.nodes[2*i+1])
+		t.nodes[i] = h.Sum(nil)
+	}
+	return t
 }
 
-// deriveQueriesPositions derives the indices of the oracle
-// function that the verifier has to pick, in sorted form.
-// * pos is the initial position, i.e. the logarithm of the first challenge
-// * size is the size of the initial polynomial
-// * The result is a slice of []int, where each entry is a tuple (iₖ), such that
-// the verifier needs to evaluate ∑ₖ oracle(iₖ)xᵏ to build
-// the folded function.
-func (s radixTwoFri) deriveQueriesPositions(pos int, size int) []int {
+func (t *merkleTree) root() []byte {
+	return t.nodes[1]
+}
 
-	_s := size / 2
-	res := make([]int, s.nbSteps)
-	res[0] = pos
-	for i := 1; i < s.nbSteps; i++ {
-		t := (res[i-1] - (res[i-1] % 2)) / 2
-		res[i] = convertCanonicalSorted(t, _s)
-		_s = _s / 2
+// prove returns the proof set [leaf ∥ node_1 ∥ .. ] of the leaf j.
+func (t *merkleTree) prove(j int) [][]byte {
+	res := [][]byte{t.leaves[j]}
+	for i := len(t.leaves) + j; i > 1; i >>= 1 {
+		res = append(res, t.nodes[i^1])
 	}
+	return res
+}
 
+// foldFiber returns ∑ₛ βˢ⋅pₛ(xᵏ), where p = ∑ₛ Xˢ⋅pₛ(Xᵏ) is the polynomial
+// whose evaluations on the fiber {x⋅ζᵗ} of xᵏ are e, with k = len(e) and ζ a
+// primitive k-th root of unity. As pₛ(xᵏ)⋅xˢ = 1/k ∑ₜ ζ^{-st}⋅eₜ, it is an
+// inverse DFT of size k followed by an evaluation at β/x.
+//
+// * zetaInv are the powers ζ⁻ᵗ, t < k
+// * xInv is x⁻¹
+func foldFiber(e, zetaInv []fr.Element, xInv, beta, kInv *fr.Element) fr.Element {
+	k := len(e)
+	var r, c, t, res fr.Element
+	r.Mul(beta, xInv)
+	for s := k - 1; s >= 0; s-- {
+		c.SetZero()
+		for i := range e {
+			t.Mul(&e[i], &zetaInv[(i*s)%k])
+			c.Add(&c, &t)
+		}
+		res.Mul(&res, &r).Add(&res, &c)
+	}
+	res.Mul(&res, kInv)
 	return res
 }
 
-// sort orders the evaluation of a polynomial on a domain
-// such that contiguous entries are in the same fiber:
-// {q(g⁰), q(g^{n/2}), q(g¹), q(g^{1+n/2}),...,q(g^{n/2-1}), q(gⁿ⁻¹)}
-func sort(evaluations []fr.Element) []fr.Element {
-	q := make([]fr.Element, len(evaluations))
-	n := len(evaluations) / 2
-	for i := 0; i < n; i++ {
-		q[2*i].Set(&evaluations[i])
-		q[2*i+1].Set(&evaluations[i+n])
+// foldParameters returns the powers ζ⁻ᵗ of the inverse of a primitive k-th
+// root of unity and 1/k.
+func foldParameters(k int) ([]fr.Element, fr.Element, error) {
+	zeta, err := fr.Generator(uint64(k))
+	if err != nil {
+		return nil, fr.Element{}, err
+	}
+	zetaInv := make([]fr.Element, k)
+	zetaInv[0].SetOne()
+	if k > 1 {
+		zeta.Inverse(&zeta)
+		for t := 1; t < k; t++ {
+			zetaInv[t].Mul(&zetaInv[t-1], &zeta)
+		}
+	}
+	var kInv fr.Element
+	kInv.SetUint64(uint64(k)).Inverse(&kInv)
+	return zetaInv, kInv, nil
+}
+
+// foldEvaluations folds the evaluations of a polynomial on the subgroup
+// generated by g into its folding with β on the subgroup generated by gᵏ.
+func foldEvaluations(evaluations []fr.Element, k int, gInv, beta fr.Element) ([]fr.Element, error) {
+	zetaInv, kInv, err := foldParameters(k)
+	if err != nil {
+		return nil, err
+	}
+	m := len(evaluations) / k
+	res := make([]fr.Element, m)
+	parallel.Execute(m, func(start, end int) {
+		var xInv fr.Element
+		xInv.Exp(gInv, big.NewInt(int64(start)))
+		e := make([]fr.Element, k)
+		for j := start; j < end; j++ {
+			for t := range e {
+				e[t] = evaluations[j+t*m]
+			}
+			res[j] = foldFiber(e, zetaInv, &xInv, &beta, &kInv)
+			xInv.Mul(&xInv, &gInv)
+		}
+	})
+	return res, nil
+}
+
+// transcript returns the Fiat Shamir transcript of the protocol, with one
+// folding challenge per step, and the challenges of the proof of work and of
+// the queries.
+func (s radixTwoFri) transcript() (*fiatshamir.Transcript, []string) {
+	ids := make([]string, len(s.arities)+2)
+	for i := range s.arities {
+		ids[i] = fmt.Sprintf("x%d", i)
 	}
-	return q
+	ids[len(s.arities)] = "grinding"
+	ids[len(s.arities)+1] = "queries"
+	return fiatshamir.NewTranscript(s.h, ids...), ids
+}
+
+// proofOfWork returns true if H(seed ∥ nonce) starts with nbBits zero bits.
+func (s radixTwoFri) proofOfWork(seed []byte, nonce uint64, nbBits int) bool {
+	var bNonce [8]byte
+	binary.BigEndian.PutUint64(bNonce[:], nonce)
+	s.h.Reset()
+	s.h.Write(seed)
+	s.h.Write(bNonce[:])
+	digest := s.h.Sum(nil)
+	for i := 0; i < nbBits; i++ {
+		if digest[i/8]&(0x80>>(i%8)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// queryPositions derives the positions of the queries from the transcript,
+// after binding the final polynomial and the proof of work. If grind is set,
+// the nonce is computed, otherwise it is checked.
+func (s radixTwoFri) queryPositions(fs *fiatshamir.Transcript, ids []string, finalPolynomial []fr.Element, nonce *uint64, grind bool) ([]uint64, error) {
+	idGrinding, idQueries := ids[len(ids)-2], ids[len(ids)-1]
+	for i := range finalPolynomial {
+		if err := fs.Bind(idGrinding, finalPolynomial[i].Marshal()); err != nil {
+			return nil, err
+		}
+	}
+	seed, err := fs.ComputeChallenge(idGrinding)
+	if err != nil {
+		return nil, err
+	}
+	if grind {
+		*nonce = 0
+		for !s.proofOfWork(seed, *nonce, s.params.GrindingBits) {
+			*nonce++
+		}
+	} else if !s.proofOfWork(seed, *nonce, s.params.GrindingBits) {
+		return nil, ErrProofOfWork
+	}
+	var bNonce [8]byte
+	binary.BigEndian.PutUint64(bNonce[:], *nonce)
+	if err = fs.Bind(idQueries, bNonce[:]); err != nil {
+		return nil, err
+	}
+	seed, err = fs.ComputeChallenge(idQueries)
+	if err != nil {
+		return nil, err
+	}
+
+	// the q-th position is H(seed ∥ q) mod ρ⋅size
+	res := make([]uint64, s.params.NbQueries)
+	var bPos, bCardinality big.Int
+	bCardinality.SetUint64(s.domain.Cardinality)
+	for q := range res {
+		var bq [8]byte
+		binary.BigEndian.PutUint64(bq[:], uint64(q))
+		s.h.Reset()
+		s.h.Write(seed)
+		s.h.Write(bq[:])
+		bPos.SetBytes(s.h.Sum(nil))
+		res[q] = bPos.Mod(&bPos, &bCardinality).Uint64()
+	}
+	return res, nil
 }
 
 // Opens a polynomial at gⁱ where i = position.
@@ -259,26 +537,17 @@ func (s radixTwoFri) Open(p []fr.Element, position uint64) (OpeningProof, error)
 	s.domain.FFT(q, fft.DIF)
 	fft.BitReverse(q)
 
-	// sort q to have fibers in contiguous entries. The goal is to have one
-	// Merkle path for both openings of entries which are in the same fiber.
-	q = sort(q)
+	// the point is in the leaf position mod m, at the slot position / m
+	k := s.arities[0]
+	m := s.domain.Cardinality / uint64(k)
+	tree := newMerkleTree(s.h, fiberLeaves([][]fr.Element{q}, k))
 
-	// build the Merkle proof, we the position is converted to fit the sorted polynomial
-	pos := convertCanonicalSorted(int(position), len(q))
-
-	tree := merkletree.New(s.h)
-	err := tree.SetIndex(uint64(pos))
-	if err != nil {
-		return OpeningProof{}, err
-	}
-	for i := 0; i < len(q); i++ {
-		tree.Push(q[i].Marshal())
-	}
 	var res OpeningProof
-	res.merkleRoot, res.ProofSet, res.index, res.numLeaves = tree.Prove()
-
-	// set the claimed value, which is the first entry of the Merkle proof
-	res.ClaimedValue.SetBytes(res.ProofSet[0])
+	res.index = position % m
+	res.numLeaves = m
+	res.merkleRoot = tree.root()
+	res.ProofSet = tree.prove(int(res.index))
+	res.ClaimedValue.Set(&q[position])
 
 	return res, nil
 }
@@ -292,396 +561,256 @@ func (s radixTwoFri) Open(p []fr.Element, position uint64) (OpeningProof, error)
 // those should be equal, if not an error is raised.
 func (s radixTwoFri) VerifyOpening(position uint64, openingProof OpeningProof, pp ProofOfProximity) error {
 
-	// To query the Merkle path, we look at the first series of Interactions, and check whether it's the point
-	// at 'position' or its neighbor that contains the full Merkle path.
-	var fullMerkleProof int
-	if len(pp.Rounds[0].Interactions[0][0].ProofSet) > len(pp.Rounds[0].Interactions[0][1].ProofSet) {
-		fullMerkleProof = 0
-	} else {
-		fullMerkleProof = 1
+	if position >= s.domain.Cardinality {
+		return ErrRangePosition
+	}
+	if len(pp.Roots) == 0 {
+		return ErrProofShape
 	}
 
 	// check that the merkle roots coincide
-	if !bytes.Equal(openingProof.merkleRoot, pp.Rounds[0].Interactions[0][fullMerkleProof].MerkleRoot) {
+	if !bytes.Equal(openingProof.merkleRoot, pp.Roots[0]) {
 		return ErrMerkleRoot
 	}
 
-	// convert position to the sorted version
-	sizePoly := s.domain.Cardinality
-	pos := convertCanonicalSorted(int(position), int(sizePoly))
-
-	// check the Merkle proof
-	res := merkletree.VerifyProof(s.h, openingProof.merkleRoot, openingProof.ProofSet, uint64(pos), openingProof.numLeaves)
-	if !res {
+	// check the Merkle proof of the leaf of the position
+	k := s.arities[0]
+	m := s.domain.Cardinality / uint64(k)
+	if !merkletree.VerifyProof(s.h, pp.Roots[0], openingProof.ProofSet, position%m, m) {
 		return ErrMerklePath
 	}
+
+	// check the claimed value
+	e, err := parseLeaf(openingProof.ProofSet[0], k)
+	if err != nil {
+		return err
+	}
+	if !e[position/m].Equal(&openingProof.ClaimedValue) {
+		return ErrClaimedValue
+	}
 	return nil
 
 }
 
-// foldPolynomialLagrangeBasis folds a polynomial p, expressed in Lagrange basis.
-//
-// Fᵣ[X]/(Xⁿ-1) is a free module of rank 2 on Fᵣ[Y]/(Y^{n/2}-1). If
-// p∈ Fᵣ[X]/(Xⁿ-1), expressed in Lagrange basis, the function finds the coordinates
-// p₁, p₂ of p in Fᵣ[Y]/(Y^{n/2}-1), expressed in Lagrange basis. Finally, it computes
-// p₁ + x*p₂ and returns it.
+// BuildProofOfProximity generates a proof that a function, given as an oracle from
+// the verifier point of view, is in fact δ-close to a polynomial.
 //
-// * p is the polynomial to fold, in Lagrange basis, sorted like this: p = [p(1),p(-1),p(g),p(-g),p(g²),p(-g²),...]
-// * g is a generator of the subgroup of Fᵣ^{*} of size len(p)
-// * x is the folding challenge x, used to return p₁+x*p₂
-func foldPolynomialLagrangeBasis(pSorted []fr.Element, gInv, x fr.Element) []fr.Element {
-
-	// we have the following system
-	// p₁(g²ⁱ)+gⁱp₂(g²ⁱ) = p(gⁱ)
-	// p₁(g²ⁱ)-gⁱp₂(g²ⁱ) = p(-gⁱ)
-	// we solve the system for p₁(g²ⁱ),p₂(g²ⁱ)
-	s := len(pSorted)
-	res := make([]fr.Element, s/2)
-
-	var p1, p2, acc fr.Element
-	acc.SetOne()
-
-	for i := 0; i < s/2; i++ {
-
-		p1.Add(&pSorted[2*i], &pSorted[2*i+1])
-		p2.Sub(&pSorted[2*i], &pSorted[2*i+1]).Mul(&p2, &acc)
-		res[i].Mul(&p2, &x).Add(&res[i], &p1).Mul(&res[i], &twoInv)
-
-		acc.Mul(&acc, &gInv)
+// The evaluations of p on the domain are committed to and folded step by
+// step with the challenges of the verifier, until the folded polynomial has
+// at most FinalPolynomialSize coefficients, which are sent. The queries are
+// then derived after a proof of work, and each query opens the fibers of the
+// successive oracles containing it.
+func (s radixTwoFri) BuildProofOfProximity(p []fr.Element) (ProofOfProximity, error) {
 
+	if uint64(len(p)) > s.domain.Cardinality {
+		return ProofOfProximity{}, ErrLowDegree
 	}
 
-	return res
+	// evaluate p
+	evaluations := make([]fr.Element, s.domain.Cardinality)
+	copy(evaluations, p)
+	s.domain.FFT(evaluations, fft.DIF)
+	fft.BitReverse(evaluations)
+
+	proof, _, err := s.proveProximity(evaluations, nil, true)
+	return proof, err
 }
 
-// buildProofOfProximitySingleRound generates a proof that a function, given as an oracle from
-// the verifier point of view, is in fact δ-close to a polynomial.
-// * salt is a variable for multi rounds, it allows to generate different challenges using Fiat Shamir
-// * p is in evaluation form
-func (s radixTwoFri) buildProofOfProximitySingleRound(salt fr.Element, p []fr.Element) (Round, error) {
-
-	// the proof will contain nbSteps Interactions
-	var res Round
-	res.Interactions = make([][2]MerkleProof, s.nbSteps)
-
-	// Fiat Shamir transcript to derive the challenges. The xᵢ are used to fold the
-	// polynomials.
-	// During the i-th round, the prover has a polynomial P of degree n. The verifier sends
-	// xᵢ∈ Fᵣ to the prover. The prover expresses F in Fᵣ[X,Y]/<Y-X²> as
-	// P₀(Y)+X P₁(Y) where P₀, P₁ are of degree n/2, and he then folds the polynomial
-	// by replacing x by xᵢ.
-	xis := make([]string, s.nbSteps+1)
-	for i := 0; i < s.nbSteps; i++ {
-		xis[i] = fmt.Sprintf("x%d", i)
-	}
-	xis[s.nbSteps] = "s0"
-	fs := fiatshamir.NewTranscript(s.h, xis...)
-
-	// the salt is binded to the first challenge, to ensure the challenges
-	// are different at each round.
-	err := fs.Bind(xis[0], salt.Marshal())
-	if err != nil {
-		return Round{}, err
+// proveProximity runs FRI on the evaluations of a polynomial on the domain,
+// in natural order. If seed is not nil, it is bound to the first challenge,
+// to chain the transcript to a previous one. If commitFirst is false, the
+// first oracle is not committed to, and the openings of its fibers at the
+// returned positions are left to the caller, so that the Roots and the
+// Interactions of the proof start at the second oracle.
+func (s radixTwoFri) proveProximity(evaluations []fr.Element, seed []byte, commitFirst bool) (ProofOfProximity, []uint64, error) {
+
+	fs, ids := s.transcript()
+	if seed != nil {
+		if err := fs.Bind(ids[0], seed); err != nil {
+			return ProofOfProximity{}, nil, err
+		}
 	}
+	var proof ProofOfProximity
+	trees := make([]*merkleTree, len(s.arities))
 
-	// step 1 : fold the polynomial using the xi
-
-	// evalsAtRound stores the list of the nbSteps polynomial evaluations, each evaluation
-	// corresponds to the evaluation o the folded polynomial at round i.
-	evalsAtRound := make([][]fr.Element, s.nbSteps)
-
-	// evaluate p and sort the result
-	_p := make([]fr.Element, s.domain.Cardinality)
-	copy(_p, p)
-
-	// gInv inverse of the generator of the cyclic group of size the size of the polynomial.
-	// The size of the cyclic group is ρ*s.domainSize, and not s.domainSize.
+	// gInv inverse of the generator of the domain of the current oracle
 	var gInv fr.Element
 	gInv.Set(&s.domain.GeneratorInv)
 
-	for i := 0; i < s.nbSteps; i++ {
-
-		evalsAtRound[i] = sort(_p)
-
-		// compute the root hash, needed to derive xi
-		t := merkletree.New(s.h)
-		for k := 0; k < len(_p); k++ {
-			t.Push(evalsAtRound[i][k].Marshal())
-		}
-		rh := t.Root()
-		err := fs.Bind(xis[i], rh)
-		if err != nil {
-			return res, err
+	// commit phase: fold the polynomial using the xᵢ
+	for i, k := range s.arities {
+		if i > 0 || commitFirst {
+			trees[i] = newMerkleTree(s.h, fiberLeaves([][]fr.Element{evaluations}, k))
+			root := trees[i].root()
+			proof.Roots = append(proof.Roots, root)
+			if err := fs.Bind(ids[i], root); err != nil {
+				return proof, nil, err
+			}
 		}
-
-		// derive the challenge
-		bxi, err := fs.ComputeChallenge(xis[i])
+		bxi, err := fs.ComputeChallenge(ids[i])
 		if err != nil {
-			return res, err
+			return proof, nil, err
 		}
 		var xi fr.Element
 		xi.SetBytes(bxi)
 
-		// fold _p, reusing its memory
-		_p = foldPolynomialLagrangeBasis(evalsAtRound[i], gInv, xi)
-
-		// g <- g²
-		gInv.Square(&gInv)
-
+		if evaluations, err = foldEvaluations(evaluations, k, gInv, xi); err != nil {
+			return proof, nil, err
+		}
+		gInv.Exp(gInv, big.NewInt(int64(k)))
 	}
 
-	// last round, provide the evaluation. The fully folded polynomial is of size rho. It should
-	// correspond to the evaluation of a polynomial of degree 1 on ρ points, so those points
-	// are supposed to be on a line.
-	res.Evaluation.Set(&_p[0])
-
-	// step 2: provide the Merkle proofs of the queries
+	// the final polynomial is interpolated from its evaluations on the last
+	// domain, of size ρ⋅finalSize
+	finalDomain := fft.NewDomain(uint64(len(evaluations)))
+	finalDomain.FFTInverse(evaluations, fft.DIF)
+	fft.BitReverse(evaluations)
+	proof.FinalPolynomial = evaluations[:s.finalSize]
 
-	// derive the verifier queries
-	err = fs.Bind(xis[s.nbSteps], res.Evaluation.Marshal())
+	// query phase: derive the queries after the proof of work
+	positions, err := s.queryPositions(fs, ids, proof.FinalPolynomial, &proof.Nonce, true)
 	if err != nil {
-		return res, err
+		return proof, nil, err
 	}
-	binSeed, err := fs.ComputeChallenge(xis[s.nbSteps])
-	if err != nil {
-		return res, err
-	}
-	var bPos, bCardinality big.Int
-	bPos.SetBytes(binSeed)
-	bCardinality.SetUint64(s.domain.Cardinality)
-	bPos.Mod(&bPos, &bCardinality)
-	si := s.deriveQueriesPositions(int(bPos.Uint64()), int(s.domain.Cardinality))
-
-	for i := 0; i < s.nbSteps; i++ {
-
-		// build proofs of queries at s[i]
-		t := merkletree.New(s.h)
-		err := t.SetIndex(uint64(si[i]))
-		if err != nil {
-			return res, err
-		}
-		for k := 0; k < len(evalsAtRound[i]); k++ {
-			t.Push(evalsAtRound[i][k].Marshal())
-		}
-		mr, ProofSet, _, numLeaves := t.Prove()
-
-		// c denotes the entry that contains the full Merkle proof. The entry 1-c will
-		// only contain 2 elements, which are the neighbor point, and the hash of the
-		// first point. The remaining of the Merkle path is common to both the original
-		// point and its neighbor.
-		c := si[i] % 2
-		res.Interactions[i][c] = MerkleProof{mr, ProofSet, numLeaves}
-		res.Interactions[i][1-c] = MerkleProof{
-			mr,
-			make([][]byte, 2),
-			numLeaves,
-		}
-		res.Interactions[i][1-c].ProofSet[0] = evalsAtRound[i][si[i]+1-2*c].Marshal()
-		s.h.Reset()
-		_, err = s.h.Write(res.Interactions[i][c].ProofSet[0])
-		if err != nil {
-			return res, err
+	proof.Rounds = make([]Round, len(positions))
+	for q, pos := range positions {
+		n := s.domain.Cardinality
+		for i, k := range s.arities {
+			m := n / uint64(k)
+			j := pos % m
+			if trees[i] != nil {
+				proof.Rounds[q].Interactions = append(proof.Rounds[q].Interactions, MerkleProof{
+					MerkleRoot: trees[i].root(),
+					ProofSet:   trees[i].prove(int(j)),
+					numLeaves:  m,
+				})
+			}
+			pos, n = j, m
 		}
-		res.Interactions[i][1-c].ProofSet[1] = s.h.Sum(nil)
-
 	}
 
-	return res, nil
-
+	return proof, positions, nil
 }
 
-// BuildProofOfProximity generates a proof that a function, given as an oracle from
-// the verifier point of view, is in fact δ-close to a polynomial.
-func (s radixTwoFri) BuildProofOfProximity(p []fr.Element) (ProofOfProximity, error) {
-
-	// the proof will contain nbSteps Interactions
-	var proof ProofOfProximity
-	proof.Rounds = make([]Round, nbRounds)
-
-	// evaluate p
-	// evaluate p and sort the result
-	_p := make([]fr.Element, s.domain.Cardinality)
-	copy(_p, p)
-	s.domain.FFT(_p, fft.DIF)
-	fft.BitReverse(_p)
-
-	var err error
-	var salt, one fr.Element
-	one.SetOne()
-	for i := 0; i < nbRounds; i++ {
-		proof.Rounds[i], err = s.buildProofOfProximitySingleRound(salt, _p)
-		if err != nil {
-			return proof, err
-		}
-		salt.Add(&salt, &one)
-	}
-
-	return proof, nil
+// VerifyProofOfProximity verifies the proof, by checking each query one
+// by one: the folding of the opened fibers must be consistent from one oracle
+// to the next, and with the final polynomial.
+func (s radixTwoFri) VerifyProofOfProximity(proof ProofOfProximity) error {
+	return s.verifyProximity(proof, nil, nil)
 }
 
-// verifyProofOfProximitySingleRound verifies the proof of proximity. It returns an error if the
-// verification fails.
-func (s radixTwoFri) verifyProofOfProximitySingleRound(salt fr.Element, proof Round) error {
+// verifyProximity verifies a proof of proximity built by proveProximity with
+// the same seed. If firstFiber is not nil, the first oracle is not committed
+// to in the proof, and firstFiber returns its evaluations on the fiber of the
+// leaf j for the q-th query, after checking their openings.
+func (s radixTwoFri) verifyProximity(proof ProofOfProximity, seed []byte, firstFiber func(q int, j uint64) ([]fr.Element, error)) error {
 
-	// Fiat Shamir transcript to derive the challenges
-	xis := make([]string, s.nbSteps+1)
-	for i := 0; i < s.nbSteps; i++ {
-		xis[i] = fmt.Sprintf("x%d", i)
+	nbCommitted := len(s.arities)
+	if firstFiber != nil {
+		nbCommitted--
 	}
-	xis[s.nbSteps] = "s0"
-	fs := fiatshamir.NewTranscript(s.h, xis...)
-
-	xi := make([]fr.Element, s.nbSteps)
-
-	// the salt is binded to the first challenge, to ensure the challenges
-	// are different at each round.
-	err := fs.Bind(xis[0], salt.Marshal())
-	if err != nil {
-		return err
+	if len(proof.Roots) != nbCommitted || len(proof.Rounds) != s.params.NbQueries {
+		return ErrProofShape
+	}
+	if len(proof.FinalPolynomial) != s.finalSize {
+		return ErrLowDegree
 	}
 
-	for i := 0; i < s.nbSteps; i++ {
-		err := fs.Bind(xis[i], proof.Interactions[i][0].MerkleRoot)
-		if err != nil {
+	// Fiat Shamir transcript to derive the challenges
+	fs, ids := s.transcript()
+	if seed != nil {
+		if err := fs.Bind(ids[0], seed); err != nil {
 			return err
 		}
-		bxi, err := fs.ComputeChallenge(xis[i])
+	}
+	xi := make([]fr.Element, len(s.arities))
+	roots := proof.Roots
+	if firstFiber != nil {
+		roots = append([][]byte{nil}, roots...)
+	}
+	for i := range s.arities {
+		if roots[i] != nil {
+			if err := fs.Bind(ids[i], roots[i]); err != nil {
+				return err
+			}
+		}
+		bxi, err := fs.ComputeChallenge(ids[i])
 		if err != nil {
 			return err
 		}
 		xi[i].SetBytes(bxi)
 	}
-
-	// derive the verifier queries
-	// for i := 0; i < len(proof.evaluation); i++ {
-	// 	err := fs.Bind(xis[s.nbSteps], proof.evaluation[i].Marshal())
-	// 	if err != nil {
-	// 		return err
-	// 	}
-	// }
-	err = fs.Bind(xis[s.nbSteps], proof.Evaluation.Marshal())
+	nonce := proof.Nonce
+	positions, err := s.queryPositions(fs, ids, proof.FinalPolynomial, &nonce, false)
 	if err != nil {
 		return err
 	}
-	binSeed, err := fs.ComputeChallenge(xis[s.nbSteps])
-	if err != nil {
-		return err
+
+	type foldParams struct {
+		zetaInv []fr.Element
+		kInv    fr.Element
 	}
-	var bPos, bCardinality big.Int
-	bPos.SetBytes(binSeed)
-	bCardinality.SetUint64(s.domain.Cardinality)
-	bPos.Mod(&bPos, &bCardinality)
-	si := s.deriveQueriesPositions(int(bPos.Uint64()), int(s.domain.Cardinality))
-
-	// for each round check the Merkle proof and the correctness of the folding
-
-	// current size of the polynomial
-	var accGInv fr.Element
-	accGInv.Set(&s.domain.GeneratorInv)
-	for i := 0; i < s.nbSteps; i++ {
-
-		// correctness of Merkle proof
-		// c is the entry containing the full Merkle proof.
-		c := si[i] % 2
-		res := merkletree.VerifyProof(
-			s.h,
-			proof.Interactions[i][c].MerkleRoot,
-			proof.Interactions[i][c].ProofSet,
-			uint64(si[i]),
-			proof.Interactions[i][c].numLeaves,
-		)
-		if !res {
-			return ErrMerklePath
+	params := make([]foldParams, len(s.arities))
+	for i, k := range s.arities {
+		if params[i].zetaInv, params[i].kInv, err = foldParameters(k); err != nil {
+			return err
 		}
+	}
 
-		// we verify the Merkle proof for the neighbor query, to do that we have
-		// to pick the full Merkle proof of the first entry, stripped off of the leaf and
-		// the first node. We replace the leaf and the first node by the leaf and the first
-		// node of the partial Merkle proof, since the leaf and the first node of both proofs
-		// are the only entries that differ.
-		ProofSet := make([][]byte, len(proof.Interactions[i][c].ProofSet))
-		copy(ProofSet[2:], proof.Interactions[i][c].ProofSet[2:])
-		ProofSet[0] = proof.Interactions[i][1-c].ProofSet[0]
-		ProofSet[1] = proof.Interactions[i][1-c].ProofSet[1]
-		res = merkletree.VerifyProof(
-			s.h,
-			proof.Interactions[i][1-c].MerkleRoot,
-			ProofSet,
-			uint64(si[i]+1-2*c),
-			proof.Interactions[i][1-c].numLeaves,
-		)
-		if !res {
-			return ErrMerklePath
+	for q, pos := range positions {
+		if len(proof.Rounds[q].Interactions) != nbCommitted {
+			return ErrProofShape
 		}
 
-		// correctness of the folding
-		if i < s.nbSteps-1 {
-
-			var fe, fo, l, r, fn fr.Element
-
-			// l = P(gⁱ), r = P(g^{i+n/2})
-			l.SetBytes(proof.Interactions[i][0].ProofSet[0])
-			r.SetBytes(proof.Interactions[i][1].ProofSet[0])
-
-			// (g^{si[i]}, g^{si[i]+1}) is the fiber of g^{2*si[i]}. The system to solve
-			// (for P₀(g^{2si[i]}), P₀(g^{2si[i]}) ) is:
-			// P(g^{si[i]}) = P₀(g^{2si[i]}) +  g^{si[i]/2}*P₀(g^{2si[i]})
-			// P(g^{si[i]+1}) = P₀(g^{2si[i]}) -  g^{si[i]/2}*P₀(g^{2si[i]})
-			bm := big.NewInt(int64(si[i] / 2))
-			var ginv fr.Element
-			ginv.Exp(accGInv, bm)
-			fe.Add(&l, &r)                                      // P₁(g²ⁱ) (to be multiplied by 2⁻¹)
-			fo.Sub(&l, &r).Mul(&fo, &ginv)                      // P₀(g²ⁱ) (to be multiplied by 2⁻¹)
-			fo.Mul(&fo, &xi[i]).Add(&fo, &fe).Mul(&fo, &twoInv) // P₀(g²ⁱ) + xᵢ * P₁(g²ⁱ)
-
-			fn.SetBytes(proof.Interactions[i+1][si[i+1]%2].ProofSet[0])
+		// g generator of the domain of the current oracle, of size n
+		g := s.domain.Generator
+		n := s.domain.Cardinality
+		var folded fr.Element
+		for i, k := range s.arities {
+			m := n / uint64(k)
+			j, slot := pos%m, pos/m
+
+			var e []fr.Element
+			if i == 0 && firstFiber != nil {
+				if e, err = firstFiber(q, j); err != nil {
+					return err
+				}
+			} else {
+				// correctness of Merkle proof
+				interaction := proof.Rounds[q].Interactions[i-len(s.arities)+nbCommitted]
+				if !merkletree.VerifyProof(s.h, roots[i], interaction.ProofSet, j, m) {
+					return ErrMerklePath
+				}
+				if e, err = parseLeaf(interaction.ProofSet[0], k); err != nil {
+					return err
+				}
+			}
 
-			if !fo.Equal(&fn) {
+			// correctness of the folding of the previous oracle
+			if i > 0 && !e[slot].Equal(&folded) {
 				return ErrProximityTestFolding
 			}
 
-			// next inverse generator
-			accGInv.Square(&accGInv)
-		}
-
-	}
-
-	// last transition
-	var fe, fo, l, r fr.Element
-
-	l.SetBytes(proof.Interactions[s.nbSteps-1][0].ProofSet[0])
-	r.SetBytes(proof.Interactions[s.nbSteps-1][1].ProofSet[0])
-
-	_si := si[s.nbSteps-1] / 2
-
-	accGInv.Exp(accGInv, big.NewInt(int64(_si)))
-
-	fe.Add(&l, &r)                                                // P₁(g²ⁱ) (to be multiplied by 2⁻¹)
-	fo.Sub(&l, &r).Mul(&fo, &accGInv)                             // P₀(g²ⁱ) (to be multiplied by 2⁻¹)
-	fo.Mul(&fo, &xi[s.nbSteps-1]).Add(&fo, &fe).Mul(&fo, &twoInv) // P₀(g²ⁱ) + xᵢ * P₁(g²ⁱ)
+			// fold the fiber of gʲ
+			var xInv fr.Element
+			xInv.Exp(g, big.NewInt(int64(j))).Inverse(&xInv)
+			folded = foldFiber(e, params[i].zetaInv, &xInv, &xi[i], &params[i].kInv)
 
-	// Last step: the final evaluation should be the evaluation of a degree 0 polynomial,
-	// so it must be constant.
-	if !fo.Equal(&proof.Evaluation) {
-		return ErrProximityTestFolding
-	}
-
-	return nil
-}
-
-// VerifyProofOfProximity verifies the proof, by checking each interaction one
-// by one.
-func (s radixTwoFri) VerifyProofOfProximity(proof ProofOfProximity) error {
+			g.Exp(g, big.NewInt(int64(k)))
+			pos, n = j, m
+		}
 
-	var salt, one fr.Element
-	one.SetOne()
-	for i := 0; i < nbRounds; i++ {
-		err := s.verifyProofOfProximitySingleRound(salt, proof.Rounds[i])
-		if err != nil {
-			return err
+		// Last step: the folded value should be the evaluation of the final
+		// polynomial.
+		var x, y fr.Element
+		x.Exp(g, big.NewInt(int64(pos)))
+		for i := len(proof.FinalPolynomial) - 1; i >= 0; i-- {
+			y.Mul(&y, &x).Add(&y, &proof.FinalPolynomial[i])
+		}
+		if !y.Equal(&folded) {
+			return ErrProximityTestFolding
 		}
-		salt.Add(&salt, &one)
 	}
-	return nil
 
+	return nil
 }
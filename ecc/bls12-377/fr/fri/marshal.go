@@ -0,0 +1,273 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+// maxSliceLen bounds the lengths read by ReadFrom, to avoid allocating
+// arbitrary amounts of memory on malformed inputs.
+const maxSliceLen = 1 << 24
+
+var errSliceTooLong = errors.New("encoded slice too long")
+
+// WriteTo writes the binary encoding of the proof: the ID, the Merkle roots,
+// the Merkle proofs of the queries, the final polynomial and the nonce. The
+// roots and the numbers of leaves of the Merkle proofs are not repeated, and
+// are restored by ReadFrom.
+func (proof *ProofOfProximity) WriteTo(w io.Writer) (int64, error) {
+	enc := encoder{w: w}
+	enc.writeBytes(proof.ID)
+	enc.writeMerkleProofs(proof.Roots, len(proof.Rounds), func(q, i int) *MerkleProof {
+		return &proof.Rounds[q].Interactions[i]
+	})
+	enc.writeElements(proof.FinalPolynomial)
+	enc.write(proof.Nonce)
+	return enc.n, enc.err
+}
+
+// ReadFrom decodes a proof written by WriteTo.
+func (proof *ProofOfProximity) ReadFrom(r io.Reader) (int64, error) {
+	dec := decoder{r: r}
+	proof.ID = dec.readBytes()
+	var nbRounds int
+	proof.Roots, nbRounds = dec.readMerkleProofs(func(nbRounds, nbRoots int) func(q, i int) *MerkleProof {
+		proof.Rounds = make([]Round, nbRounds)
+		for q := range proof.Rounds {
+			if nbRoots > 0 {
+				proof.Rounds[q].Interactions = make([]MerkleProof, nbRoots)
+			}
+		}
+		return func(q, i int) *MerkleProof {
+			return &proof.Rounds[q].Interactions[i]
+		}
+	})
+	if dec.err == nil && nbRounds != len(proof.Rounds) {
+		dec.err = ErrProofShape
+	}
+	proof.FinalPolynomial = dec.readElements()
+	proof.Nonce = dec.readUint64()
+	return dec.n, dec.err
+}
+
+// WriteTo writes the binary encoding of the proof.
+func (proof *BatchOpeningProof) WriteTo(w io.Writer) (int64, error) {
+	enc := encoder{w: w}
+	enc.writeElements([]fr.Element{proof.Point})
+	enc.writeUint32(len(proof.ClaimedValues))
+	for _, m := range proof.ClaimedValues {
+		enc.writeUint32(len(m))
+		for _, p := range m {
+			enc.writeElements(p)
+		}
+	}
+	var roots [][]byte
+	if len(proof.Rows) > 0 {
+		for _, row := range proof.Rows[0] {
+			roots = append(roots, row.MerkleRoot)
+		}
+	}
+	enc.writeMerkleProofs(roots, len(proof.Rows), func(q, m int) *MerkleProof {
+		return &proof.Rows[q][m]
+	})
+	if enc.err != nil {
+		return enc.n, enc.err
+	}
+	n, err := proof.ProofOfProximity.WriteTo(w)
+	return enc.n + n, err
+}
+
+// ReadFrom decodes a proof written by WriteTo.
+func (proof *BatchOpeningProof) ReadFrom(r io.Reader) (int64, error) {
+	dec := decoder{r: r}
+	if point := dec.readElements(); dec.err == nil {
+		if len(point) != 1 {
+			return dec.n, ErrProofShape
+		}
+		proof.Point = point[0]
+	}
+	proof.ClaimedValues = make([][][]fr.Element, dec.readUint32())
+	for m := range proof.ClaimedValues {
+		proof.ClaimedValues[m] = make([][]fr.Element, dec.readUint32())
+		for i := range proof.ClaimedValues[m] {
+			proof.ClaimedValues[m][i] = dec.readElements()
+		}
+	}
+	dec.readMerkleProofs(func(nbRounds, nbRoots int) func(q, m int) *MerkleProof {
+		proof.Rows = make([][]MerkleProof, nbRounds)
+		for q := range proof.Rows {
+			proof.Rows[q] = make([]MerkleProof, nbRoots)
+		}
+		return func(q, m int) *MerkleProof {
+			return &proof.Rows[q][m]
+		}
+	})
+	if dec.err != nil {
+		return dec.n, dec.err
+	}
+	n, err := proof.ProofOfProximity.ReadFrom(r)
+	return dec.n + n, err
+}
+
+// encoder writes big-endian encodings, and keeps the first error.
+type encoder struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (enc *encoder) write(v interface{}) {
+	if enc.err != nil {
+		return
+	}
+	if enc.err = binary.Write(enc.w, binary.BigEndian, v); enc.err == nil {
+		enc.n += int64(binary.Size(v))
+	}
+}
+
+func (enc *encoder) writeUint32(v int) {
+	enc.write(uint32(v))
+}
+
+func (enc *encoder) writeBytes(b []byte) {
+	enc.writeUint32(len(b))
+	if enc.err != nil {
+		return
+	}
+	m, err := enc.w.Write(b)
+	enc.n += int64(m)
+	enc.err = err
+}
+
+func (enc *encoder) writeElements(v []fr.Element) {
+	if enc.err != nil {
+		return
+	}
+	vector := fr.Vector(v)
+	m, err := vector.WriteTo(enc.w)
+	enc.n += m
+	enc.err = err
+}
+
+// writeMerkleProofs writes the roots once, and then the proof sets of the
+// nbRounds⋅len(roots) Merkle proofs.
+func (enc *encoder) writeMerkleProofs(roots [][]byte, nbRounds int, proof func(q, i int) *MerkleProof) {
+	enc.writeUint32(len(roots))
+	for _, root := range roots {
+		enc.writeBytes(root)
+	}
+	enc.writeUint32(nbRounds)
+	for q := 0; q < nbRounds; q++ {
+		for i := range roots {
+			p := proof(q, i)
+			enc.writeUint32(len(p.ProofSet))
+			for _, node := range p.ProofSet {
+				enc.writeBytes(node)
+			}
+		}
+	}
+}
+
+// decoder reads big-endian encodings, and keeps the first error.
+type decoder struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+func (dec *decoder) readFull(b []byte) {
+	if dec.err != nil {
+		return
+	}
+	m, err := io.ReadFull(dec.r, b)
+	dec.n += int64(m)
+	dec.err = err
+}
+
+func (dec *decoder) readUint32() int {
+	var buf [4]byte
+	dec.readFull(buf[:])
+	if dec.err != nil {
+		return 0
+	}
+	v := binary.BigEndian.Uint32(buf[:])
+	if v > maxSliceLen {
+		dec.err = errSliceTooLong
+		return 0
+	}
+	return int(v)
+}
+
+func (dec *decoder) readUint64() uint64 {
+	var buf [8]byte
+	dec.readFull(buf[:])
+	return binary.BigEndian.Uint64(buf[:])
+}
+
+func (dec *decoder) readBytes() []byte {
+	l := dec.readUint32()
+	if dec.err != nil || l == 0 {
+		return nil
+	}
+	b := make([]byte, l)
+	dec.readFull(b)
+	return b
+}
+
+func (dec *decoder) readElements() []fr.Element {
+	if dec.err != nil {
+		return nil
+	}
+	var vector fr.Vector
+	m, err := vector.ReadFrom(dec.r)
+	dec.n += m
+	dec.err = err
+	return vector
+}
+
+// readMerkleProofs reads Merkle proofs written by writeMerkleProofs, into the
+// proofs returned by alloc, restoring their roots and numbers of leaves. It
+// returns the roots and the number of rounds.
+func (dec *decoder) readMerkleProofs(alloc func(nbRounds, nbRoots int) func(q, i int) *MerkleProof) ([][]byte, int) {
+	var roots [][]byte
+	if nbRoots := dec.readUint32(); nbRoots > 0 {
+		roots = make([][]byte, nbRoots)
+	}
+	for i := range roots {
+		roots[i] = dec.readBytes()
+	}
+	nbRounds := dec.readUint32()
+	if dec.err != nil {
+		return nil, 0
+	}
+	proof := alloc(nbRounds, len(roots))
+	for q := 0; q < nbRounds; q++ {
+		for i := range roots {
+			l := dec.readUint32()
+			if dec.err != nil {
+				return nil, 0
+			}
+			if l == 0 || l > bits.UintSize {
+				dec.err = ErrMerklePath
+				return nil, 0
+			}
+			p := proof(q, i)
+			p.MerkleRoot = roots[i]
+			p.numLeaves = 1 << (l - 1)
+			p.ProofSet = make([][]byte, l)
+			for k := range p.ProofSet {
+				p.ProofSet[k] = dec.readBytes()
+			}
+		}
+	}
+	return roots, nbRounds
+}
@@ -0,0 +1,470 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// HidingProvingKey used to create or open hiding commitments. A polynomial p
+// is committed to with a random blinding polynomial r as
+// [p(α)]G₁ + [r(α)]H, where H = [γ]G₁ is the blinding base.
+type HidingProvingKey struct {
+	ProvingKey
+	G1Blinding []curve.G1Affine // [H, [α]H, [α²]H, ... ]
+}
+
+// HidingVerifyingKey used to verify hiding opening proofs
+type HidingVerifyingKey struct {
+	VerifyingKey
+	H curve.G1Affine // blinding base [γ]G₁
+}
+
+// HidingSRS is the SRS of the hiding commitments, with the powers of α of a
+// second generator H of G₁. It must be computed through MPC, see
+// HidingMpcSetup.
+type HidingSRS struct {
+	Pk HidingProvingKey
+	Vk HidingVerifyingKey
+}
+
+// HidingOpeningProof hiding KZG proof for opening at a single point. It
+// reveals the evaluation only through the Pedersen commitment BlindedValue.
+//
+// implements io.ReaderFrom and io.WriterTo
+type HidingOpeningProof struct {
+	// H [q(α)]G₁ + [q̂(α)]H, with q, q̂ the quotients of the polynomial and
+	// of its blinding polynomial
+	H curve.G1Affine
+
+	// BlindedValue [p(a)]G₁ + [r(a)]H, with p the polynomial and r its
+	// blinding polynomial
+	BlindedValue curve.G1Affine
+}
+
+// HidingBatchOpeningProof hiding opening proof for many polynomials at the
+// same point
+//
+// implements io.ReaderFrom and io.WriterTo
+type HidingBatchOpeningProof struct {
+	// H quotient polynomials of the folded polynomial and blinding polynomial
+	H curve.G1Affine
+
+	// BlindedValues commitments to the purported values
+	BlindedValues []curve.G1Affine
+}
+
+// NewHidingSRS returns a new HidingSRS using alpha and gamma as randomness
+// source, the blinding base being H = [γ]G₁.
+//
+// In production, a SRS generated through MPC should be used.
+func NewHidingSRS(size uint64, bAlpha, bGamma *big.Int) (*HidingSRS, error) {
+	srs, err := NewSRS(size, bAlpha)
+	if err != nil {
+		return nil, err
+	}
+	var res HidingSRS
+	res.Pk.ProvingKey = srs.Pk
+	res.Vk.VerifyingKey = srs.Vk
+
+	var gamma fr.Element
+	var gammaBigInt big.Int
+	gamma.SetBigInt(bGamma).BigInt(&gammaBigInt)
+	blinding := make([]curve.G1Jac, size)
+	parallel.Execute(int(size), func(start, end int) {
+		for i := start; i < end; i++ {
+			blinding[i].FromAffine(&srs.Pk.G1[i])
+			blinding[i].ScalarMultiplication(&blinding[i], &gammaBigInt)
+		}
+	})
+	res.Pk.G1Blinding = curve.BatchJacobianToAffineG1(blinding)
+	res.Vk.H = res.Pk.G1Blinding[0]
+
+	return &res, nil
+}
+
+// CommitHiding commits to a polynomial in canonical form with a random
+// blinding polynomial of the same size, and returns the commitment and the
+// blinding polynomial, which is needed to open the commitment.
+func CommitHiding(p []fr.Element, pk HidingProvingKey, nbTasks ...int) (Digest, []fr.Element, error) {
+	if len(p) == 0 || len(p) > len(pk.G1) || len(p) > len(pk.G1Blinding) {
+		return Digest{}, nil, ErrInvalidPolynomialSize
+	}
+	blinding := make([]fr.Element, len(p))
+	for i := range blinding {
+		if _, err := blinding[i].SetRandom(); err != nil {
+			return Digest{}, nil, err
+		}
+	}
+	digest, err := commitHiding(p, blinding, pk, nbTasks...)
+	if err != nil {
+		return Digest{}, nil, err
+	}
+	return digest, blinding, nil
+}
+
+// OpenHiding computes a hiding opening proof at point of the polynomial p,
+// committed to with the blinding polynomial blinding.
+func OpenHiding(p, blinding []fr.Element, point fr.Element, pk HidingProvingKey) (HidingOpeningProof, error) {
+	if len(p) == 0 || len(p) > len(pk.G1) || len(blinding) == 0 || len(blinding) > len(pk.G1Blinding) {
+		return HidingOpeningProof{}, ErrInvalidPolynomialSize
+	}
+
+	claimedValue, blindingValue := eval(p, point), eval(blinding, point)
+	var res HidingOpeningProof
+	res.BlindedValue = commitValue(claimedValue, blindingValue, pk.ProvingKey.G1[0], pk.G1Blinding[0])
+
+	// compute the quotients, which reuse memory from _p and _blinding
+	_p := make([]fr.Element, len(p))
+	copy(_p, p)
+	_blinding := make([]fr.Element, len(blinding))
+	copy(_blinding, blinding)
+	h := dividePolyByXminusA(_p, claimedValue, point)
+	hBlinding := dividePolyByXminusA(_blinding, blindingValue, point)
+
+	var err error
+	if res.H, err = commitHiding(h, hBlinding, pk); err != nil {
+		return HidingOpeningProof{}, err
+	}
+	return res, nil
+}
+
+// VerifyHiding verifies a hiding KZG opening proof at a single point, that
+// is that the polynomial committed to evaluates at point to the value
+// committed to in proof.BlindedValue.
+func VerifyHiding(commitment *Digest, proof *HidingOpeningProof, point fr.Element, vk HidingVerifyingKey) error {
+
+	// [p(a) + r(a)γ]G₁ + [-a]([H(α)]G₁) - [p(α) + r(α)γ]G₁
+	var totalG1, tmp curve.G1Jac
+	var pointNeg fr.Element
+	var pointInt big.Int
+	pointNeg.Neg(&point).BigInt(&pointInt)
+	totalG1.ScalarMultiplication(tmp.FromAffine(&proof.H), &pointInt)
+	totalG1.AddMixed(&proof.BlindedValue)
+	totalG1.SubAssign(tmp.FromAffine(commitment))
+
+	// e([p(a) + r(a)γ - aH(α) - p(α) - r(α)γ]G₁, G₂).e([H(α)]G₁, [α]G₂) == 1
+	var totalG1Aff curve.G1Affine
+	totalG1Aff.FromJacobian(&totalG1)
+	check, err := curve.PairingCheckFixedQ(
+		[]curve.G1Affine{totalG1Aff, proof.H},
+		vk.Lines[:],
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// VerifyBlindedValue checks that blindedValue = [claimedValue]G₁ + [blindingValue]H,
+// that is that claimedValue is the evaluation proven by a hiding opening
+// proof, when the prover chooses to reveal it along with the evaluation of
+// the blinding polynomial.
+func VerifyBlindedValue(blindedValue *curve.G1Affine, claimedValue, blindingValue fr.Element, vk HidingVerifyingKey) error {
+	expected := commitValue(claimedValue, blindingValue, vk.G1, vk.H)
+	if !expected.Equal(blindedValue) {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// BatchOpenSinglePointHiding creates a hiding batch opening proof at point
+// of a list of polynomials, committed to with the blinding polynomials
+// blindings. It's an interactive protocol, made non-interactive using Fiat
+// Shamir.
+//
+// * point is the point at which the polynomials are opened.
+// * digests is the list of committed polynomials to open, need to derive the challenge using Fiat Shamir.
+// * polynomials is the list of polynomials to open, they are supposed to be of the same size.
+// * dataTranscript extra data that might be needed to derive the challenge used for folding
+func BatchOpenSinglePointHiding(polynomials, blindings [][]fr.Element, digests []Digest, point fr.Element, hf hash.Hash, pk HidingProvingKey, dataTranscript ...[]byte) (HidingBatchOpeningProof, error) {
+
+	// check for invalid sizes
+	nbDigests := len(digests)
+	if nbDigests != len(polynomials) || nbDigests != len(blindings) {
+		return HidingBatchOpeningProof{}, ErrInvalidNbDigests
+	}
+	if nbDigests == 0 {
+		return HidingBatchOpeningProof{}, ErrZeroNbDigests
+	}
+	largestPoly, largestBlinding := 0, 0
+	for i := range polynomials {
+		if len(polynomials[i]) == 0 || len(polynomials[i]) > len(pk.G1) || len(blindings[i]) == 0 || len(blindings[i]) > len(pk.G1Blinding) {
+			return HidingBatchOpeningProof{}, ErrInvalidPolynomialSize
+		}
+		largestPoly = max(largestPoly, len(polynomials[i]))
+		largestBlinding = max(largestBlinding, len(blindings[i]))
+	}
+
+	// compute the blinded values
+	var res HidingBatchOpeningProof
+	claimedValues := make([]fr.Element, nbDigests)
+	blindingValues := make([]fr.Element, nbDigests)
+	res.BlindedValues = make([]curve.G1Affine, nbDigests)
+	parallel.Execute(nbDigests, func(start, end int) {
+		for i := start; i < end; i++ {
+			claimedValues[i] = eval(polynomials[i], point)
+			blindingValues[i] = eval(blindings[i], point)
+			res.BlindedValues[i] = commitValue(claimedValues[i], blindingValues[i], pk.ProvingKey.G1[0], pk.G1Blinding[0])
+		}
+	})
+
+	// derive the challenge γ, binded to the point and the commitments
+	gamma, err := deriveGammaHiding(point, digests, res.BlindedValues, hf, dataTranscript...)
+	if err != nil {
+		return HidingBatchOpeningProof{}, err
+	}
+
+	// ∑ᵢγⁱpᵢ, ∑ᵢγⁱrᵢ and their values at point
+	foldedPolynomials, foldedEvaluations := foldPolynomials(polynomials, claimedValues, gamma, largestPoly)
+	foldedBlindings, foldedBlindingValues := foldPolynomials(blindings, blindingValues, gamma, largestBlinding)
+
+	// compute H
+	h := dividePolyByXminusA(foldedPolynomials, foldedEvaluations, point)
+	hBlinding := dividePolyByXminusA(foldedBlindings, foldedBlindingValues, point)
+	if res.H, err = commitHiding(h, hBlinding, pk); err != nil {
+		return HidingBatchOpeningProof{}, err
+	}
+
+	return res, nil
+}
+
+// FoldProofHiding fold the digests and the proofs in batchOpeningProof using
+// Fiat Shamir to obtain a hiding opening proof at a single point.
+//
+// * digests list of digests on which batchOpeningProof is based
+// * batchOpeningProof opening proof of digests
+// * transcript extra data needed to derive the challenge used for folding.
+// * returns the folded version of batchOpeningProof, Digest, the folded version of digests
+func FoldProofHiding(digests []Digest, batchOpeningProof *HidingBatchOpeningProof, point fr.Element, hf hash.Hash, dataTranscript ...[]byte) (HidingOpeningProof, Digest, error) {
+
+	nbDigests := len(digests)
+
+	// check consistency between numbers of claims vs number of digests
+	if nbDigests != len(batchOpeningProof.BlindedValues) {
+		return HidingOpeningProof{}, Digest{}, ErrInvalidNbDigests
+	}
+	if nbDigests == 0 {
+		return HidingOpeningProof{}, Digest{}, ErrZeroNbDigests
+	}
+
+	// derive the challenge γ, binded to the point and the commitments
+	gamma, err := deriveGammaHiding(point, digests, batchOpeningProof.BlindedValues, hf, dataTranscript...)
+	if err != nil {
+		return HidingOpeningProof{}, Digest{}, err
+	}
+
+	// gammai = [1,γ,γ²,..,γⁿ⁻¹]
+	gammai := make([]fr.Element, nbDigests)
+	gammai[0].SetOne()
+	for i := 1; i < nbDigests; i++ {
+		gammai[i].Mul(&gammai[i-1], &gamma)
+	}
+
+	// fold the digests and the blinded values
+	config := ecc.MultiExpConfig{}
+	var res HidingOpeningProof
+	var foldedDigests Digest
+	if _, err := foldedDigests.MultiExp(digests, gammai, config); err != nil {
+		return HidingOpeningProof{}, Digest{}, err
+	}
+	if _, err := res.BlindedValue.MultiExp(batchOpeningProof.BlindedValues, gammai, config); err != nil {
+		return HidingOpeningProof{}, Digest{}, err
+	}
+	res.H.Set(&batchOpeningProof.H)
+
+	return res, foldedDigests, nil
+}
+
+// BatchVerifySinglePointHiding verifies a hiding batched opening proof at a
+// single point of a list of polynomials.
+//
+// * digests list of digests on which opening proof is done
+// * batchOpeningProof proof of correct opening on the digests
+// * dataTranscript extra data that might be needed to derive the challenge used for the folding
+func BatchVerifySinglePointHiding(digests []Digest, batchOpeningProof *HidingBatchOpeningProof, point fr.Element, hf hash.Hash, vk HidingVerifyingKey, dataTranscript ...[]byte) error {
+
+	// fold the proof
+	foldedProof, foldedDigest, err := FoldProofHiding(digests, batchOpeningProof, point, hf, dataTranscript...)
+	if err != nil {
+		return err
+	}
+
+	// verify the foldedProof against the foldedDigest
+	return VerifyHiding(&foldedDigest, &foldedProof, point, vk)
+}
+
+// BatchVerifyMultiPointsHiding batch verifies a list of hiding opening
+// proofs at different points, with a single pairing.
+//
+// * digests list of committed polynomials
+// * proofs list of opening proofs, one for each digest
+// * points the list of points at which the opening are done
+func BatchVerifyMultiPointsHiding(digests []Digest, proofs []HidingOpeningProof, points []fr.Element, vk HidingVerifyingKey) error {
+
+	// check consistency nb proofs vs nb digests
+	if len(digests) != len(proofs) || len(digests) != len(points) {
+		return ErrInvalidNbDigests
+	}
+	if len(digests) == 0 {
+		return ErrZeroNbDigests
+	}
+
+	// if only one digest, call VerifyHiding
+	if len(digests) == 1 {
+		return VerifyHiding(&digests[0], &proofs[0], points[0], vk)
+	}
+
+	// sample random numbers λᵢ
+	n := len(digests)
+	randomNumbers := make([]fr.Element, n)
+	randomNumbers[0].SetOne()
+	for i := 1; i < n; i++ {
+		if _, err := randomNumbers[i].SetRandom(); err != nil {
+			return err
+		}
+	}
+
+	// ∑ᵢλᵢ([Cᵢ]G₁ - [Eᵢ]G₁ - aᵢ[Hᵢ(α)]G₁) with a single multi exponentiation
+	// on the digests, the blinded values and the quotients
+	points3 := make([]curve.G1Affine, 3*n)
+	scalars := make([]fr.Element, 3*n)
+	quotients := points3[2*n:]
+	for i := 0; i < n; i++ {
+		points3[i] = digests[i]
+		points3[n+i] = proofs[i].BlindedValue
+		quotients[i] = proofs[i].H
+		scalars[i] = randomNumbers[i]
+		scalars[n+i].Neg(&randomNumbers[i])
+		scalars[2*n+i].Mul(&randomNumbers[i], &points[i])
+	}
+	config := ecc.MultiExpConfig{}
+	var foldedDigests, foldedQuotients curve.G1Affine
+	if _, err := foldedDigests.MultiExp(points3, scalars, config); err != nil {
+		return err
+	}
+
+	// -∑ᵢλᵢ[Hᵢ(α)]G₁
+	if _, err := foldedQuotients.MultiExp(quotients, randomNumbers, config); err != nil {
+		return err
+	}
+	foldedQuotients.Neg(&foldedQuotients)
+
+	// e(∑ᵢλᵢ(Cᵢ - Eᵢ + aᵢHᵢ), G₂).e(-∑ᵢλᵢHᵢ, [α]G₂) == 1
+	check, err := curve.PairingCheckFixedQ(
+		[]curve.G1Affine{foldedDigests, foldedQuotients},
+		vk.Lines[:],
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// commitHiding returns [p(α)]G₁ + [r(α)]H.
+func commitHiding(p, r []fr.Element, pk HidingProvingKey, nbTasks ...int) (Digest, error) {
+	if len(p) == 0 || len(p) > len(pk.G1) || len(r) > len(pk.G1Blinding) {
+		return Digest{}, ErrInvalidPolynomialSize
+	}
+	points := make([]curve.G1Affine, 0, len(p)+len(r))
+	points = append(points, pk.G1[:len(p)]...)
+	points = append(points, pk.G1Blinding[:len(r)]...)
+	scalars := make([]fr.Element, 0, len(p)+len(r))
+	scalars = append(scalars, p...)
+	scalars = append(scalars, r...)
+
+	config := ecc.MultiExpConfig{}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+	var res Digest
+	if _, err := res.MultiExp(points, scalars, config); err != nil {
+		return Digest{}, err
+	}
+	return res, nil
+}
+
+// commitValue returns [value]G + [blinding]H.
+func commitValue(value, blinding fr.Element, g, h curve.G1Affine) curve.G1Affine {
+	var valueBigInt, blindingBigInt big.Int
+	value.BigInt(&valueBigInt)
+	blinding.BigInt(&blindingBigInt)
+	var res curve.G1Jac
+	res.JointScalarMultiplication(&g, &h, &valueBigInt, &blindingBigInt)
+	var resAff curve.G1Affine
+	resAff.FromJacobian(&res)
+	return resAff
+}
+
+// foldPolynomials returns ∑ᵢγⁱpᵢ of the given size and ∑ᵢγⁱvᵢ.
+func foldPolynomials(polynomials [][]fr.Element, values []fr.Element, gamma fr.Element, size int) ([]fr.Element, fr.Element) {
+	folded := make([]fr.Element, size)
+	copy(folded, polynomials[0])
+	foldedValue := values[0]
+	var gammai, tmp fr.Element
+	gammai.SetOne()
+	for i := 1; i < len(polynomials); i++ {
+		gammai.Mul(&gammai, &gamma)
+		tmp.Mul(&values[i], &gammai)
+		foldedValue.Add(&foldedValue, &tmp)
+		parallel.Execute(len(polynomials[i]), func(start, end int) {
+			var pj fr.Element
+			for j := start; j < end; j++ {
+				pj.Mul(&polynomials[i][j], &gammai)
+				folded[j].Add(&folded[j], &pj)
+			}
+		})
+	}
+	return folded, foldedValue
+}
+
+// deriveGammaHiding derives a challenge using Fiat Shamir to fold hiding
+// proofs.
+func deriveGammaHiding(point fr.Element, digests []Digest, blindedValues []curve.G1Affine, hf hash.Hash, dataTranscript ...[]byte) (fr.Element, error) {
+
+	// derive the challenge gamma, binded to the point and the commitments
+	fs := fiatshamir.NewTranscript(hf, "gamma")
+	if err := fs.Bind("gamma", point.Marshal()); err != nil {
+		return fr.Element{}, err
+	}
+	for i := range digests {
+		if err := fs.Bind("gamma", digests[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	for i := range blindedValues {
+		if err := fs.Bind("gamma", blindedValues[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	for i := range dataTranscript {
+		if err := fs.Bind("gamma", dataTranscript[i]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+
+	gammaByte, err := fs.ComputeChallenge("gamma")
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var gamma fr.Element
+	gamma.SetBytes(gammaByte)
+
+	return gamma, nil
+}
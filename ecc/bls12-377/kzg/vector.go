@@ -0,0 +1,537 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidVectorSize      = errors.New("invalid vector size (not a power of 2 or larger than SRS)")
+	ErrInvalidPosition        = errors.New("position out of the vector or repeated")
+	ErrAggregationKeyTooShort = errors.New("aggregation verifying key too short for the number of positions")
+	ErrVerifyAggregatedProof  = errors.New("can't verify aggregated vector opening proof")
+)
+
+// VectorProvingKey is the key of the KZG vector commitments with updatable
+// proofs and aggregation (aSVC) of Tomescu et al.
+//
+// A vector v of size n is committed to as the polynomial φ = ∑ vᵢ⋅Lᵢ in
+// Lagrange form on the subgroup ⟨ω⟩ of order n, and the proof of the position
+// i is the KZG opening proof of φ at ωⁱ, [(φ(τ) - vᵢ)/(τ - ωⁱ)]G₁. When vᵢ
+// changes, the commitment and all the proofs are updated with O(1) group
+// operations each with the update keys A and U, instead of recommitting and
+// reopening.
+//
+// See Tomescu, Abraham, Buterin, Drake, Feist, Khovratovich, Aggregatable
+// Subvector Commitments for Stateless Cryptocurrencies,
+// https://eprint.iacr.org/2020/527
+type VectorProvingKey struct {
+	// G1[j] = [τʲ]G₁ for j < n
+	G1 []curve.G1Affine
+
+	// Lagrange[i] = [Lᵢ(τ)]G₁
+	Lagrange []curve.G1Affine
+
+	// A[i] = [A(τ)/(τ - ωⁱ)]G₁, with A = Xⁿ - 1
+	A []curve.G1Affine
+
+	// U[i] = [(Lᵢ(τ) - 1)/(τ - ωⁱ)]G₁
+	U []curve.G1Affine
+
+	omegas []fr.Element    // ωⁱ
+	cells  *CellProvingKey // FK20 key to compute all the proofs
+}
+
+// NewVectorProvingKey returns the key to commit to vectors of the given size,
+// a power of 2 not larger than len(pk.G1).
+//
+// The update keys are computed with FFTs in G₁: as
+// A(X)/(X - ωⁱ) = ω⁻ⁱ∑_{j<n} ω^{-ij}Xʲ and
+// (Lᵢ(X) - 1)/(X - ωⁱ) = ω⁻ⁱ/n ∑_{j<n-1} (n-1-j)ω^{-ij}Xʲ,
+// they are scalings of inverse FFTs of the points [τʲ]G₁ and [(n-1-j)τʲ]G₁.
+func NewVectorProvingKey(pk ProvingKey, size uint64) (*VectorProvingKey, error) {
+	if size < 2 || bits.OnesCount64(size) != 1 || size > uint64(len(pk.G1)) {
+		return nil, ErrInvalidVectorSize
+	}
+	n := int(size)
+	res := &VectorProvingKey{G1: append([]curve.G1Affine(nil), pk.G1[:n]...)}
+	if err := res.precompute(); err != nil {
+		return nil, err
+	}
+
+	twiddlesInv, err := computeTwiddles(n, true)
+	if err != nil {
+		return nil, err
+	}
+	f := make([]curve.G1Jac, n)
+	g := make([]curve.G1Jac, n)
+	parallel.Execute(n, func(start, end int) {
+		var c fr.Element
+		var cBigInt big.Int
+		for j := start; j < end; j++ {
+			f[j].FromAffine(&res.G1[j])
+			c.SetUint64(uint64(n - 1 - j))
+			g[j].ScalarMultiplication(&f[j], c.BigInt(&cBigInt))
+		}
+	})
+	fftG1(f, twiddlesInv)
+	fftG1(g, twiddlesInv)
+
+	// Lagrange[i] = f[i]/n, A[i] = ω⁻ⁱ⋅f[i], U[i] = ω⁻ⁱ/n⋅g[i]
+	var nInv fr.Element
+	nInv.SetUint64(size).Inverse(&nInv)
+	l := make([]curve.G1Jac, n)
+	parallel.Execute(n, func(start, end int) {
+		var omegaInv, s fr.Element
+		var sBigInt big.Int
+		for i := start; i < end; i++ {
+			omegaInv.Inverse(&res.omegas[i])
+			l[i].ScalarMultiplication(&f[i], nInv.BigInt(&sBigInt))
+			f[i].ScalarMultiplication(&f[i], omegaInv.BigInt(&sBigInt))
+			s.Mul(&omegaInv, &nInv)
+			g[i].ScalarMultiplication(&g[i], s.BigInt(&sBigInt))
+		}
+	})
+	res.Lagrange = curve.BatchJacobianToAffineG1(l)
+	res.A = curve.BatchJacobianToAffineG1(f)
+	res.U = curve.BatchJacobianToAffineG1(g)
+
+	return res, nil
+}
+
+// precompute sets the roots of unity and the FK20 key from G1.
+func (pk *VectorProvingKey) precompute() error {
+	n := uint64(len(pk.G1))
+	if n < 2 || bits.OnesCount64(n) != 1 {
+		return ErrInvalidVectorSize
+	}
+	var err error
+	if pk.omegas, err = rootsOfUnity(n); err != nil {
+		return err
+	}
+	pk.cells, err = NewCellProvingKey(ProvingKey{G1: pk.G1}, n, n, 1)
+	return err
+}
+
+// Size returns the size of the vectors of the key.
+func (pk *VectorProvingKey) Size() int {
+	return len(pk.G1)
+}
+
+// Commit returns the commitment ∑ vᵢ⋅[Lᵢ(τ)]G₁ to the vector v. v may be
+// shorter than the key, the missing entries being zero.
+func (pk *VectorProvingKey) Commit(v []fr.Element) (Digest, error) {
+	return Commit(v, ProvingKey{G1: pk.Lagrange})
+}
+
+// Open returns the proof of the position i of the vector v, the opening proof
+// of its polynomial at ωⁱ, in O(n).
+func (pk *VectorProvingKey) Open(v []fr.Element, i int) (Digest, error) {
+	if len(v) != pk.Size() {
+		return Digest{}, ErrInvalidVectorSize
+	}
+	if i < 0 || i >= len(v) {
+		return Digest{}, ErrInvalidPosition
+	}
+	lpk := LagrangeProvingKey{G1: pk.Lagrange}
+	lpk.Shift.SetOne()
+	proof, err := OpenLagrange(v, pk.omegas[i], lpk)
+	if err != nil {
+		return Digest{}, err
+	}
+	return proof.H, nil
+}
+
+// OpenAll returns the proofs of all the positions of the vector v in
+// O(n log n), with the FK20 method of CellProvingKey.
+func (pk *VectorProvingKey) OpenAll(v []fr.Element) ([]Digest, error) {
+	if len(v) != pk.Size() {
+		return nil, ErrInvalidVectorSize
+	}
+	p := make([]fr.Element, len(v))
+	copy(p, v)
+	domain := fft.NewDomain(uint64(len(p)))
+	domain.FFTInverse(p, fft.DIF)
+	fft.BitReverse(p)
+	return pk.cells.ComputeProofs(p)
+}
+
+// UpdateCommitment returns the commitment to the vector after vᵢ is
+// incremented by delta, commitment + delta⋅[Lᵢ(τ)]G₁.
+func (pk *VectorProvingKey) UpdateCommitment(commitment *Digest, i int, delta fr.Element) (Digest, error) {
+	if i < 0 || i >= pk.Size() {
+		return Digest{}, ErrInvalidPosition
+	}
+	var res Digest
+	var b big.Int
+	res.ScalarMultiplication(&pk.Lagrange[i], delta.BigInt(&b))
+	res.Add(&res, commitment)
+	return res, nil
+}
+
+// UpdateProof returns the proof of the position j after vᵢ is incremented by
+// delta. The quotient of the proof changes by delta⋅Lᵢ/(X - ωʲ), which is
+// delta⋅(Lᵢ - 1)/(X - ωⁱ) if j = i, and otherwise
+//
+//	delta⋅ωⁱ/(n(ωʲ - ωⁱ))⋅(A/(X - ωʲ) - A/(X - ωⁱ))
+func (pk *VectorProvingKey) UpdateProof(proof *Digest, j, i int, delta fr.Element) (Digest, error) {
+	if i < 0 || i >= pk.Size() || j < 0 || j >= pk.Size() {
+		return Digest{}, ErrInvalidPosition
+	}
+	var res Digest
+	if j == i {
+		var b big.Int
+		res.ScalarMultiplication(&pk.U[i], delta.BigInt(&b))
+		res.Add(&res, proof)
+		return res, nil
+	}
+	var d fr.Element
+	d.Sub(&pk.omegas[j], &pk.omegas[i]).Inverse(&d)
+	pk.updateProof(&res, proof, j, i, &delta, &d)
+	return res, nil
+}
+
+// updateProof sets res to the proof of the position j ≠ i after vᵢ is
+// incremented by delta, with dInv = 1/(ωʲ - ωⁱ).
+func (pk *VectorProvingKey) updateProof(res, proof *Digest, j, i int, delta, dInv *fr.Element) {
+	var c, nInv fr.Element
+	var b big.Int
+	nInv.SetUint64(uint64(pk.Size())).Inverse(&nInv)
+	c.Mul(delta, dInv).Mul(&c, &pk.omegas[i]).Mul(&c, &nInv)
+
+	var w curve.G1Jac
+	w.FromAffine(&pk.A[i])
+	w.Neg(&w).AddMixed(&pk.A[j])
+	w.ScalarMultiplication(&w, c.BigInt(&b))
+	w.AddMixed(proof)
+	res.FromJacobian(&w)
+}
+
+// VerifyVectorProof verifies the proof that the position i of the vector of
+// the given size committed to is value. It is the Verify of the opening proof
+// at ωⁱ.
+func VerifyVectorProof(commitment, proof *Digest, i int, value fr.Element, size uint64, vk VerifyingKey) error {
+	if i < 0 || uint64(i) >= size {
+		return ErrInvalidPosition
+	}
+	omega, err := fr.Generator(size)
+	if err != nil {
+		return err
+	}
+	var point fr.Element
+	point.Exp(omega, big.NewInt(int64(i)))
+	return Verify(commitment, &OpeningProof{H: *proof, ClaimedValue: value}, point, vk)
+}
+
+// AggregateVectorProofs aggregates the proofs πᵢ of the positions i ∈ I of a
+// vector of the given size into the proof of the subvector,
+// π_I = ∑ πᵢ/A_I'(ωⁱ), with A_I = ∏_{i∈I} (X - ωⁱ). It is the commitment to
+// the quotient (φ - R_I)/A_I, where R_I interpolates the subvector on the ωⁱ.
+// It does not require any key.
+func AggregateVectorProofs(proofs []Digest, positions []int, size uint64) (Digest, error) {
+	if len(proofs) != len(positions) || len(proofs) == 0 {
+		return Digest{}, ErrInvalidNbDigests
+	}
+	points, err := positionPoints(positions, size)
+	if err != nil {
+		return Digest{}, err
+	}
+	c := fr.BatchInvert(derivativeAt(points))
+
+	var res Digest
+	if _, err = res.MultiExp(proofs, c, ecc.MultiExpConfig{}); err != nil {
+		return Digest{}, err
+	}
+	return res, nil
+}
+
+// AggregationVerifyingKey is the verifying key of the aggregated proofs of at
+// most len(G2)-1 positions: G1[j] = [τʲ]G₁ for j < len(G2)-1 and
+// G2[j] = [τʲ]G₂. The powers must come from the same setup as the ProvingKey,
+// for instance the monomial G₂ points of the Ethereum KZG ceremony.
+type AggregationVerifyingKey struct {
+	G1 []curve.G1Affine
+	G2 []curve.G2Affine
+}
+
+// NewAggregationVerifyingKey returns the verifying key of the aggregations of
+// at most nbPositions proofs, using alpha as randomness source, consistently
+// with NewSRS(_, bAlpha), including for bAlpha = -1.
+//
+// In production, a SRS generated through MPC should be used.
+func NewAggregationVerifyingKey(nbPositions uint64, bAlpha *big.Int) (AggregationVerifyingKey, error) {
+	if nbPositions == 0 {
+		return AggregationVerifyingKey{}, ErrMinSRSSize
+	}
+	var alpha fr.Element
+	if bAlpha.Cmp(big.NewInt(-1)) == 0 {
+		t, err := fr.Generator(4)
+		if err != nil {
+			return AggregationVerifyingKey{}, err
+		}
+		alpha = t
+	} else {
+		alpha.SetBigInt(bAlpha)
+	}
+
+	alphas := make([]fr.Element, nbPositions+1)
+	alphas[0].SetOne()
+	for i := 1; i < len(alphas); i++ {
+		alphas[i].Mul(&alphas[i-1], &alpha)
+	}
+	_, _, g1, g2 := curve.Generators()
+	return AggregationVerifyingKey{
+		G1: curve.BatchScalarMultiplicationG1(&g1, alphas[:nbPositions]),
+		G2: curve.BatchScalarMultiplicationG2(&g2, alphas),
+	}, nil
+}
+
+// VerifyAggregatedVectorProof verifies the aggregated proof that the positions
+// of the vector of the given size committed to are the values, with
+// e(C - [R_I(τ)]G₁, G₂) = e(π_I, [A_I(τ)]G₂).
+func VerifyAggregatedVectorProof(commitment, proof *Digest, positions []int, values []fr.Element, size uint64, vk AggregationVerifyingKey) error {
+	if len(positions) != len(values) || len(positions) == 0 {
+		return ErrInvalidNbDigests
+	}
+	if len(positions) >= len(vk.G2) || len(positions) > len(vk.G1) {
+		return ErrAggregationKeyTooShort
+	}
+	points, err := positionPoints(positions, size)
+	if err != nil {
+		return err
+	}
+
+	// A_I and R_I = ∑ vᵢ/A_I'(ωⁱ)⋅A_I/(X - ωⁱ) in canonical form
+	a := vanishingPolynomial(points)
+	c := fr.BatchInvert(derivativeAt(points))
+	r := make([]fr.Element, len(points))
+	q := make([]fr.Element, len(a))
+	var s fr.Element
+	for i := range points {
+		copy(q, a)
+		quotient := dividePolyByXminusA(q, fr.Element{}, points[i])
+		s.Mul(&values[i], &c[i])
+		for j := range r {
+			var t fr.Element
+			t.Mul(&quotient[j], &s)
+			r[j].Add(&r[j], &t)
+		}
+	}
+
+	var rCommit, lhs Digest
+	var aCommit curve.G2Affine
+	config := ecc.MultiExpConfig{}
+	if _, err = rCommit.MultiExp(vk.G1[:len(r)], r, config); err != nil {
+		return err
+	}
+	if _, err = aCommit.MultiExp(vk.G2[:len(a)], a, config); err != nil {
+		return err
+	}
+	lhs.Sub(commitment, &rCommit)
+	var negProof Digest
+	negProof.Neg(proof)
+
+	check, err := curve.PairingCheck(
+		[]curve.G1Affine{lhs, negProof},
+		[]curve.G2Affine{vk.G2[0], aCommit},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyAggregatedProof
+	}
+	return nil
+}
+
+// CommittedVector is a vector with its commitment and a cache of proofs of
+// some of its positions, kept up to date with O(1) group operations per
+// cached proof when an entry changes.
+type CommittedVector struct {
+	pk         *VectorProvingKey
+	values     []fr.Element
+	commitment Digest
+	proofs     map[int]Digest
+}
+
+// NewCommittedVector commits to a copy of values, of the size of the key.
+func NewCommittedVector(values []fr.Element, pk *VectorProvingKey) (*CommittedVector, error) {
+	if len(values) != pk.Size() {
+		return nil, ErrInvalidVectorSize
+	}
+	commitment, err := pk.Commit(values)
+	if err != nil {
+		return nil, err
+	}
+	return &CommittedVector{
+		pk:         pk,
+		values:     append([]fr.Element(nil), values...),
+		commitment: commitment,
+		proofs:     make(map[int]Digest),
+	}, nil
+}
+
+// Commitment returns the current commitment to the vector.
+func (cv *CommittedVector) Commitment() Digest {
+	return cv.commitment
+}
+
+// Value returns the entry at the position i.
+func (cv *CommittedVector) Value(i int) fr.Element {
+	return cv.values[i]
+}
+
+// Proof returns the proof of the position i, computed in O(n) and cached if
+// it is not already.
+func (cv *CommittedVector) Proof(i int) (Digest, error) {
+	if proof, ok := cv.proofs[i]; ok {
+		return proof, nil
+	}
+	proof, err := cv.pk.Open(cv.values, i)
+	if err != nil {
+		return Digest{}, err
+	}
+	cv.proofs[i] = proof
+	return proof, nil
+}
+
+// ComputeAllProofs computes and caches the proofs of all the positions in
+// O(n log n).
+func (cv *CommittedVector) ComputeAllProofs() error {
+	proofs, err := cv.pk.OpenAll(cv.values)
+	if err != nil {
+		return err
+	}
+	for i := range proofs {
+		cv.proofs[i] = proofs[i]
+	}
+	return nil
+}
+
+// Set sets the entry at the position i to value, and updates the commitment
+// and the cached proofs.
+func (cv *CommittedVector) Set(i int, value fr.Element) error {
+	if i < 0 || i >= len(cv.values) {
+		return ErrInvalidPosition
+	}
+	var delta fr.Element
+	delta.Sub(&value, &cv.values[i])
+	if delta.IsZero() {
+		return nil
+	}
+	commitment, err := cv.pk.UpdateCommitment(&cv.commitment, i, delta)
+	if err != nil {
+		return err
+	}
+	cv.commitment = commitment
+	cv.values[i] = value
+
+	// the proof of i is updated with U, the others with A and 1/(ωʲ - ωⁱ),
+	// inverted in batch
+	positions := make([]int, 0, len(cv.proofs))
+	for j := range cv.proofs {
+		if j != i {
+			positions = append(positions, j)
+		}
+	}
+	d := make([]fr.Element, len(positions))
+	for k, j := range positions {
+		d[k].Sub(&cv.pk.omegas[j], &cv.pk.omegas[i])
+	}
+	d = fr.BatchInvert(d)
+	proofs := make([]Digest, len(positions))
+	parallel.Execute(len(positions), func(start, end int) {
+		for k := start; k < end; k++ {
+			j := positions[k]
+			proof := cv.proofs[j]
+			cv.pk.updateProof(&proofs[k], &proof, j, i, &delta, &d[k])
+		}
+	})
+	for k, j := range positions {
+		cv.proofs[j] = proofs[k]
+	}
+	if proof, ok := cv.proofs[i]; ok {
+		if cv.proofs[i], err = cv.pk.UpdateProof(&proof, i, i, delta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rootsOfUnity returns the powers of the generator of the subgroup of order
+// n, in natural order.
+func rootsOfUnity(n uint64) ([]fr.Element, error) {
+	omega, err := fr.Generator(n)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]fr.Element, n)
+	res[0].SetOne()
+	for i := 1; i < len(res); i++ {
+		res[i].Mul(&res[i-1], &omega)
+	}
+	return res, nil
+}
+
+// positionPoints returns the ωⁱ of the distinct positions i of a vector of
+// the given size.
+func positionPoints(positions []int, size uint64) ([]fr.Element, error) {
+	omega, err := fr.Generator(size)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[int]struct{}, len(positions))
+	points := make([]fr.Element, len(positions))
+	for k, i := range positions {
+		if _, ok := seen[i]; ok || i < 0 || uint64(i) >= size {
+			return nil, ErrInvalidPosition
+		}
+		seen[i] = struct{}{}
+		points[k].Exp(omega, big.NewInt(int64(i)))
+	}
+	return points, nil
+}
+
+// derivativeAt returns the A'(xᵢ) = ∏_{j≠i} (xᵢ - xⱼ), with A = ∏ (X - xⱼ).
+func derivativeAt(points []fr.Element) []fr.Element {
+	res := make([]fr.Element, len(points))
+	var d fr.Element
+	for i := range points {
+		res[i].SetOne()
+		for j := range points {
+			if j != i {
+				d.Sub(&points[i], &points[j])
+				res[i].Mul(&res[i], &d)
+			}
+		}
+	}
+	return res
+}
+
+// vanishingPolynomial returns ∏ (X - xᵢ) in canonical form.
+func vanishingPolynomial(points []fr.Element) []fr.Element {
+	res := make([]fr.Element, len(points)+1)
+	res[0].SetOne()
+	var t fr.Element
+	for i := range points {
+		// res ← res⋅(X - xᵢ)
+		for j := i + 1; j > 0; j-- {
+			t.Mul(&res[j], &points[i])
+			res[j].Sub(&res[j-1], &t)
+		}
+		res[0].Mul(&res[0], &points[i]).Neg(&res[0])
+	}
+	return res
+}
@@ -0,0 +1,240 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pst
+
+import (
+	"errors"
+	"io"
+	"math/bits"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
+)
+
+var errInvalidSRSSize = errors.New("invalid number of points in the SRS")
+
+// WriteTo writes binary encoding of the ProvingKey
+func (pk *ProvingKey) WriteTo(w io.Writer) (int64, error) {
+	return pk.writeTo(w)
+}
+
+// WriteRawTo writes binary encoding of ProvingKey to w without point compression
+func (pk *ProvingKey) WriteRawTo(w io.Writer) (int64, error) {
+	return pk.writeTo(w, curve.RawEncoding())
+}
+
+func (pk *ProvingKey) writeTo(w io.Writer, options ...func(*curve.Encoder)) (int64, error) {
+	// encode the Lagrange bases, from the one in all the variables; their
+	// number is implied by the size of the first one
+	enc := curve.NewEncoder(w, options...)
+	for i := range pk.G1 {
+		if err := enc.Encode(pk.G1[i]); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes ProvingKey data from reader.
+func (pk *ProvingKey) ReadFrom(r io.Reader) (int64, error) {
+	return pk.readFrom(r)
+}
+
+// UnsafeReadFrom decodes ProvingKey data from reader without checking
+// that point are in the correct subgroup.
+func (pk *ProvingKey) UnsafeReadFrom(r io.Reader) (int64, error) {
+	return pk.readFrom(r, curve.NoSubgroupChecks())
+}
+
+func (pk *ProvingKey) readFrom(r io.Reader, options ...func(*curve.Decoder)) (int64, error) {
+	dec := curve.NewDecoder(r, options...)
+	var g1 []curve.G1Affine
+	if err := dec.Decode(&g1); err != nil {
+		return dec.BytesRead(), err
+	}
+	n := bits.TrailingZeros(uint(len(g1)))
+	if len(g1) < 2 || len(g1) != 1<<n {
+		return dec.BytesRead(), errInvalidSRSSize
+	}
+	pk.G1 = make([][]curve.G1Affine, n+1)
+	pk.G1[0] = g1
+	for i := 1; i <= n; i++ {
+		if err := dec.Decode(&pk.G1[i]); err != nil {
+			return dec.BytesRead(), err
+		}
+		if len(pk.G1[i]) != 1<<(n-i) {
+			return dec.BytesRead(), errInvalidSRSSize
+		}
+	}
+	return dec.BytesRead(), nil
+}
+
+// WriteTo writes binary encoding of the VerifyingKey
+func (vk *VerifyingKey) WriteTo(w io.Writer) (int64, error) {
+	return vk.writeTo(w)
+}
+
+// WriteRawTo writes binary encoding of VerifyingKey to w without point compression
+func (vk *VerifyingKey) WriteRawTo(w io.Writer) (int64, error) {
+	return vk.writeTo(w, curve.RawEncoding())
+}
+
+func (vk *VerifyingKey) writeTo(w io.Writer, options ...func(*curve.Encoder)) (int64, error) {
+	// the pairing lines are not encoded, but precomputed when decoding
+	enc := curve.NewEncoder(w, options...)
+	toEncode := []interface{}{
+		&vk.G1,
+		vk.G2,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes VerifyingKey data from reader.
+func (vk *VerifyingKey) ReadFrom(r io.Reader) (int64, error) {
+	dec := curve.NewDecoder(r)
+	toDecode := []interface{}{
+		&vk.G1,
+		&vk.G2,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+	if len(vk.G2) < 2 {
+		return dec.BytesRead(), errInvalidSRSSize
+	}
+	vk.precomputeLines()
+
+	return dec.BytesRead(), nil
+}
+
+// WriteTo writes binary encoding of the entire SRS
+func (srs *SRS) WriteTo(w io.Writer) (int64, error) {
+	// encode the SRS
+	var pn, vn int64
+	var err error
+	if pn, err = srs.Pk.WriteTo(w); err != nil {
+		return pn, err
+	}
+	vn, err = srs.Vk.WriteTo(w)
+	return pn + vn, err
+}
+
+// WriteRawTo writes binary encoding of the entire SRS without point compression
+func (srs *SRS) WriteRawTo(w io.Writer) (int64, error) {
+	// encode the SRS
+	var pn, vn int64
+	var err error
+	if pn, err = srs.Pk.WriteRawTo(w); err != nil {
+		return pn, err
+	}
+	vn, err = srs.Vk.WriteRawTo(w)
+	return pn + vn, err
+}
+
+// ReadFrom decodes SRS data from reader.
+func (srs *SRS) ReadFrom(r io.Reader) (int64, error) {
+	// decode the SRS
+	var pn, vn int64
+	var err error
+	if pn, err = srs.Pk.ReadFrom(r); err != nil {
+		return pn, err
+	}
+	vn, err = srs.Vk.ReadFrom(r)
+	return pn + vn, err
+}
+
+// UnsafeReadFrom decodes SRS data from reader without sub group checks
+func (srs *SRS) UnsafeReadFrom(r io.Reader) (int64, error) {
+	// decode the SRS
+	var pn, vn int64
+	var err error
+	if pn, err = srs.Pk.UnsafeReadFrom(r); err != nil {
+		return pn, err
+	}
+	vn, err = srs.Vk.ReadFrom(r)
+	return pn + vn, err
+}
+
+// WriteTo writes binary encoding of a OpeningProof
+func (proof *OpeningProof) WriteTo(w io.Writer) (int64, error) {
+	enc := curve.NewEncoder(w)
+
+	toEncode := []interface{}{
+		proof.Quotients,
+		&proof.ClaimedValue,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes OpeningProof data from reader.
+func (proof *OpeningProof) ReadFrom(r io.Reader) (int64, error) {
+	dec := curve.NewDecoder(r)
+
+	toDecode := []interface{}{
+		&proof.Quotients,
+		&proof.ClaimedValue,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+
+// WriteTo writes binary encoding of a BatchOpeningProof
+func (proof *BatchOpeningProof) WriteTo(w io.Writer) (int64, error) {
+	enc := curve.NewEncoder(w)
+
+	toEncode := []interface{}{
+		proof.Quotients,
+		proof.ClaimedValues,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes BatchOpeningProof data from reader.
+func (proof *BatchOpeningProof) ReadFrom(r io.Reader) (int64, error) {
+	dec := curve.NewDecoder(r)
+
+	toDecode := []interface{}{
+		&proof.Quotients,
+		&proof.ClaimedValues,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
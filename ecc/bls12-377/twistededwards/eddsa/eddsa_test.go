@@ -7,6 +7,7 @@ package eddsa
 
 import (
 	"crypto/sha256"
+	"errors"
 	"math/big"
 	"math/rand"
 	"testing"
@@ -254,6 +255,67 @@ func TestEddsaSHA256(t *testing.T) {
 
 }
 
+func TestBatchVerify(t *testing.T) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src) //#nosec G404 weak rng is fine here
+
+	hFunc := hash.MIMC_BLS12_377.New()
+
+	const n = 20
+	pubs := make([]*PublicKey, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	var privKey *PrivateKey
+	var err error
+	for i := 0; i < n; i++ {
+		// some public keys sign several messages
+		if i%3 != 2 {
+			if privKey, err = GenerateKey(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+		pubs[i] = &privKey.PublicKey
+		var frMsg fr.Element
+		frMsg.SetUint64(uint64(i))
+		msgBin := frMsg.Bytes()
+		msgs[i] = msgBin[:]
+		if sigs[i], err = privKey.Sign(msgs[i], hFunc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// valid batch
+	res, err := BatchVerify(pubs, msgs, sigs, hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("BatchVerify of correct signatures should return true")
+	}
+
+	// wrong message
+	msgs[7], msgs[8] = msgs[8], msgs[7]
+	res, err = BatchVerify(pubs, msgs, sigs, hFunc)
+	var sigErr *InvalidSignatureError
+	if res || !errors.As(err, &sigErr) || sigErr.Index != 7 {
+		t.Fatal("BatchVerify should report the first wrong signature")
+	}
+	msgs[7], msgs[8] = msgs[8], msgs[7]
+
+	// malformed signature
+	sigs[3] = sigs[3][:sizeFr]
+	res, err = BatchVerify(pubs, msgs, sigs, hFunc)
+	if res || !errors.As(err, &sigErr) || sigErr.Index != 3 || !errors.Is(err, errWrongSize) {
+		t.Fatal("BatchVerify should report the malformed signature")
+	}
+
+	// inputs of different lengths
+	if _, err := BatchVerify(pubs, msgs[1:], sigs, hFunc); err == nil {
+		t.Fatal("BatchVerify should fail on inputs of different lengths")
+	}
+}
+
 // benchmarks
 
 func BenchmarkVerify(b *testing.B) {
@@ -279,3 +341,30 @@ func BenchmarkVerify(b *testing.B) {
 		pubKey.Verify(signature, msgBin[:], hFunc)
 	}
 }
+
+func BenchmarkBatchVerify(b *testing.B) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src) //#nosec G404 weak rng is fine here
+
+	hFunc := hash.MIMC_BLS12_377.New()
+
+	const n = 128
+	pubs := make([]*PublicKey, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		privKey, _ := GenerateKey(r)
+		pubs[i] = &privKey.PublicKey
+		var frMsg fr.Element
+		frMsg.SetUint64(uint64(i))
+		msgBin := frMsg.Bytes()
+		msgs[i] = msgBin[:]
+		sigs[i], _ = privKey.Sign(msgs[i], hFunc)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BatchVerify(pubs, msgs, sigs, hFunc)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+)
+
+// MultiExp computes the multi-scalar multiplication ∑ scalars[i]⋅points[i]
+// with the bucket method (Pippenger) and sets p to the result.
+//
+// The scalars must be non-negative. They are not reduced modulo the order of
+// the curve.
+func (p *PointExtended) MultiExp(points []PointAffine, scalars []big.Int) (*PointExtended, error) {
+	if len(points) != len(scalars) {
+		return nil, errors.New("len(points) != len(scalars)")
+	}
+
+	maxBits := 0
+	for i := range scalars {
+		if scalars[i].Sign() < 0 {
+			return nil, errors.New("negative scalar")
+		}
+		if l := scalars[i].BitLen(); l > maxBits {
+			maxBits = l
+		}
+	}
+
+	var res PointExtended
+	res.setInfinity()
+	if maxBits == 0 {
+		p.Set(&res)
+		return p, nil
+	}
+
+	c := bestC(len(points))
+	nbChunks := (maxBits + c - 1) / c
+	buckets := make([]PointExtended, (1<<c)-1)
+	var runningSum, chunkSum PointExtended
+
+	for chunk := nbChunks - 1; chunk >= 0; chunk-- {
+		for j := 0; j < c; j++ {
+			res.Double(&res)
+		}
+
+		for i := range buckets {
+			buckets[i].setInfinity()
+		}
+		for i := range points {
+			if digit := chunkDigit(&scalars[i], chunk, c); digit != 0 {
+				buckets[digit-1].MixedAdd(&buckets[digit-1], &points[i])
+			}
+		}
+
+		// ∑ (k+1)⋅buckets[k] with running sums
+		runningSum.setInfinity()
+		chunkSum.setInfinity()
+		for k := len(buckets) - 1; k >= 0; k-- {
+			runningSum.Add(&runningSum, &buckets[k])
+			chunkSum.Add(&chunkSum, &runningSum)
+		}
+		res.Add(&res, &chunkSum)
+	}
+
+	p.Set(&res)
+	return p, nil
+}
+
+// bestC returns the window size of the bucket method for nbPoints points.
+func bestC(nbPoints int) int {
+	// the cost is about nbChunks⋅(nbPoints + 2^(c+1)) additions
+	c := bits.Len(uint(nbPoints)) - 2
+	if c < 2 {
+		return 2
+	}
+	if c > 16 {
+		return 16
+	}
+	return c
+}
+
+// chunkDigit returns the bits [chunk⋅c, (chunk+1)⋅c) of s.
+func chunkDigit(s *big.Int, chunk, c int) int {
+	digit := 0
+	for j := c - 1; j >= 0; j-- {
+		digit = digit<<1 | int(s.Bit(chunk*c+j))
+	}
+	return digit
+}
@@ -0,0 +1,18 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Package banderwagon implements the Banderwagon prime order group, the
+// quotient of the bandersnatch points of the form 2⋅P by the 2-torsion point
+// (0, -1), as used by the Ethereum verkle trees.
+//
+// Two bandersnatch points (x, y) and (-x, -y) represent the same element, so
+// that the group has prime order, equal to the order of the bandersnatch
+// scalar field. An element is serialized as the 32 bytes big-endian encoding
+// of x⋅sign(y), and the decoding checks that the point is in the group with a
+// single Legendre symbol. Elements are mapped to the scalar field with x/y,
+// which is well defined on the quotient.
+//
+// Documentation:
+//   - https://hackmd.io/@6iQDuIePQjyYBqDChYw_jg/BJ2-L6Nzc
+//   - https://github.com/crate-crypto/go-ipa/tree/master/banderwagon
+package banderwagon
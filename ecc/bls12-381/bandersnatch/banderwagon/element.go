@@ -0,0 +1,227 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package banderwagon
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// SizeCompressed is the size in bytes of a serialized element
+const SizeCompressed = fp.Bytes
+
+var (
+	ErrInvalidSize     = errors.New("invalid encoding size")
+	ErrNotOnCurve      = errors.New("point is not on the curve")
+	ErrNotInSubgroup   = errors.New("point is not in the banderwagon subgroup")
+	ErrNonCanonicalEnc = errors.New("non canonical encoding")
+)
+
+// Element is an element of the banderwagon group, represented by any of the
+// two bandersnatch points (x, y), (-x, -y) of its class.
+type Element struct {
+	inner bandersnatch.PointExtended
+}
+
+var (
+	// Generator is the generator of the group, the class of the bandersnatch
+	// base point
+	Generator Element
+
+	// Identity is the neutral element of the group
+	Identity Element
+
+	curveParams bandersnatch.CurveParams
+)
+
+func init() {
+	curveParams = bandersnatch.GetEdwardsCurve()
+	Generator.inner.FromAffine(&curveParams.Base)
+	Identity.inner.FromAffine(&bandersnatch.PointAffine{Y: fp.One()})
+}
+
+// Set sets p to p1 and returns it.
+func (p *Element) Set(p1 *Element) *Element {
+	p.inner.Set(&p1.inner)
+	return p
+}
+
+// FromAffine sets p to the class of the bandersnatch point a, which must be in
+// the subgroup of order 2⋅r, and returns it.
+func (p *Element) FromAffine(a *bandersnatch.PointAffine) *Element {
+	p.inner.FromAffine(a)
+	return p
+}
+
+// Add sets p to p1 + p2 and returns it.
+func (p *Element) Add(p1, p2 *Element) *Element {
+	p.inner.Add(&p1.inner, &p2.inner)
+	return p
+}
+
+// Sub sets p to p1 - p2 and returns it.
+func (p *Element) Sub(p1, p2 *Element) *Element {
+	var neg bandersnatch.PointExtended
+	neg.Neg(&p2.inner)
+	p.inner.Add(&p1.inner, &neg)
+	return p
+}
+
+// Neg sets p to -p1 and returns it.
+func (p *Element) Neg(p1 *Element) *Element {
+	p.inner.Neg(&p1.inner)
+	return p
+}
+
+// Double sets p to 2⋅p1 and returns it.
+func (p *Element) Double(p1 *Element) *Element {
+	p.inner.Double(&p1.inner)
+	return p
+}
+
+// ScalarMultiplication sets p to s⋅p1 and returns it.
+func (p *Element) ScalarMultiplication(p1 *Element, s *fr.Element) *Element {
+	var b big.Int
+	p.inner.ScalarMultiplication(&p1.inner, s.BigInt(&b))
+	return p
+}
+
+// Equal returns true if p and p1 are the same element of the group, i.e. if
+// x⋅y₁ = x₁⋅y.
+func (p *Element) Equal(p1 *Element) bool {
+	var l, r fp.Element
+	l.Mul(&p.inner.X, &p1.inner.Y)
+	r.Mul(&p1.inner.X, &p.inner.Y)
+	return l.Equal(&r)
+}
+
+// IsIdentity returns true if p is the neutral element.
+func (p *Element) IsIdentity() bool {
+	return p.inner.X.IsZero()
+}
+
+// Bytes returns the serialization of p, the big-endian encoding of x if y is
+// lexicographically largest and of -x otherwise.
+func (p *Element) Bytes() [SizeCompressed]byte {
+	var a bandersnatch.PointAffine
+	a.FromExtended(&p.inner)
+	return serialize(&a)
+}
+
+func serialize(a *bandersnatch.PointAffine) [SizeCompressed]byte {
+	x := a.X
+	if !a.Y.LexicographicallyLargest() {
+		x.Neg(&x)
+	}
+	return x.Bytes()
+}
+
+// SetBytes decodes buf into p. It returns an error if buf is not the
+// canonical encoding of a coordinate x, if x is not the abscissa of a point of
+// the curve or if the point is not in the banderwagon subgroup.
+func (p *Element) SetBytes(buf []byte) error {
+	if len(buf) != SizeCompressed {
+		return ErrInvalidSize
+	}
+	var a bandersnatch.PointAffine
+	if err := a.X.SetBytesCanonical(buf); err != nil {
+		return ErrNonCanonicalEnc
+	}
+
+	// the points of 2⋅E are the points for which 1 - a⋅x² is a square
+	var one, num, den fp.Element
+	one.SetOne()
+	num.Square(&a.X)
+	den.Mul(&num, &curveParams.D)
+	num.Mul(&num, &curveParams.A)
+	num.Sub(&one, &num)
+	if num.Legendre() != 1 {
+		return ErrNotInSubgroup
+	}
+
+	// y² = (1 - a⋅x²) / (1 - d⋅x²)
+	den.Sub(&one, &den)
+	a.Y.Div(&num, &den)
+	if a.Y.Sqrt(&a.Y) == nil {
+		return ErrNotOnCurve
+	}
+	if !a.Y.LexicographicallyLargest() {
+		a.Y.Neg(&a.Y)
+	}
+	p.inner.FromAffine(&a)
+	return nil
+}
+
+// Marshal returns the serialization of p.
+func (p *Element) Marshal() []byte {
+	b := p.Bytes()
+	return b[:]
+}
+
+// Unmarshal is an alias of SetBytes.
+func (p *Element) Unmarshal(buf []byte) error {
+	return p.SetBytes(buf)
+}
+
+// MapToScalarField sets res to x/y reduced modulo the order of the group, and
+// returns it.
+func (p *Element) MapToScalarField(res *fr.Element) *fr.Element {
+	var xy fp.Element
+	xy.Div(&p.inner.X, &p.inner.Y)
+	return baseToScalar(res, &xy)
+}
+
+// BatchMapToScalarField sets res[i] to the mapping of points[i] to the scalar
+// field, see MapToScalarField, with a single inversion.
+func BatchMapToScalarField(res []fr.Element, points []Element) {
+	if len(res) != len(points) {
+		panic("len(res) != len(points)")
+	}
+	ys := make([]fp.Element, len(points))
+	for i := range points {
+		ys[i] = points[i].inner.Y
+	}
+	ys = fp.BatchInvert(ys)
+	for i := range points {
+		ys[i].Mul(&ys[i], &points[i].inner.X)
+		baseToScalar(&res[i], &ys[i])
+	}
+}
+
+// BatchToBytes returns the serializations of points with a single inversion.
+func BatchToBytes(points []Element) [][SizeCompressed]byte {
+	affine := BatchToAffine(points)
+	res := make([][SizeCompressed]byte, len(points))
+	for i := range affine {
+		res[i] = serialize(&affine[i])
+	}
+	return res
+}
+
+// baseToScalar sets res to x reduced modulo the order of the group.
+func baseToScalar(res *fr.Element, x *fp.Element) *fr.Element {
+	var b big.Int
+	x.BigInt(&b)
+	return res.SetBigInt(&b)
+}
+
+// BatchToAffine returns bandersnatch points of the classes of points, with a
+// single inversion.
+func BatchToAffine(points []Element) []bandersnatch.PointAffine {
+	zs := make([]fp.Element, len(points))
+	for i := range points {
+		zs[i] = points[i].inner.Z
+	}
+	zs = fp.BatchInvert(zs)
+	res := make([]bandersnatch.PointAffine, len(points))
+	for i := range points {
+		res[i].X.Mul(&points[i].inner.X, &zs[i])
+		res[i].Y.Mul(&points[i].inner.Y, &zs[i])
+	}
+	return res
+}
@@ -0,0 +1,226 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package banderwagon
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func randomElement() Element {
+	var s fr.Element
+	s.MustSetRandom()
+	var res Element
+	res.ScalarMultiplication(&Generator, &s)
+	return res
+}
+
+func TestEncodingVectors(t *testing.T) {
+	assert := require.New(t)
+
+	// encodings of 2ⁱ⋅G, from go-ipa
+	expected := []string{
+		"4a2c7486fd924882bf02c6908de395122843e3e05264d7991e18e7985dad51e9",
+		"43aa74ef706605705989e8fd38df46873b7eae5921fbed115ac9d937399ce4d5",
+		"5e5f550494159f38aa54d2ed7f11a7e93e4968617990445cc93ac8e59808c126",
+		"0e7e3748db7c5c999a7bcd93d71d671f1f40090423792266f94cb27ca43fce5c",
+		"14ddaa48820cb6523b9ae5fe9fe257cbbd1f3d598a28e670a40da5d1159d864a",
+		"6989d1c82b2d05c74b62fb0fbdf8843adae62ff720d370e209a7b84e14548a7d",
+		"26b8df6fa414bf348a3dc780ea53b70303ce49f3369212dec6fbe4b349b832bf",
+		"37e46072db18f038f2cc7d3d5b5d1374c0eb86ca46f869d6a95fc2fb092c0d35",
+		"2c1ce64f26e1c772282a6633fac7ca73067ae820637ce348bb2c8477d228dc7d",
+		"297ab0f5a8336a7a4e2657ad7a33a66e360fb6e50812d4be3326fab73d6cee07",
+		"5b285811efa7a965bd6ef5632151ebf399115fcc8f5b9b8083415ce533cc39ce",
+		"1f939fa2fd457b3effb82b25d3fe8ab965f54015f108f8c09d67e696294ab626",
+		"3088dcb4d3f4bacd706487648b239e0be3072ed2059d981fe04ce6525af6f1b8",
+		"35fbc386a16d0227ff8673bc3760ad6b11009f749bb82d4facaea67f58fc60ed",
+		"00f29b4f3255e318438f0a31e058e4c081085426adb0479f14c64985d0b956e0",
+		"3fa4384b2fa0ecc3c0582223602921daaa893a97b64bdf94dcaa504e8b7b9e5f",
+	}
+
+	p := Generator
+	points := make([]Element, len(expected))
+	for i := range expected {
+		b := p.Bytes()
+		assert.Equal(expected[i], hex.EncodeToString(b[:]), "2^%d⋅G", i)
+
+		var q Element
+		assert.NoError(q.SetBytes(b[:]))
+		assert.True(q.Equal(&p))
+
+		points[i] = p
+		p.Double(&p)
+	}
+
+	for i, b := range BatchToBytes(points) {
+		assert.Equal(expected[i], hex.EncodeToString(b[:]))
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	assert := require.New(t)
+
+	for i := 0; i < 20; i++ {
+		p := randomElement()
+		b := p.Bytes()
+		var q Element
+		assert.NoError(q.SetBytes(b[:]))
+		assert.True(q.Equal(&p))
+		assert.Equal(b, q.Bytes())
+	}
+
+	// (x, y) and (-x, -y) are the same element
+	p := randomElement()
+	var q Element
+	q.inner.X.Neg(&p.inner.X)
+	q.inner.Y.Neg(&p.inner.Y)
+	q.inner.T = p.inner.T
+	q.inner.Z = p.inner.Z
+	assert.True(q.Equal(&p))
+	assert.Equal(p.Bytes(), q.Bytes())
+
+	// the identity is encoded as 0
+	b := Identity.Bytes()
+	assert.Equal(make([]byte, SizeCompressed), b[:])
+	assert.NoError(q.SetBytes(b[:]))
+	assert.True(q.IsIdentity())
+
+	// invalid encodings
+	assert.ErrorIs(q.SetBytes(b[1:]), ErrInvalidSize)
+	modulus := fp.Modulus().Bytes()
+	assert.ErrorIs(q.SetBytes(modulus), ErrNonCanonicalEnc)
+
+	// about half of the coordinates are not in the subgroup
+	var x fp.Element
+	nbInvalid := 0
+	for i := 0; i < 32; i++ {
+		x.MustSetRandom()
+		b := x.Bytes()
+		if q.SetBytes(b[:]) != nil {
+			nbInvalid++
+		}
+	}
+	assert.NotZero(nbInvalid)
+}
+
+func TestGroupLaw(t *testing.T) {
+	assert := require.New(t)
+
+	p, q := randomElement(), randomElement()
+	var s, d, r Element
+	s.Add(&p, &q)
+	d.Sub(&s, &q)
+	assert.True(d.Equal(&p))
+	r.Neg(&p)
+	r.Add(&r, &p)
+	assert.True(r.IsIdentity())
+	assert.True(r.Equal(&Identity))
+	r.Double(&p)
+	s.Add(&p, &p)
+	assert.True(r.Equal(&s))
+	assert.False(p.Equal(&q))
+
+	// the group has the order of the scalar field
+	var order Element
+	var b big.Int
+	order.inner.ScalarMultiplication(&p.inner, b.Set(fr.Modulus()))
+	assert.True(order.IsIdentity())
+}
+
+func TestMapToScalarField(t *testing.T) {
+	assert := require.New(t)
+
+	points := make([]Element, 10)
+	for i := range points {
+		points[i] = randomElement()
+	}
+	points[3] = Identity
+	res := make([]fr.Element, len(points))
+	BatchMapToScalarField(res, points)
+	for i := range points {
+		var s fr.Element
+		points[i].MapToScalarField(&s)
+		assert.True(s.Equal(&res[i]))
+
+		// x/y is well defined on the class of the point
+		var neg Element
+		neg.inner.X.Neg(&points[i].inner.X)
+		neg.inner.Y.Neg(&points[i].inner.Y)
+		neg.inner.Z = points[i].inner.Z
+		neg.MapToScalarField(&s)
+		assert.True(s.Equal(&res[i]))
+	}
+	assert.True(res[3].IsZero())
+}
+
+func TestMultiExp(t *testing.T) {
+	assert := require.New(t)
+
+	const size = 300
+	points := make([]Element, size)
+	scalars := make([]fr.Element, size)
+	var expected Element
+	expected.Set(&Identity)
+	for i := range points {
+		points[i] = randomElement()
+		scalars[i].MustSetRandom()
+		var tmp Element
+		tmp.ScalarMultiplication(&points[i], &scalars[i])
+		expected.Add(&expected, &tmp)
+	}
+
+	var res Element
+	_, err := res.MultiExp(points, scalars)
+	assert.NoError(err)
+	assert.True(res.Equal(&expected))
+
+	table := NewPrecomputedTable(points)
+	res, err = table.Commit(scalars)
+	assert.NoError(err)
+	assert.True(res.Equal(&expected))
+
+	// sparse vectors
+	sparse := make([]fr.Element, size)
+	sparse[7] = scalars[7]
+	sparse[size-1].SetOne()
+	res, err = table.Commit(sparse)
+	assert.NoError(err)
+	expected.ScalarMultiplication(&points[7], &scalars[7])
+	expected.Add(&expected, &points[size-1])
+	assert.True(res.Equal(&expected))
+
+	res = table.ScalarMultiplicationBase(7, &scalars[7])
+	expected.ScalarMultiplication(&points[7], &scalars[7])
+	assert.True(res.Equal(&expected))
+
+	_, err = table.Commit(make([]fr.Element, size+1))
+	assert.Error(err)
+}
+
+func BenchmarkPrecomputedTable(b *testing.B) {
+	points := make([]Element, 256)
+	scalars := make([]fr.Element, 256)
+	for i := range points {
+		points[i] = randomElement()
+		scalars[i].MustSetRandom()
+	}
+	table := NewPrecomputedTable(points)
+
+	b.Run("commit", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = table.Commit(scalars)
+		}
+	})
+	b.Run("multiexp", func(b *testing.B) {
+		var res Element
+		for i := 0; i < b.N; i++ {
+			_, _ = res.MultiExp(points, scalars)
+		}
+	})
+}
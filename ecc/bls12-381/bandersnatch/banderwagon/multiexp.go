@@ -0,0 +1,147 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package banderwagon
+
+import (
+	"errors"
+	"math/big"
+	"runtime"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var errLenMismatch = errors.New("len(points) != len(scalars)")
+
+// MultiExp sets p to ∑ scalars[i]⋅points[i] and returns it.
+func (p *Element) MultiExp(points []Element, scalars []fr.Element) (*Element, error) {
+	if len(points) != len(scalars) {
+		return nil, errLenMismatch
+	}
+	affine := BatchToAffine(points)
+
+	// split the multi-exponentiation between the available CPUs
+	var res bandersnatch.PointExtended
+	res.Set(&Identity.inner)
+	var err error
+	var lock sync.Mutex
+	nbTasks := min(runtime.NumCPU(), (len(points)+63)/64)
+	parallel.Execute(len(points), func(start, end int) {
+		bi := make([]big.Int, end-start)
+		for i := start; i < end; i++ {
+			scalars[i].BigInt(&bi[i-start])
+		}
+		var partial bandersnatch.PointExtended
+		_, errPartial := partial.MultiExp(affine[start:end], bi)
+		lock.Lock()
+		res.Add(&res, &partial)
+		if errPartial != nil {
+			err = errPartial
+		}
+		lock.Unlock()
+	}, nbTasks)
+	if err != nil {
+		return nil, err
+	}
+	p.inner.Set(&res)
+	return p, nil
+}
+
+const (
+	// windowSize is the number of bits of the digits of the scalars in the
+	// commitments with a PrecomputedTable
+	windowSize = 4
+	nbDigits   = 1<<windowSize - 1
+	nbWindows  = (fr.Bits + windowSize - 1) / windowSize
+)
+
+// PrecomputedTable holds, for fixed bases Gᵢ, the multiples k⋅2⁴ʲ⋅Gᵢ for all
+// the 4 bits digits k and the windows j. A commitment ∑ sᵢ⋅Gᵢ then costs one
+// mixed addition per non-zero digit of the scalars, and no doubling, which
+// makes it very efficient for sparse vectors.
+type PrecomputedTable struct {
+	bases []Element
+
+	// table[i][j⋅15 + k-1] = k⋅2⁴ʲ⋅Gᵢ
+	table [][]bandersnatch.PointAffine
+}
+
+// NewPrecomputedTable returns the precomputed multiples of the bases.
+func NewPrecomputedTable(bases []Element) *PrecomputedTable {
+	t := &PrecomputedTable{
+		bases: append([]Element(nil), bases...),
+		table: make([][]bandersnatch.PointAffine, len(bases)),
+	}
+	parallel.Execute(len(bases), func(start, end int) {
+		multiples := make([]Element, nbWindows*nbDigits)
+		for i := start; i < end; i++ {
+			var g bandersnatch.PointExtended
+			g.Set(&bases[i].inner)
+			for j := 0; j < nbWindows; j++ {
+				w := multiples[j*nbDigits : (j+1)*nbDigits]
+				w[0].inner.Set(&g)
+				for k := 1; k < nbDigits; k++ {
+					w[k].inner.Add(&w[k-1].inner, &g)
+				}
+				g.Add(&w[nbDigits-1].inner, &g)
+			}
+			t.table[i] = BatchToAffine(multiples)
+		}
+	})
+	return t
+}
+
+// Bases returns the bases of the table.
+func (t *PrecomputedTable) Bases() []Element {
+	return t.bases
+}
+
+// Commit returns ∑ scalars[i]⋅Gᵢ. There must not be more scalars than bases.
+func (t *PrecomputedTable) Commit(scalars []fr.Element) (Element, error) {
+	if len(scalars) > len(t.bases) {
+		return Element{}, errLenMismatch
+	}
+
+	var res Element
+	res.Set(&Identity)
+	var lock sync.Mutex
+	nbTasks := min(runtime.NumCPU(), (len(scalars)+31)/32)
+	parallel.Execute(len(scalars), func(start, end int) {
+		var partial bandersnatch.PointExtended
+		partial.Set(&Identity.inner)
+		for i := start; i < end; i++ {
+			t.mulAdd(&partial, i, &scalars[i])
+		}
+		lock.Lock()
+		res.inner.Add(&res.inner, &partial)
+		lock.Unlock()
+	}, nbTasks)
+	return res, nil
+}
+
+// ScalarMultiplicationBase returns s⋅Gᵢ.
+func (t *PrecomputedTable) ScalarMultiplicationBase(i int, s *fr.Element) Element {
+	var res Element
+	res.Set(&Identity)
+	t.mulAdd(&res.inner, i, s)
+	return res
+}
+
+// mulAdd sets res to res + s⋅Gᵢ.
+func (t *PrecomputedTable) mulAdd(res *bandersnatch.PointExtended, i int, s *fr.Element) {
+	if s.IsZero() {
+		return
+	}
+	table := t.table[i]
+	words := s.Bits()
+	for j := 0; j < nbWindows; j++ {
+		bit := j * windowSize
+		digit := (words[bit/64] >> (bit % 64)) & nbDigits
+		if digit != 0 {
+			res.MixedAdd(res, &table[j*nbDigits+int(digit)-1])
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package ecvrf provides the ECVRF verifiable random function of RFC 9381 on bls12-381's bandersnatch curve.
+//
+// The owner of a private key computes with Prove a proof pi for an input
+// alpha. Anyone can check the proof with Verify and the public key, and
+// derive the pseudorandom output beta = ProofToHash(pi), which is unique for
+// a given public key and input.
+//
+// RFC 9381 defines no ciphersuite on this curve. The default ciphersuite
+// follows ECVRF-EDWARDS25519-SHA512-TAI: SHA-512, try-and-increment encoding
+// to the curve and compressed points. Integers are encoded in big endian, as
+// in the rest of gnark-crypto.
+//
+// The SNARK-friendly ciphersuite, selected with WithSNARKHash, replaces
+// SHA-512 by a hash function over fr (MiMC or Poseidon2): the points are
+// hashed as their two coordinates, so that the proofs can be verified
+// efficiently in a gnark circuit. In this ciphersuite, alpha must be a
+// sequence of field elements.
+//
+// # See also
+//
+// https://datatracker.ietf.org/doc/html/rfc9381
+package ecvrf
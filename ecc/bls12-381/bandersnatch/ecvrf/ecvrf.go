@@ -0,0 +1,419 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecvrf
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+const (
+	sizeFr        = fr.Bytes
+	sizePoint     = sizeFr // ptLen, compressed point
+	sizeChallenge = 16     // cLen
+	sizeScalar    = 32     // qLen
+
+	// log2 of the cofactor
+	logCofactor = 2
+
+	// SizeSecretKey is the size in bytes of a secret key.
+	SizeSecretKey = 32
+	// SizeProof is the size in bytes of a proof Gamma || c || s.
+	SizeProof = sizePoint + sizeChallenge + sizeScalar
+)
+
+// suite_string of the ciphersuites. RFC 9381 defines no ciphersuite on this
+// curve, these values are specific to this package.
+const (
+	suiteSHA512TAI byte = 0xf0
+	suiteSNARK     byte = 0xf1
+)
+
+// domain separators of the hash functions, RFC 9381 Section 5
+const (
+	domainEncodeToCurve byte = 0x01
+	domainChallenge     byte = 0x02
+	domainProofToHash   byte = 0x03
+	domainBack          byte = 0x00
+)
+
+var (
+	ErrInvalidProof     = errors.New("invalid proof")
+	ErrInvalidPublicKey = errors.New("public key is not in the prime order subgroup or is the identity")
+	errNotOnCurve       = errors.New("point not on curve")
+	errNonCanonical     = errors.New("point is not canonically encoded")
+	errEncodeToCurve    = errors.New("try-and-increment found no point")
+	errHashSize         = errors.New("the hash function must output field elements")
+	errWrongSize        = errors.New("wrong size buffer")
+	errZeroScalar       = errors.New("secret scalar is zero")
+)
+
+// PublicKey is an ECVRF public key Y = x*Base.
+type PublicKey struct {
+	Y bandersnatch.PointAffine
+}
+
+// PrivateKey is an ECVRF private key.
+type PrivateKey struct {
+	PublicKey PublicKey
+	secretKey [SizeSecretKey]byte // SK
+	scalar    big.Int             // x
+	nonceKey  [32]byte            // second half of SHA-512(SK)
+}
+
+// Option selects the ciphersuite of Prove, Verify and ProofToHash. The
+// default ciphersuite uses SHA-512.
+type Option func(*config)
+
+type config struct {
+	suite byte
+	hFunc hash.Hash
+}
+
+// WithSNARKHash selects the SNARK-friendly ciphersuite, with the hash
+// function hFunc over fr, for instance hash.MIMC_BLS12_381 or
+// hash.POSEIDON2_BLS12_381 of the hash package.
+func WithSNARKHash(hFunc hash.Hash) Option {
+	return func(cfg *config) {
+		cfg.suite = suiteSNARK
+		cfg.hFunc = hFunc
+	}
+}
+
+func newConfig(opts []Option) (*config, error) {
+	cfg := &config{suite: suiteSHA512TAI, hFunc: sha512.New()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.suite == suiteSNARK && (cfg.hFunc == nil || cfg.hFunc.Size() != sizeFr) {
+		return nil, errHashSize
+	}
+	return cfg, nil
+}
+
+// GenerateKey generates a key pair from a random secret key.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	var sk [SizeSecretKey]byte
+	if _, err := io.ReadFull(rand, sk[:]); err != nil {
+		return nil, err
+	}
+	return NewPrivateKey(sk[:])
+}
+
+// NewPrivateKey returns the key pair of the 32 bytes secret key sk. As in
+// ECVRF-EDWARDS25519-SHA512-TAI, the secret scalar x and the key of the nonce
+// generation are derived from the two halves of SHA-512(sk).
+func NewPrivateKey(sk []byte) (*PrivateKey, error) {
+	if len(sk) != SizeSecretKey {
+		return nil, errWrongSize
+	}
+	curveParams := bandersnatch.GetEdwardsCurve()
+	h := sha512.Sum512(sk)
+
+	privKey := new(PrivateKey)
+	copy(privKey.secretKey[:], sk)
+	privKey.scalar.SetBytes(h[:32]).Mod(&privKey.scalar, &curveParams.Order)
+	if privKey.scalar.Sign() == 0 {
+		return nil, errZeroScalar
+	}
+	copy(privKey.nonceKey[:], h[32:])
+	privKey.PublicKey.Y.ScalarMultiplication(&curveParams.Base, &privKey.scalar)
+	return privKey, nil
+}
+
+// Prove returns the proof pi that beta = ProofToHash(pi) is the output of
+// the VRF on the input alpha.
+//
+// RFC 9381, Section 5.1
+func Prove(privKey *PrivateKey, alpha []byte, opts ...Option) ([]byte, error) {
+	cfg, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	curveParams := bandersnatch.GetEdwardsCurve()
+	Y := &privKey.PublicKey.Y
+
+	H, err := cfg.encodeToCurve(Y, alpha)
+	if err != nil {
+		return nil, err
+	}
+	hString := H.Bytes()
+
+	var Gamma, U, V bandersnatch.PointAffine
+	Gamma.ScalarMultiplication(H, &privKey.scalar)
+	k := nonceGeneration(privKey.nonceKey[:], hString[:])
+	U.ScalarMultiplication(&curveParams.Base, k)
+	V.ScalarMultiplication(H, k)
+	c, err := cfg.challenge(Y, H, &Gamma, &U, &V)
+	if err != nil {
+		return nil, err
+	}
+
+	// s = k + c*x mod q
+	var s big.Int
+	s.Mul(c, &privKey.scalar).
+		Add(&s, k).
+		Mod(&s, &curveParams.Order)
+
+	pi := make([]byte, SizeProof)
+	GammaBin := Gamma.Bytes()
+	copy(pi[:sizePoint], GammaBin[:])
+	c.FillBytes(pi[sizePoint : sizePoint+sizeChallenge])
+	s.FillBytes(pi[sizePoint+sizeChallenge:])
+	return pi, nil
+}
+
+// Verify checks the proof pi that beta is the output of the VRF on the input
+// alpha under the public key, and returns beta. It returns ErrInvalidProof if
+// the proof is invalid.
+//
+// RFC 9381, Section 5.3
+func Verify(publicKey *PublicKey, alpha, pi []byte, opts ...Option) ([]byte, error) {
+	cfg, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	curveParams := bandersnatch.GetEdwardsCurve()
+	Y := &publicKey.Y
+	if err := validateKey(Y); err != nil {
+		return nil, err
+	}
+	Gamma, c, s, err := decodeProof(pi)
+	if err != nil {
+		return nil, err
+	}
+	H, err := cfg.encodeToCurve(Y, alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	// U = s*Base - c*Y, V = s*H - c*Gamma
+	var U, V, tmp bandersnatch.PointAffine
+	U.ScalarMultiplication(&curveParams.Base, s)
+	tmp.ScalarMultiplication(Y, c)
+	U.Add(&U, tmp.Neg(&tmp))
+	V.ScalarMultiplication(H, s)
+	tmp.ScalarMultiplication(Gamma, c)
+	V.Add(&V, tmp.Neg(&tmp))
+
+	cPrime, err := cfg.challenge(Y, H, Gamma, &U, &V)
+	if err != nil {
+		return nil, err
+	}
+	if cPrime.Cmp(c) != 0 {
+		return nil, ErrInvalidProof
+	}
+	return cfg.proofToHash(Gamma), nil
+}
+
+// ProofToHash returns the output beta of the VRF from the proof pi. It does
+// not verify the proof: pi must come from Prove or have been checked with
+// Verify.
+//
+// RFC 9381, Section 5.2
+func ProofToHash(pi []byte, opts ...Option) ([]byte, error) {
+	cfg, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	Gamma, _, _, err := decodeProof(pi)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.proofToHash(Gamma), nil
+}
+
+// encodeToCurve hashes the public key and alpha to a point of the prime order
+// subgroup with the try-and-increment method. Unlike in edwards25519, the
+// modulus can be much smaller than 2^(8*ptLen-1): the unused bits of the
+// candidate strings are cleared.
+//
+// RFC 9381, Section 5.4.1.1
+func (cfg *config) encodeToCurve(Y *bandersnatch.PointAffine, alpha []byte) (*bandersnatch.PointAffine, error) {
+	h := cfg.hFunc
+	var H bandersnatch.PointAffine
+	for ctr := 0; ctr < 256; ctr++ {
+		cfg.writePrefix(domainEncodeToCurve, Y)
+		if _, err := h.Write(alpha); err != nil {
+			return nil, err
+		}
+		var found bool
+		if cfg.suite == suiteSNARK {
+			// the digest is the ordinate of the candidate point
+			var buf [sizeFr]byte
+			buf[sizeFr-1] = byte(ctr)
+			h.Write(buf[:])
+			var y fr.Element
+			if err := y.SetBytesCanonical(h.Sum(nil)); err != nil {
+				return nil, errHashSize
+			}
+			found = pointFromY(&H, &y)
+		} else {
+			h.Write([]byte{byte(ctr), domainBack})
+			candidate := h.Sum(nil)[:sizePoint]
+			// clear the bits of the little endian ordinate above the size of
+			// the modulus, the most significant bit is the sign of the abscissa
+			for i := fr.Bits; i < 8*sizePoint-1; i++ {
+				candidate[i/8] &^= 1 << (i % 8)
+			}
+			found = stringToPoint(&H, candidate) == nil
+		}
+		if found {
+			clearCofactor(&H)
+			return &H, nil
+		}
+	}
+	return nil, errEncodeToCurve
+}
+
+// challenge returns the truncated hash of the points.
+//
+// RFC 9381, Section 5.4.3
+func (cfg *config) challenge(Y, H, Gamma, U, V *bandersnatch.PointAffine) (*big.Int, error) {
+	cfg.writePrefix(domainChallenge, Y, H, Gamma, U, V)
+	if cfg.suite != suiteSNARK {
+		cfg.hFunc.Write([]byte{domainBack})
+	}
+	cString := cfg.hFunc.Sum(nil)
+	if len(cString) < sizeChallenge {
+		return nil, errHashSize
+	}
+	return new(big.Int).SetBytes(cString[:sizeChallenge]), nil
+}
+
+// proofToHash returns the hash of cofactor*Gamma.
+func (cfg *config) proofToHash(Gamma *bandersnatch.PointAffine) []byte {
+	var p bandersnatch.PointAffine
+	p.Set(Gamma)
+	clearCofactor(&p)
+	cfg.writePrefix(domainProofToHash, &p)
+	if cfg.suite != suiteSNARK {
+		cfg.hFunc.Write([]byte{domainBack})
+	}
+	return cfg.hFunc.Sum(nil)
+}
+
+// writePrefix resets the hash function and writes suite_string, the domain
+// separator and the points. In the SNARK-friendly ciphersuite, suite_string
+// and the domain separator are written as a single field element, and the
+// points as their two coordinates.
+func (cfg *config) writePrefix(domain byte, points ...*bandersnatch.PointAffine) {
+	h := cfg.hFunc
+	h.Reset()
+	if cfg.suite == suiteSNARK {
+		var buf [sizeFr]byte
+		buf[sizeFr-2], buf[sizeFr-1] = cfg.suite, domain
+		h.Write(buf[:])
+		for _, p := range points {
+			x, y := p.X.Bytes(), p.Y.Bytes()
+			h.Write(x[:])
+			h.Write(y[:])
+		}
+		return
+	}
+	h.Write([]byte{cfg.suite, domain})
+	for _, p := range points {
+		b := p.Bytes()
+		h.Write(b[:])
+	}
+}
+
+// nonceGeneration returns k = SHA-512(nonceKey || h_string) mod q.
+//
+// RFC 9381, Section 5.4.2.2
+func nonceGeneration(nonceKey, hString []byte) *big.Int {
+	h := sha512.New()
+	h.Write(nonceKey)
+	h.Write(hString)
+	k := new(big.Int).SetBytes(h.Sum(nil))
+	curveParams := bandersnatch.GetEdwardsCurve()
+	return k.Mod(k, &curveParams.Order)
+}
+
+// decodeProof returns Gamma, c and s from the proof pi.
+//
+// RFC 9381, Section 5.4.4
+func decodeProof(pi []byte) (*bandersnatch.PointAffine, *big.Int, *big.Int, error) {
+	if len(pi) != SizeProof {
+		return nil, nil, nil, errWrongSize
+	}
+	var Gamma bandersnatch.PointAffine
+	if err := stringToPoint(&Gamma, pi[:sizePoint]); err != nil {
+		return nil, nil, nil, err
+	}
+	c := new(big.Int).SetBytes(pi[sizePoint : sizePoint+sizeChallenge])
+	s := new(big.Int).SetBytes(pi[sizePoint+sizeChallenge:])
+	curveParams := bandersnatch.GetEdwardsCurve()
+	if s.Cmp(&curveParams.Order) >= 0 {
+		return nil, nil, nil, ErrInvalidProof
+	}
+	return &Gamma, c, s, nil
+}
+
+// validateKey checks that the public key is in the prime order subgroup and
+// is not the identity, which is stricter than RFC 9381, Section 5.4.5.
+func validateKey(Y *bandersnatch.PointAffine) error {
+	if !Y.IsOnCurve() || Y.IsZero() || !Y.IsInSubGroup() {
+		return ErrInvalidPublicKey
+	}
+	return nil
+}
+
+// stringToPoint decodes the compressed point b, and checks that it is on the
+// curve and canonically encoded.
+func stringToPoint(p *bandersnatch.PointAffine, b []byte) error {
+	if _, err := p.SetBytes(b); err != nil {
+		return err
+	}
+	if !p.IsOnCurve() {
+		return errNotOnCurve
+	}
+	if enc := p.Bytes(); !bytes.Equal(enc[:], b) {
+		return errNonCanonical
+	}
+	return nil
+}
+
+// pointFromY sets p to the point of ordinate y whose abscissa is not
+// lexicographically largest, and returns false if there is none.
+func pointFromY(p *bandersnatch.PointAffine, y *fr.Element) bool {
+	curveParams := bandersnatch.GetEdwardsCurve()
+
+	// x² = (1 - y²) / (a - d*y²)
+	var one, num, den fr.Element
+	one.SetOne()
+	num.Square(y)
+	den.Mul(&num, &curveParams.D)
+	num.Sub(&one, &num)
+	den.Sub(&curveParams.A, &den)
+	if den.IsZero() {
+		return false
+	}
+	num.Div(&num, &den)
+	if p.X.Sqrt(&num) == nil {
+		return false
+	}
+	if p.X.LexicographicallyLargest() {
+		p.X.Neg(&p.X)
+	}
+	p.Y.Set(y)
+	return true
+}
+
+// clearCofactor sets p to cofactor*p. The cofactor is a power of 2, and the
+// GLV scalar multiplication is only valid in the prime order subgroup.
+func clearCofactor(p *bandersnatch.PointAffine) {
+	for i := 0; i < logCofactor; i++ {
+		p.Double(p)
+	}
+}
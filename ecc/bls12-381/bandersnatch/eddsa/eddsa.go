@@ -6,14 +6,16 @@
 package eddsa
 
 import (
+	"crypto/rand"
 	"crypto/subtle"
 	"errors"
+	"fmt"
 	"hash"
 	"io"
 	"math/big"
 
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
-	"github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards"
 	"github.com/consensys/gnark-crypto/signature"
 	"golang.org/x/crypto/blake2b"
 )
@@ -31,7 +33,7 @@ const (
 // PublicKey eddsa signature object
 // cf https://en.wikipedia.org/wiki/EdDSA for notation
 type PublicKey struct {
-	A twistededwards.PointAffine
+	A bandersnatch.PointAffine
 }
 
 // PrivateKey private key of an eddsa instance
@@ -44,13 +46,13 @@ type PrivateKey struct {
 // Signature represents an eddsa signature
 // cf https://en.wikipedia.org/wiki/EdDSA for notation
 type Signature struct {
-	R twistededwards.PointAffine
+	R bandersnatch.PointAffine
 	S [sizeFr]byte
 }
 
 // GenerateKey generates a public and private key pair.
 func GenerateKey(r io.Reader) (*PrivateKey, error) {
-	c := twistededwards.GetEdwardsCurve()
+	c := bandersnatch.GetEdwardsCurve()
 
 	var pub PublicKey
 	var priv PrivateKey
@@ -115,7 +117,7 @@ func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error)
 		return nil, errHashNeeded
 	}
 
-	curveParams := twistededwards.GetEdwardsCurve()
+	curveParams := bandersnatch.GetEdwardsCurve()
 
 	var res Signature
 
@@ -183,7 +185,7 @@ func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, err
 		return false, errHashNeeded
 	}
 
-	curveParams := twistededwards.GetEdwardsCurve()
+	curveParams := bandersnatch.GetEdwardsCurve()
 
 	// verify that pubKey and R are on the curve
 	if !pub.A.IsOnCurve() {
@@ -217,7 +219,7 @@ func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, err
 	hramInt.SetBytes(hramBin)
 
 	// lhs = cofactor*S*Base
-	var lhs twistededwards.PointAffine
+	var lhs bandersnatch.PointAffine
 	var bCofactor, bs big.Int
 	curveParams.Cofactor.BigInt(&bCofactor)
 	bs.SetBytes(sig.S[:])
@@ -229,7 +231,7 @@ func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, err
 	}
 
 	// rhs = cofactor*(R + H(R,A,M)*A)
-	var rhs twistededwards.PointAffine
+	var rhs bandersnatch.PointAffine
 	rhs.ScalarMultiplication(&pub.A, &hramInt).
 		Add(&rhs, &sig.R).
 		ScalarMultiplication(&rhs, &bCofactor)
@@ -244,3 +246,123 @@ func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, err
 
 	return true, nil
 }
+
+// InvalidSignatureError is returned by BatchVerify to report the first
+// invalid signature of the batch.
+type InvalidSignatureError struct {
+	Index int   // index of the signature in the batch
+	Err   error // deserialization error, nil if the signature is well-formed but wrong
+}
+
+func (e *InvalidSignatureError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("signature %d is invalid: %v", e.Index, e.Err)
+	}
+	return fmt.Sprintf("signature %d is invalid", e.Index)
+}
+
+func (e *InvalidSignatureError) Unwrap() error {
+	return e.Err
+}
+
+// BatchVerify verifies the signatures sigs[i] of the messages msgs[i] under
+// the public keys pubs[i]. It returns true if all the signatures are valid.
+//
+// The verification equations are combined with random 128-bit coefficients
+// z_i into a single multi-scalar multiplication
+//
+//	cofactor*((∑ z_i*S_i)*Base - ∑ z_i*R_i - ∑ (z_i*H(R_i,A_i,M_i))*A_i) ?= 0
+//
+// If the batch does not verify, the signatures are verified one by one and
+// an *InvalidSignatureError reports the first invalid one.
+func BatchVerify(pubs []*PublicKey, msgs [][]byte, sigs [][]byte, hFunc hash.Hash) (bool, error) {
+
+	// hFunc cannot be nil.
+	// We need a hash function for the Fiat-Shamir.
+	if hFunc == nil {
+		return false, errHashNeeded
+	}
+	n := len(pubs)
+	if n != len(msgs) || n != len(sigs) {
+		return false, errors.New("inputs of different lengths")
+	}
+	if n == 0 {
+		return true, nil
+	}
+
+	if !batchVerify(pubs, msgs, sigs, hFunc) {
+		// find the invalid signature
+		for i := range sigs {
+			ok, err := pubs[i].Verify(sigs[i], msgs[i], hFunc)
+			if err != nil || !ok {
+				return false, &InvalidSignatureError{Index: i, Err: err}
+			}
+		}
+		// unreachable unless the coefficients z_i cancel a wrong signature
+		return false, nil
+	}
+	return true, nil
+}
+
+// batchVerify returns true if the combined verification equation holds.
+func batchVerify(pubs []*PublicKey, msgs [][]byte, sigs [][]byte, hFunc hash.Hash) bool {
+	curveParams := bandersnatch.GetEdwardsCurve()
+	n := len(pubs)
+
+	// points = [Base, -R_0, -A_0, -R_1, -A_1, ...]
+	points := make([]bandersnatch.PointAffine, 2*n+1)
+	scalars := make([]big.Int, 2*n+1)
+	points[0].Set(&curveParams.Base)
+
+	var sig Signature
+	var z, s, hramInt big.Int
+	var zBin [16]byte
+	for i := 0; i < n; i++ {
+		if !pubs[i].A.IsOnCurve() {
+			return false
+		}
+		if _, err := sig.SetBytes(sigs[i]); err != nil {
+			return false
+		}
+
+		// compute H(R, A, M)
+		hFunc.Reset()
+		sigRX := sig.R.X.Bytes()
+		sigRY := sig.R.Y.Bytes()
+		sigAX := pubs[i].A.X.Bytes()
+		sigAY := pubs[i].A.Y.Bytes()
+		toWrite := [][]byte{sigRX[:], sigRY[:], sigAX[:], sigAY[:], msgs[i]}
+		for _, bytes := range toWrite {
+			if _, err := hFunc.Write(bytes); err != nil {
+				return false
+			}
+		}
+		hramInt.SetBytes(hFunc.Sum(nil))
+
+		if _, err := rand.Read(zBin[:]); err != nil {
+			return false
+		}
+		z.SetBytes(zBin[:])
+
+		s.SetBytes(sig.S[:])
+		s.Mul(&s, &z)
+		scalars[0].Add(&scalars[0], &s)
+
+		points[2*i+1].Neg(&sig.R)
+		scalars[2*i+1].Set(&z)
+		points[2*i+2].Neg(&pubs[i].A)
+		scalars[2*i+2].Mul(&z, &hramInt).
+			Mod(&scalars[2*i+2], &curveParams.Order)
+	}
+	scalars[0].Mod(&scalars[0], &curveParams.Order)
+
+	var res bandersnatch.PointExtended
+	if _, err := res.MultiExp(points, scalars); err != nil {
+		return false
+	}
+	var bCofactor big.Int
+	curveParams.Cofactor.BigInt(&bCofactor)
+	res.ScalarMultiplication(&res, &bCofactor)
+
+	return res.IsZero()
+}
@@ -8,8 +8,8 @@ package eddsa
 import (
 	"crypto/subtle"
 	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
-	"github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards"
 	"io"
 	"math/big"
 )
@@ -147,7 +147,7 @@ func (sig *Signature) SetBytes(buf []byte) (int, error) {
 	if bufBigInt.Cmp(zero) == 0 {
 		return 0, errZero
 	}
-	cp := twistededwards.GetEdwardsCurve()
+	cp := bandersnatch.GetEdwardsCurve()
 	if bufBigInt.Cmp(&cp.Order) != -1 {
 		return 0, errSBiggerThanRMod
 	}
@@ -0,0 +1,762 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fr
+
+// expBySqrtExp is equivalent to z.Exp(x, 73eda753299d7d483339d80809a1d803fe3e1c01d06411c5d3f41ad4a1db9f)
+//
+// uses github.com/mmcloughlin/addchain v0.4.0 to generate a shorter addition chain
+func (z *Element) expBySqrtExp(x Element) *Element {
+	// addition chain:
+	//
+	//	_10       = 2*1
+	//	_100      = 2*_10
+	//	_110      = _10 + _100
+	//	_1000     = _10 + _110
+	//	_1010     = _10 + _1000
+	//	_1100     = _10 + _1010
+	//	_1101     = 1 + _1100
+	//	_10000    = _100 + _1100
+	//	_11000    = _1000 + _10000
+	//	_11001    = 1 + _11000
+	//	_11101    = _100 + _11001
+	//	_101010   = _1101 + _11101
+	//	_110101   = _11000 + _11101
+	//	_111011   = _110 + _110101
+	//	_1000111  = _1100 + _111011
+	//	_1001101  = _110 + _1000111
+	//	_1011101  = _10000 + _1001101
+	//	_1100111  = _1010 + _1011101
+	//	_1101011  = _100 + _1100111
+	//	_10000011 = _11000 + _1101011
+	//	_10000111 = _100 + _10000011
+	//	_10001111 = _1000 + _10000111
+	//	_10011001 = _1010 + _10001111
+	//	_10011101 = _100 + _10011001
+	//	_10100101 = _1000 + _10011101
+	//	_11001111 = _101010 + _10100101
+	//	_11010111 = _1000 + _11001111
+	//	_11011011 = _100 + _11010111
+	//	_11100111 = _1100 + _11011011
+	//	_11101101 = _110 + _11100111
+	//	_11111101 = _10000 + _11101101
+	//	i59       = ((_11100111 << 8 + _11011011) << 9 + _10011101) << 9
+	//	i79       = ((_10011001 + i59) << 9 + _10011001) << 8 + _11010111
+	//	i106      = ((i79 << 6 + _110101) << 10 + _10000011) << 9
+	//	i125      = ((_1100111 + i106) << 8 + _111011) << 8 + 1
+	//	i168      = ((i125 << 14 + _1001101) << 10 + _111011) << 17
+	//	i187      = ((_11111101 + i168 + _10) << 8 + _10001111) << 8
+	//	i214      = ((_10000111 + i187) << 14 + _11101) << 10 + _11001
+	//	i248      = ((i214 << 12 + _1000111) << 10 + _1011101) << 10
+	//	i272      = ((_11111101 + i248) << 12 + _1101011) << 9 + _10100101
+	//	i295      = 2*((i272 << 12 + _11101101) << 8 + _11001111)
+	//	return      1 + i295
+	//
+	// Operations: 241 squares 55 multiplies
+
+	// Allocate Temporaries.
+	var (
+		t0  = new(Element)
+		t1  = new(Element)
+		t2  = new(Element)
+		t3  = new(Element)
+		t4  = new(Element)
+		t5  = new(Element)
+		t6  = new(Element)
+		t7  = new(Element)
+		t8  = new(Element)
+		t9  = new(Element)
+		t10 = new(Element)
+		t11 = new(Element)
+		t12 = new(Element)
+		t13 = new(Element)
+		t14 = new(Element)
+		t15 = new(Element)
+		t16 = new(Element)
+		t17 = new(Element)
+		t18 = new(Element)
+		t19 = new(Element)
+		t20 = new(Element)
+	)
+
+	// var t0,t1,t2,t3,t4,t5,t6,t7,t8,t9,t10,t11,t12,t13,t14,t15,t16,t17,t18,t19,t20 Element
+	// Step 1: t10 = x^0x2
+	t10.Square(&x)
+
+	// Step 2: t19 = x^0x4
+	t19.Square(t10)
+
+	// Step 3: t0 = x^0x6
+	t0.Mul(t10, t19)
+
+	// Step 4: t16 = x^0x8
+	t16.Mul(t10, t0)
+
+	// Step 5: t1 = x^0xa
+	t1.Mul(t10, t16)
+
+	// Step 6: t20 = x^0xc
+	t20.Mul(t10, t1)
+
+	// Step 7: z = x^0xd
+	z.Mul(&x, t20)
+
+	// Step 8: t3 = x^0x10
+	t3.Mul(t19, t20)
+
+	// Step 9: t8 = x^0x18
+	t8.Mul(t16, t3)
+
+	// Step 10: t6 = x^0x19
+	t6.Mul(&x, t8)
+
+	// Step 11: t7 = x^0x1d
+	t7.Mul(t19, t6)
+
+	// Step 12: z = x^0x2a
+	z.Mul(z, t7)
+
+	// Step 13: t15 = x^0x35
+	t15.Mul(t8, t7)
+
+	// Step 14: t11 = x^0x3b
+	t11.Mul(t0, t15)
+
+	// Step 15: t5 = x^0x47
+	t5.Mul(t20, t11)
+
+	// Step 16: t12 = x^0x4d
+	t12.Mul(t0, t5)
+
+	// Step 17: t4 = x^0x5d
+	t4.Mul(t3, t12)
+
+	// Step 18: t13 = x^0x67
+	t13.Mul(t1, t4)
+
+	// Step 19: t2 = x^0x6b
+	t2.Mul(t19, t13)
+
+	// Step 20: t14 = x^0x83
+	t14.Mul(t8, t2)
+
+	// Step 21: t8 = x^0x87
+	t8.Mul(t19, t14)
+
+	// Step 22: t9 = x^0x8f
+	t9.Mul(t16, t8)
+
+	// Step 23: t17 = x^0x99
+	t17.Mul(t1, t9)
+
+	// Step 24: t18 = x^0x9d
+	t18.Mul(t19, t17)
+
+	// Step 25: t1 = x^0xa5
+	t1.Mul(t16, t18)
+
+	// Step 26: z = x^0xcf
+	z.Mul(z, t1)
+
+	// Step 27: t16 = x^0xd7
+	t16.Mul(t16, z)
+
+	// Step 28: t19 = x^0xdb
+	t19.Mul(t19, t16)
+
+	// Step 29: t20 = x^0xe7
+	t20.Mul(t20, t19)
+
+	// Step 30: t0 = x^0xed
+	t0.Mul(t0, t20)
+
+	// Step 31: t3 = x^0xfd
+	t3.Mul(t3, t0)
+
+	// Step 39: t20 = x^0xe700
+	for s := 0; s < 8; s++ {
+		t20.Square(t20)
+	}
+
+	// Step 40: t19 = x^0xe7db
+	t19.Mul(t19, t20)
+
+	// Step 49: t19 = x^0x1cfb600
+	for s := 0; s < 9; s++ {
+		t19.Square(t19)
+	}
+
+	// Step 50: t18 = x^0x1cfb69d
+	t18.Mul(t18, t19)
+
+	// Step 59: t18 = x^0x39f6d3a00
+	for s := 0; s < 9; s++ {
+		t18.Square(t18)
+	}
+
+	// Step 60: t18 = x^0x39f6d3a99
+	t18.Mul(t17, t18)
+
+	// Step 69: t18 = x^0x73eda753200
+	for s := 0; s < 9; s++ {
+		t18.Square(t18)
+	}
+
+	// Step 70: t17 = x^0x73eda753299
+	t17.Mul(t17, t18)
+
+	// Step 78: t17 = x^0x73eda75329900
+	for s := 0; s < 8; s++ {
+		t17.Square(t17)
+	}
+
+	// Step 79: t16 = x^0x73eda753299d7
+	t16.Mul(t16, t17)
+
+	// Step 85: t16 = x^0x1cfb69d4ca675c0
+	for s := 0; s < 6; s++ {
+		t16.Square(t16)
+	}
+
+	// Step 86: t15 = x^0x1cfb69d4ca675f5
+	t15.Mul(t15, t16)
+
+	// Step 96: t15 = x^0x73eda753299d7d400
+	for s := 0; s < 10; s++ {
+		t15.Square(t15)
+	}
+
+	// Step 97: t14 = x^0x73eda753299d7d483
+	t14.Mul(t14, t15)
+
+	// Step 106: t14 = x^0xe7db4ea6533afa90600
+	for s := 0; s < 9; s++ {
+		t14.Square(t14)
+	}
+
+	// Step 107: t13 = x^0xe7db4ea6533afa90667
+	t13.Mul(t13, t14)
+
+	// Step 115: t13 = x^0xe7db4ea6533afa9066700
+	for s := 0; s < 8; s++ {
+		t13.Square(t13)
+	}
+
+	// Step 116: t13 = x^0xe7db4ea6533afa906673b
+	t13.Mul(t11, t13)
+
+	// Step 124: t13 = x^0xe7db4ea6533afa906673b00
+	for s := 0; s < 8; s++ {
+		t13.Square(t13)
+	}
+
+	// Step 125: t13 = x^0xe7db4ea6533afa906673b01
+	t13.Mul(&x, t13)
+
+	// Step 139: t13 = x^0x39f6d3a994cebea4199cec04000
+	for s := 0; s < 14; s++ {
+		t13.Square(t13)
+	}
+
+	// Step 140: t12 = x^0x39f6d3a994cebea4199cec0404d
+	t12.Mul(t12, t13)
+
+	// Step 150: t12 = x^0xe7db4ea6533afa906673b01013400
+	for s := 0; s < 10; s++ {
+		t12.Square(t12)
+	}
+
+	// Step 151: t11 = x^0xe7db4ea6533afa906673b0101343b
+	t11.Mul(t11, t12)
+
+	// Step 168: t11 = x^0x1cfb69d4ca675f520cce76020268760000
+	for s := 0; s < 17; s++ {
+		t11.Square(t11)
+	}
+
+	// Step 169: t11 = x^0x1cfb69d4ca675f520cce760202687600fd
+	t11.Mul(t3, t11)
+
+	// Step 170: t10 = x^0x1cfb69d4ca675f520cce760202687600ff
+	t10.Mul(t10, t11)
+
+	// Step 178: t10 = x^0x1cfb69d4ca675f520cce760202687600ff00
+	for s := 0; s < 8; s++ {
+		t10.Square(t10)
+	}
+
+	// Step 179: t9 = x^0x1cfb69d4ca675f520cce760202687600ff8f
+	t9.Mul(t9, t10)
+
+	// Step 187: t9 = x^0x1cfb69d4ca675f520cce760202687600ff8f00
+	for s := 0; s < 8; s++ {
+		t9.Square(t9)
+	}
+
+	// Step 188: t8 = x^0x1cfb69d4ca675f520cce760202687600ff8f87
+	t8.Mul(t8, t9)
+
+	// Step 202: t8 = x^0x73eda753299d7d483339d80809a1d803fe3e1c000
+	for s := 0; s < 14; s++ {
+		t8.Square(t8)
+	}
+
+	// Step 203: t7 = x^0x73eda753299d7d483339d80809a1d803fe3e1c01d
+	t7.Mul(t7, t8)
+
+	// Step 213: t7 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007400
+	for s := 0; s < 10; s++ {
+		t7.Square(t7)
+	}
+
+	// Step 214: t6 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419
+	t6.Mul(t6, t7)
+
+	// Step 226: t6 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419000
+	for s := 0; s < 12; s++ {
+		t6.Square(t6)
+	}
+
+	// Step 227: t5 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419047
+	t5.Mul(t5, t6)
+
+	// Step 237: t5 = x^0x73eda753299d7d483339d80809a1d803fe3e1c01d06411c00
+	for s := 0; s < 10; s++ {
+		t5.Square(t5)
+	}
+
+	// Step 238: t4 = x^0x73eda753299d7d483339d80809a1d803fe3e1c01d06411c5d
+	t4.Mul(t4, t5)
+
+	// Step 248: t4 = x^0x1cfb69d4ca675f520cce760202687600ff8f8700741904717400
+	for s := 0; s < 10; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 249: t3 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419047174fd
+	t3.Mul(t3, t4)
+
+	// Step 261: t3 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419047174fd000
+	for s := 0; s < 12; s++ {
+		t3.Square(t3)
+	}
+
+	// Step 262: t2 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419047174fd06b
+	t2.Mul(t2, t3)
+
+	// Step 271: t2 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d600
+	for s := 0; s < 9; s++ {
+		t2.Square(t2)
+	}
+
+	// Step 272: t1 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d6a5
+	t1.Mul(t1, t2)
+
+	// Step 284: t1 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d6a5000
+	for s := 0; s < 12; s++ {
+		t1.Square(t1)
+	}
+
+	// Step 285: t0 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d6a50ed
+	t0.Mul(t0, t1)
+
+	// Step 293: t0 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d6a50ed00
+	for s := 0; s < 8; s++ {
+		t0.Square(t0)
+	}
+
+	// Step 294: z = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d6a50edcf
+	z.Mul(z, t0)
+
+	// Step 295: z = x^0x73eda753299d7d483339d80809a1d803fe3e1c01d06411c5d3f41ad4a1db9e
+	z.Square(z)
+
+	// Step 296: z = x^0x73eda753299d7d483339d80809a1d803fe3e1c01d06411c5d3f41ad4a1db9f
+	z.Mul(&x, z)
+
+	return z
+}
+
+// expByLegendreExp is equivalent to z.Exp(x, e7db4ea6533afa906673b0101343b007fc7c3803a0c8238ba7e835a943b73f0)
+//
+// uses github.com/mmcloughlin/addchain v0.4.0 to generate a shorter addition chain
+func (z *Element) expByLegendreExp(x Element) *Element {
+	// addition chain:
+	//
+	//	_10       = 2*1
+	//	_11       = 1 + _10
+	//	_100      = 1 + _11
+	//	_110      = _10 + _100
+	//	_1000     = _10 + _110
+	//	_1010     = _10 + _1000
+	//	_1101     = _11 + _1010
+	//	_10000    = _11 + _1101
+	//	_11000    = _1000 + _10000
+	//	_11001    = 1 + _11000
+	//	_11101    = _100 + _11001
+	//	_101010   = _1101 + _11101
+	//	_110101   = _11000 + _11101
+	//	_111011   = _110 + _110101
+	//	_1000111  = _11101 + _101010
+	//	_1001101  = _110 + _1000111
+	//	_1011101  = _10000 + _1001101
+	//	_1100111  = _1010 + _1011101
+	//	_1101011  = _100 + _1100111
+	//	_10000011 = _11000 + _1101011
+	//	_10000111 = _100 + _10000011
+	//	_10001111 = _1000 + _10000111
+	//	_10011001 = _1010 + _10001111
+	//	_10011101 = _100 + _10011001
+	//	_10100101 = _1000 + _10011101
+	//	_11001111 = _101010 + _10100101
+	//	_11010111 = _1000 + _11001111
+	//	_11011011 = _100 + _11010111
+	//	_11100111 = _10000 + _11010111
+	//	_11101101 = _110 + _11100111
+	//	_11111101 = _10000 + _11101101
+	//	i59       = ((_11100111 << 8 + _11011011) << 9 + _10011101) << 9
+	//	i79       = ((_10011001 + i59) << 9 + _10011001) << 8 + _11010111
+	//	i106      = ((i79 << 6 + _110101) << 10 + _10000011) << 9
+	//	i125      = ((_1100111 + i106) << 8 + _111011) << 8 + 1
+	//	i168      = ((i125 << 14 + _1001101) << 10 + _111011) << 17
+	//	i187      = ((_10 + i168 + _11111101) << 8 + _10001111) << 8
+	//	i214      = ((_10000111 + i187) << 14 + _11101) << 10 + _11001
+	//	i248      = ((i214 << 12 + _1000111) << 10 + _1011101) << 10
+	//	i272      = ((_11111101 + i248) << 12 + _1101011) << 9 + _10100101
+	//	i296      = ((i272 << 12 + _11101101) << 8 + _11001111) << 2
+	//	return      (_11 + i296) << 4
+	//
+	// Operations: 245 squares 56 multiplies
+
+	// Allocate Temporaries.
+	var (
+		t0  = new(Element)
+		t1  = new(Element)
+		t2  = new(Element)
+		t3  = new(Element)
+		t4  = new(Element)
+		t5  = new(Element)
+		t6  = new(Element)
+		t7  = new(Element)
+		t8  = new(Element)
+		t9  = new(Element)
+		t10 = new(Element)
+		t11 = new(Element)
+		t12 = new(Element)
+		t13 = new(Element)
+		t14 = new(Element)
+		t15 = new(Element)
+		t16 = new(Element)
+		t17 = new(Element)
+		t18 = new(Element)
+		t19 = new(Element)
+		t20 = new(Element)
+		t21 = new(Element)
+	)
+
+	// var t0,t1,t2,t3,t4,t5,t6,t7,t8,t9,t10,t11,t12,t13,t14,t15,t16,t17,t18,t19,t20,t21 Element
+	// Step 1: t11 = x^0x2
+	t11.Square(&x)
+
+	// Step 2: z = x^0x3
+	z.Mul(&x, t11)
+
+	// Step 3: t20 = x^0x4
+	t20.Mul(&x, z)
+
+	// Step 4: t1 = x^0x6
+	t1.Mul(t11, t20)
+
+	// Step 5: t17 = x^0x8
+	t17.Mul(t11, t1)
+
+	// Step 6: t2 = x^0xa
+	t2.Mul(t11, t17)
+
+	// Step 7: t0 = x^0xd
+	t0.Mul(z, t2)
+
+	// Step 8: t4 = x^0x10
+	t4.Mul(z, t0)
+
+	// Step 9: t9 = x^0x18
+	t9.Mul(t17, t4)
+
+	// Step 10: t7 = x^0x19
+	t7.Mul(&x, t9)
+
+	// Step 11: t8 = x^0x1d
+	t8.Mul(t20, t7)
+
+	// Step 12: t0 = x^0x2a
+	t0.Mul(t0, t8)
+
+	// Step 13: t16 = x^0x35
+	t16.Mul(t9, t8)
+
+	// Step 14: t12 = x^0x3b
+	t12.Mul(t1, t16)
+
+	// Step 15: t6 = x^0x47
+	t6.Mul(t8, t0)
+
+	// Step 16: t13 = x^0x4d
+	t13.Mul(t1, t6)
+
+	// Step 17: t5 = x^0x5d
+	t5.Mul(t4, t13)
+
+	// Step 18: t14 = x^0x67
+	t14.Mul(t2, t5)
+
+	// Step 19: t3 = x^0x6b
+	t3.Mul(t20, t14)
+
+	// Step 20: t15 = x^0x83
+	t15.Mul(t9, t3)
+
+	// Step 21: t9 = x^0x87
+	t9.Mul(t20, t15)
+
+	// Step 22: t10 = x^0x8f
+	t10.Mul(t17, t9)
+
+	// Step 23: t18 = x^0x99
+	t18.Mul(t2, t10)
+
+	// Step 24: t19 = x^0x9d
+	t19.Mul(t20, t18)
+
+	// Step 25: t2 = x^0xa5
+	t2.Mul(t17, t19)
+
+	// Step 26: t0 = x^0xcf
+	t0.Mul(t0, t2)
+
+	// Step 27: t17 = x^0xd7
+	t17.Mul(t17, t0)
+
+	// Step 28: t20 = x^0xdb
+	t20.Mul(t20, t17)
+
+	// Step 29: t21 = x^0xe7
+	t21.Mul(t4, t17)
+
+	// Step 30: t1 = x^0xed
+	t1.Mul(t1, t21)
+
+	// Step 31: t4 = x^0xfd
+	t4.Mul(t4, t1)
+
+	// Step 39: t21 = x^0xe700
+	for s := 0; s < 8; s++ {
+		t21.Square(t21)
+	}
+
+	// Step 40: t20 = x^0xe7db
+	t20.Mul(t20, t21)
+
+	// Step 49: t20 = x^0x1cfb600
+	for s := 0; s < 9; s++ {
+		t20.Square(t20)
+	}
+
+	// Step 50: t19 = x^0x1cfb69d
+	t19.Mul(t19, t20)
+
+	// Step 59: t19 = x^0x39f6d3a00
+	for s := 0; s < 9; s++ {
+		t19.Square(t19)
+	}
+
+	// Step 60: t19 = x^0x39f6d3a99
+	t19.Mul(t18, t19)
+
+	// Step 69: t19 = x^0x73eda753200
+	for s := 0; s < 9; s++ {
+		t19.Square(t19)
+	}
+
+	// Step 70: t18 = x^0x73eda753299
+	t18.Mul(t18, t19)
+
+	// Step 78: t18 = x^0x73eda75329900
+	for s := 0; s < 8; s++ {
+		t18.Square(t18)
+	}
+
+	// Step 79: t17 = x^0x73eda753299d7
+	t17.Mul(t17, t18)
+
+	// Step 85: t17 = x^0x1cfb69d4ca675c0
+	for s := 0; s < 6; s++ {
+		t17.Square(t17)
+	}
+
+	// Step 86: t16 = x^0x1cfb69d4ca675f5
+	t16.Mul(t16, t17)
+
+	// Step 96: t16 = x^0x73eda753299d7d400
+	for s := 0; s < 10; s++ {
+		t16.Square(t16)
+	}
+
+	// Step 97: t15 = x^0x73eda753299d7d483
+	t15.Mul(t15, t16)
+
+	// Step 106: t15 = x^0xe7db4ea6533afa90600
+	for s := 0; s < 9; s++ {
+		t15.Square(t15)
+	}
+
+	// Step 107: t14 = x^0xe7db4ea6533afa90667
+	t14.Mul(t14, t15)
+
+	// Step 115: t14 = x^0xe7db4ea6533afa9066700
+	for s := 0; s < 8; s++ {
+		t14.Square(t14)
+	}
+
+	// Step 116: t14 = x^0xe7db4ea6533afa906673b
+	t14.Mul(t12, t14)
+
+	// Step 124: t14 = x^0xe7db4ea6533afa906673b00
+	for s := 0; s < 8; s++ {
+		t14.Square(t14)
+	}
+
+	// Step 125: t14 = x^0xe7db4ea6533afa906673b01
+	t14.Mul(&x, t14)
+
+	// Step 139: t14 = x^0x39f6d3a994cebea4199cec04000
+	for s := 0; s < 14; s++ {
+		t14.Square(t14)
+	}
+
+	// Step 140: t13 = x^0x39f6d3a994cebea4199cec0404d
+	t13.Mul(t13, t14)
+
+	// Step 150: t13 = x^0xe7db4ea6533afa906673b01013400
+	for s := 0; s < 10; s++ {
+		t13.Square(t13)
+	}
+
+	// Step 151: t12 = x^0xe7db4ea6533afa906673b0101343b
+	t12.Mul(t12, t13)
+
+	// Step 168: t12 = x^0x1cfb69d4ca675f520cce76020268760000
+	for s := 0; s < 17; s++ {
+		t12.Square(t12)
+	}
+
+	// Step 169: t11 = x^0x1cfb69d4ca675f520cce76020268760002
+	t11.Mul(t11, t12)
+
+	// Step 170: t11 = x^0x1cfb69d4ca675f520cce760202687600ff
+	t11.Mul(t4, t11)
+
+	// Step 178: t11 = x^0x1cfb69d4ca675f520cce760202687600ff00
+	for s := 0; s < 8; s++ {
+		t11.Square(t11)
+	}
+
+	// Step 179: t10 = x^0x1cfb69d4ca675f520cce760202687600ff8f
+	t10.Mul(t10, t11)
+
+	// Step 187: t10 = x^0x1cfb69d4ca675f520cce760202687600ff8f00
+	for s := 0; s < 8; s++ {
+		t10.Square(t10)
+	}
+
+	// Step 188: t9 = x^0x1cfb69d4ca675f520cce760202687600ff8f87
+	t9.Mul(t9, t10)
+
+	// Step 202: t9 = x^0x73eda753299d7d483339d80809a1d803fe3e1c000
+	for s := 0; s < 14; s++ {
+		t9.Square(t9)
+	}
+
+	// Step 203: t8 = x^0x73eda753299d7d483339d80809a1d803fe3e1c01d
+	t8.Mul(t8, t9)
+
+	// Step 213: t8 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007400
+	for s := 0; s < 10; s++ {
+		t8.Square(t8)
+	}
+
+	// Step 214: t7 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419
+	t7.Mul(t7, t8)
+
+	// Step 226: t7 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419000
+	for s := 0; s < 12; s++ {
+		t7.Square(t7)
+	}
+
+	// Step 227: t6 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419047
+	t6.Mul(t6, t7)
+
+	// Step 237: t6 = x^0x73eda753299d7d483339d80809a1d803fe3e1c01d06411c00
+	for s := 0; s < 10; s++ {
+		t6.Square(t6)
+	}
+
+	// Step 238: t5 = x^0x73eda753299d7d483339d80809a1d803fe3e1c01d06411c5d
+	t5.Mul(t5, t6)
+
+	// Step 248: t5 = x^0x1cfb69d4ca675f520cce760202687600ff8f8700741904717400
+	for s := 0; s < 10; s++ {
+		t5.Square(t5)
+	}
+
+	// Step 249: t4 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419047174fd
+	t4.Mul(t4, t5)
+
+	// Step 261: t4 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419047174fd000
+	for s := 0; s < 12; s++ {
+		t4.Square(t4)
+	}
+
+	// Step 262: t3 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419047174fd06b
+	t3.Mul(t3, t4)
+
+	// Step 271: t3 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d600
+	for s := 0; s < 9; s++ {
+		t3.Square(t3)
+	}
+
+	// Step 272: t2 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d6a5
+	t2.Mul(t2, t3)
+
+	// Step 284: t2 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d6a5000
+	for s := 0; s < 12; s++ {
+		t2.Square(t2)
+	}
+
+	// Step 285: t1 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d6a50ed
+	t1.Mul(t1, t2)
+
+	// Step 293: t1 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d6a50ed00
+	for s := 0; s < 8; s++ {
+		t1.Square(t1)
+	}
+
+	// Step 294: t0 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d6a50edcf
+	t0.Mul(t0, t1)
+
+	// Step 296: t0 = x^0xe7db4ea6533afa906673b0101343b007fc7c3803a0c8238ba7e835a943b73c
+	for s := 0; s < 2; s++ {
+		t0.Square(t0)
+	}
+
+	// Step 297: z = x^0xe7db4ea6533afa906673b0101343b007fc7c3803a0c8238ba7e835a943b73f
+	z.Mul(z, t0)
+
+	// Step 301: z = x^0xe7db4ea6533afa906673b0101343b007fc7c3803a0c8238ba7e835a943b73f0
+	for s := 0; s < 4; s++ {
+		z.Square(z)
+	}
+
+	return z
+}
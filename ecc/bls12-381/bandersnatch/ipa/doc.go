@@ -0,0 +1,22 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package ipa provides an inner product argument (IPA) polynomial
+// commitment scheme on bandersnatch, in the style of Bulletproofs.
+//
+// The scheme is transparent and pairing-free: the SRS is made of the bases of
+// Pedersen vector commitments, derived by hashing to the curve, and a
+// polynomial is committed to as the multi-exponentiation of its coefficients
+// with the bases. An opening proof at a point z is an argument that the claimed
+// value is the inner product of the coefficients with (1, z, z², ...). It has
+// 2⋅log(n) group elements and its verification costs a multi-exponentiation of
+// size n, so that several proofs can be batch verified with a single
+// multi-exponentiation.
+//
+// The commitments are binding but not hiding.
+//
+// See https://eprint.iacr.org/2017/1066.pdf (Bulletproofs) and
+// https://eprint.iacr.org/2019/1021.pdf (Halo, section 3).
+package ipa
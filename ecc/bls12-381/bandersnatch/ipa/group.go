@@ -0,0 +1,102 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ipa
+
+import (
+	"errors"
+	"math/big"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// sizePoint is the size in bytes of a compressed point
+const sizePoint = fp.Bytes
+
+var errInvalidPoint = errors.New("point is not in the prime order subgroup")
+
+// multiExp returns ∑ᵢ scalars[i]⋅points[i]
+func multiExp(points []curve.PointAffine, scalars []fr.Element) (curve.PointAffine, error) {
+	bScalars := make([]big.Int, len(scalars))
+	for i := range scalars {
+		scalars[i].BigInt(&bScalars[i])
+	}
+	var resExtended curve.PointExtended
+	if _, err := resExtended.MultiExp(points, bScalars); err != nil {
+		return curve.PointAffine{}, err
+	}
+	var res curve.PointAffine
+	res.FromExtended(&resExtended)
+	return res, nil
+}
+
+// foldBases sets res[i] = left[i] + x⋅right[i]. res may be left.
+func foldBases(res, left, right []curve.PointAffine, x *fr.Element) {
+	var xBig big.Int
+	x.BigInt(&xBig)
+	parallel.Execute(len(res), func(start, end int) {
+		var l, r curve.PointExtended
+		for i := start; i < end; i++ {
+			l.FromAffine(&left[i])
+			r.FromAffine(&right[i])
+			r.ScalarMultiplication(&r, &xBig)
+			l.Add(&l, &r)
+			res[i].FromExtended(&l)
+		}
+	})
+}
+
+// hashToGroup hashes msg to a point of the prime order subgroup, by
+// try-and-increment on the y coordinate followed by the clearing of the
+// cofactor.
+func hashToGroup(msg, dst []byte) (curve.PointAffine, error) {
+	params := curve.GetEdwardsCurve()
+	var cofactor big.Int
+	params.Cofactor.BigInt(&cofactor)
+
+	var one, num, den, x fp.Element
+	one.SetOne()
+	buf := make([]byte, len(msg)+1)
+	copy(buf, msg)
+	for counter := 0; counter < 256; counter++ {
+		buf[len(msg)] = byte(counter)
+		y, err := fp.Hash(buf, dst, 1)
+		if err != nil {
+			return curve.PointAffine{}, err
+		}
+
+		// x² = (1 - y²) / (a - d⋅y²)
+		num.Square(&y[0])
+		den.Mul(&num, &params.D)
+		num.Sub(&one, &num)
+		den.Sub(&params.A, &den)
+		x.Div(&num, &den)
+		if x.Sqrt(&x) == nil {
+			continue
+		}
+
+		res := curve.NewPointAffine(x, y[0])
+		res.ScalarMultiplication(&res, &cofactor)
+		if !res.IsZero() {
+			return res, nil
+		}
+	}
+	return curve.PointAffine{}, errors.New("could not hash to the curve")
+}
+
+// setBytes decodes a compressed point, which must be in the prime order
+// subgroup.
+func setBytes(p *curve.PointAffine, buf []byte) error {
+	if _, err := p.SetBytes(buf); err != nil {
+		return err
+	}
+	if !p.IsOnCurve() || !p.IsInSubGroup() {
+		return errInvalidPoint
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ipa
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+
+	"github.com/consensys/gnark-crypto/utils/testutils"
+)
+
+// Test SRS re-used across tests of the inner product argument
+var testSrs *SRS
+
+const srsSize = 64
+
+func init() {
+	testSrs, _ = NewSRS(srsSize, []byte("test"))
+}
+
+func randomPolynomial(size int) []fr.Element {
+	p := make([]fr.Element, size)
+	for i := range p {
+		p[i].MustSetRandom()
+	}
+	return p
+}
+
+func eval(p []fr.Element, point fr.Element) fr.Element {
+	var res fr.Element
+	for i := len(p) - 1; i >= 0; i-- {
+		res.Mul(&res, &point).Add(&res, &p[i])
+	}
+	return res
+}
+
+func TestSRS(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Len(testSrs.G, srsSize)
+	for i := range testSrs.G {
+		assert.True(testSrs.G[i].IsOnCurve() && testSrs.G[i].IsInSubGroup(), "point %d", i)
+	}
+
+	// the bases are derived deterministically from the seed
+	srs, err := NewSRS(5, []byte("test"))
+	assert.NoError(err)
+	assert.Len(srs.G, 8)
+	assert.Equal(testSrs.G[:8], srs.G)
+	assert.True(testSrs.U.Equal(&srs.U))
+
+	srs, err = NewSRS(8, []byte("other"))
+	assert.NoError(err)
+	assert.False(testSrs.G[0].Equal(&srs.G[0]))
+	assert.False(testSrs.U.Equal(&srs.U))
+
+	_, err = NewSRS(0, []byte("test"))
+	assert.ErrorIs(err, ErrMinSRSSize)
+}
+
+func TestCommit(t *testing.T) {
+	assert := require.New(t)
+
+	p := randomPolynomial(13)
+	digest, err := Commit(p, testSrs)
+	assert.NoError(err)
+
+	var expected, tmp curve.PointAffine
+	var b big.Int
+	for i := range p {
+		tmp.ScalarMultiplication(&testSrs.G[i], p[i].BigInt(&b))
+		if i == 0 {
+			expected = tmp
+		} else {
+			expected.Add(&expected, &tmp)
+		}
+	}
+	assert.True(expected.Equal(&digest))
+
+	_, err = Commit(nil, testSrs)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+	_, err = Commit(randomPolynomial(srsSize+1), testSrs)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+}
+
+func TestVerifySinglePoint(t *testing.T) {
+	assert := require.New(t)
+
+	for _, size := range []int{srsSize, 21, 2, 1} {
+		p := randomPolynomial(size)
+		digest, err := Commit(p, testSrs)
+		assert.NoError(err)
+
+		var point fr.Element
+		point.MustSetRandom()
+		proof, err := Open(p, digest, point, sha256.New(), testSrs, []byte("test"))
+		assert.NoError(err)
+
+		expected := eval(p, point)
+		assert.True(expected.Equal(&proof.ClaimedValue), "wrong claimed value")
+
+		// verify correct proof
+		assert.NoError(Verify(&digest, &proof, point, sha256.New(), testSrs, []byte("test")))
+
+		// verify wrong proofs
+		proof.ClaimedValue.Double(&proof.ClaimedValue)
+		assert.ErrorIs(Verify(&digest, &proof, point, sha256.New(), testSrs, []byte("test")), ErrVerifyOpeningProof)
+		proof.ClaimedValue = expected
+
+		a := proof.A
+		proof.A.Double(&proof.A)
+		assert.ErrorIs(Verify(&digest, &proof, point, sha256.New(), testSrs, []byte("test")), ErrVerifyOpeningProof)
+		proof.A = a
+
+		// the opening of a constant polynomial reveals it and does not depend
+		// on the point
+		if len(proof.L) > 0 {
+			assert.ErrorIs(Verify(&digest, &proof, point, sha256.New(), testSrs, []byte("wrong")), ErrVerifyOpeningProof)
+
+			var wrongPoint fr.Element
+			wrongPoint.MustSetRandom()
+			assert.ErrorIs(Verify(&digest, &proof, wrongPoint, sha256.New(), testSrs, []byte("test")), ErrVerifyOpeningProof)
+
+			proof.L[0], proof.R[0] = proof.R[0], proof.L[0]
+			assert.ErrorIs(Verify(&digest, &proof, point, sha256.New(), testSrs, []byte("test")), ErrVerifyOpeningProof)
+			proof.R = proof.R[1:]
+			assert.ErrorIs(Verify(&digest, &proof, point, sha256.New(), testSrs, []byte("test")), ErrInvalidProofSize)
+		}
+	}
+
+	var point fr.Element
+	_, err := Open(randomPolynomial(srsSize+1), Digest{}, point, sha256.New(), testSrs)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+}
+
+func TestBatchVerify(t *testing.T) {
+	assert := require.New(t)
+
+	// polynomials of different sizes
+	sizes := []int{srsSize, 7, 7, 30, 1}
+
+	digests := make([]Digest, len(sizes))
+	proofs := make([]OpeningProof, len(sizes))
+	points := make([]fr.Element, len(sizes))
+	for i, size := range sizes {
+		p := randomPolynomial(size)
+		var err error
+		digests[i], err = Commit(p, testSrs)
+		assert.NoError(err)
+		points[i].MustSetRandom()
+		proofs[i], err = Open(p, digests[i], points[i], sha256.New(), testSrs)
+		assert.NoError(err)
+	}
+
+	// verify correct proofs
+	assert.NoError(BatchVerify(digests, proofs, points, sha256.New(), testSrs))
+
+	// verify wrong proofs
+	points[1], points[2] = points[2], points[1]
+	assert.ErrorIs(BatchVerify(digests, proofs, points, sha256.New(), testSrs), ErrVerifyOpeningProof)
+	points[1], points[2] = points[2], points[1]
+
+	proofs[3].ClaimedValue.Double(&proofs[3].ClaimedValue)
+	assert.ErrorIs(BatchVerify(digests, proofs, points, sha256.New(), testSrs), ErrVerifyOpeningProof)
+	proofs[3].ClaimedValue.Halve()
+
+	digests[0], digests[4] = digests[4], digests[0]
+	assert.ErrorIs(BatchVerify(digests, proofs, points, sha256.New(), testSrs), ErrVerifyOpeningProof)
+	digests[0], digests[4] = digests[4], digests[0]
+	assert.NoError(BatchVerify(digests, proofs, points, sha256.New(), testSrs))
+
+	assert.ErrorIs(BatchVerify(digests[1:], proofs, points, sha256.New(), testSrs), ErrInvalidNbDigests)
+	assert.ErrorIs(BatchVerify(digests, proofs, points[1:], sha256.New(), testSrs), ErrInvalidNbPoints)
+}
+
+func TestSerialization(t *testing.T) {
+	t.Parallel()
+
+	p := randomPolynomial(27)
+	digest, err := Commit(p, testSrs)
+	require.NoError(t, err)
+	var point fr.Element
+	point.MustSetRandom()
+	proof, err := Open(p, digest, point, sha256.New(), testSrs)
+	require.NoError(t, err)
+
+	t.Run("opening proof round trip", testutils.SerializationRoundTrip(&proof))
+	t.Run("srs round trip", testutils.SerializationRoundTrip(testSrs))
+}
+
+const benchSize = 1 << 12
+
+func BenchmarkCommit(b *testing.B) {
+	srs, err := NewSRS(benchSize, []byte("bench"))
+	require.NoError(b, err)
+	p := randomPolynomial(benchSize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Commit(p, srs)
+	}
+}
+
+func BenchmarkOpen(b *testing.B) {
+	srs, err := NewSRS(benchSize, []byte("bench"))
+	require.NoError(b, err)
+	p := randomPolynomial(benchSize)
+	digest, err := Commit(p, srs)
+	require.NoError(b, err)
+	var point fr.Element
+	point.MustSetRandom()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Open(p, digest, point, sha256.New(), srs)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	srs, err := NewSRS(benchSize, []byte("bench"))
+	require.NoError(b, err)
+	p := randomPolynomial(benchSize)
+	digest, err := Commit(p, srs)
+	require.NoError(b, err)
+	var point fr.Element
+	point.MustSetRandom()
+	proof, err := Open(p, digest, point, sha256.New(), srs)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Verify(&digest, &proof, point, sha256.New(), srs)
+	}
+}
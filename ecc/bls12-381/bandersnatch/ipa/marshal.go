@@ -0,0 +1,155 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ipa
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+var errInvalidSize = errors.New("invalid size")
+
+// WriteTo writes the binary encoding of the SRS, with compressed points.
+func (srs *SRS) WriteTo(w io.Writer) (int64, error) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(len(srs.G)))
+	n, err := w.Write(buf[:])
+	written := int64(n)
+	if err != nil {
+		return written, err
+	}
+	n64, err := writePoints(w, srs.U)
+	written += n64
+	if err != nil {
+		return written, err
+	}
+	n64, err = writePoints(w, srs.G...)
+	return written + n64, err
+}
+
+// ReadFrom decodes an SRS from reader, checking that the points are in the
+// prime order subgroup.
+func (srs *SRS) ReadFrom(r io.Reader) (int64, error) {
+	var buf [8]byte
+	n, err := io.ReadFull(r, buf[:])
+	read := int64(n)
+	if err != nil {
+		return read, err
+	}
+	size := binary.BigEndian.Uint64(buf[:])
+	if size == 0 || size&(size-1) != 0 || size > 1<<40 {
+		return read, errInvalidSize
+	}
+	u := make([]curve.PointAffine, 1)
+	n64, err := readPoints(r, u)
+	read += n64
+	if err != nil {
+		return read, err
+	}
+	srs.U = u[0]
+	srs.G = make([]curve.PointAffine, size)
+	n64, err = readPoints(r, srs.G)
+	return read + n64, err
+}
+
+// WriteTo writes the binary encoding of the OpeningProof.
+func (proof *OpeningProof) WriteTo(w io.Writer) (int64, error) {
+	if len(proof.L) != len(proof.R) || len(proof.L) > 255 {
+		return 0, errInvalidSize
+	}
+	n, err := w.Write([]byte{byte(len(proof.L))})
+	written := int64(n)
+	if err != nil {
+		return written, err
+	}
+	n64, err := writePoints(w, proof.L...)
+	written += n64
+	if err != nil {
+		return written, err
+	}
+	n64, err = writePoints(w, proof.R...)
+	written += n64
+	if err != nil {
+		return written, err
+	}
+	for _, e := range []*fr.Element{&proof.A, &proof.ClaimedValue} {
+		b := e.Bytes()
+		n, err = w.Write(b[:])
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// ReadFrom decodes an OpeningProof from reader, checking that the points are
+// in the prime order subgroup.
+func (proof *OpeningProof) ReadFrom(r io.Reader) (int64, error) {
+	var nbRounds [1]byte
+	n, err := io.ReadFull(r, nbRounds[:])
+	read := int64(n)
+	if err != nil {
+		return read, err
+	}
+	proof.L = make([]curve.PointAffine, nbRounds[0])
+	proof.R = make([]curve.PointAffine, nbRounds[0])
+	n64, err := readPoints(r, proof.L)
+	read += n64
+	if err != nil {
+		return read, err
+	}
+	n64, err = readPoints(r, proof.R)
+	read += n64
+	if err != nil {
+		return read, err
+	}
+	var buf [fr.Bytes]byte
+	for _, e := range []*fr.Element{&proof.A, &proof.ClaimedValue} {
+		n, err = io.ReadFull(r, buf[:])
+		read += int64(n)
+		if err != nil {
+			return read, err
+		}
+		if err = e.SetBytesCanonical(buf[:]); err != nil {
+			return read, err
+		}
+	}
+	return read, nil
+}
+
+func writePoints(w io.Writer, points ...curve.PointAffine) (int64, error) {
+	var written int64
+	for i := range points {
+		b := points[i].Bytes()
+		n, err := w.Write(b[:])
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+func readPoints(r io.Reader, points []curve.PointAffine) (int64, error) {
+	var read int64
+	var buf [sizePoint]byte
+	for i := range points {
+		n, err := io.ReadFull(r, buf[:])
+		read += int64(n)
+		if err != nil {
+			return read, err
+		}
+		if err = setBytes(&points[i], buf[:]); err != nil {
+			return read, err
+		}
+	}
+	return read, nil
+}
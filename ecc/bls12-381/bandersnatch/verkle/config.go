@@ -0,0 +1,102 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package verkle
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/banderwagon"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+const (
+	// NodeWidth is the number of children of the nodes of the trie, and the
+	// size of the committed vectors
+	NodeWidth = 256
+
+	// CRSSeed is the seed of the bases of the commitments
+	CRSSeed = "eth_verkle_oct_2021"
+
+	// nbRounds is the number of rounds of an IPA of size NodeWidth
+	nbRounds = 8
+)
+
+// Config holds the bases of the vector commitments, with their precomputed
+// multiples, and the precomputed data of the evaluation domain {0, …, 255}.
+type Config struct {
+	// SRS are the bases of the commitments to the vectors, i.e. to the
+	// polynomials in evaluation form over the domain
+	SRS []banderwagon.Element
+
+	// Q is the base of the inner products in the IPA
+	Q banderwagon.Element
+
+	table  *banderwagon.PrecomputedTable
+	domain *domain
+
+	// srsAffine are points of the classes of the SRS, for the inner product
+	// arguments on bandersnatch
+	srsAffine []bandersnatch.PointAffine
+}
+
+var (
+	configOnce sync.Once
+	config     *Config
+)
+
+// GetConfig returns the configuration of the Ethereum verkle trees. It is
+// computed once, at the first call.
+func GetConfig() *Config {
+	configOnce.Do(func() {
+		config = NewConfig(GenerateBases(CRSSeed, NodeWidth))
+	})
+	return config
+}
+
+// NewConfig returns a configuration with the given NodeWidth bases.
+func NewConfig(srs []banderwagon.Element) *Config {
+	if len(srs) != NodeWidth {
+		panic("the SRS must have NodeWidth bases")
+	}
+	return &Config{
+		SRS:       srs,
+		Q:         banderwagon.Generator,
+		table:     banderwagon.NewPrecomputedTable(srs),
+		domain:    newDomain(),
+		srsAffine: banderwagon.BatchToAffine(srs),
+	}
+}
+
+// GenerateBases returns n group elements derived from seed. The i-th candidate
+// is the coordinate x = SHA-256(seed ‖ i), with i on 8 big-endian bytes, and it
+// is kept if it is the serialization of a group element.
+func GenerateBases(seed string, n int) []banderwagon.Element {
+	res := make([]banderwagon.Element, 0, n)
+	var buf [8]byte
+	for i := uint64(0); len(res) < n; i++ {
+		h := sha256.New()
+		h.Write([]byte(seed))
+		binary.BigEndian.PutUint64(buf[:], i)
+		h.Write(buf[:])
+
+		var x fp.Element
+		x.SetBytes(h.Sum(nil))
+		xBytes := x.Bytes()
+		var p banderwagon.Element
+		if p.SetBytes(xBytes[:]) == nil {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// Commit returns the commitment ∑ values[i]⋅SRS[i] to the vector values, of
+// size at most NodeWidth.
+func (c *Config) Commit(values []fr.Element) (banderwagon.Element, error) {
+	return c.table.Commit(values)
+}
@@ -0,0 +1,30 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Package verkle implements the verkle trees of Ethereum on the banderwagon
+// group, with the commitments, the transcript and the multiproofs of go-ipa
+// and go-verkle. The proofs of the trie are made of the same elements as
+// go-verkle's (extension statuses, commitments sorted by path, other stems and
+// multiproof), and the IPA and multiproofs are encoded as in go-ipa. The
+// folding rounds of the IPA are the ones of the ipa package.
+//
+// The nodes of the trie are committed to with Pedersen vector commitments of
+// width 256, whose bases are derived from the seed "eth_verkle_oct_2021". The
+// committed vectors are seen as polynomials in evaluation form over the domain
+// {0, …, 255}, and are opened with an inner product argument (IPA). The
+// openings of many commitments at points of the domain are aggregated in a
+// single multiproof of constant size.
+//
+// The keys are made of a 31 bytes stem, which is the path in the trie, and of
+// a suffix selecting one of the 256 values of the leaf of the stem. A leaf
+// (extension node) commits to 1, its stem and the commitments C₁, C₂ to the
+// first and the last 128 values. Each 32 bytes value v is committed to as the
+// two scalars v[:16] + 2¹²⁸ and v[16:] (little-endian), the 2¹²⁸ marker
+// distinguishing a zero value from an absent one. An internal node commits to
+// the mapping to the scalar field of the commitments of its children.
+//
+// Documentation:
+//   - https://notes.ethereum.org/@vbuterin/verkle_tree_eip
+//   - https://dankradfeist.de/ethereum/2021/06/18/pcs-multiproofs.html
+//   - https://github.com/crate-crypto/go-ipa and https://github.com/ethereum/go-verkle
+package verkle
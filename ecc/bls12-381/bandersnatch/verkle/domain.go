@@ -0,0 +1,114 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package verkle
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+// domain holds the precomputed data of the evaluation domain {0, …, 255}, with
+// A(X) = ∏ (X - i) its vanishing polynomial.
+type domain struct {
+	// weights[i] = A'(i) = ∏_{j≠i} (i - j)
+	weights [NodeWidth]fr.Element
+
+	// invWeights[i] = 1/A'(i)
+	invWeights [NodeWidth]fr.Element
+
+	// invDiffs[k-1] = 1/k for k in 1..255
+	invDiffs [NodeWidth - 1]fr.Element
+}
+
+func newDomain() *domain {
+	d := new(domain)
+	var t fr.Element
+	for i := 0; i < NodeWidth; i++ {
+		d.weights[i].SetOne()
+		for j := 0; j < NodeWidth; j++ {
+			if j != i {
+				t.SetInt64(int64(i - j))
+				d.weights[i].Mul(&d.weights[i], &t)
+			}
+		}
+	}
+	copy(d.invWeights[:], fr.BatchInvert(d.weights[:]))
+	for k := range d.invDiffs {
+		d.invDiffs[k].SetUint64(uint64(k + 1))
+	}
+	copy(d.invDiffs[:], fr.BatchInvert(d.invDiffs[:]))
+	return d
+}
+
+// invDiff returns 1/(i - j), i ≠ j.
+func (d *domain) invDiff(i, j int) fr.Element {
+	if i > j {
+		return d.invDiffs[i-j-1]
+	}
+	var res fr.Element
+	res.Neg(&d.invDiffs[j-i-1])
+	return res
+}
+
+// lagrangeCoefficients returns the evaluations at z of the Lagrange
+// polynomials Lᵢ of the domain, so that f(z) = ∑ fᵢ⋅Lᵢ(z) for f in evaluation
+// form.
+func (d *domain) lagrangeCoefficients(z *fr.Element) []fr.Element {
+	res := make([]fr.Element, NodeWidth)
+	var t fr.Element
+	for i := range res {
+		t.SetUint64(uint64(i))
+		res[i].Sub(z, &t)
+		if res[i].IsZero() {
+			// z is in the domain
+			for j := range res {
+				res[j].SetZero()
+			}
+			res[i].SetOne()
+			return res
+		}
+	}
+
+	// Lᵢ(z) = A(z) / (A'(i)⋅(z - i))
+	var az fr.Element
+	az.SetOne()
+	for i := range res {
+		az.Mul(&az, &res[i])
+		res[i].Mul(&res[i], &d.weights[i])
+	}
+	res = fr.BatchInvert(res)
+	for i := range res {
+		res[i].Mul(&res[i], &az)
+	}
+	return res
+}
+
+// divideOnDomain returns the evaluation form of (f(X) - f(m)) / (X - m), m in
+// the domain.
+func (d *domain) divideOnDomain(m int, f []fr.Element) []fr.Element {
+	q := make([]fr.Element, NodeWidth)
+	var t fr.Element
+	for i := range q {
+		if i == m {
+			continue
+		}
+		// q(i) = (f(i) - f(m)) / (i - m)
+		inv := d.invDiff(i, m)
+		q[i].Sub(&f[i], &f[m]).Mul(&q[i], &inv)
+
+		// q(m) = f'(m) = -∑_{i≠m} A'(m)/A'(i)⋅q(i)
+		t.Mul(&d.weights[m], &d.invWeights[i]).Mul(&t, &q[i])
+		q[m].Sub(&q[m], &t)
+	}
+	return q
+}
+
+// innerProduct returns ⟨a, b⟩
+func innerProduct(a, b []fr.Element) fr.Element {
+	var res, t fr.Element
+	for i := range a {
+		t.Mul(&a[i], &b[i])
+		res.Add(&res, &t)
+	}
+	return res
+}
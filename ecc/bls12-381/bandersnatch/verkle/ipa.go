@@ -0,0 +1,134 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package verkle
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/banderwagon"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/ipa"
+)
+
+var (
+	ErrInvalidPolynomialSize = errors.New("the polynomial must have NodeWidth evaluations")
+	ErrInvalidProofSize      = errors.New("the IPA proof must have 8 rounds")
+	ErrVerifyOpeningProof    = errors.New("can't verify opening proof")
+)
+
+// IPAProof is an inner product argument that a polynomial in evaluation form,
+// committed to by C, evaluates to y at a point z, i.e. that y is the inner
+// product of its evaluations with the Lagrange coefficients at z.
+type IPAProof struct {
+	// L, R are the cross terms of the folding rounds
+	L, R []banderwagon.Element
+
+	// A is the vector of evaluations folded down to a scalar
+	A fr.Element
+}
+
+// ProveIPA returns a proof of the evaluation at z of poly, given in evaluation
+// form and committed to by commitment. The evaluation is absorbed in the
+// transcript and not part of the proof.
+//
+// The folding rounds are the ones of the inner product argument of the ipa
+// package, run on bandersnatch points of the classes of the bases, with the
+// Lagrange coefficients at z as the vector b.
+func ProveIPA(t *Transcript, conf *Config, commitment *banderwagon.Element, poly []fr.Element, z *fr.Element) (IPAProof, error) {
+	if len(poly) != NodeWidth {
+		return IPAProof{}, ErrInvalidPolynomialSize
+	}
+	b := conf.domain.lagrangeCoefficients(z)
+	y := innerProduct(poly, b)
+	q := ipaBase(t, conf, commitment, z, &y)
+
+	proof := IPAProof{
+		L: make([]banderwagon.Element, nbRounds),
+		R: make([]banderwagon.Element, nbRounds),
+	}
+	challenge := func(round int, l, r *bandersnatch.PointAffine) (fr.Element, error) {
+		proof.L[round].FromAffine(l)
+		proof.R[round].FromAffine(r)
+		return deriveX(t, &proof.L[round], &proof.R[round]), nil
+	}
+	var err error
+	qAffine := banderwagon.BatchToAffine([]banderwagon.Element{q})[0]
+	if _, _, proof.A, err = ipa.ProveInnerProduct(poly, b, conf.srsAffine, &qAffine, challenge); err != nil {
+		return IPAProof{}, err
+	}
+
+	return proof, nil
+}
+
+// VerifyIPA verifies a proof that the polynomial committed to by commitment
+// evaluates to y at z.
+func VerifyIPA(t *Transcript, conf *Config, commitment *banderwagon.Element, proof *IPAProof, z, y *fr.Element) error {
+	if len(proof.L) != nbRounds || len(proof.R) != nbRounds {
+		return ErrInvalidProofSize
+	}
+	b := conf.domain.lagrangeCoefficients(z)
+	q := ipaBase(t, conf, commitment, z, y)
+
+	x := make([]fr.Element, nbRounds)
+	for round := range x {
+		x[round] = deriveX(t, &proof.L[round], &proof.R[round])
+	}
+	xInv := fr.BatchInvert(x)
+
+	// the folded base is G₀ = ⟨s, G⟩ and the folded b is b₀ = ⟨s, b⟩
+	s := ipa.FoldingScalars(xInv)
+	b0 := innerProduct(s, b)
+
+	// a⋅G₀ + (a⋅b₀ - y)⋅q - C - ∑ xⱼ⋅Lⱼ + xⱼ⁻¹⋅Rⱼ = 0
+	bases := make([]banderwagon.Element, 0, NodeWidth+2+2*nbRounds)
+	scalars := make([]fr.Element, 0, cap(bases))
+	bases = append(bases, conf.SRS...)
+	for i := range s {
+		s[i].Mul(&s[i], &proof.A)
+	}
+	scalars = append(scalars, s...)
+
+	var tmp fr.Element
+	tmp.Mul(&proof.A, &b0).Sub(&tmp, y)
+	bases = append(bases, q, *commitment)
+	scalars = append(scalars, tmp, fr.One())
+	scalars[len(scalars)-1].Neg(&scalars[len(scalars)-1])
+	for round := range x {
+		bases = append(bases, proof.L[round], proof.R[round])
+		scalars = append(scalars, x[round], xInv[round])
+		scalars[len(scalars)-2].Neg(&scalars[len(scalars)-2])
+		scalars[len(scalars)-1].Neg(&scalars[len(scalars)-1])
+	}
+
+	var res banderwagon.Element
+	if _, err := res.MultiExp(bases, scalars); err != nil {
+		return err
+	}
+	if !res.IsIdentity() {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// ipaBase absorbs the statement of the IPA and returns the base w⋅Q of the
+// inner products, with w the first challenge.
+func ipaBase(t *Transcript, conf *Config, commitment *banderwagon.Element, z, y *fr.Element) banderwagon.Element {
+	t.DomainSep("ipa")
+	t.AppendPoint(commitment, "C")
+	t.AppendScalar(z, "input point")
+	t.AppendScalar(y, "output point")
+	w := t.ChallengeScalar("w")
+
+	var q banderwagon.Element
+	q.ScalarMultiplication(&conf.Q, &w)
+	return q
+}
+
+// deriveX returns the challenge of a round of the IPA.
+func deriveX(t *Transcript, l, r *banderwagon.Element) fr.Element {
+	t.AppendPoint(l, "L")
+	t.AppendPoint(r, "R")
+	return t.ChallengeScalar("x")
+}
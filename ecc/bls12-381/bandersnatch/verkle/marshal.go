@@ -0,0 +1,222 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package verkle
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/banderwagon"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+// maxProofItems bounds the sizes of the decoded proofs
+const maxProofItems = 1 << 20
+
+var errInvalidSize = errors.New("invalid size")
+
+// WriteTo writes the encoding of the IPA proof, as in go-ipa: the points L,
+// the points R and the little-endian scalar A.
+func (proof *IPAProof) WriteTo(w io.Writer) (int64, error) {
+	if len(proof.L) != nbRounds || len(proof.R) != nbRounds {
+		return 0, ErrInvalidProofSize
+	}
+	written, err := writePoints(w, proof.L)
+	if err != nil {
+		return written, err
+	}
+	n64, err := writePoints(w, proof.R)
+	written += n64
+	if err != nil {
+		return written, err
+	}
+	var buf [fr.Bytes]byte
+	fr.LittleEndian.PutElement(&buf, proof.A)
+	n, err := w.Write(buf[:])
+	return written + int64(n), err
+}
+
+// ReadFrom decodes an IPA proof from r.
+func (proof *IPAProof) ReadFrom(r io.Reader) (int64, error) {
+	proof.L = make([]banderwagon.Element, nbRounds)
+	proof.R = make([]banderwagon.Element, nbRounds)
+	read, err := readPoints(r, proof.L)
+	if err != nil {
+		return read, err
+	}
+	n64, err := readPoints(r, proof.R)
+	read += n64
+	if err != nil {
+		return read, err
+	}
+	var buf [fr.Bytes]byte
+	n, err := io.ReadFull(r, buf[:])
+	read += int64(n)
+	if err != nil {
+		return read, err
+	}
+	proof.A, err = fr.LittleEndian.Element(&buf)
+	return read, err
+}
+
+// WriteTo writes the encoding of the multiproof, as in go-ipa: D followed by
+// the IPA proof.
+func (proof *MultiProof) WriteTo(w io.Writer) (int64, error) {
+	written, err := writePoints(w, []banderwagon.Element{proof.D})
+	if err != nil {
+		return written, err
+	}
+	n64, err := proof.IPA.WriteTo(w)
+	return written + n64, err
+}
+
+// ReadFrom decodes a multiproof from r.
+func (proof *MultiProof) ReadFrom(r io.Reader) (int64, error) {
+	d := make([]banderwagon.Element, 1)
+	read, err := readPoints(r, d)
+	if err != nil {
+		return read, err
+	}
+	proof.D = d[0]
+	n64, err := proof.IPA.ReadFrom(r)
+	return read + n64, err
+}
+
+// WriteTo writes the encoding of the proof: the extension statuses, the
+// commitments and the other stems, each prefixed by their number on 4
+// big-endian bytes, followed by the multiproof.
+func (proof *Proof) WriteTo(w io.Writer) (int64, error) {
+	written, err := writeLength(w, len(proof.ExtStatus))
+	if err != nil {
+		return written, err
+	}
+	n, err := w.Write(proof.ExtStatus)
+	written += int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	n64, err := writeLength(w, len(proof.Commitments))
+	written += n64
+	if err != nil {
+		return written, err
+	}
+	n64, err = writePoints(w, proof.Commitments)
+	written += n64
+	if err != nil {
+		return written, err
+	}
+
+	n64, err = writeLength(w, len(proof.OtherStems))
+	written += n64
+	if err != nil {
+		return written, err
+	}
+	for i := range proof.OtherStems {
+		n, err = w.Write(proof.OtherStems[i][:])
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+
+	n64, err = proof.MultiProof.WriteTo(w)
+	return written + n64, err
+}
+
+// ReadFrom decodes a proof from r.
+func (proof *Proof) ReadFrom(r io.Reader) (int64, error) {
+	size, read, err := readLength(r)
+	if err != nil {
+		return read, err
+	}
+	proof.ExtStatus = make([]byte, size)
+	n, err := io.ReadFull(r, proof.ExtStatus)
+	read += int64(n)
+	if err != nil {
+		return read, err
+	}
+
+	size, n64, err := readLength(r)
+	read += n64
+	if err != nil {
+		return read, err
+	}
+	proof.Commitments = make([]banderwagon.Element, size)
+	n64, err = readPoints(r, proof.Commitments)
+	read += n64
+	if err != nil {
+		return read, err
+	}
+
+	size, n64, err = readLength(r)
+	read += n64
+	if err != nil {
+		return read, err
+	}
+	proof.OtherStems = make([][StemSize]byte, size)
+	for i := range proof.OtherStems {
+		n, err = io.ReadFull(r, proof.OtherStems[i][:])
+		read += int64(n)
+		if err != nil {
+			return read, err
+		}
+	}
+
+	n64, err = proof.MultiProof.ReadFrom(r)
+	return read + n64, err
+}
+
+func writeLength(w io.Writer, l int) (int64, error) {
+	if l > maxProofItems {
+		return 0, errInvalidSize
+	}
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(l))
+	n, err := w.Write(buf[:])
+	return int64(n), err
+}
+
+func readLength(r io.Reader) (int, int64, error) {
+	var buf [4]byte
+	n, err := io.ReadFull(r, buf[:])
+	if err != nil {
+		return 0, int64(n), err
+	}
+	l := binary.BigEndian.Uint32(buf[:])
+	if l > maxProofItems {
+		return 0, int64(n), errInvalidSize
+	}
+	return int(l), int64(n), nil
+}
+
+func writePoints(w io.Writer, points []banderwagon.Element) (int64, error) {
+	var written int64
+	for _, b := range banderwagon.BatchToBytes(points) {
+		n, err := w.Write(b[:])
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// readPoints decodes points from r, checking that they are in the group.
+func readPoints(r io.Reader, points []banderwagon.Element) (int64, error) {
+	var read int64
+	var buf [banderwagon.SizeCompressed]byte
+	for i := range points {
+		n, err := io.ReadFull(r, buf[:])
+		read += int64(n)
+		if err != nil {
+			return read, err
+		}
+		if err = points[i].SetBytes(buf[:]); err != nil {
+			return read, err
+		}
+	}
+	return read, nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package verkle
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/banderwagon"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+var (
+	ErrInvalidNbOpenings = errors.New("the numbers of commitments, polynomials, values and indices must match")
+	ErrNoOpening         = errors.New("at least one opening is required")
+)
+
+// MultiProof is a proof of the openings of several commitments at points of
+// the domain, made of a commitment D and of a single IPA.
+//
+// With r a random challenge, the prover commits to
+// g(X) = ∑ rⁱ⋅(fᵢ(X) - yᵢ) / (X - zᵢ), and opens h(X) - g(X) at a random t, with
+// h(X) = ∑ rⁱ⋅fᵢ(X) / (t - zᵢ), whose commitment the verifier computes from the
+// commitments Cᵢ to the fᵢ. The value of h - g at t is ∑ rⁱ⋅yᵢ / (t - zᵢ).
+type MultiProof struct {
+	IPA IPAProof
+	D   banderwagon.Element
+}
+
+// ProveMultiProof returns a proof of the evaluations of polys[i], given in
+// evaluation form and committed to by commitments[i], at the points
+// indices[i] of the domain.
+func ProveMultiProof(t *Transcript, conf *Config, commitments []banderwagon.Element, polys [][]fr.Element, indices []uint8) (MultiProof, error) {
+	if len(commitments) != len(polys) || len(commitments) != len(indices) {
+		return MultiProof{}, ErrInvalidNbOpenings
+	}
+	if len(commitments) == 0 {
+		return MultiProof{}, ErrNoOpening
+	}
+	for i := range polys {
+		if len(polys[i]) != NodeWidth {
+			return MultiProof{}, ErrInvalidPolynomialSize
+		}
+	}
+
+	values := make([]fr.Element, len(polys))
+	for i := range polys {
+		values[i] = polys[i][indices[i]]
+	}
+	r := multiProofStatement(t, commitments, values, indices)
+
+	// aggregate the polynomials opened at the same point, fⱼ = ∑_{zᵢ = j} rⁱ⋅fᵢ
+	var grouped [NodeWidth][]fr.Element
+	var ri, tmp fr.Element
+	ri.SetOne()
+	for i := range polys {
+		f := grouped[indices[i]]
+		if f == nil {
+			f = make([]fr.Element, NodeWidth)
+			grouped[indices[i]] = f
+		}
+		for k := range f {
+			tmp.Mul(&polys[i][k], &ri)
+			f[k].Add(&f[k], &tmp)
+		}
+		ri.Mul(&ri, &r)
+	}
+
+	// g(X) = ∑ (fⱼ(X) - fⱼ(j)) / (X - j)
+	g := make([]fr.Element, NodeWidth)
+	for j := range grouped {
+		if grouped[j] == nil {
+			continue
+		}
+		q := conf.domain.divideOnDomain(j, grouped[j])
+		for k := range g {
+			g[k].Add(&g[k], &q[k])
+		}
+	}
+	var proof MultiProof
+	var err error
+	if proof.D, err = conf.Commit(g); err != nil {
+		return MultiProof{}, err
+	}
+	t.AppendPoint(&proof.D, "D")
+	z := t.ChallengeScalar("t")
+
+	// h(X) = ∑ fⱼ(X) / (t - j)
+	den := denominators(&z, &grouped)
+	h := make([]fr.Element, NodeWidth)
+	for j := range grouped {
+		if grouped[j] == nil {
+			continue
+		}
+		for k := range h {
+			tmp.Mul(&grouped[j][k], &den[j])
+			h[k].Add(&h[k], &tmp)
+		}
+	}
+	e, err := conf.Commit(h)
+	if err != nil {
+		return MultiProof{}, err
+	}
+	t.AppendPoint(&e, "E")
+
+	// open h - g at t
+	var eMinusD banderwagon.Element
+	eMinusD.Sub(&e, &proof.D)
+	for k := range h {
+		h[k].Sub(&h[k], &g[k])
+	}
+	proof.IPA, err = ProveIPA(t, conf, &eMinusD, h, &z)
+	return proof, err
+}
+
+// VerifyMultiProof verifies a proof that the polynomials committed to by
+// commitments[i] evaluate to values[i] at the points indices[i] of the domain.
+func VerifyMultiProof(t *Transcript, conf *Config, proof *MultiProof, commitments []banderwagon.Element, values []fr.Element, indices []uint8) error {
+	if len(commitments) != len(values) || len(commitments) != len(indices) {
+		return ErrInvalidNbOpenings
+	}
+	if len(commitments) == 0 {
+		return ErrNoOpening
+	}
+	r := multiProofStatement(t, commitments, values, indices)
+	t.AppendPoint(&proof.D, "D")
+	z := t.ChallengeScalar("t")
+
+	var used [NodeWidth][]fr.Element
+	for _, j := range indices {
+		used[j] = []fr.Element{}
+	}
+	den := denominators(&z, &used)
+
+	// E = ∑ rⁱ/(t - zᵢ)⋅Cᵢ and (h - g)(t) = ∑ rⁱ⋅yᵢ/(t - zᵢ)
+	scalars := make([]fr.Element, len(commitments))
+	var ri, y, tmp fr.Element
+	ri.SetOne()
+	for i := range scalars {
+		scalars[i].Mul(&ri, &den[indices[i]])
+		tmp.Mul(&scalars[i], &values[i])
+		y.Add(&y, &tmp)
+		ri.Mul(&ri, &r)
+	}
+	var e banderwagon.Element
+	if _, err := e.MultiExp(commitments, scalars); err != nil {
+		return err
+	}
+	t.AppendPoint(&e, "E")
+
+	var eMinusD banderwagon.Element
+	eMinusD.Sub(&e, &proof.D)
+	return VerifyIPA(t, conf, &eMinusD, &proof.IPA, &z, &y)
+}
+
+// multiProofStatement absorbs the openings and returns the challenge r.
+func multiProofStatement(t *Transcript, commitments []banderwagon.Element, values []fr.Element, indices []uint8) fr.Element {
+	t.DomainSep("multiproof")
+	encoded := banderwagon.BatchToBytes(commitments)
+	var z fr.Element
+	for i := range commitments {
+		t.AppendMessage(encoded[i][:], "C")
+		z.SetUint64(uint64(indices[i]))
+		t.AppendScalar(&z, "z")
+		t.AppendScalar(&values[i], "y")
+	}
+	return t.ChallengeScalar("r")
+}
+
+// denominators returns 1/(t - j) for the points j of the domain with a non-nil
+// entry in used.
+func denominators(t *fr.Element, used *[NodeWidth][]fr.Element) []fr.Element {
+	res := make([]fr.Element, NodeWidth)
+	for j := range used {
+		if used[j] != nil {
+			res[j].SetUint64(uint64(j))
+			res[j].Sub(t, &res[j])
+		}
+	}
+	return fr.BatchInvert(res)
+}
@@ -0,0 +1,434 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package verkle
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/banderwagon"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+var (
+	ErrInvalidProof    = errors.New("invalid verkle proof")
+	ErrInvalidNbValues = errors.New("the numbers of keys and values must match")
+)
+
+// extension status of a stem in a proof
+const (
+	// no leaf on the path of the stem
+	extStatusAbsentEmpty = iota
+
+	// a leaf with an other stem on the path of the stem
+	extStatusAbsentOther
+
+	// the leaf of the stem is in the trie
+	extStatusPresent
+)
+
+// Proof is a proof of the values of a set of keys, present or absent, in a
+// trie with a given root commitment.
+//
+// For each stem, the proof opens the internal nodes on its path at the index
+// of the next node, down to an empty child or to a leaf. The openings of a
+// leaf prove its stem and the values of the suffixes, the absent values being
+// zero. All the openings are aggregated in a multiproof.
+type Proof struct {
+	// ExtStatus holds, for each stem of the keys in increasing order, the
+	// depth of its path and the status of its extension: depth<<3 | status
+	ExtStatus []byte
+
+	// Commitments are the commitments of the nodes opened by the proof,
+	// except the root, sorted by path
+	Commitments []banderwagon.Element
+
+	// OtherStems are the stems of the leaves found on the paths of absent
+	// stems, sorted by path, except the leaves of the present stems
+	OtherStems [][StemSize]byte
+
+	MultiProof MultiProof
+}
+
+// commitmentKey identifies a committed vector in a proof: the node at path,
+// or one of the two suffix commitments of the leaf at path
+type commitmentKey struct {
+	path string
+	kind uint8 // 0 for the node, 1 + k for Cₖ₊₁
+}
+
+type openingKey struct {
+	commitment commitmentKey
+	index      uint8
+}
+
+// openings accumulates the openings of a proof, without duplicates.
+type openings struct {
+	seen        map[openingKey]int
+	keys        []openingKey
+	commitments []banderwagon.Element
+	polys       [][]fr.Element
+	values      []fr.Element
+	indices     []uint8
+}
+
+func newOpenings() *openings {
+	return &openings{seen: make(map[openingKey]int)}
+}
+
+// add adds the opening of c at index. It returns false if the same commitment
+// was opened at the same index to a different value.
+func (o *openings) add(key commitmentKey, c *banderwagon.Element, poly []fr.Element, index uint8, value *fr.Element) bool {
+	k := openingKey{key, index}
+	if i, ok := o.seen[k]; ok {
+		return o.values[i].Equal(value)
+	}
+	o.seen[k] = len(o.values)
+	o.keys = append(o.keys, k)
+	o.commitments = append(o.commitments, *c)
+	o.polys = append(o.polys, poly)
+	o.values = append(o.values, *value)
+	o.indices = append(o.indices, index)
+	return true
+}
+
+// sort orders the openings as go-verkle does: by path, which visits the nodes
+// depth first, then by commitment of the node and by index.
+func (o *openings) sort() {
+	order := make([]int, len(o.keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := o.keys[order[i]], o.keys[order[j]]
+		if a.commitment.path != b.commitment.path {
+			return a.commitment.path < b.commitment.path
+		}
+		if a.commitment.kind != b.commitment.kind {
+			return a.commitment.kind < b.commitment.kind
+		}
+		return a.index < b.index
+	})
+
+	commitments := make([]banderwagon.Element, len(order))
+	polys := make([][]fr.Element, len(order))
+	values := make([]fr.Element, len(order))
+	indices := make([]uint8, len(order))
+	for i, j := range order {
+		commitments[i], polys[i], values[i], indices[i] = o.commitments[j], o.polys[j], o.values[j], o.indices[j]
+	}
+	o.commitments, o.polys, o.values, o.indices = commitments, polys, values, indices
+}
+
+// stemKeys are the suffixes and values of the keys with the same stem
+type stemKeys struct {
+	stem     []byte
+	suffixes []byte
+	values   [][]byte
+}
+
+// groupByStem sorts the keys and groups them by stem. It returns an error if
+// a key appears twice with different values.
+func groupByStem(keys, values [][]byte) ([]stemKeys, error) {
+	order := make([]int, len(keys))
+	for i := range order {
+		if len(keys[i]) != KeySize {
+			return nil, ErrInvalidKeySize
+		}
+		if values[i] != nil && len(values[i]) != ValueSize {
+			return nil, ErrInvalidValueSize
+		}
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return bytes.Compare(keys[order[i]], keys[order[j]]) < 0
+	})
+
+	var res []stemKeys
+	for k, i := range order {
+		key := keys[i]
+		if k > 0 && bytes.Equal(key, keys[order[k-1]]) {
+			if !bytes.Equal(values[i], values[order[k-1]]) {
+				return nil, ErrInvalidProof
+			}
+			continue
+		}
+		if len(res) == 0 || !bytes.Equal(res[len(res)-1].stem, key[:StemSize]) {
+			res = append(res, stemKeys{stem: key[:StemSize]})
+		}
+		s := &res[len(res)-1]
+		s.suffixes = append(s.suffixes, key[StemSize])
+		s.values = append(s.values, values[i])
+	}
+	return res, nil
+}
+
+// Prove returns a proof of the values of keys in the trie, which are also
+// returned, nil for the absent keys. The trie is committed to first.
+func (t *Tree) Prove(keys [][]byte) (*Proof, [][]byte, error) {
+	values := make([][]byte, len(keys))
+	for i := range keys {
+		var err error
+		if values[i], err = t.Get(keys[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	stems, err := groupByStem(keys, values)
+	if err != nil {
+		return nil, nil, err
+	}
+	t.Commit()
+
+	proof := new(Proof)
+	o := newOpenings()
+	known := make(map[commitmentKey]bool)
+	addCommitment := func(key commitmentKey, c *banderwagon.Element) {
+		if !known[key] {
+			known[key] = true
+			proof.Commitments = append(proof.Commitments, *c)
+		}
+	}
+	// the stem of a leaf on the path of an absent stem is part of the proof,
+	// unless the leaf is proved present
+	var otherLeaves []*leafNode
+	presentLeaves := make(map[*leafNode]bool)
+
+	for _, s := range stems {
+		// open the internal nodes down to an empty child or a leaf
+		n := t.root
+		var leaf *leafNode
+		status := byte(extStatusAbsentEmpty)
+		for leaf == nil {
+			i := s.stem[n.depth]
+			key := commitmentKey{path: string(s.stem[:n.depth])}
+			o.add(key, &n.commitment, n.scalars[:], i, &n.scalars[i])
+
+			childKey := commitmentKey{path: string(s.stem[:n.depth+1])}
+			child, ok := n.children[i].(*internalNode)
+			if ok {
+				addCommitment(childKey, &child.commitment)
+				n = child
+				continue
+			}
+			if leaf, ok = n.children[i].(*leafNode); !ok {
+				break
+			}
+			addCommitment(childKey, &leaf.commitment)
+			if bytes.Equal(leaf.stem[:], s.stem) {
+				status = extStatusPresent
+				presentLeaves[leaf] = true
+			} else {
+				status = extStatusAbsentOther
+				if len(otherLeaves) == 0 || otherLeaves[len(otherLeaves)-1] != leaf {
+					otherLeaves = append(otherLeaves, leaf)
+				}
+			}
+		}
+		proof.ExtStatus = append(proof.ExtStatus, byte(n.depth+1)<<3|status)
+		if leaf == nil {
+			continue
+		}
+
+		// open the leaf at its marker and its stem, and the values
+		leafKey := commitmentKey{path: string(s.stem[:n.depth+1])}
+		poly := leaf.polynomial()
+		o.add(leafKey, &leaf.commitment, poly, 0, &poly[0])
+		o.add(leafKey, &leaf.commitment, poly, 1, &poly[1])
+		if status != extStatusPresent {
+			continue
+		}
+		var suffixPolys [2][]fr.Element
+		for _, suffix := range s.suffixes {
+			k := int(suffix) / (NodeWidth / 2)
+			o.add(leafKey, &leaf.commitment, poly, uint8(2+k), &poly[2+k])
+
+			cKey := commitmentKey{path: leafKey.path, kind: uint8(1 + k)}
+			addCommitment(cKey, &leaf.c[k])
+			if suffixPolys[k] == nil {
+				suffixPolys[k] = leaf.suffixPolynomial(k)
+			}
+			i := uint8(2 * (int(suffix) % (NodeWidth / 2)))
+			o.add(cKey, &leaf.c[k], suffixPolys[k], i, &suffixPolys[k][i])
+			o.add(cKey, &leaf.c[k], suffixPolys[k], i+1, &suffixPolys[k][i+1])
+		}
+	}
+
+	for _, leaf := range otherLeaves {
+		if !presentLeaves[leaf] {
+			proof.OtherStems = append(proof.OtherStems, leaf.stem)
+		}
+	}
+
+	o.sort()
+	proof.MultiProof, err = ProveMultiProof(NewTranscript("vt"), t.conf, o.commitments, o.polys, o.indices)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proof, values, nil
+}
+
+// VerifyProof verifies a proof that keys have the given values, nil for the
+// absent keys, in the trie committed to by root.
+func VerifyProof(conf *Config, root *banderwagon.Element, keys, values [][]byte, proof *Proof) error {
+	if len(keys) != len(values) {
+		return ErrInvalidNbValues
+	}
+	stems, err := groupByStem(keys, values)
+	if err != nil {
+		return err
+	}
+	if len(stems) != len(proof.ExtStatus) {
+		return ErrInvalidProof
+	}
+
+	// the commitments of the proof are consumed in the order of the prover
+	commitments := map[commitmentKey]*banderwagon.Element{{}: root}
+	getCommitment := func(key commitmentKey) (*banderwagon.Element, error) {
+		if c, ok := commitments[key]; ok {
+			return c, nil
+		}
+		if len(proof.Commitments) <= len(commitments)-1 {
+			return nil, ErrInvalidProof
+		}
+		c := &proof.Commitments[len(commitments)-1]
+		commitments[key] = c
+		return c, nil
+	}
+
+	// the kinds of the nodes on the paths must be consistent
+	const (
+		kindInternal = iota + 1
+		kindLeaf
+		kindEmpty
+	)
+	kinds := map[string]int{"": kindInternal}
+	setKind := func(path []byte, kind int) bool {
+		if k, ok := kinds[string(path)]; ok {
+			return k == kind
+		}
+		kinds[string(path)] = kind
+		return true
+	}
+	// the stem of a leaf is the stem proved present there, if any, and
+	// otherwise the next of the other stems
+	leafStems := make(map[string][]byte)
+	for k, s := range stems {
+		depth := int(proof.ExtStatus[k] >> 3)
+		if proof.ExtStatus[k]&7 == extStatusPresent && depth <= StemSize {
+			if _, ok := leafStems[string(s.stem[:depth])]; !ok {
+				leafStems[string(s.stem[:depth])] = s.stem
+			}
+		}
+	}
+	nbOtherStems := 0
+
+	o := newOpenings()
+	var y fr.Element
+	for k, s := range stems {
+		depth, status := int(proof.ExtStatus[k]>>3), proof.ExtStatus[k]&7
+		if depth < 1 || depth > StemSize || status > extStatusPresent {
+			return ErrInvalidProof
+		}
+
+		// the internal nodes on the path
+		for i := 0; i < depth; i++ {
+			parent := commitmentKey{path: string(s.stem[:i])}
+			if !setKind(s.stem[:i], kindInternal) {
+				return ErrInvalidProof
+			}
+			childPath := s.stem[:i+1]
+			switch {
+			case i < depth-1:
+				if !setKind(childPath, kindInternal) {
+					return ErrInvalidProof
+				}
+			case status == extStatusAbsentEmpty:
+				if !setKind(childPath, kindEmpty) {
+					return ErrInvalidProof
+				}
+			default:
+				if !setKind(childPath, kindLeaf) {
+					return ErrInvalidProof
+				}
+			}
+			y.SetZero()
+			if kinds[string(childPath)] != kindEmpty {
+				c, err := getCommitment(commitmentKey{path: string(childPath)})
+				if err != nil {
+					return err
+				}
+				c.MapToScalarField(&y)
+			}
+			if !o.add(parent, commitments[parent], nil, s.stem[i], &y) {
+				return ErrInvalidProof
+			}
+		}
+
+		if status != extStatusPresent {
+			for _, v := range s.values {
+				if v != nil {
+					return ErrInvalidProof
+				}
+			}
+		}
+		if status == extStatusAbsentEmpty {
+			continue
+		}
+
+		// the stem of the leaf
+		leafPath := s.stem[:depth]
+		leafStem, ok := leafStems[string(leafPath)]
+		if !ok {
+			if nbOtherStems == len(proof.OtherStems) {
+				return ErrInvalidProof
+			}
+			leafStem = proof.OtherStems[nbOtherStems][:]
+			nbOtherStems++
+			leafStems[string(leafPath)] = leafStem
+		}
+		if !bytes.HasPrefix(leafStem, leafPath) || (status == extStatusPresent) != bytes.Equal(leafStem, s.stem) {
+			return ErrInvalidProof
+		}
+		leafKey := commitmentKey{path: string(leafPath)}
+		leafC := commitments[leafKey]
+		y.SetOne()
+		if !o.add(leafKey, leafC, nil, 0, &y) {
+			return ErrInvalidProof
+		}
+		stemToScalar(&y, leafStem)
+		if !o.add(leafKey, leafC, nil, 1, &y) {
+			return ErrInvalidProof
+		}
+		if status != extStatusPresent {
+			continue
+		}
+
+		// the values
+		var scalars [2]fr.Element
+		for j, suffix := range s.suffixes {
+			k := int(suffix) / (NodeWidth / 2)
+			cKey := commitmentKey{path: leafKey.path, kind: uint8(1 + k)}
+			c, err := getCommitment(cKey)
+			if err != nil {
+				return err
+			}
+			c.MapToScalarField(&y)
+			if !o.add(leafKey, leafC, nil, uint8(2+k), &y) {
+				return ErrInvalidProof
+			}
+			i := uint8(2 * (int(suffix) % (NodeWidth / 2)))
+			valueToScalars(scalars[:], s.values[j])
+			if !o.add(cKey, c, nil, i, &scalars[0]) || !o.add(cKey, c, nil, i+1, &scalars[1]) {
+				return ErrInvalidProof
+			}
+		}
+	}
+	if len(commitments)-1 != len(proof.Commitments) || nbOtherStems != len(proof.OtherStems) {
+		return ErrInvalidProof
+	}
+
+	o.sort()
+	return VerifyMultiProof(NewTranscript("vt"), conf, &proof.MultiProof, o.commitments, o.values, o.indices)
+}
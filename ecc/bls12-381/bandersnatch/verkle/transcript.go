@@ -0,0 +1,67 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package verkle
+
+import (
+	"crypto/sha256"
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/banderwagon"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+// Transcript is the Fiat-Shamir transcript of go-ipa: the labels and the
+// messages are absorbed in a running SHA-256 state, and a challenge is the
+// little-endian digest reduced modulo the order of the group, which is
+// absorbed in a fresh state.
+type Transcript struct {
+	state hash.Hash
+}
+
+// NewTranscript returns a transcript separated by label.
+func NewTranscript(label string) *Transcript {
+	t := &Transcript{state: sha256.New()}
+	t.DomainSep(label)
+	return t
+}
+
+// DomainSep absorbs label.
+func (t *Transcript) DomainSep(label string) {
+	t.state.Write([]byte(label))
+}
+
+// AppendMessage absorbs label and message.
+func (t *Transcript) AppendMessage(message []byte, label string) {
+	t.state.Write([]byte(label))
+	t.state.Write(message)
+}
+
+// AppendScalar absorbs label and the little-endian encoding of s.
+func (t *Transcript) AppendScalar(s *fr.Element, label string) {
+	var b [fr.Bytes]byte
+	fr.LittleEndian.PutElement(&b, *s)
+	t.AppendMessage(b[:], label)
+}
+
+// AppendPoint absorbs label and the serialization of p.
+func (t *Transcript) AppendPoint(p *banderwagon.Element, label string) {
+	b := p.Bytes()
+	t.AppendMessage(b[:], label)
+}
+
+// ChallengeScalar returns the challenge labeled label.
+func (t *Transcript) ChallengeScalar(label string) fr.Element {
+	t.DomainSep(label)
+	digest := t.state.Sum(nil)
+	t.state.Reset()
+
+	// the digest is a little-endian integer
+	for i, j := 0, len(digest)-1; i < j; i, j = i+1, j-1 {
+		digest[i], digest[j] = digest[j], digest[i]
+	}
+	var res fr.Element
+	res.SetBytes(digest)
+	t.AppendScalar(&res, label)
+	return res
+}
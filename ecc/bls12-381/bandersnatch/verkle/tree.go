@@ -0,0 +1,300 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package verkle
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/banderwagon"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+const (
+	// KeySize is the size in bytes of a key, made of a stem and a suffix
+	KeySize = 32
+
+	// StemSize is the size in bytes of the stem of a key
+	StemSize = 31
+
+	// ValueSize is the size in bytes of a value
+	ValueSize = 32
+)
+
+var (
+	ErrInvalidKeySize   = errors.New("keys must have KeySize bytes")
+	ErrInvalidValueSize = errors.New("values must have ValueSize bytes")
+)
+
+// Tree is a verkle trie of width NodeWidth. Its commitments are computed
+// lazily by Commit, and updated incrementally after an insertion.
+type Tree struct {
+	conf *Config
+	root *internalNode
+}
+
+// New returns an empty trie, with the configuration of GetConfig.
+func New() *Tree {
+	return NewWithConfig(GetConfig())
+}
+
+// NewWithConfig returns an empty trie with the given configuration.
+func NewWithConfig(conf *Config) *Tree {
+	return &Tree{conf: conf, root: newInternalNode(0)}
+}
+
+// Insert sets the value of key, inserting it if it is absent or updating it
+// otherwise.
+func (t *Tree) Insert(key, value []byte) error {
+	if len(key) != KeySize {
+		return ErrInvalidKeySize
+	}
+	if len(value) != ValueSize {
+		return ErrInvalidValueSize
+	}
+	t.root.insert(key[:StemSize], key[StemSize], value)
+	return nil
+}
+
+// Get returns the value of key, or nil if it is absent.
+func (t *Tree) Get(key []byte) ([]byte, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidKeySize
+	}
+	n := t.root
+	for {
+		switch child := n.children[key[n.depth]].(type) {
+		case *internalNode:
+			n = child
+		case *leafNode:
+			if !bytes.Equal(child.stem[:], key[:StemSize]) || child.values[key[StemSize]] == nil {
+				return nil, nil
+			}
+			return bytes.Clone(child.values[key[StemSize]]), nil
+		default:
+			return nil, nil
+		}
+	}
+}
+
+// Commit returns the commitment to the root of the trie. Only the nodes
+// modified since the previous call are recommitted.
+func (t *Tree) Commit() banderwagon.Element {
+	return t.root.commit(t.conf)
+}
+
+// node is an *internalNode or a *leafNode
+type node interface {
+	commit(conf *Config) banderwagon.Element
+}
+
+// internalNode is a node of the trie with NodeWidth children, committed to as
+// ∑ map(Cᵢ)⋅Gᵢ, with Cᵢ the commitment of the i-th child and map the mapping
+// to the scalar field.
+type internalNode struct {
+	depth    int
+	children [NodeWidth]node
+
+	// the committed values, the mappings of the commitments of the children,
+	// and the children modified since the last commitment
+	committed  bool
+	commitment banderwagon.Element
+	scalars    [NodeWidth]fr.Element
+	dirty      [NodeWidth]bool
+}
+
+func newInternalNode(depth int) *internalNode {
+	return &internalNode{depth: depth}
+}
+
+func (n *internalNode) insert(stem []byte, suffix byte, value []byte) {
+	i := stem[n.depth]
+	n.dirty[i] = true
+	switch child := n.children[i].(type) {
+	case nil:
+		leaf := &leafNode{}
+		copy(leaf.stem[:], stem)
+		leaf.set(suffix, value)
+		n.children[i] = leaf
+	case *internalNode:
+		child.insert(stem, suffix, value)
+	case *leafNode:
+		if bytes.Equal(child.stem[:], stem) {
+			child.set(suffix, value)
+			return
+		}
+		// the leaf is pushed down in a new internal node, its commitment
+		// doesn't depend on its depth
+		split := newInternalNode(n.depth + 1)
+		j := child.stem[split.depth]
+		split.children[j] = child
+		split.dirty[j] = true
+		n.children[i] = split
+		split.insert(stem, suffix, value)
+	}
+}
+
+func (n *internalNode) commit(conf *Config) banderwagon.Element {
+	var indices []int
+	for i := range n.dirty {
+		if n.dirty[i] {
+			indices = append(indices, i)
+			n.dirty[i] = false
+		}
+	}
+	if n.committed && len(indices) == 0 {
+		return n.commitment
+	}
+
+	commitments := make([]banderwagon.Element, len(indices))
+	for k, i := range indices {
+		commitments[k] = n.children[i].commit(conf)
+	}
+	scalars := make([]fr.Element, len(indices))
+	banderwagon.BatchMapToScalarField(scalars, commitments)
+
+	if !n.committed || len(indices) > NodeWidth/8 {
+		for k, i := range indices {
+			n.scalars[i] = scalars[k]
+		}
+		n.commitment, _ = conf.Commit(n.scalars[:])
+		n.committed = true
+		return n.commitment
+	}
+
+	// C ← C + ∑ (map(Cᵢ) - map(C'ᵢ))⋅Gᵢ, with C'ᵢ the previous commitment of
+	// the i-th child
+	var delta fr.Element
+	for k, i := range indices {
+		delta.Sub(&scalars[k], &n.scalars[i])
+		n.scalars[i] = scalars[k]
+		d := conf.table.ScalarMultiplicationBase(i, &delta)
+		n.commitment.Add(&n.commitment, &d)
+	}
+	return n.commitment
+}
+
+// leafNode is an extension node of the trie, holding the values of the keys
+// with its stem. It is committed to as 1⋅G₀ + stem⋅G₁ + map(C₁)⋅G₂ + map(C₂)⋅G₃,
+// where C₁ (resp. C₂) is the commitment to the first (resp. last) 128 values,
+// each value being committed to as two scalars.
+type leafNode struct {
+	stem   [StemSize]byte
+	values [NodeWidth][]byte
+
+	// the committed values, and the previous values of the suffixes modified
+	// since the last commitment
+	committed  bool
+	commitment banderwagon.Element
+	c          [2]banderwagon.Element
+	cScalars   [2]fr.Element
+	previous   map[byte][]byte
+}
+
+func (n *leafNode) set(suffix byte, value []byte) {
+	if n.committed {
+		if n.previous == nil {
+			n.previous = make(map[byte][]byte)
+		}
+		if _, ok := n.previous[suffix]; !ok {
+			n.previous[suffix] = n.values[suffix]
+		}
+	}
+	n.values[suffix] = bytes.Clone(value)
+}
+
+func (n *leafNode) commit(conf *Config) banderwagon.Element {
+	if !n.committed {
+		for k := range n.c {
+			n.c[k], _ = conf.Commit(n.suffixPolynomial(k))
+		}
+		banderwagon.BatchMapToScalarField(n.cScalars[:], n.c[:])
+		n.commitment, _ = conf.Commit(n.polynomial())
+		n.committed = true
+		return n.commitment
+	}
+	if len(n.previous) == 0 {
+		return n.commitment
+	}
+
+	// Cₖ ← Cₖ + ∑ (v - v')⋅G for the two scalars of the modified values, with
+	// v' the previous scalars
+	var updated [2]bool
+	var old, cur [2]fr.Element
+	for suffix, previous := range n.previous {
+		k, i := int(suffix)/(NodeWidth/2), 2*(int(suffix)%(NodeWidth/2))
+		valueToScalars(old[:], previous)
+		valueToScalars(cur[:], n.values[suffix])
+		for l := range cur {
+			cur[l].Sub(&cur[l], &old[l])
+			d := conf.table.ScalarMultiplicationBase(i+l, &cur[l])
+			n.c[k].Add(&n.c[k], &d)
+		}
+		updated[k] = true
+	}
+	n.previous = nil
+
+	// C ← C + (map(Cₖ) - map(C'ₖ))⋅G₂₊ₖ
+	var s fr.Element
+	for k := range updated {
+		if updated[k] {
+			n.c[k].MapToScalarField(&s)
+			cur[k].Sub(&s, &n.cScalars[k])
+			n.cScalars[k] = s
+			d := conf.table.ScalarMultiplicationBase(2+k, &cur[k])
+			n.commitment.Add(&n.commitment, &d)
+		}
+	}
+	return n.commitment
+}
+
+// polynomial returns the committed vector (1, stem, map(C₁), map(C₂), 0, …) of
+// a committed leaf.
+func (n *leafNode) polynomial() []fr.Element {
+	res := make([]fr.Element, NodeWidth)
+	res[0].SetOne()
+	stemToScalar(&res[1], n.stem[:])
+	res[2], res[3] = n.cScalars[0], n.cScalars[1]
+	return res
+}
+
+// suffixPolynomial returns the vector committed to by Cₖ₊₁, with the scalars
+// of the values k⋅128, …, k⋅128 + 127.
+func (n *leafNode) suffixPolynomial(k int) []fr.Element {
+	res := make([]fr.Element, NodeWidth)
+	values := n.values[k*NodeWidth/2 : (k+1)*NodeWidth/2]
+	for i := range values {
+		valueToScalars(res[2*i:2*i+2], values[i])
+	}
+	return res
+}
+
+// valueToScalars sets res to the little-endian scalars v[:16] + 2¹²⁸ and v[16:]
+// of the value v, or to zero if v is nil.
+func valueToScalars(res []fr.Element, v []byte) {
+	if v == nil {
+		res[0].SetZero()
+		res[1].SetZero()
+		return
+	}
+	var lo [17]byte
+	copy(lo[:], v[:16])
+	lo[16] = 1
+	leToScalar(&res[0], lo[:])
+	leToScalar(&res[1], v[16:])
+}
+
+// stemToScalar sets res to the little-endian integer stem.
+func stemToScalar(res *fr.Element, stem []byte) {
+	leToScalar(res, stem)
+}
+
+// leToScalar sets res to the little-endian integer b, of less than 32 bytes.
+func leToScalar(res *fr.Element, b []byte) {
+	var buf [fr.Bytes]byte
+	for i := range b {
+		buf[len(buf)-1-i] = b[i]
+	}
+	res.SetBytes(buf[:])
+}
@@ -0,0 +1,677 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package verkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/banderwagon"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func randomPolynomial() []fr.Element {
+	p := make([]fr.Element, NodeWidth)
+	for i := range p {
+		p[i].MustSetRandom()
+	}
+	return p
+}
+
+func commit(t testing.TB, p []fr.Element) banderwagon.Element {
+	c, err := GetConfig().Commit(p)
+	require.NoError(t, err)
+	return c
+}
+
+func TestCRS(t *testing.T) {
+	assert := require.New(t)
+
+	// from go-ipa
+	srs := GetConfig().SRS
+	assert.Len(srs, NodeWidth)
+	first, last := srs[0].Bytes(), srs[NodeWidth-1].Bytes()
+	assert.Equal("01587ad1336675eb912550ec2a28eb8923b824b490dd2ba82e48f14590a298a0", hex.EncodeToString(first[:]))
+	assert.Equal("3de2be346b539395b0c0de56a5ccca54a317f1b5c80107b0802af9a62276a4d8", hex.EncodeToString(last[:]))
+
+	h := sha256.New()
+	for _, b := range banderwagon.BatchToBytes(srs) {
+		h.Write(b[:])
+	}
+	assert.Equal("1fcaea10bf24f750200e06fa473c76ff0468007291fa548e2d99f09ba9256fdb", hex.EncodeToString(h.Sum(nil)))
+}
+
+func TestDomain(t *testing.T) {
+	assert := require.New(t)
+	d := GetConfig().domain
+
+	// the Lagrange coefficients interpolate f
+	f := randomPolynomial()
+	var z fr.Element
+	z.SetUint64(17)
+	l := d.lagrangeCoefficients(&z)
+	y := innerProduct(f, l)
+	assert.True(y.Equal(&f[17]))
+
+	// (f(X) - f(m)) / (X - m) ⋅ (X - m) = f(X) - f(m), checked at a random z
+	z.MustSetRandom()
+	l = d.lagrangeCoefficients(&z)
+	for _, m := range []int{0, 1, 100, NodeWidth - 1} {
+		q := d.divideOnDomain(m, f)
+		var lhs, rhs, t fr.Element
+		lhs = innerProduct(q, l)
+		t.SetUint64(uint64(m))
+		t.Sub(&z, &t)
+		lhs.Mul(&lhs, &t)
+		rhs = innerProduct(f, l)
+		rhs.Sub(&rhs, &f[m])
+		assert.True(lhs.Equal(&rhs), "m = %d", m)
+	}
+}
+
+func TestIPA(t *testing.T) {
+	assert := require.New(t)
+	conf := GetConfig()
+
+	p := randomPolynomial()
+	c := commit(t, p)
+	var z fr.Element
+	z.MustSetRandom()
+	proof, err := ProveIPA(NewTranscript("test"), conf, &c, p, &z)
+	assert.NoError(err)
+	y := innerProduct(p, conf.domain.lagrangeCoefficients(&z))
+
+	assert.NoError(VerifyIPA(NewTranscript("test"), conf, &c, &proof, &z, &y))
+
+	// wrong proofs
+	assert.ErrorIs(VerifyIPA(NewTranscript("wrong"), conf, &c, &proof, &z, &y), ErrVerifyOpeningProof)
+	var wrong fr.Element
+	wrong.Double(&y)
+	assert.ErrorIs(VerifyIPA(NewTranscript("test"), conf, &c, &proof, &z, &wrong), ErrVerifyOpeningProof)
+	wrong.Double(&z)
+	assert.ErrorIs(VerifyIPA(NewTranscript("test"), conf, &c, &proof, &wrong, &y), ErrVerifyOpeningProof)
+	proof.L[3], proof.R[3] = proof.R[3], proof.L[3]
+	assert.ErrorIs(VerifyIPA(NewTranscript("test"), conf, &c, &proof, &z, &y), ErrVerifyOpeningProof)
+	proof.L = proof.L[1:]
+	assert.ErrorIs(VerifyIPA(NewTranscript("test"), conf, &c, &proof, &z, &y), ErrInvalidProofSize)
+
+	// openings in the domain
+	z.SetUint64(42)
+	proof, err = ProveIPA(NewTranscript("test"), conf, &c, p, &z)
+	assert.NoError(err)
+	assert.NoError(VerifyIPA(NewTranscript("test"), conf, &c, &proof, &z, &p[42]))
+
+	_, err = ProveIPA(NewTranscript("test"), conf, &c, p[1:], &z)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+}
+
+func TestMultiProof(t *testing.T) {
+	assert := require.New(t)
+	conf := GetConfig()
+
+	const nbOpenings = 10
+	polys := make([][]fr.Element, nbOpenings)
+	commitments := make([]banderwagon.Element, nbOpenings)
+	indices := []uint8{0, 3, 3, 255, 7, 0, 128, 3, 9, 1}
+	values := make([]fr.Element, nbOpenings)
+	for i := range polys {
+		polys[i] = randomPolynomial()
+		commitments[i] = commit(t, polys[i])
+		values[i] = polys[i][indices[i]]
+	}
+	// the same polynomial opened at two points
+	polys[9], commitments[9], values[9] = polys[8], commitments[8], polys[8][indices[9]]
+
+	proof, err := ProveMultiProof(NewTranscript("test"), conf, commitments, polys, indices)
+	assert.NoError(err)
+	assert.NoError(VerifyMultiProof(NewTranscript("test"), conf, &proof, commitments, values, indices))
+
+	// wrong proofs
+	values[4].Double(&values[4])
+	assert.ErrorIs(VerifyMultiProof(NewTranscript("test"), conf, &proof, commitments, values, indices), ErrVerifyOpeningProof)
+	values[4] = polys[4][indices[4]]
+
+	indices[2] = 4
+	assert.ErrorIs(VerifyMultiProof(NewTranscript("test"), conf, &proof, commitments, values, indices), ErrVerifyOpeningProof)
+	indices[2] = 3
+
+	commitments[0], commitments[5] = commitments[5], commitments[0]
+	assert.ErrorIs(VerifyMultiProof(NewTranscript("test"), conf, &proof, commitments, values, indices), ErrVerifyOpeningProof)
+	commitments[0], commitments[5] = commitments[5], commitments[0]
+
+	assert.ErrorIs(VerifyMultiProof(NewTranscript("test"), conf, &proof, commitments[1:], values, indices), ErrInvalidNbOpenings)
+	assert.NoError(VerifyMultiProof(NewTranscript("test"), conf, &proof, commitments, values, indices))
+
+	// single opening
+	proof, err = ProveMultiProof(NewTranscript("test"), conf, commitments[:1], polys[:1], indices[:1])
+	assert.NoError(err)
+	assert.NoError(VerifyMultiProof(NewTranscript("test"), conf, &proof, commitments[:1], values[:1], indices[:1]))
+}
+
+// cyclicPolynomial returns the polynomial whose evaluations repeat pattern.
+func cyclicPolynomial(pattern ...uint64) []fr.Element {
+	p := make([]fr.Element, NodeWidth)
+	for i := range p {
+		p[i].SetUint64(pattern[i%len(pattern)])
+	}
+	return p
+}
+
+func scalarHex(s fr.Element) string {
+	var b [fr.Bytes]byte
+	fr.LittleEndian.PutElement(&b, s)
+	return hex.EncodeToString(b[:])
+}
+
+func pointHex(p banderwagon.Element) string {
+	b := p.Bytes()
+	return hex.EncodeToString(b[:])
+}
+
+// the polynomials of the consistency tests of go-ipa
+func goIPAPolynomials() (a, b []fr.Element) {
+	pattern := make([]uint64, 32)
+	for i := range pattern {
+		pattern[i] = uint64(i + 1)
+	}
+	a = cyclicPolynomial(pattern...)
+	for i := range pattern {
+		pattern[i] = uint64(32 - i)
+	}
+	b = cyclicPolynomial(pattern...)
+	return a, b
+}
+
+func TestIPAConsistency(t *testing.T) {
+	assert := require.New(t)
+	conf := GetConfig()
+
+	// from go-ipa's TestIPAConsistencySimpleProof
+	p, _ := goIPAPolynomials()
+	c := commit(t, p)
+	assert.Equal("1b9dff8f5ebbac250d291dfe90e36283a227c64b113c37f1bfb9e7a743cdb128", pointHex(c))
+
+	var z fr.Element
+	z.SetUint64(2101)
+	transcript := NewTranscript("test")
+	proof, err := ProveIPA(transcript, conf, &c, p, &z)
+	assert.NoError(err)
+	y := innerProduct(p, conf.domain.lagrangeCoefficients(&z))
+	assert.Equal("4a353e70b03c89f161de002e8713beec0d740a5e20722fd5bd68b30540a33208", scalarHex(y))
+	state := transcript.ChallengeScalar("state")
+	assert.Equal("0a81881cbfd7d7197a54ebd67ed6a68b5867f3c783706675b34ece43e85e7306", scalarHex(state))
+
+	var buf bytes.Buffer
+	_, err = proof.WriteTo(&buf)
+	assert.NoError(err)
+	assert.Equal("273395a8febdaed38e94c3d874e99c911a47dd84616d54c55021d5c4131b507e46a4ec2c7e82b77ec2f533994c91ca7edaef212c666a1169b29c323eabb0cf690e0146638d0e2d543f81da4bd597bf3013e1663f340a8f87b845495598d0a3951590b6417f868edaeb3424ff174901d1185a53a3ee127fb7be0af42dda44bf992885bde279ef821a298087717ef3f2b78b2ede7f5d2ea1b60a4195de86a530eb247fd7e456012ae9a070c61635e55d1b7a340dfab8dae991d6273d099d9552815434cc1ba7bcdae341cf7928c6f25102370bdf4b26aad3af654d9dff4b3735661db3177342de5aad774a59d3e1b12754aee641d5f9cd1ecd2751471b308d2d8410add1c9fcc5a2b7371259f0538270832a98d18151f653efbc60895fab8be9650510449081626b5cd24671d1a3253487d44f589c2ff0da3557e307e520cf4e0054bbf8bdffaa24b7e4cce5092ccae5a08281ee24758374f4e65f126cacce64051905b5e2038060ad399c69ca6cb1d596d7c9cb5e161c7dcddc1a7ad62660dd4a5f69b31229b80e6b3df520714e4ea2b5896ebd48d14c7455e91c1ecf4acc5ffb36937c49413b7d1005dd6efbd526f5af5d61131ca3fcdae1218ce81c75e62b39100ec7f474b48a2bee6cef453fa1bc3db95c7c6575bc2d5927cbf7413181ac905766a4038a7b422a8ef2bf7b5059b5c546c19a33c1049482b9a9093f864913ca82290decf6e9a65bf3f66bc3ba4a8ed17b56d890a83bcbe74435a42499dec115", hex.EncodeToString(buf.Bytes()))
+
+	var decoded IPAProof
+	_, err = decoded.ReadFrom(&buf)
+	assert.NoError(err)
+	transcript = NewTranscript("test")
+	assert.NoError(VerifyIPA(transcript, conf, &c, &decoded, &z, &y))
+	state = transcript.ChallengeScalar("state")
+	assert.Equal("0a81881cbfd7d7197a54ebd67ed6a68b5867f3c783706675b34ece43e85e7306", scalarHex(state))
+}
+
+func TestMultiProofConsistency(t *testing.T) {
+	assert := require.New(t)
+	conf := GetConfig()
+
+	// from go-ipa's TestMultiProofConsistency
+	a, b := goIPAPolynomials()
+	commitments := []banderwagon.Element{commit(t, a), commit(t, b)}
+	values := []fr.Element{a[0], b[0]}
+	indices := []uint8{0, 0}
+
+	transcript := NewTranscript("test")
+	proof, err := ProveMultiProof(transcript, conf, commitments, [][]fr.Element{a, b}, indices)
+	assert.NoError(err)
+	state := transcript.ChallengeScalar("state")
+	assert.Equal("eee8a80357ff74b766eba39db90797d022e8d6dee426ded71234241be504d519", scalarHex(state))
+
+	var buf bytes.Buffer
+	_, err = proof.WriteTo(&buf)
+	assert.NoError(err)
+	assert.Equal("4f53588244efaf07a370ee3f9c467f933eed360d4fbf7a19dfc8bc49b67df4711bf1d0a720717cd6a8c75f1a668cb7cbdd63b48c676b89a7aee4298e71bd7f4013d7657146aa9736817da47051ed6a45fc7b5a61d00eb23e5df82a7f285cc10e67d444e91618465ca68d8ae4f2c916d1942201b7e2aae491ef0f809867d00e83468fb7f9af9b42ede76c1e90d89dd789ff22eb09e8b1d062d8a58b6f88b3cbe80136fc68331178cd45a1df9496ded092d976911b5244b85bc3de41e844ec194256b39aeee4ea55538a36139211e9910ad6b7a74e75d45b869d0a67aa4bf600930a5f760dfb8e4df9938d1f47b743d71c78ba8585e3b80aba26d24b1f50b36fa1458e79d54c05f58049245392bc3e2b5c5f9a1b99d43ed112ca82b201fb143d401741713188e47f1d6682b0bf496a5d4182836121efff0fd3b030fc6bfb5e21d6314a200963fe75cb856d444a813426b2084dfdc49dca2e649cb9da8bcb47859a4c629e97898e3547c591e39764110a224150d579c33fb74fa5eb96427036899c04154feab5344873d36a53a5baefd78c132be419f3f3a8dd8f60f72eb78dd5f43c53226f5ceb68947da3e19a750d760fb31fa8d4c7f53bfef11c4b89158aa56b1f4395430e16a3128f88e234ce1df7ef865f2d2c4975e8c82225f578310c31fd41d265fd530cbfa2b8895b228a510b806c31dff3b1fa5c08bffad443d567ed0e628febdd22775776e0cc9cebcaea9c6df9279a5d91dd0ee5e7a0434e989a160005321c97026cb559f71db23360105460d959bcdf74bee22c4ad8805a1d497507", hex.EncodeToString(buf.Bytes()))
+
+	var decoded MultiProof
+	_, err = decoded.ReadFrom(&buf)
+	assert.NoError(err)
+	transcript = NewTranscript("test")
+	assert.NoError(VerifyMultiProof(transcript, conf, &decoded, commitments, values, indices))
+	state = transcript.ChallengeScalar("state")
+	assert.Equal("eee8a80357ff74b766eba39db90797d022e8d6dee426ded71234241be504d519", scalarHex(state))
+}
+
+// naiveCommit recomputes the commitment of a node from scratch, with
+// the formulas of the specification.
+func naiveCommit(t testing.TB, n node) banderwagon.Element {
+	poly := make([]fr.Element, NodeWidth)
+	switch n := n.(type) {
+	case *internalNode:
+		for i, child := range n.children {
+			if child != nil {
+				c := naiveCommit(t, child)
+				c.MapToScalarField(&poly[i])
+			}
+		}
+	case *leafNode:
+		var c [2]banderwagon.Element
+		for k := range c {
+			suffixPoly := make([]fr.Element, NodeWidth)
+			for i := 0; i < NodeWidth/2; i++ {
+				v := n.values[k*NodeWidth/2+i]
+				if v == nil {
+					continue
+				}
+				// v[:16] + 2¹²⁸ and v[16:], little-endian
+				var lo, hi [32]byte
+				copy(lo[:], v[:16])
+				lo[16] = 1
+				copy(hi[:], v[16:])
+				suffixPoly[2*i] = leBytes(lo[:])
+				suffixPoly[2*i+1] = leBytes(hi[:])
+			}
+			c[k] = naiveMultiExp(t, suffixPoly)
+		}
+		poly[0].SetOne()
+		poly[1] = leBytes(n.stem[:])
+		c[0].MapToScalarField(&poly[2])
+		c[1].MapToScalarField(&poly[3])
+	}
+	return naiveMultiExp(t, poly)
+}
+
+func naiveMultiExp(t testing.TB, scalars []fr.Element) banderwagon.Element {
+	var res banderwagon.Element
+	_, err := res.MultiExp(GetConfig().SRS, scalars)
+	require.NoError(t, err)
+	return res
+}
+
+func leBytes(b []byte) fr.Element {
+	be := bytes.Clone(b)
+	for i, j := 0, len(be)-1; i < j; i, j = i+1, j-1 {
+		be[i], be[j] = be[j], be[i]
+	}
+	var res fr.Element
+	res.SetBytes(be)
+	return res
+}
+
+func key(stem byte, suffix byte) []byte {
+	res := make([]byte, KeySize)
+	res[0] = stem
+	res[KeySize-1] = suffix
+	return res
+}
+
+func TestInsertKey0Value0(t *testing.T) {
+	assert := require.New(t)
+	srs := GetConfig().SRS
+
+	tree := New()
+	empty := tree.Commit()
+	assert.True(empty.IsIdentity())
+	assert.NoError(tree.Insert(make([]byte, KeySize), make([]byte, ValueSize)))
+
+	// C₁ = 2¹²⁸⋅G₀, C = G₀ + map(C₁)⋅G₂ and the root is map(C)⋅G₀
+	var s fr.Element
+	var c1, leaf, root, tmp banderwagon.Element
+	s.SetString("340282366920938463463374607431768211456")
+	c1.ScalarMultiplication(&srs[0], &s)
+	c1.MapToScalarField(&s)
+	tmp.ScalarMultiplication(&srs[2], &s)
+	leaf.Add(&srs[0], &tmp)
+	leaf.MapToScalarField(&s)
+	root.ScalarMultiplication(&srs[0], &s)
+
+	c := tree.Commit()
+	assert.True(c.Equal(&root))
+
+	// from go-verkle
+	b := c.Bytes()
+	assert.Equal("6b630905ce275e39f223e175242df2c1e8395e6f46ec71dce5557012c1334a5c", hex.EncodeToString(b[:]))
+}
+
+func TestTreeCommit(t *testing.T) {
+	assert := require.New(t)
+
+	tree := New()
+	value := func(i int) []byte {
+		v := make([]byte, ValueSize)
+		v[0], v[ValueSize-1] = byte(i), byte(i>>8)
+		return v
+	}
+
+	// leaves in different subtrees, and stems sharing a prefix, which are
+	// split into internal nodes
+	keys := [][]byte{key(0, 0), key(0, 200), key(1, 5), key(255, 255)}
+	deep := key(1, 6)
+	deep[5] = 1
+	keys = append(keys, deep)
+	deeper := bytes.Clone(deep)
+	deeper[20] = 3
+	keys = append(keys, deeper)
+	for i, k := range keys {
+		assert.NoError(tree.Insert(k, value(i)))
+	}
+	c := tree.Commit()
+	expected := naiveCommit(t, tree.root)
+	assert.True(c.Equal(&expected))
+
+	for i, k := range keys {
+		v, err := tree.Get(k)
+		assert.NoError(err)
+		assert.Equal(value(i), v)
+	}
+	v, err := tree.Get(key(0, 1))
+	assert.NoError(err)
+	assert.Nil(v)
+	v, err = tree.Get(key(2, 0))
+	assert.NoError(err)
+	assert.Nil(v)
+
+	// incremental updates
+	assert.NoError(tree.Insert(keys[0], value(100)))
+	assert.NoError(tree.Insert(keys[3], value(101)))
+	assert.NoError(tree.Insert(key(0, 130), value(102)))
+	split := bytes.Clone(deeper)
+	split[30] = 9
+	assert.NoError(tree.Insert(split, value(103)))
+	c = tree.Commit()
+	expected = naiveCommit(t, tree.root)
+	assert.True(c.Equal(&expected))
+
+	// the commitment doesn't depend on the order of the insertions
+	other := New()
+	for _, k := range [][]byte{split, key(0, 130), keys[5], keys[4], keys[3], keys[2], keys[1], keys[0]} {
+		v, err := tree.Get(k)
+		assert.NoError(err)
+		assert.NoError(other.Insert(k, v))
+	}
+	otherC := other.Commit()
+	assert.True(c.Equal(&otherC))
+
+	assert.ErrorIs(tree.Insert(keys[0][1:], value(0)), ErrInvalidKeySize)
+	assert.ErrorIs(tree.Insert(keys[0], value(0)[1:]), ErrInvalidValueSize)
+}
+
+func randomTree(t testing.TB, nbKeys int, rng *rand.Rand) (*Tree, [][]byte) {
+	tree := New()
+	keys := make([][]byte, nbKeys)
+	for i := range keys {
+		keys[i] = make([]byte, KeySize)
+		rng.Read(keys[i])
+		if i%4 == 1 {
+			// same stem as the previous key
+			copy(keys[i], keys[i-1][:StemSize])
+		}
+		value := make([]byte, ValueSize)
+		rng.Read(value)
+		require.NoError(t, tree.Insert(keys[i], value))
+	}
+	return tree, keys
+}
+
+func TestProof(t *testing.T) {
+	assert := require.New(t)
+	rng := rand.New(rand.NewSource(1))
+	conf := GetConfig()
+
+	tree, keys := randomTree(t, 200, rng)
+	root := tree.Commit()
+
+	// absent keys: in a present leaf, with an empty child, and with a leaf
+	// of an other stem on the path
+	inLeaf := bytes.Clone(keys[10])
+	inLeaf[StemSize]++
+	empty := make([]byte, KeySize)
+	for {
+		rng.Read(empty)
+		if v, _ := tree.Get(empty); v == nil && tree.root.children[empty[0]] == nil {
+			break
+		}
+	}
+	other := bytes.Clone(keys[20])
+	other[StemSize-1]++
+	proved := [][]byte{keys[0], keys[1], keys[10], keys[199], keys[50], inLeaf, empty, other, keys[0]}
+
+	proof, values, err := tree.Prove(proved)
+	assert.NoError(err)
+	assert.Nil(values[5])
+	assert.Nil(values[6])
+	assert.Nil(values[7])
+	assert.NoError(VerifyProof(conf, &root, proved, values, proof))
+
+	// wrong values
+	values[2][0]++
+	assert.Error(VerifyProof(conf, &root, proved, values, proof))
+	values[2][0]--
+	values[6] = make([]byte, ValueSize)
+	assert.ErrorIs(VerifyProof(conf, &root, proved, values, proof), ErrInvalidProof)
+	values[5] = make([]byte, ValueSize)
+	values[6] = nil
+	assert.ErrorIs(VerifyProof(conf, &root, proved, values, proof), ErrVerifyOpeningProof)
+	values[5] = nil
+	assert.NoError(VerifyProof(conf, &root, proved, values, proof))
+
+	// wrong root, statuses and commitments
+	wrongRoot := root
+	wrongRoot.Double(&wrongRoot)
+	assert.ErrorIs(VerifyProof(conf, &wrongRoot, proved, values, proof), ErrVerifyOpeningProof)
+	proof.ExtStatus[0] ^= 1
+	assert.ErrorIs(VerifyProof(conf, &root, proved, values, proof), ErrInvalidProof)
+	proof.ExtStatus[0] ^= 1
+	proof.Commitments[1], proof.Commitments[2] = proof.Commitments[2], proof.Commitments[1]
+	assert.ErrorIs(VerifyProof(conf, &root, proved, values, proof), ErrVerifyOpeningProof)
+	proof.Commitments[1], proof.Commitments[2] = proof.Commitments[2], proof.Commitments[1]
+	fewer, fewerValues := append(proved[:3:3], proved[4:]...), append(values[:3:3], values[4:]...)
+	assert.ErrorIs(VerifyProof(conf, &root, fewer, fewerValues, proof), ErrInvalidProof)
+	assert.NoError(VerifyProof(conf, &root, proved, values, proof))
+
+	// the proof is for the committed state of the trie
+	newValue := make([]byte, ValueSize)
+	assert.NoError(tree.Insert(keys[50], newValue))
+	newRoot := tree.Commit()
+	assert.ErrorIs(VerifyProof(conf, &newRoot, proved, values, proof), ErrVerifyOpeningProof)
+	proof, values, err = tree.Prove(proved)
+	assert.NoError(err)
+	assert.Equal(newValue, values[4])
+	assert.NoError(VerifyProof(conf, &newRoot, proved, values, proof))
+}
+
+// the accounts of go-verkle's TestWithRustCompatibility
+var (
+	accountKeys = [][]byte{
+		{245, 110, 100, 66, 36, 244, 87, 100, 144, 207, 224, 222, 20, 36, 164, 83, 34, 18, 82, 155, 254, 55, 71, 19, 216, 78, 125, 126, 142, 146, 114, 0},
+		{245, 110, 100, 66, 36, 244, 87, 100, 144, 207, 224, 222, 20, 36, 164, 83, 34, 18, 82, 155, 254, 55, 71, 19, 216, 78, 125, 126, 142, 146, 114, 1},
+		{245, 110, 100, 66, 36, 244, 87, 100, 144, 207, 224, 222, 20, 36, 164, 83, 34, 18, 82, 155, 254, 55, 71, 19, 216, 78, 125, 126, 142, 146, 114, 2},
+		{245, 110, 100, 66, 36, 244, 87, 100, 144, 207, 224, 222, 20, 36, 164, 83, 34, 18, 82, 155, 254, 55, 71, 19, 216, 78, 125, 126, 142, 146, 114, 3},
+		{245, 110, 100, 66, 36, 244, 87, 100, 144, 207, 224, 222, 20, 36, 164, 83, 34, 18, 82, 155, 254, 55, 71, 19, 216, 78, 125, 126, 142, 146, 114, 4},
+	}
+	accountValues = [][]byte{
+		make([]byte, ValueSize),
+		{0, 0, 100, 167, 179, 182, 224, 13, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		make([]byte, ValueSize),
+		{197, 210, 70, 1, 134, 247, 35, 60, 146, 126, 125, 178, 220, 199, 3, 192, 229, 0, 182, 83, 202, 130, 39, 59, 123, 250, 216, 4, 93, 133, 164, 112},
+		make([]byte, ValueSize),
+	}
+)
+
+func TestGoVerkleConsistency(t *testing.T) {
+	assert := require.New(t)
+
+	tree := New()
+	for i := range accountKeys {
+		assert.NoError(tree.Insert(accountKeys[i], accountValues[i]))
+	}
+	assert.Equal("10ed89d89047bb168baa4e69b8607e260049e928ddbcb2fdd23ea0f4182b1f8a", pointHex(tree.Commit()))
+
+	// the proofs are the ones of go-verkle's MakeVerkleMultiProof
+	type goVerkleProof struct {
+		keys        [][]byte
+		extStatus   string
+		commitments []string
+		otherStems  []string
+		multiProof  string
+	}
+	checkProof := func(root banderwagon.Element, tc goVerkleProof) {
+		proof, values, err := tree.Prove(tc.keys)
+		assert.NoError(err)
+		assert.Equal(tc.extStatus, hex.EncodeToString(proof.ExtStatus))
+		assert.Len(proof.Commitments, len(tc.commitments))
+		for i := range tc.commitments {
+			assert.Equal(tc.commitments[i], pointHex(proof.Commitments[i]))
+		}
+		assert.Len(proof.OtherStems, len(tc.otherStems))
+		for i := range tc.otherStems {
+			assert.Equal(tc.otherStems[i], hex.EncodeToString(proof.OtherStems[i][:]))
+		}
+		var buf bytes.Buffer
+		_, err = proof.MultiProof.WriteTo(&buf)
+		assert.NoError(err)
+		assert.Equal(tc.multiProof, hex.EncodeToString(buf.Bytes()))
+
+		assert.NoError(VerifyProof(GetConfig(), &root, tc.keys, values, proof))
+	}
+
+	absentEmpty := make([]byte, KeySize)
+	absentEmpty[0] = 3
+	absentOther := bytes.Clone(accountKeys[0])
+	absentOther[1] = 8
+	absentSameStem := bytes.Clone(accountKeys[0])
+	absentSameStem[StemSize] = 200
+	absentInLeaf := bytes.Clone(accountKeys[0])
+	absentInLeaf[StemSize-1] = 0x71
+
+	other := bytes.Clone(accountKeys[0])
+	other[1] = 7
+	assert.NoError(tree.Insert(other, accountKeys[0]))
+	root := tree.Commit()
+	assert.Equal("192ca8bffd8f675907a84a3731bd6d73b07f2e46047dbe40ced72a8c68093093", pointHex(root))
+	checkProof(root, goVerkleProof{
+		// absent and present stems
+		keys:      [][]byte{absentEmpty, accountKeys[1], accountKeys[3], absentSameStem, absentOther, other},
+		extStatus: "08121012",
+		commitments: []string{
+			"03cb160be078fa917b3d913235afd90ff92d4e6d87d07244373b5f3d0520142a",
+			"38f0d610a128103467ae45ad4ec9c5029274468997d901978eb1d3e721f638e2",
+			"109193509c0dd35e7c00a17e11182ed65f85cef1c91a20f9a6738cb65a8eccf1",
+			"56f852b8f884e98cc91d5ba060cb5d3a23f70a7fbe3c02ab72adec074475821d",
+			"2dbe8373f04ae4e481aac25a677afd65a7f0da30f68412b1b55607b6834db7c5",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		multiProof: "53cee91f1d903b2daa88b7af747bd01face17238f49cdd05716a24a70b42859e3a43f2f3ec01fda1bbbcbc448ab0c5b44d159ff77b337fd4a840034517d72e245a60aa964421fff4797118fa28c23f1e20185f9d04526629d5142129bca60ec25e46bcff78ec9c8cbe6693e3dc92eb21a1582575223e666576c9e4158e3b8c5f34cb7ecf7975b7b1ca5811df62f553fca57a532b8ff61e8e70b57cad7c3a3f5537ca8440ae3ff0f06fbe68247a584eceae9dde4539e02ba67c3fcd7c2156200f45b5c5bdf854b1b586a64d2dbc05e78a095848733d4e2f1682e11f76342103dc07ee1f794af3d749eda4b7adca6c136a8b87377de0b80975d914ba4c514fc248224c9ec5780ed8300d01e44743acdcdf8360aff9e7faa7892442ae74a5ab520e6beea5421522ed5e623cf229f061aab7b15bb089bb08d05e3cb376e941b7613c269fb530bdef2d7d20b7399592e1bd4e54393155b90284d0f51d75ebc4b310ab64000f49dc66a5a1b8f6a71a736510b6e1497d0d088894bc22eb29397bfe1436482e79e848f70c098facabcf33767a2c2d3042ceffcc7dce12960fc141ede9e06513ee19c21440ab0bf800d78bc8c2688bf07933f90a87ba35355e8132e048d17304a7792023d4a9731c4606dfc78a91b35f743da78716fa8ca3424ab6d0d4064f4d1b772cb063eaeb67ba6a868de0c58e0a567ee7fedd640e2a433d6cb46b344f5bf4c276c3ca667374a0287e34a2485a163d1f8c34484d8402728a087d439a06d59f4c5514fb497892f82035b66cbef2fdc7e3b35961c3a55e7c8c37773304",
+	})
+
+	c2Key := bytes.Clone(accountKeys[0])
+	c2Key[StemSize] = 130
+	assert.NoError(tree.Insert(c2Key, accountKeys[3]))
+	root = tree.Commit()
+	assert.Equal("36ce8e238ffaf696a63619985d50d752abd982113af042f99a9220138b25dfa0", pointHex(root))
+	for _, tc := range []goVerkleProof{
+		{
+			// both suffix commitments and an absent stem in a present leaf
+			keys:      [][]byte{accountKeys[4], c2Key, absentInLeaf, absentSameStem, accountKeys[0]},
+			extStatus: "1112",
+			commitments: []string{
+				"715fceb6339affebc690fb2266a9e30e8941bf6ea1aa4de9ce3a2a86501dc6ad",
+				"58d4f752e788df69a95fd23292b4f1fe4eff502501028c43326eadc326c8606c",
+				"2dbe8373f04ae4e481aac25a677afd65a7f0da30f68412b1b55607b6834db7c5",
+				"23c619c860735ed8bb46ff1eae6b7387e53ff0b0ea951a79910361638d700241",
+			},
+			multiProof: "1bad43a7a936e787d6a04712ebab06bc1f70b3f9f09d2d2711a159c3e3a10f8e0ddb01a0fba95b318ae2d64ca22d00c3852257820b232cc817217d4c0038e19f59afaa86c9eabb93be8af2c9f559522609563074ffc78962088da0b02a4630620fac3c58d9ed3bb706e5ba7afa3f5b33a336d86451ce571bbebafa2a9b41c88f65eb6de5ca63e8354cfb933a12a11c2e3515281c4a95a05114f17d03885b371b129bec54345852b047b3d59d0a145ba07813bcde5ef25391855929d3edb40bcc229d253dcbff74518f25b6d93dd8f54bb5fd0dacb9a6b8447e26861376b0a63563f60e027cf7d0584b68967f0b2eb61ac9c1ddcb612d4264a084c4f3d8ee3b5536eead95ba0376e1653f5a5f6c2f26028e9eb233aa816f2d877570df7d2beb4b27fa32e78717ecdd63298746f2ab373028cf14175ab46a886a0c9edf340cf1a8503ad0dd38eed1d3f2c12681446d386ad983227a58008ce8da1c73dac5218ec71b167afd5a5b2f2410af588dc8d753472e4db695165ac7afaaaa7ca7a5c09634511d8a25c6b0fe6e5844e532b68d8f453008950059d5c1052ac729a634274b6a6821b5888b0d33652ada18ece65564082702f599f51eb91602083465a77d727057a38ecec95cdcefe2c243e3d869c28e9c45e543f936f05cba5ce1cdce3efe3c0bec323b01282474a2b624c4373b6724ff2cef3ebeef71a0076458fc582a701740f746233dbafe09978f56eb27a5bcd73b3817cb2b6f62de99ecd01dfd32d3c5892955f1d772c2d0630c5f85ac6110178856fa1a41230b8442b5c6beabee1512",
+		},
+		{
+			// a stem of an other leaf
+			keys:      [][]byte{absentInLeaf, absentEmpty},
+			extStatus: "0811",
+			commitments: []string{
+				"715fceb6339affebc690fb2266a9e30e8941bf6ea1aa4de9ce3a2a86501dc6ad",
+				"58d4f752e788df69a95fd23292b4f1fe4eff502501028c43326eadc326c8606c",
+			},
+			otherStems: []string{
+				"f56e644224f4576490cfe0de1424a4532212529bfe374713d84e7d7e8e9272",
+			},
+			multiProof: "1fa21b3197713e916e398ce072b1b1d07b8c628b966e7c363bb95dc881a01fe05027e0037b999d8397be6076627502a5c07cff6ca0033513afcfe10faebe472d32cad63874ec7392226117a6ac3858ced31305d14ba62e3cd6890bbd0362641e3c3215277c283595d89a549e7f51681de40196eae171727dbd94d4f0e38e7e306a4f13e438fcfb7afa9d5c46c8962039850c64cb72cf123abca8d9368c666aca31531d46ce7a952a206cc43e512b9322388de192a102c0274b59d5c33833e6c940d7ee21f0e892ed6380d828eb01462a1469981c72b7c5ea8b107baa9886102a34bb655d1c6c898f01eea2c2bdcf9e774343d10735ceb999d1e71915379980e65c218a5d6b2392c8437c8c70fb030d856a74d014ba38ae188c79974e9ca34f6e12a84199933270febb2c22b36a4ee2801ee37dbfec9a697c3450756cdc54b7a821dee575e38f29bab1af2f7a070666e48df8a9d90ee6a60c16020a002a07405c2f55651d1073b49cf4d64ac87cd69ee61f8018b5d0fd472e18f57508a055110a0ffa7b7dffd5ae78aabc64c2b3eea77134821926a8d29ab2c579b3d5f74e6e292a2bf46fcef7021188b517ed36602f4d9d86abc2b2702f01687567753cf9f1aa3ec7723ba4cf1be6ca0727b4ab7b31175e129d85b3f51dfc33a05746c06546804c3f125526d11767e33beea8c04bc17c9ac4a7f3e58326a59b06f731b7ffcfd227beae3d58cf92486dd3fc0d67c89315d42c1cf6968a8ac0b85f23884a6531cde7b01a1d4cbe91b9e8eed002286060cfc43807f9b6c25ff2da867e7037ca6b09",
+		},
+	} {
+		checkProof(root, tc)
+	}
+}
+
+func TestSerialization(t *testing.T) {
+	assert := require.New(t)
+	rng := rand.New(rand.NewSource(2))
+
+	tree, keys := randomTree(t, 50, rng)
+	root := tree.Commit()
+	absent := bytes.Clone(keys[3])
+	absent[StemSize-1]++
+	proved := append(keys[:5:5], absent)
+	proof, values, err := tree.Prove(proved)
+	assert.NoError(err)
+
+	var buf bytes.Buffer
+	written, err := proof.WriteTo(&buf)
+	assert.NoError(err)
+	assert.EqualValues(buf.Len(), written)
+	encoded := bytes.Clone(buf.Bytes())
+
+	// the multiproof has the size of go-ipa's
+	var mBuf bytes.Buffer
+	_, err = proof.MultiProof.WriteTo(&mBuf)
+	assert.NoError(err)
+	assert.Equal(576, mBuf.Len())
+
+	var decoded Proof
+	read, err := decoded.ReadFrom(&buf)
+	assert.NoError(err)
+	assert.Equal(written, read)
+	assert.NoError(VerifyProof(GetConfig(), &root, proved, values, &decoded))
+
+	buf.Reset()
+	_, err = decoded.WriteTo(&buf)
+	assert.NoError(err)
+	assert.Equal(encoded, buf.Bytes())
+
+	_, err = decoded.ReadFrom(bytes.NewReader(encoded[:len(encoded)-1]))
+	assert.Error(err)
+}
+
+func BenchmarkTree(b *testing.B) {
+	rng := rand.New(rand.NewSource(3))
+	const nbKeys = 1000
+	tree, keys := randomTree(b, nbKeys, rng)
+	tree.Commit()
+	value := make([]byte, ValueSize)
+
+	b.Run("insert and commit", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			value[0] = byte(i)
+			_ = tree.Insert(keys[i%nbKeys], value)
+			tree.Commit()
+		}
+	})
+	b.Run("prove", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, _ = tree.Prove(keys[:100])
+		}
+	})
+	root := tree.Commit()
+	proof, values, err := tree.Prove(keys[:100])
+	require.NoError(b, err)
+	b.Run("verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = VerifyProof(GetConfig(), &root, keys[:100], values, proof)
+		}
+	})
+}
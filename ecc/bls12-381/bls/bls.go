@@ -0,0 +1,325 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package bls
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// SizeSecretKey is the size in bytes of a serialized secret key.
+	SizeSecretKey = fr.Bytes
+
+	// minimum length of the input keying material of KeyGen
+	minIKMLength = 32
+	// length in bytes of the HKDF output in KeyGen, ceil((3 * ceil(log2(r))) / 16)
+	keyGenL    = 48
+	keyGenSalt = "BLS-SIG-KEYGEN-SALT-"
+)
+
+var (
+	ErrShortIKM             = errors.New("input keying material must be at least 32 bytes")
+	ErrInvalidSecretKey     = errors.New("secret key must be a non-zero scalar smaller than the group order")
+	ErrInvalidPublicKey     = errors.New("invalid public key")
+	ErrInvalidSignature     = errors.New("invalid signature")
+	ErrNoSignatures         = errors.New("nothing to aggregate")
+	ErrNotProofOfPossession = errors.New("ciphersuite does not use the proof of possession scheme")
+	errInvalidPointEncoding = errors.New("point must be in compressed form")
+)
+
+// Scheme selects how a ciphersuite protects against rogue key attacks
+// when signatures are aggregated.
+type Scheme uint8
+
+const (
+	// Basic requires the messages of an aggregate signature to be distinct.
+	Basic Scheme = iota
+	// MessageAugmentation signs the public key concatenated with the message.
+	MessageAugmentation
+	// ProofOfPossession requires each public key to come with a proof of
+	// possession of the secret key, and enables FastAggregateVerify.
+	ProofOfPossession
+)
+
+// Ciphersuite is a BLS signature ciphersuite as defined in section 4 of the
+// IETF draft. It fixes the group of the public keys, the hash to curve
+// suite and the scheme.
+type Ciphersuite struct {
+	id     string // domain separation tag of the signatures
+	popID  string // domain separation tag of the proofs of possession
+	scheme Scheme
+	core   core
+}
+
+// The ciphersuites of the IETF draft, section 4.2. The MinPk suites have
+// 48 bytes public keys in G1 and 96 bytes signatures in G2, the MinSig
+// suites have 96 bytes public keys in G2 and 48 bytes signatures in G1.
+var (
+	MinPkBasic = &Ciphersuite{
+		id:     "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_",
+		scheme: Basic,
+		core:   minPk{},
+	}
+	MinPkMessageAugmentation = &Ciphersuite{
+		id:     "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG_",
+		scheme: MessageAugmentation,
+		core:   minPk{},
+	}
+	MinPkProofOfPossession = &Ciphersuite{
+		id:     "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_",
+		popID:  "BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_",
+		scheme: ProofOfPossession,
+		core:   minPk{},
+	}
+	MinSigBasic = &Ciphersuite{
+		id:     "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_NUL_",
+		scheme: Basic,
+		core:   minSig{},
+	}
+	MinSigMessageAugmentation = &Ciphersuite{
+		id:     "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_AUG_",
+		scheme: MessageAugmentation,
+		core:   minSig{},
+	}
+	MinSigProofOfPossession = &Ciphersuite{
+		id:     "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_",
+		popID:  "BLS_POP_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_",
+		scheme: ProofOfPossession,
+		core:   minSig{},
+	}
+)
+
+// ID returns the ciphersuite identifier, which is also the domain
+// separation tag used to hash messages to the curve.
+func (cs *Ciphersuite) ID() string {
+	return cs.id
+}
+
+// Scheme returns the scheme of the ciphersuite.
+func (cs *Ciphersuite) Scheme() Scheme {
+	return cs.scheme
+}
+
+// PublicKeySize returns the size in bytes of a serialized public key.
+func (cs *Ciphersuite) PublicKeySize() int {
+	return cs.core.publicKeySize()
+}
+
+// SignatureSize returns the size in bytes of a serialized signature.
+func (cs *Ciphersuite) SignatureSize() int {
+	return cs.core.signatureSize()
+}
+
+// SecretKey represents a BLS secret key. It is shared by all the
+// ciphersuites.
+type SecretKey struct {
+	scalar fr.Element
+}
+
+// KeyGen derives a secret key from the input keying material ikm, which must
+// be at least 32 bytes of secret randomness, and an optional keyInfo.
+//
+// IETF draft, section 2.3
+func KeyGen(ikm, keyInfo []byte) (*SecretKey, error) {
+	if len(ikm) < minIKMLength {
+		return nil, ErrShortIKM
+	}
+
+	// IKM ∥ I2OSP(0, 1)
+	ikmPrime := make([]byte, len(ikm)+1)
+	copy(ikmPrime, ikm)
+	// key_info ∥ I2OSP(L, 2)
+	info := make([]byte, len(keyInfo)+2)
+	copy(info, keyInfo)
+	info[len(keyInfo)] = byte(keyGenL >> 8)
+	info[len(keyInfo)+1] = byte(keyGenL)
+
+	salt := []byte(keyGenSalt)
+	okm := make([]byte, keyGenL)
+	sk := new(SecretKey)
+	for sk.scalar.IsZero() {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+		prk := hkdf.Extract(sha256.New, ikmPrime, salt)
+		if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, info), okm); err != nil {
+			return nil, err
+		}
+		sk.scalar.SetBigInt(new(big.Int).SetBytes(okm))
+	}
+	return sk, nil
+}
+
+// GenerateKey derives a secret key from 32 bytes of input keying material
+// read from rand.
+func GenerateKey(rand io.Reader) (*SecretKey, error) {
+	ikm := make([]byte, minIKMLength)
+	if _, err := io.ReadFull(rand, ikm); err != nil {
+		return nil, err
+	}
+	return KeyGen(ikm, nil)
+}
+
+// Bytes returns the big endian representation of the secret scalar.
+func (sk *SecretKey) Bytes() []byte {
+	b := sk.scalar.Bytes()
+	return b[:]
+}
+
+// SetBytes sets sk from the big endian representation of a scalar. The
+// scalar must be in [1, r-1]. It returns the number of bytes read.
+func (sk *SecretKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < SizeSecretKey {
+		return 0, io.ErrShortBuffer
+	}
+	var s fr.Element
+	if err := s.SetBytesCanonical(buf[:SizeSecretKey]); err != nil || s.IsZero() {
+		return 0, ErrInvalidSecretKey
+	}
+	sk.scalar = s
+	return SizeSecretKey, nil
+}
+
+// Equal compares two secret keys in constant time.
+func (sk *SecretKey) Equal(other *SecretKey) bool {
+	return subtle.ConstantTimeCompare(sk.Bytes(), other.Bytes()) == 1
+}
+
+func (sk *SecretKey) bigInt() *big.Int {
+	return sk.scalar.BigInt(new(big.Int))
+}
+
+// SkToPk returns the serialized public key of sk.
+//
+// IETF draft, section 2.4
+func (cs *Ciphersuite) SkToPk(sk *SecretKey) []byte {
+	return cs.core.skToPk(sk.bigInt())
+}
+
+// KeyValidate checks that pk encodes a point of the prime order subgroup
+// other than the identity.
+//
+// IETF draft, section 2.5
+func (cs *Ciphersuite) KeyValidate(pk []byte) bool {
+	return cs.core.keyValidate(pk)
+}
+
+// Sign signs msg with sk. With the message augmentation scheme, the signed
+// value is the public key of sk concatenated with msg.
+//
+// IETF draft, sections 3.1, 3.2.1 and 3.3
+func (cs *Ciphersuite) Sign(sk *SecretKey, msg []byte) ([]byte, error) {
+	if cs.scheme == MessageAugmentation {
+		msg = augment(cs.SkToPk(sk), msg)
+	}
+	return cs.core.sign(sk.bigInt(), msg, []byte(cs.id))
+}
+
+// Verify checks that sig is a valid signature of msg under pk.
+//
+// IETF draft, sections 3.1, 3.2.2 and 3.3
+func (cs *Ciphersuite) Verify(pk, msg, sig []byte) bool {
+	if cs.scheme == MessageAugmentation {
+		msg = augment(pk, msg)
+	}
+	return cs.core.verify(pk, msg, sig, []byte(cs.id))
+}
+
+// Aggregate aggregates one or more signatures into a single one. It does not
+// check that the signatures are valid, only that they are well encoded.
+//
+// IETF draft, section 2.8
+func (cs *Ciphersuite) Aggregate(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, ErrNoSignatures
+	}
+	return cs.core.aggregateSignatures(sigs)
+}
+
+// AggregatePublicKeys aggregates one or more public keys into a single one.
+// Each public key must pass KeyValidate.
+func (cs *Ciphersuite) AggregatePublicKeys(pks [][]byte) ([]byte, error) {
+	if len(pks) == 0 {
+		return nil, ErrInvalidPublicKey
+	}
+	return cs.core.aggregatePublicKeys(pks)
+}
+
+// AggregateVerify checks an aggregate signature of the messages msgs[i]
+// signed by the public keys pks[i]. With the basic scheme the messages must
+// be pairwise distinct.
+//
+// IETF draft, sections 3.1.1, 3.2.3 and 3.3
+func (cs *Ciphersuite) AggregateVerify(pks, msgs [][]byte, sig []byte) bool {
+	if len(pks) == 0 || len(pks) != len(msgs) {
+		return false
+	}
+	switch cs.scheme {
+	case Basic:
+		seen := make(map[string]struct{}, len(msgs))
+		for _, m := range msgs {
+			if _, ok := seen[string(m)]; ok {
+				return false
+			}
+			seen[string(m)] = struct{}{}
+		}
+	case MessageAugmentation:
+		augmented := make([][]byte, len(msgs))
+		for i := range msgs {
+			augmented[i] = augment(pks[i], msgs[i])
+		}
+		msgs = augmented
+	}
+	return cs.core.aggregateVerify(pks, msgs, sig, []byte(cs.id))
+}
+
+// FastAggregateVerify checks an aggregate signature of a single message
+// signed by all the public keys pks. It is only sound with the proof of
+// possession scheme, and always fails with the other schemes.
+//
+// IETF draft, section 3.3.4
+func (cs *Ciphersuite) FastAggregateVerify(pks [][]byte, msg, sig []byte) bool {
+	if cs.scheme != ProofOfPossession || len(pks) == 0 {
+		return false
+	}
+	pk, err := cs.core.aggregatePublicKeys(pks)
+	if err != nil {
+		return false
+	}
+	return cs.core.verify(pk, msg, sig, []byte(cs.id))
+}
+
+// PopProve returns a proof of possession of sk, which is a signature of the
+// public key of sk under a dedicated domain separation tag.
+//
+// IETF draft, section 3.3.2
+func (cs *Ciphersuite) PopProve(sk *SecretKey) ([]byte, error) {
+	if cs.scheme != ProofOfPossession {
+		return nil, ErrNotProofOfPossession
+	}
+	return cs.core.sign(sk.bigInt(), cs.SkToPk(sk), []byte(cs.popID))
+}
+
+// PopVerify checks a proof of possession of the secret key of pk.
+//
+// IETF draft, section 3.3.3
+func (cs *Ciphersuite) PopVerify(pk, proof []byte) bool {
+	if cs.scheme != ProofOfPossession {
+		return false
+	}
+	return cs.core.verify(pk, pk, proof, []byte(cs.popID))
+}
+
+// augment returns pk ∥ msg.
+func augment(pk, msg []byte) []byte {
+	res := make([]byte, 0, len(pk)+len(msg))
+	res = append(res, pk...)
+	return append(res, msg...)
+}
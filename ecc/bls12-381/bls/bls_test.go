@@ -0,0 +1,311 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package bls
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+var suites = []*Ciphersuite{
+	MinPkBasic,
+	MinPkMessageAugmentation,
+	MinPkProofOfPossession,
+	MinSigBasic,
+	MinSigMessageAugmentation,
+	MinSigProofOfPossession,
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestVectors(t *testing.T) {
+	t.Parallel()
+	for _, v := range vectors {
+		v := v
+		t.Run(v.suite.ID(), func(t *testing.T) {
+			t.Parallel()
+			var pks, sigs [][]byte
+			for i, c := range v.sign {
+				var sk SecretKey
+				if _, err := sk.SetBytes(mustDecodeHex(t, c.sk)); err != nil {
+					t.Fatal(err)
+				}
+				pk, msg, expected := mustDecodeHex(t, c.pk), mustDecodeHex(t, c.msg), mustDecodeHex(t, c.sig)
+				if !bytes.Equal(v.suite.SkToPk(&sk), pk) {
+					t.Fatalf("case %d: public key mismatch", i)
+				}
+				sig, err := v.suite.Sign(&sk, msg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(sig, expected) {
+					t.Fatalf("case %d: signature mismatch", i)
+				}
+				if !v.suite.Verify(pk, msg, sig) {
+					t.Fatalf("case %d: valid signature rejected", i)
+				}
+				msg[0] ^= 1
+				if v.suite.Verify(pk, msg, sig) {
+					t.Fatalf("case %d: signature of another message accepted", i)
+				}
+				if i%3 == 0 {
+					pks = append(pks, pk)
+				}
+				sigs = append(sigs, sig)
+			}
+
+			// signatures of the three keys over the last message
+			msg := mustDecodeHex(t, v.sign[2].msg)
+			sameMessage := [][]byte{sigs[2], sigs[5], sigs[8]}
+			agg, err := v.suite.Aggregate(sameMessage)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(agg, mustDecodeHex(t, v.aggregateSameMessage)) {
+				t.Fatal("aggregate signature mismatch")
+			}
+			if fast := v.suite.FastAggregateVerify(pks, msg, agg); fast != (v.suite.Scheme() == ProofOfPossession) {
+				t.Fatal("unexpected FastAggregateVerify result")
+			}
+			if v.suite.Scheme() != MessageAugmentation {
+				if v.suite.AggregateVerify(pks, [][]byte{msg, msg, msg}, agg) != (v.suite.Scheme() == ProofOfPossession) {
+					t.Fatal("unexpected AggregateVerify result on repeated messages")
+				}
+			}
+
+			// signature of key i over message i
+			msgs := [][]byte{mustDecodeHex(t, v.sign[0].msg), mustDecodeHex(t, v.sign[1].msg), msg}
+			agg, err = v.suite.Aggregate([][]byte{sigs[0], sigs[4], sigs[8]})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(agg, mustDecodeHex(t, v.aggregateDistinctMessages)) {
+				t.Fatal("aggregate signature mismatch")
+			}
+			if !v.suite.AggregateVerify(pks, msgs, agg) {
+				t.Fatal("valid aggregate signature rejected")
+			}
+			msgs[0], msgs[1] = msgs[1], msgs[0]
+			if v.suite.AggregateVerify(pks, msgs, agg) {
+				t.Fatal("aggregate signature accepted with swapped messages")
+			}
+
+			for i, p := range v.pops {
+				var sk SecretKey
+				if _, err := sk.SetBytes(mustDecodeHex(t, v.sign[3*i].sk)); err != nil {
+					t.Fatal(err)
+				}
+				proof, err := v.suite.PopProve(&sk)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(proof, mustDecodeHex(t, p)) {
+					t.Fatalf("key %d: proof of possession mismatch", i)
+				}
+				if !v.suite.PopVerify(pks[i], proof) {
+					t.Fatalf("key %d: valid proof of possession rejected", i)
+				}
+				if v.suite.PopVerify(pks[(i+1)%len(pks)], proof) {
+					t.Fatalf("key %d: proof of possession accepted for another key", i)
+				}
+			}
+		})
+	}
+}
+
+func TestBLS(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 2
+	} else {
+		parameters.MinSuccessfulTests = 10
+	}
+	properties := gopter.NewProperties(parameters)
+
+	genSuite := gen.IntRange(0, len(suites)-1).Map(func(i int) *Ciphersuite { return suites[i] })
+
+	properties.Property("[BLS12-381] test the signing and verification", prop.ForAll(
+		func(cs *Ciphersuite, msg []byte) bool {
+			sk, err := GenerateKey(rand.Reader)
+			if err != nil {
+				return false
+			}
+			pk := cs.SkToPk(sk)
+			sig, err := cs.Sign(sk, msg)
+			if err != nil {
+				return false
+			}
+			return cs.KeyValidate(pk) &&
+				len(pk) == cs.PublicKeySize() &&
+				len(sig) == cs.SignatureSize() &&
+				cs.Verify(pk, msg, sig) &&
+				!cs.Verify(pk, append(msg, 0), sig)
+		},
+		genSuite,
+		gen.SliceOf(gen.UInt8()),
+	))
+
+	properties.Property("[BLS12-381] aggregate signatures of distinct messages", prop.ForAll(
+		func(cs *Ciphersuite, n int) bool {
+			pks, msgs, sigs := make([][]byte, n), make([][]byte, n), make([][]byte, n)
+			for i := 0; i < n; i++ {
+				sk, err := GenerateKey(rand.Reader)
+				if err != nil {
+					return false
+				}
+				pks[i] = cs.SkToPk(sk)
+				msgs[i] = []byte{byte(i)}
+				if sigs[i], err = cs.Sign(sk, msgs[i]); err != nil {
+					return false
+				}
+			}
+			agg, err := cs.Aggregate(sigs)
+			if err != nil || !cs.AggregateVerify(pks, msgs, agg) {
+				return false
+			}
+			// drop a signer
+			return !cs.AggregateVerify(pks[1:], msgs[1:], agg)
+		},
+		genSuite,
+		gen.IntRange(2, 4),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestKeyGen(t *testing.T) {
+	t.Parallel()
+	ikm := make([]byte, 32)
+	if _, err := KeyGen(ikm[:31], nil); err != ErrShortIKM {
+		t.Fatal("short input keying material accepted")
+	}
+	sk1, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sk2, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sk1.Equal(sk2) {
+		t.Fatal("KeyGen is not deterministic")
+	}
+	sk3, err := KeyGen(ikm, []byte("key info"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sk1.Equal(sk3) {
+		t.Fatal("KeyGen ignores key info")
+	}
+
+	var sk SecretKey
+	if _, err := sk.SetBytes(sk1.Bytes()); err != nil || !sk.Equal(sk1) {
+		t.Fatal("secret key round trip failed")
+	}
+	if _, err := sk.SetBytes(make([]byte, SizeSecretKey)); err != ErrInvalidSecretKey {
+		t.Fatal("zero secret key accepted")
+	}
+	invalid := bytes.Repeat([]byte{0xff}, SizeSecretKey)
+	if _, err := sk.SetBytes(invalid); err != ErrInvalidSecretKey {
+		t.Fatal("secret key larger than the order accepted")
+	}
+}
+
+func TestInvalidInputs(t *testing.T) {
+	t.Parallel()
+	sk, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("message")
+
+	for _, cs := range suites {
+		pk := cs.SkToPk(sk)
+		sig, err := cs.Sign(sk, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// identity public key and signature
+		var infinity []byte
+		if cs.PublicKeySize() == bls12381.SizeOfG1AffineCompressed {
+			var p bls12381.G1Affine
+			infinity = encodeG1(&p)
+		} else {
+			var p bls12381.G2Affine
+			infinity = encodeG2(&p)
+		}
+		if cs.KeyValidate(infinity) {
+			t.Fatal("identity public key accepted")
+		}
+		if _, err := cs.AggregatePublicKeys([][]byte{pk, infinity}); err != ErrInvalidPublicKey {
+			t.Fatal("identity public key aggregated")
+		}
+
+		// uncompressed encodings are rejected
+		uncompressed := make([]byte, 2*len(pk))
+		if cs.KeyValidate(uncompressed[:len(pk)]) {
+			t.Fatal("uncompressed public key accepted")
+		}
+
+		// truncated inputs
+		if cs.KeyValidate(pk[1:]) || cs.Verify(pk, msg, sig[1:]) {
+			t.Fatal("truncated input accepted")
+		}
+		if _, err := cs.Aggregate(nil); err != ErrNoSignatures {
+			t.Fatal("empty aggregate accepted")
+		}
+		if cs.AggregateVerify(nil, nil, sig) || cs.FastAggregateVerify(nil, msg, sig) {
+			t.Fatal("empty aggregate verified")
+		}
+		if cs.Scheme() != ProofOfPossession {
+			if _, err := cs.PopProve(sk); err != ErrNotProofOfPossession {
+				t.Fatal("proof of possession outside of the proof of possession scheme")
+			}
+		}
+	}
+}
+
+func BenchmarkSign(b *testing.B) {
+	sk, _ := GenerateKey(rand.Reader)
+	msg := []byte("benchmarking BLS sign()")
+	for _, cs := range []*Ciphersuite{MinPkProofOfPossession, MinSigProofOfPossession} {
+		b.Run(cs.ID(), func(b *testing.B) {
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				cs.Sign(sk, msg)
+			}
+		})
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	sk, _ := GenerateKey(rand.Reader)
+	msg := []byte("benchmarking BLS verify()")
+	for _, cs := range []*Ciphersuite{MinPkProofOfPossession, MinSigProofOfPossession} {
+		pk := cs.SkToPk(sk)
+		sig, _ := cs.Sign(sk, msg)
+		b.Run(cs.ID(), func(b *testing.B) {
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				cs.Verify(pk, msg, sig)
+			}
+		})
+	}
+}
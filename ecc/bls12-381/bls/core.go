@@ -0,0 +1,244 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package bls
+
+import (
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// core implements the core operations of the IETF draft (section 2) for a
+// given placement of the public keys and the signatures in G1 and G2.
+// Points are passed around in their compressed encoding.
+type core interface {
+	publicKeySize() int
+	signatureSize() int
+	skToPk(sk *big.Int) []byte
+	keyValidate(pk []byte) bool
+	sign(sk *big.Int, msg, dst []byte) ([]byte, error)
+	verify(pk, msg, sig, dst []byte) bool
+	aggregateVerify(pks, msgs [][]byte, sig, dst []byte) bool
+	aggregateSignatures(sigs [][]byte) ([]byte, error)
+	aggregatePublicKeys(pks [][]byte) ([]byte, error)
+}
+
+var g1GenNeg bls12381.G1Affine
+var g2GenNeg bls12381.G2Affine
+
+func init() {
+	_, _, g1Gen, g2Gen := bls12381.Generators()
+	g1GenNeg.Neg(&g1Gen)
+	g2GenNeg.Neg(&g2Gen)
+}
+
+// decodeG1 decodes a compressed G1 point and checks it is in the subgroup.
+func decodeG1(buf []byte) (p bls12381.G1Affine, err error) {
+	if len(buf) != bls12381.SizeOfG1AffineCompressed || buf[0]&0x80 == 0 {
+		return p, errInvalidPointEncoding
+	}
+	_, err = p.SetBytes(buf)
+	return
+}
+
+// decodeG2 decodes a compressed G2 point and checks it is in the subgroup.
+func decodeG2(buf []byte) (p bls12381.G2Affine, err error) {
+	if len(buf) != bls12381.SizeOfG2AffineCompressed || buf[0]&0x80 == 0 {
+		return p, errInvalidPointEncoding
+	}
+	_, err = p.SetBytes(buf)
+	return
+}
+
+func encodeG1(p *bls12381.G1Affine) []byte {
+	b := p.Bytes()
+	return b[:]
+}
+
+func encodeG2(p *bls12381.G2Affine) []byte {
+	b := p.Bytes()
+	return b[:]
+}
+
+// minPk places the public keys in G1 and the signatures in G2.
+type minPk struct{}
+
+func (minPk) publicKeySize() int { return bls12381.SizeOfG1AffineCompressed }
+func (minPk) signatureSize() int { return bls12381.SizeOfG2AffineCompressed }
+
+func (minPk) skToPk(sk *big.Int) []byte {
+	var pk bls12381.G1Affine
+	pk.ScalarMultiplicationBase(sk)
+	return encodeG1(&pk)
+}
+
+func (minPk) decodePublicKey(buf []byte) (bls12381.G1Affine, error) {
+	pk, err := decodeG1(buf)
+	if err != nil || pk.IsInfinity() {
+		return pk, ErrInvalidPublicKey
+	}
+	return pk, nil
+}
+
+func (c minPk) keyValidate(pk []byte) bool {
+	_, err := c.decodePublicKey(pk)
+	return err == nil
+}
+
+func (minPk) sign(sk *big.Int, msg, dst []byte) ([]byte, error) {
+	q, err := bls12381.HashToG2(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	q.ScalarMultiplication(&q, sk)
+	return encodeG2(&q), nil
+}
+
+func (c minPk) verify(pk, msg, sig, dst []byte) bool {
+	return c.aggregateVerify([][]byte{pk}, [][]byte{msg}, sig, dst)
+}
+
+// aggregateVerify checks e(pk₁, H(m₁))⋯e(pkₙ, H(mₙ)) = e(g₁, σ).
+func (c minPk) aggregateVerify(pks, msgs [][]byte, sig, dst []byte) bool {
+	if len(pks) == 0 || len(pks) != len(msgs) {
+		return false
+	}
+	s, err := decodeG2(sig)
+	if err != nil {
+		return false
+	}
+	P := make([]bls12381.G1Affine, len(pks)+1)
+	Q := make([]bls12381.G2Affine, len(pks)+1)
+	for i := range pks {
+		if P[i], err = c.decodePublicKey(pks[i]); err != nil {
+			return false
+		}
+		if Q[i], err = bls12381.HashToG2(msgs[i], dst); err != nil {
+			return false
+		}
+	}
+	P[len(pks)] = g1GenNeg
+	Q[len(pks)] = s
+	ok, err := bls12381.PairingCheck(P, Q)
+	return err == nil && ok
+}
+
+func (minPk) aggregateSignatures(sigs [][]byte) ([]byte, error) {
+	var acc bls12381.G2Jac
+	for i := range sigs {
+		s, err := decodeG2(sigs[i])
+		if err != nil {
+			return nil, ErrInvalidSignature
+		}
+		acc.AddMixed(&s)
+	}
+	var res bls12381.G2Affine
+	res.FromJacobian(&acc)
+	return encodeG2(&res), nil
+}
+
+func (c minPk) aggregatePublicKeys(pks [][]byte) ([]byte, error) {
+	var acc bls12381.G1Jac
+	for i := range pks {
+		pk, err := c.decodePublicKey(pks[i])
+		if err != nil {
+			return nil, err
+		}
+		acc.AddMixed(&pk)
+	}
+	var res bls12381.G1Affine
+	res.FromJacobian(&acc)
+	return encodeG1(&res), nil
+}
+
+// minSig places the public keys in G2 and the signatures in G1.
+type minSig struct{}
+
+func (minSig) publicKeySize() int { return bls12381.SizeOfG2AffineCompressed }
+func (minSig) signatureSize() int { return bls12381.SizeOfG1AffineCompressed }
+
+func (minSig) skToPk(sk *big.Int) []byte {
+	var pk bls12381.G2Affine
+	pk.ScalarMultiplicationBase(sk)
+	return encodeG2(&pk)
+}
+
+func (minSig) decodePublicKey(buf []byte) (bls12381.G2Affine, error) {
+	pk, err := decodeG2(buf)
+	if err != nil || pk.IsInfinity() {
+		return pk, ErrInvalidPublicKey
+	}
+	return pk, nil
+}
+
+func (c minSig) keyValidate(pk []byte) bool {
+	_, err := c.decodePublicKey(pk)
+	return err == nil
+}
+
+func (minSig) sign(sk *big.Int, msg, dst []byte) ([]byte, error) {
+	q, err := bls12381.HashToG1(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	q.ScalarMultiplication(&q, sk)
+	return encodeG1(&q), nil
+}
+
+func (c minSig) verify(pk, msg, sig, dst []byte) bool {
+	return c.aggregateVerify([][]byte{pk}, [][]byte{msg}, sig, dst)
+}
+
+// aggregateVerify checks e(H(m₁), pk₁)⋯e(H(mₙ), pkₙ) = e(σ, g₂).
+func (c minSig) aggregateVerify(pks, msgs [][]byte, sig, dst []byte) bool {
+	if len(pks) == 0 || len(pks) != len(msgs) {
+		return false
+	}
+	s, err := decodeG1(sig)
+	if err != nil {
+		return false
+	}
+	P := make([]bls12381.G1Affine, len(pks)+1)
+	Q := make([]bls12381.G2Affine, len(pks)+1)
+	for i := range pks {
+		if Q[i], err = c.decodePublicKey(pks[i]); err != nil {
+			return false
+		}
+		if P[i], err = bls12381.HashToG1(msgs[i], dst); err != nil {
+			return false
+		}
+	}
+	P[len(pks)] = s
+	Q[len(pks)] = g2GenNeg
+	ok, err := bls12381.PairingCheck(P, Q)
+	return err == nil && ok
+}
+
+func (minSig) aggregateSignatures(sigs [][]byte) ([]byte, error) {
+	var acc bls12381.G1Jac
+	for i := range sigs {
+		s, err := decodeG1(sigs[i])
+		if err != nil {
+			return nil, ErrInvalidSignature
+		}
+		acc.AddMixed(&s)
+	}
+	var res bls12381.G1Affine
+	res.FromJacobian(&acc)
+	return encodeG1(&res), nil
+}
+
+func (c minSig) aggregatePublicKeys(pks [][]byte) ([]byte, error) {
+	var acc bls12381.G2Jac
+	for i := range pks {
+		pk, err := c.decodePublicKey(pks[i])
+		if err != nil {
+			return nil, err
+		}
+		acc.AddMixed(&pk)
+	}
+	var res bls12381.G2Affine
+	res.FromJacobian(&acc)
+	return encodeG2(&res), nil
+}
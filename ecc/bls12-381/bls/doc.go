@@ -0,0 +1,25 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Package bls implements the BLS signature scheme on the bls12-381 curve,
+// following the IETF specification.
+//
+// Six ciphersuites are provided: public keys in G1 and signatures in G2
+// ("minimal-pubkey-size") or the converse ("minimal-signature-size"), each
+// with the basic, message-augmentation and proof-of-possession schemes. The
+// proof-of-possession ciphersuite with public keys in G1 is the one used by
+// the Ethereum consensus layer.
+//
+// Keys, signatures and proofs are handled as octet strings, using the
+// compressed ZCash encoding of bls12-381 points. Decoding a point always
+// checks that it lies in the prime order subgroup.
+//
+// PrivateKey and PublicKey wrap the MinPkProofOfPossession ciphersuite in
+// the signature.Signer and signature.PublicKey interfaces, like the bls
+// packages of the other pairing curves.
+//
+// Documentation:
+//   - IETF draft: https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05
+//   - Hash to curve: https://datatracker.ietf.org/doc/html/rfc9380
+//   - Ethereum test vectors: https://github.com/ethereum/bls12-381-tests
+package bls
@@ -0,0 +1,142 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package bls
+
+import (
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+const (
+	sizePublicKey  = bls12381.SizeOfG1AffineCompressed
+	sizePrivateKey = sizePublicKey + SizeSecretKey
+)
+
+var errPublicKeyMismatch = errors.New("public key does not match the secret key")
+
+// PublicKey is a MinPkProofOfPossession public key implementing
+// signature.PublicKey.
+type PublicKey struct {
+	A bls12381.G1Affine
+}
+
+// PrivateKey is a MinPkProofOfPossession private key implementing
+// signature.Signer, as used by the other curves of the signature/bls
+// package.
+type PrivateKey struct {
+	PublicKey PublicKey
+	sk        SecretKey
+}
+
+// NewPrivateKey returns the signature.Signer associated to sk.
+func NewPrivateKey(sk *SecretKey) *PrivateKey {
+	privKey := &PrivateKey{sk: *sk}
+	privKey.PublicKey.A.ScalarMultiplicationBase(sk.bigInt())
+	return privKey
+}
+
+// Public returns the public key associated to the private key.
+func (privKey *PrivateKey) Public() signature.PublicKey {
+	var pub PublicKey
+	pub.A.Set(&privKey.PublicKey.A)
+	return &pub
+}
+
+// Sign signs the message with MinPkProofOfPossession. If hFunc is provided,
+// the message is hashed with hFunc first.
+func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	message, err := prehash(message, hFunc)
+	if err != nil {
+		return nil, err
+	}
+	return MinPkProofOfPossession.Sign(&privKey.sk, message)
+}
+
+// Bytes returns the binary representation of privKey as publicKey||scalar,
+// where publicKey is as publicKey.Bytes(), and scalar is as SecretKey.Bytes().
+func (privKey *PrivateKey) Bytes() []byte {
+	var res [sizePrivateKey]byte
+	pkBin := privKey.PublicKey.A.Bytes()
+	subtle.ConstantTimeCopy(1, res[:sizePublicKey], pkBin[:])
+	subtle.ConstantTimeCopy(1, res[sizePublicKey:], privKey.sk.Bytes())
+	return res[:]
+}
+
+// SetBytes sets privKey from buf, interpreted as publicKey||scalar. It
+// checks that the public key matches the scalar. It returns the number of
+// bytes read.
+func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePrivateKey {
+		return 0, io.ErrShortBuffer
+	}
+	var pk PublicKey
+	if _, err := pk.SetBytes(buf[:sizePublicKey]); err != nil {
+		return 0, err
+	}
+	var sk SecretKey
+	if _, err := sk.SetBytes(buf[sizePublicKey:sizePrivateKey]); err != nil {
+		return 0, err
+	}
+	expected := NewPrivateKey(&sk)
+	if !expected.PublicKey.A.Equal(&pk.A) {
+		return 0, errPublicKeyMismatch
+	}
+	*privKey = *expected
+	return sizePrivateKey, nil
+}
+
+// Verify checks a MinPkProofOfPossession signature of the message. If hFunc
+// is provided, the message is hashed with hFunc first.
+func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
+	message, err := prehash(message, hFunc)
+	if err != nil {
+		return false, err
+	}
+	return MinPkProofOfPossession.Verify(pub.Bytes(), message, sigBin), nil
+}
+
+// Bytes returns the compressed encoding of the public key.
+func (pub *PublicKey) Bytes() []byte {
+	b := pub.A.Bytes()
+	return b[:]
+}
+
+// SetBytes sets pub from its compressed encoding, and checks it passes
+// KeyValidate. It returns the number of bytes read.
+func (pub *PublicKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePublicKey {
+		return 0, io.ErrShortBuffer
+	}
+	a, err := minPk{}.decodePublicKey(buf[:sizePublicKey])
+	if err != nil {
+		return 0, err
+	}
+	pub.A = a
+	return sizePublicKey, nil
+}
+
+// Equal compares 2 public keys
+func (pub *PublicKey) Equal(x signature.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare(pub.Bytes(), xx.Bytes()) == 1
+}
+
+func prehash(message []byte, hFunc hash.Hash) ([]byte, error) {
+	if hFunc == nil {
+		return message, nil
+	}
+	hFunc.Reset()
+	if _, err := hFunc.Write(message); err != nil {
+		return nil, err
+	}
+	return hFunc.Sum(nil), nil
+}
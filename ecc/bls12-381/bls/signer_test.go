@@ -0,0 +1,61 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package bls
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestSigner(t *testing.T) {
+	t.Parallel()
+	sk, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privKey := NewPrivateKey(sk)
+	pub := privKey.Public()
+	msg := []byte("testing BLS")
+
+	// the signer produces MinPkProofOfPossession signatures
+	sig, err := privKey.Sign(msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := MinPkProofOfPossession.Sign(sk, msg)
+	if !bytes.Equal(sig, expected) {
+		t.Fatal("signer and ciphersuite disagree")
+	}
+	if ok, err := pub.Verify(sig, msg, nil); err != nil || !ok {
+		t.Fatal("valid signature rejected")
+	}
+
+	sig, err = privKey.Sign(msg, sha256.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := pub.Verify(sig, msg, sha256.New()); err != nil || !ok {
+		t.Fatal("valid signature rejected (hashed)")
+	}
+	if ok, _ := pub.Verify(sig, msg, nil); ok {
+		t.Fatal("signature of the digest accepted for the message")
+	}
+
+	// serialization
+	var end PrivateKey
+	if _, err := end.SetBytes(privKey.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if !end.sk.Equal(sk) || !end.PublicKey.Equal(pub) {
+		t.Fatal("private key round trip failed")
+	}
+	other, _ := GenerateKey(rand.Reader)
+	buf := privKey.Bytes()
+	copy(buf[sizePublicKey:], other.Bytes())
+	if _, err := end.SetBytes(buf); err != errPublicKeyMismatch {
+		t.Fatal("private key accepted with another public key")
+	}
+}
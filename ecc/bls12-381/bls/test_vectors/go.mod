@@ -0,0 +1,7 @@
+// The generator of the known answer vectors depends on blst, which is kept
+// out of the gnark-crypto module.
+module github.com/consensys/gnark-crypto/ecc/bls12-381/bls/test_vectors
+
+go 1.22
+
+require github.com/supranational/blst v0.3.17
@@ -0,0 +1,550 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bulletproofs
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math/big"
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidNbBits        = errors.New("the number of bits must be a power of two not larger than 64")
+	ErrInvalidNbValues      = errors.New("the number of values must be a power of two, equal to the number of blindings")
+	ErrInvalidNbCommitments = errors.New("the number of commitments is not the same as the number of proofs")
+	ErrValueOutOfRange      = errors.New("value out of range")
+	ErrGeneratorsTooSmall   = errors.New("not enough generators for the number of bits")
+	ErrInvalidProofSize     = errors.New("invalid number of rounds in the inner product proof")
+	ErrVerifyRangeProof     = errors.New("can't verify range proof")
+	ErrMinGeneratorsSize    = errors.New("minimum generators size is 1")
+)
+
+// Generators are the bases of the range proofs, shared by the prover and the
+// verifier.
+type Generators struct {
+	// G, H are the bases of the Pedersen commitments v⋅G + γ⋅H to the values
+	G, H curve.G1Affine
+
+	// Gs, Hs are the bases of the vector commitments to the bits of the values
+	Gs, Hs []curve.G1Affine
+
+	// U is the base of the inner products
+	U curve.G1Affine
+}
+
+// Proof is an aggregated range proof for m values of n bits.
+type Proof struct {
+	// A, S are the commitments to the bits of the values and to the blinding
+	// vectors of the bits
+	A, S curve.G1Affine
+
+	// T1, T2 are the commitments to the coefficients of t(X) = ⟨l(X), r(X)⟩
+	T1, T2 curve.G1Affine
+
+	// TauX, Mu are the blinding factors of t(x) and of A + x⋅S
+	TauX, Mu fr.Element
+
+	// THat is the evaluation t(x)
+	THat fr.Element
+
+	// InnerProduct proves that t(x) = ⟨l(x), r(x)⟩
+	InnerProduct InnerProductProof
+}
+
+// NewGenerators returns generators for range proofs of up to size bits in
+// total, size being rounded up to the next power of two. An aggregated proof
+// of m values of n bits needs n⋅m bits.
+//
+// The generators are derived by hashing to G1, seed being the domain
+// separation tag, so that there is no trusted setup.
+func NewGenerators(size uint64, seed []byte) (*Generators, error) {
+	if size == 0 {
+		return nil, ErrMinGeneratorsSize
+	}
+	size = ecc.NextPowerOfTwo(size)
+
+	var gens Generators
+	var err error
+	if gens.G, err = curve.HashToG1([]byte("G"), seed); err != nil {
+		return nil, err
+	}
+	if gens.H, err = curve.HashToG1([]byte("H"), seed); err != nil {
+		return nil, err
+	}
+	if gens.U, err = curve.HashToG1([]byte("U"), seed); err != nil {
+		return nil, err
+	}
+
+	gens.Gs = make([]curve.G1Affine, size)
+	gens.Hs = make([]curve.G1Affine, size)
+	var lock sync.Mutex
+	parallel.Execute(int(size), func(start, end int) {
+		var msg [9]byte
+		for i := start; i < end; i++ {
+			binary.BigEndian.PutUint64(msg[1:], uint64(i))
+			msg[0] = 'G'
+			g, errG := curve.HashToG1(msg[:], seed)
+			msg[0] = 'H'
+			h, errH := curve.HashToG1(msg[:], seed)
+			if errG != nil || errH != nil {
+				lock.Lock()
+				err = errors.Join(errG, errH)
+				lock.Unlock()
+				return
+			}
+			gens.Gs[i], gens.Hs[i] = g, h
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &gens, nil
+}
+
+// Commit returns the Pedersen commitment value⋅G + blinding⋅H.
+func Commit(value uint64, blinding fr.Element, gens *Generators) curve.G1Affine {
+	var v fr.Element
+	v.SetUint64(value)
+	return commit(gens, &v, &blinding)
+}
+
+// Prove computes an aggregated proof that the values are in [0, 2^nbBits),
+// and returns it along with the commitments to the values with the given
+// blindings.
+//
+// nbBits must be a power of two not larger than 64, and the number of values
+// must be a power of two. The transcript is bound to the commitments and the
+// optional dataTranscript.
+func Prove(values []uint64, blindings []fr.Element, nbBits int, gens *Generators, hf hash.Hash, dataTranscript ...[]byte) (Proof, []curve.G1Affine, error) {
+	if nbBits <= 0 || nbBits > 64 || nbBits&(nbBits-1) != 0 {
+		return Proof{}, nil, ErrInvalidNbBits
+	}
+	m := len(values)
+	if m == 0 || m&(m-1) != 0 || len(blindings) != m {
+		return Proof{}, nil, ErrInvalidNbValues
+	}
+	n := nbBits * m
+	if n > len(gens.Gs) {
+		return Proof{}, nil, ErrGeneratorsTooSmall
+	}
+	for _, v := range values {
+		if nbBits < 64 && v>>nbBits != 0 {
+			return Proof{}, nil, ErrValueOutOfRange
+		}
+	}
+
+	commitments := make([]curve.G1Affine, m)
+	for j := range values {
+		commitments[j] = Commit(values[j], blindings[j], gens)
+	}
+
+	var proof Proof
+	var err error
+
+	// aL are the bits of the values and aR = aL - 1
+	aL := make([]fr.Element, n)
+	aR := make([]fr.Element, n)
+	var one fr.Element
+	one.SetOne()
+	for j, v := range values {
+		for k := 0; k < nbBits; k++ {
+			if v>>k&1 == 1 {
+				aL[j*nbBits+k].SetOne()
+			} else {
+				aR[j*nbBits+k].Neg(&one)
+			}
+		}
+	}
+	sL, err := randomVector(n)
+	if err != nil {
+		return Proof{}, nil, err
+	}
+	sR, err := randomVector(n)
+	if err != nil {
+		return Proof{}, nil, err
+	}
+	blinding, err := randomVector(4)
+	if err != nil {
+		return Proof{}, nil, err
+	}
+	alpha, rho, tau1, tau2 := blinding[0], blinding[1], blinding[2], blinding[3]
+
+	// A = α⋅H + ⟨aL, Gs⟩ + ⟨aR, Hs⟩ and S = ρ⋅H + ⟨sL, Gs⟩ + ⟨sR, Hs⟩
+	if proof.A, err = vectorCommit(gens, &alpha, aL, aR); err != nil {
+		return Proof{}, nil, err
+	}
+	if proof.S, err = vectorCommit(gens, &rho, sL, sR); err != nil {
+		return Proof{}, nil, err
+	}
+
+	fs := fiatshamir.NewTranscript(hf, challengeNames(bits.TrailingZeros(uint(n)))...)
+	y, err := deriveY(fs, nbBits, commitments, &proof, dataTranscript)
+	if err != nil {
+		return Proof{}, nil, err
+	}
+	z, err := deriveChallenge(fs, "z")
+	if err != nil {
+		return Proof{}, nil, err
+	}
+
+	// l(X) = aL - z + sL⋅X
+	// r(X) = yⁱ∘(aR + z + sR⋅X) + z²⁺ʲ⋅2ᵏ, for i = j⋅n + k
+	zPowers := powers(z, m+2)[2:]
+	l0, l1 := aL, sL
+	r0, r1 := aR, sR
+	var yPow, twoPow, t fr.Element
+	yPow.SetOne()
+	for j := 0; j < m; j++ {
+		twoPow.SetOne()
+		for k := 0; k < nbBits; k++ {
+			i := j*nbBits + k
+			l0[i].Sub(&l0[i], &z)
+			r0[i].Add(&r0[i], &z).Mul(&r0[i], &yPow)
+			t.Mul(&zPowers[j], &twoPow)
+			r0[i].Add(&r0[i], &t)
+			r1[i].Mul(&r1[i], &yPow)
+			yPow.Mul(&yPow, &y)
+			twoPow.Double(&twoPow)
+		}
+	}
+
+	// t(X) = t₀ + t₁⋅X + t₂⋅X², T₁ = t₁⋅G + τ₁⋅H and T₂ = t₂⋅G + τ₂⋅H
+	var t1, t2 fr.Element
+	t1 = innerProduct(l0, r1)
+	t = innerProduct(l1, r0)
+	t1.Add(&t1, &t)
+	t2 = innerProduct(l1, r1)
+	proof.T1 = commit(gens, &t1, &tau1)
+	proof.T2 = commit(gens, &t2, &tau2)
+
+	x, err := deriveChallenge(fs, "x", pointBytes(&proof.T1), pointBytes(&proof.T2))
+	if err != nil {
+		return Proof{}, nil, err
+	}
+
+	// τx = τ₂⋅x² + τ₁⋅x + ∑ⱼ z²⁺ʲ⋅γⱼ and μ = α + ρ⋅x
+	proof.TauX.Mul(&tau2, &x).Add(&proof.TauX, &tau1).Mul(&proof.TauX, &x)
+	for j := range blindings {
+		t.Mul(&zPowers[j], &blindings[j])
+		proof.TauX.Add(&proof.TauX, &t)
+	}
+	proof.Mu.Mul(&rho, &x).Add(&proof.Mu, &alpha)
+
+	// l = l(x), r = r(x) and t̂ = ⟨l, r⟩
+	for i := range l0 {
+		t.Mul(&l1[i], &x)
+		l0[i].Add(&l0[i], &t)
+		t.Mul(&r1[i], &x)
+		r0[i].Add(&r0[i], &t)
+	}
+	proof.THat = innerProduct(l0, r0)
+
+	w, err := deriveChallenge(fs, "w", proof.TauX.Marshal(), proof.Mu.Marshal(), proof.THat.Marshal())
+	if err != nil {
+		return Proof{}, nil, err
+	}
+	var q curve.G1Affine
+	var wBig big.Int
+	q.ScalarMultiplication(&gens.U, w.BigInt(&wBig))
+
+	// ⟨l, Gs⟩ + ⟨r, H's⟩ + t̂⋅Q with H'ᵢ = y⁻ⁱ⋅Hsᵢ
+	y.Inverse(&y)
+	proof.InnerProduct, err = proveInnerProduct(fs, &q, gens.Gs[:n], gens.Hs[:n], powers(y, n), l0, r0)
+	if err != nil {
+		return Proof{}, nil, err
+	}
+
+	return proof, commitments, nil
+}
+
+// Verify verifies an aggregated range proof for the values committed to in
+// commitments.
+func Verify(commitments []curve.G1Affine, proof *Proof, nbBits int, gens *Generators, hf hash.Hash, dataTranscript ...[]byte) error {
+	return BatchVerify([][]curve.G1Affine{commitments}, []Proof{*proof}, nbBits, gens, hf, dataTranscript...)
+}
+
+// BatchVerify verifies a list of range proofs of nbBits, for possibly
+// different numbers of values, with a single multi-exponentiation.
+//
+// The verification equations are combined with random coefficients, so that
+// the sums of the multi-exponentiations over the generators are computed once.
+func BatchVerify(commitments [][]curve.G1Affine, proofs []Proof, nbBits int, gens *Generators, hf hash.Hash, dataTranscript ...[]byte) error {
+	if len(commitments) != len(proofs) {
+		return ErrInvalidNbCommitments
+	}
+	if nbBits <= 0 || nbBits > 64 || nbBits&(nbBits-1) != 0 {
+		return ErrInvalidNbBits
+	}
+
+	var v verifier
+	for i := range proofs {
+		if err := v.add(commitments[i], &proofs[i], nbBits, gens, hf, dataTranscript); err != nil {
+			return err
+		}
+	}
+	return v.check(gens)
+}
+
+// verifier accumulates the verification equations of range proofs, each
+// multiplied by random weights, in a single multi-exponentiation which must
+// be zero.
+type verifier struct {
+	g, h, u fr.Element       // coefficients of G, H and U
+	gs, hs  []fr.Element     // coefficients of Gs and Hs
+	bases   []curve.G1Affine // the points of the proofs and the commitments
+	scalars []fr.Element
+}
+
+// add adds the verification equations of a range proof:
+//
+//	t̂⋅G + τx⋅H = ∑ⱼ z²⁺ʲ⋅Vⱼ + δ(y, z)⋅G + x⋅T₁ + x²⋅T₂
+//	A + x⋅S - μ⋅H - z⋅∑ᵢ Gsᵢ + ∑ᵢ (z + y⁻ⁱ⋅z²⁺ʲ⋅2ᵏ)⋅Hsᵢ + ∑ⱼ (uⱼ²⋅Lⱼ + uⱼ⁻²⋅Rⱼ) + (t̂ - a⋅b)⋅w⋅U
+//	  = a⋅∑ᵢ sᵢ⋅Gsᵢ + b⋅∑ᵢ y⁻ⁱ⋅sᵢ⁻¹⋅Hsᵢ
+//
+// where δ(y, z) = (z - z²)⋅∑ᵢ yⁱ - ∑ⱼ z³⁺ʲ⋅(2ⁿ - 1) and sᵢ = ∏ⱼ uⱼ^{±1} is the
+// coefficient of Gsᵢ in the folded base of the inner product argument.
+func (v *verifier) add(commitments []curve.G1Affine, proof *Proof, nbBits int, gens *Generators, hf hash.Hash, dataTranscript [][]byte) error {
+	m := len(commitments)
+	if m == 0 || m&(m-1) != 0 {
+		return ErrInvalidNbValues
+	}
+	n := nbBits * m
+	if n > len(gens.Gs) {
+		return ErrGeneratorsTooSmall
+	}
+	nbRounds := bits.TrailingZeros(uint(n))
+	ipp := &proof.InnerProduct
+	if len(ipp.L) != nbRounds || len(ipp.R) != nbRounds {
+		return ErrInvalidProofSize
+	}
+
+	// replay the transcript
+	fs := fiatshamir.NewTranscript(hf, challengeNames(nbRounds)...)
+	y, err := deriveY(fs, nbBits, commitments, proof, dataTranscript)
+	if err != nil {
+		return err
+	}
+	z, err := deriveChallenge(fs, "z")
+	if err != nil {
+		return err
+	}
+	x, err := deriveChallenge(fs, "x", pointBytes(&proof.T1), pointBytes(&proof.T2))
+	if err != nil {
+		return err
+	}
+	w, err := deriveChallenge(fs, "w", proof.TauX.Marshal(), proof.Mu.Marshal(), proof.THat.Marshal())
+	if err != nil {
+		return err
+	}
+	u := make([]fr.Element, nbRounds)
+	for j := range u {
+		if u[j], err = deriveU(fs, j, &ipp.L[j], &ipp.R[j]); err != nil {
+			return err
+		}
+		if u[j].IsZero() {
+			return ErrVerifyRangeProof
+		}
+	}
+	uInv := fr.BatchInvert(u)
+
+	// r weights the inner product equation and rT the equation of t̂
+	weights, err := randomVector(2)
+	if err != nil {
+		return err
+	}
+	r, rT := weights[0], weights[1]
+
+	// sᵢ, the round j folding the bit log(n)-1-j of the indices
+	s := make([]fr.Element, n)
+	s[0].SetOne()
+	for j := 0; j < nbRounds; j++ {
+		for i := 1<<j - 1; i >= 0; i-- {
+			s[2*i+1].Mul(&s[i], &u[j])
+			s[2*i].Mul(&s[i], &uInv[j])
+		}
+	}
+
+	for len(v.gs) < n {
+		v.gs = append(v.gs, fr.Element{})
+		v.hs = append(v.hs, fr.Element{})
+	}
+	zPowers := powers(z, m+3)[2:]
+	var yInv, yInvPow, sumY, yPow, twoPow, t, c fr.Element
+	yInv.Inverse(&y)
+	yInvPow.SetOne()
+	yPow.SetOne()
+	var ra, rb, rz fr.Element
+	ra.Mul(&r, &ipp.A)
+	rb.Mul(&r, &ipp.B)
+	rz.Mul(&r, &z)
+	for j := 0; j < m; j++ {
+		twoPow.SetOne()
+		for k := 0; k < nbBits; k++ {
+			i := j*nbBits + k
+
+			// r⋅(-z - a⋅sᵢ)
+			t.Mul(&ra, &s[i]).Add(&t, &rz)
+			v.gs[i].Sub(&v.gs[i], &t)
+
+			// r⋅(z + y⁻ⁱ⋅(z²⁺ʲ⋅2ᵏ - b⋅sᵢ⁻¹)), with sᵢ⁻¹ = sₙ₋₁₋ᵢ
+			c.Mul(&zPowers[j], &twoPow).Mul(&c, &r)
+			t.Mul(&rb, &s[n-1-i])
+			c.Sub(&c, &t).Mul(&c, &yInvPow).Add(&c, &rz)
+			v.hs[i].Add(&v.hs[i], &c)
+
+			sumY.Add(&sumY, &yPow)
+			yPow.Mul(&yPow, &y)
+			yInvPow.Mul(&yInvPow, &yInv)
+			twoPow.Double(&twoPow)
+		}
+	}
+
+	// G: rT⋅(δ(y, z) - t̂), with 2ⁿ - 1 in twoPow
+	var delta fr.Element
+	twoPow.SetOne()
+	for k := 0; k < nbBits; k++ {
+		twoPow.Double(&twoPow)
+	}
+	twoPow.Sub(&twoPow, new(fr.Element).SetOne())
+	delta.Square(&z).Sub(&z, &delta).Mul(&delta, &sumY)
+	for j := 0; j < m; j++ {
+		t.Mul(&zPowers[j+1], &twoPow)
+		delta.Sub(&delta, &t)
+	}
+	delta.Sub(&delta, &proof.THat).Mul(&delta, &rT)
+	v.g.Add(&v.g, &delta)
+
+	// H: -r⋅μ - rT⋅τx
+	t.Mul(&r, &proof.Mu)
+	v.h.Sub(&v.h, &t)
+	t.Mul(&rT, &proof.TauX)
+	v.h.Sub(&v.h, &t)
+
+	// U: r⋅w⋅(t̂ - a⋅b)
+	t.Mul(&ipp.A, &ipp.B).Sub(&proof.THat, &t).Mul(&t, &w).Mul(&t, &r)
+	v.u.Add(&v.u, &t)
+
+	// A: r, S: r⋅x, T₁: rT⋅x, T₂: rT⋅x²
+	v.bases = append(v.bases, proof.A, proof.S, proof.T1, proof.T2)
+	var rx, rTx, rTx2 fr.Element
+	rx.Mul(&r, &x)
+	rTx.Mul(&rT, &x)
+	rTx2.Mul(&rTx, &x)
+	v.scalars = append(v.scalars, r, rx, rTx, rTx2)
+
+	// Vⱼ: rT⋅z²⁺ʲ
+	v.bases = append(v.bases, commitments...)
+	for j := 0; j < m; j++ {
+		t.Mul(&rT, &zPowers[j])
+		v.scalars = append(v.scalars, t)
+	}
+
+	// Lⱼ: r⋅uⱼ², Rⱼ: r⋅uⱼ⁻²
+	v.bases = append(v.bases, ipp.L...)
+	v.bases = append(v.bases, ipp.R...)
+	for j := range u {
+		t.Square(&u[j]).Mul(&t, &r)
+		v.scalars = append(v.scalars, t)
+	}
+	for j := range uInv {
+		t.Square(&uInv[j]).Mul(&t, &r)
+		v.scalars = append(v.scalars, t)
+	}
+
+	return nil
+}
+
+// check returns an error if the accumulated multi-exponentiation is not zero.
+func (v *verifier) check(gens *Generators) error {
+	n := len(v.gs)
+	bases := make([]curve.G1Affine, 0, 3+2*n+len(v.bases))
+	bases = append(bases, gens.G, gens.H, gens.U)
+	bases = append(bases, gens.Gs[:n]...)
+	bases = append(bases, gens.Hs[:n]...)
+	bases = append(bases, v.bases...)
+	scalars := make([]fr.Element, 0, len(bases))
+	scalars = append(scalars, v.g, v.h, v.u)
+	scalars = append(scalars, v.gs...)
+	scalars = append(scalars, v.hs...)
+	scalars = append(scalars, v.scalars...)
+
+	res, err := multiExp(bases, scalars)
+	if err != nil {
+		return err
+	}
+	if !res.IsInfinity() {
+		return ErrVerifyRangeProof
+	}
+	return nil
+}
+
+// commit returns v⋅G + γ⋅H
+func commit(gens *Generators, v, gamma *fr.Element) curve.G1Affine {
+	var vBig, gammaBig big.Int
+	var resJac curve.G1Jac
+	resJac.JointScalarMultiplication(&gens.G, &gens.H, v.BigInt(&vBig), gamma.BigInt(&gammaBig))
+	var res curve.G1Affine
+	res.FromJacobian(&resJac)
+	return res
+}
+
+// vectorCommit returns blinding⋅H + ⟨left, Gs⟩ + ⟨right, Hs⟩
+func vectorCommit(gens *Generators, blinding *fr.Element, left, right []fr.Element) (curve.G1Affine, error) {
+	n := len(left)
+	bases := make([]curve.G1Affine, 0, 2*n+1)
+	bases = append(bases, gens.H)
+	bases = append(bases, gens.Gs[:n]...)
+	bases = append(bases, gens.Hs[:n]...)
+	scalars := make([]fr.Element, 0, 2*n+1)
+	scalars = append(scalars, *blinding)
+	scalars = append(scalars, left...)
+	scalars = append(scalars, right...)
+	return multiExp(bases, scalars)
+}
+
+// deriveY returns the challenge y, bound to the number of bits, the
+// commitments to the values, A and S.
+func deriveY(fs *fiatshamir.Transcript, nbBits int, commitments []curve.G1Affine, proof *Proof, dataTranscript [][]byte) (fr.Element, error) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(nbBits))
+	bindings := [][]byte{buf[:]}
+	for i := range commitments {
+		bindings = append(bindings, pointBytes(&commitments[i]))
+	}
+	bindings = append(bindings, pointBytes(&proof.A), pointBytes(&proof.S))
+	bindings = append(bindings, dataTranscript...)
+	return deriveChallenge(fs, "y", bindings...)
+}
+
+// powers returns (1, x, ..., xⁿ⁻¹)
+func powers(x fr.Element, n int) []fr.Element {
+	res := make([]fr.Element, n)
+	res[0].SetOne()
+	for i := 1; i < n; i++ {
+		res[i].Mul(&res[i-1], &x)
+	}
+	return res
+}
+
+func randomVector(n int) ([]fr.Element, error) {
+	res := make([]fr.Element, n)
+	for i := range res {
+		if _, err := res[i].SetRandom(); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bulletproofs
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// sizePoint is the size in bytes of an encoded point, see pointBytes
+const sizePoint = curve.SizeOfG1AffineCompressed
+
+var errInvalidSize = errors.New("invalid size")
+
+// WriteTo writes the binary encoding of the generators.
+func (gens *Generators) WriteTo(w io.Writer) (int64, error) {
+	if len(gens.Gs) != len(gens.Hs) {
+		return 0, errInvalidSize
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(len(gens.Gs)))
+	n, err := w.Write(buf[:])
+	written := int64(n)
+	if err != nil {
+		return written, err
+	}
+	n64, err := writePoints(w, gens.G, gens.H, gens.U)
+	written += n64
+	if err != nil {
+		return written, err
+	}
+	n64, err = writePoints(w, gens.Gs...)
+	written += n64
+	if err != nil {
+		return written, err
+	}
+	n64, err = writePoints(w, gens.Hs...)
+	return written + n64, err
+}
+
+// ReadFrom decodes generators from reader, checking that the points are in
+// the prime order subgroup.
+func (gens *Generators) ReadFrom(r io.Reader) (int64, error) {
+	var buf [8]byte
+	n, err := io.ReadFull(r, buf[:])
+	read := int64(n)
+	if err != nil {
+		return read, err
+	}
+	size := binary.BigEndian.Uint64(buf[:])
+	if size == 0 || size&(size-1) != 0 || size > 1<<40 {
+		return read, errInvalidSize
+	}
+	points := make([]curve.G1Affine, 3)
+	n64, err := readPoints(r, points)
+	read += n64
+	if err != nil {
+		return read, err
+	}
+	gens.G, gens.H, gens.U = points[0], points[1], points[2]
+	gens.Gs = make([]curve.G1Affine, size)
+	gens.Hs = make([]curve.G1Affine, size)
+	n64, err = readPoints(r, gens.Gs)
+	read += n64
+	if err != nil {
+		return read, err
+	}
+	n64, err = readPoints(r, gens.Hs)
+	return read + n64, err
+}
+
+// WriteTo writes the binary encoding of the Proof.
+func (proof *Proof) WriteTo(w io.Writer) (int64, error) {
+	ipp := &proof.InnerProduct
+	if len(ipp.L) != len(ipp.R) || len(ipp.L) > 255 {
+		return 0, errInvalidSize
+	}
+	written, err := writePoints(w, proof.A, proof.S, proof.T1, proof.T2)
+	if err != nil {
+		return written, err
+	}
+	n64, err := writeScalars(w, &proof.TauX, &proof.Mu, &proof.THat)
+	written += n64
+	if err != nil {
+		return written, err
+	}
+	n, err := w.Write([]byte{byte(len(ipp.L))})
+	written += int64(n)
+	if err != nil {
+		return written, err
+	}
+	n64, err = writePoints(w, ipp.L...)
+	written += n64
+	if err != nil {
+		return written, err
+	}
+	n64, err = writePoints(w, ipp.R...)
+	written += n64
+	if err != nil {
+		return written, err
+	}
+	n64, err = writeScalars(w, &ipp.A, &ipp.B)
+	return written + n64, err
+}
+
+// ReadFrom decodes a Proof from reader, checking that the points are in the
+// prime order subgroup.
+func (proof *Proof) ReadFrom(r io.Reader) (int64, error) {
+	points := make([]curve.G1Affine, 4)
+	read, err := readPoints(r, points)
+	if err != nil {
+		return read, err
+	}
+	proof.A, proof.S, proof.T1, proof.T2 = points[0], points[1], points[2], points[3]
+	n64, err := readScalars(r, &proof.TauX, &proof.Mu, &proof.THat)
+	read += n64
+	if err != nil {
+		return read, err
+	}
+
+	ipp := &proof.InnerProduct
+	var nbRounds [1]byte
+	n, err := io.ReadFull(r, nbRounds[:])
+	read += int64(n)
+	if err != nil {
+		return read, err
+	}
+	ipp.L = make([]curve.G1Affine, nbRounds[0])
+	ipp.R = make([]curve.G1Affine, nbRounds[0])
+	n64, err = readPoints(r, ipp.L)
+	read += n64
+	if err != nil {
+		return read, err
+	}
+	n64, err = readPoints(r, ipp.R)
+	read += n64
+	if err != nil {
+		return read, err
+	}
+	n64, err = readScalars(r, &ipp.A, &ipp.B)
+	return read + n64, err
+}
+
+func writePoints(w io.Writer, points ...curve.G1Affine) (int64, error) {
+	var written int64
+	for i := range points {
+		n, err := w.Write(pointBytes(&points[i]))
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+func readPoints(r io.Reader, points []curve.G1Affine) (int64, error) {
+	var read int64
+	var buf [sizePoint]byte
+	for i := range points {
+		n, err := io.ReadFull(r, buf[:])
+		read += int64(n)
+		if err != nil {
+			return read, err
+		}
+		if _, err = points[i].SetBytes(buf[:]); err != nil {
+			return read, err
+		}
+	}
+	return read, nil
+}
+
+func writeScalars(w io.Writer, scalars ...*fr.Element) (int64, error) {
+	var written int64
+	for _, s := range scalars {
+		b := s.Bytes()
+		n, err := w.Write(b[:])
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+func readScalars(r io.Reader, scalars ...*fr.Element) (int64, error) {
+	var read int64
+	var buf [fr.Bytes]byte
+	for _, s := range scalars {
+		n, err := io.ReadFull(r, buf[:])
+		read += int64(n)
+		if err != nil {
+			return read, err
+		}
+		if err = s.SetBytesCanonical(buf[:]); err != nil {
+			return read, err
+		}
+	}
+	return read, nil
+}
@@ -0,0 +1,402 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/accumulator/merkletree"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrEmptyMatrix      = errors.New("a matrix must contain at least one polynomial of size at least 1")
+	ErrNoShift          = errors.New("at least one out of domain shift is needed")
+	ErrOutOfDomainPoint = errors.New("the out of domain point is in the evaluation domain")
+	ErrNbMatrices       = errors.New("the number of commitments does not match the opening proof")
+	ErrPointMismatch    = errors.New("the out of domain point does not match the transcript")
+)
+
+// MatrixCommitment is the commitment to a matrix of polynomials: the root of
+// the Merkle tree of the rows of their evaluations on the domain, and their
+// sizes, which are the degree bounds proven by the batched FRI.
+type MatrixCommitment struct {
+	Root  Digest
+	Sizes []int
+}
+
+// Matrix is a matrix of polynomials committed to by CommitMatrix, whose
+// columns are their codewords.
+type Matrix struct {
+	Commitment MatrixCommitment
+
+	polynomials [][]fr.Element
+	codewords   [][]fr.Element
+	tree        *merkleTree
+}
+
+// BatchOpeningProof is the proof of the evaluations of the polynomials of
+// several matrices at out of domain points, with a single FRI.
+//
+// implements io.ReaderFrom and io.WriterTo
+type BatchOpeningProof struct {
+
+	// Point is the out of domain point z, derived with Fiat Shamir.
+	Point fr.Element
+
+	// ClaimedValues[m][i][s] is the evaluation of the i-th polynomial of the
+	// m-th matrix at z⋅shifts[s].
+	ClaimedValues [][][]fr.Element
+
+	// Rows[q][m] is the Merkle proof of the rows of the m-th matrix on the
+	// fiber of the q-th query, the first oracle of the FRI.
+	Rows [][]MerkleProof
+
+	// ProofOfProximity is the FRI proof of the DEEP combination, whose first
+	// oracle is not committed to.
+	ProofOfProximity ProofOfProximity
+}
+
+// CommitMatrix commits to polynomials in canonical form, of possibly
+// different sizes not larger than the size of the IOPP, with a single Merkle
+// tree. The leaves are the rows of their evaluations on the fibers of the
+// first folding of the FRI, so that a query opens a single Merkle path per
+// matrix.
+func (s radixTwoFri) CommitMatrix(polynomials [][]fr.Element) (*Matrix, error) {
+	if len(polynomials) == 0 {
+		return nil, ErrEmptyMatrix
+	}
+	res := &Matrix{
+		Commitment:  MatrixCommitment{Sizes: make([]int, len(polynomials))},
+		polynomials: polynomials,
+		codewords:   make([][]fr.Element, len(polynomials)),
+	}
+	for i, p := range polynomials {
+		if len(p) == 0 {
+			return nil, ErrEmptyMatrix
+		}
+		if uint64(len(p)) > s.domain.Cardinality/uint64(s.params.Rate) {
+			return nil, ErrLowDegree
+		}
+		res.Commitment.Sizes[i] = len(p)
+	}
+	parallel.Execute(len(polynomials), func(start, end int) {
+		for i := start; i < end; i++ {
+			res.codewords[i] = make([]fr.Element, s.domain.Cardinality)
+			copy(res.codewords[i], polynomials[i])
+			s.domain.FFT(res.codewords[i], fft.DIF)
+			fft.BitReverse(res.codewords[i])
+		}
+	}, 1)
+	res.tree = newMerkleTree(s.h, fiberLeaves(res.codewords, s.arities[0]))
+	res.Commitment.Root = res.tree.root()
+	return res, nil
+}
+
+// batchTranscript binds the commitments, derives the out of domain point,
+// binds the claimed values if any and derives the combination challenge.
+type batchTranscript struct {
+	fs *fiatshamir.Transcript
+}
+
+func (s radixTwoFri) newBatchTranscript(commitments []MatrixCommitment, dataTranscript [][]byte) (batchTranscript, error) {
+	t := batchTranscript{fs: fiatshamir.NewTranscript(s.h, "z", "gamma")}
+	for _, data := range dataTranscript {
+		if err := t.fs.Bind("z", data); err != nil {
+			return t, err
+		}
+	}
+	var buf [4]byte
+	for _, c := range commitments {
+		if err := t.fs.Bind("z", c.Root); err != nil {
+			return t, err
+		}
+		for _, size := range c.Sizes {
+			binary.BigEndian.PutUint32(buf[:], uint32(size))
+			if err := t.fs.Bind("z", buf[:]); err != nil {
+				return t, err
+			}
+		}
+	}
+	return t, nil
+}
+
+func (t batchTranscript) point() (fr.Element, error) {
+	var z fr.Element
+	b, err := t.fs.ComputeChallenge("z")
+	if err != nil {
+		return z, err
+	}
+	z.SetBytes(b)
+	return z, nil
+}
+
+func (t batchTranscript) gamma(claimedValues [][][]fr.Element) (fr.Element, []byte, error) {
+	var gamma fr.Element
+	for _, m := range claimedValues {
+		for _, p := range m {
+			for _, v := range p {
+				if err := t.fs.Bind("gamma", v.Marshal()); err != nil {
+					return gamma, nil, err
+				}
+			}
+		}
+	}
+	b, err := t.fs.ComputeChallenge("gamma")
+	if err != nil {
+		return gamma, nil, err
+	}
+	gamma.SetBytes(b)
+	return gamma, b, nil
+}
+
+// deepPoints returns the points z⋅shifts[s], and checks that they are not in
+// the domain.
+func (s radixTwoFri) deepPoints(z fr.Element, shifts []fr.Element) ([]fr.Element, error) {
+	if len(shifts) == 0 {
+		return nil, ErrNoShift
+	}
+	points := make([]fr.Element, len(shifts))
+	var zN fr.Element
+	for i := range shifts {
+		points[i].Mul(&z, &shifts[i])
+		zN.Exp(points[i], new(big.Int).SetUint64(s.domain.Cardinality))
+		if zN.IsOne() {
+			return nil, ErrOutOfDomainPoint
+		}
+	}
+	return points, nil
+}
+
+// BatchOpen proves the evaluations of all the polynomials of the matrices at
+// the out of domain points z⋅shifts[s], where z is derived from the
+// commitments and dataTranscript with Fiat Shamir.
+//
+// The polynomials pᵢ of sizes dᵢ are batched into the DEEP combination
+//
+//	F = ∑ γᵏ⋅X^{D+1-dᵢ}⋅(pᵢ - pᵢ(z⋅shiftₛ))/(X - z⋅shiftₛ)
+//
+// over the polynomials and the shifts, where D is the size of the IOPP. Each
+// term has size D exactly when pᵢ has size dᵢ, so that a single FRI on F
+// proves the degree bounds and the evaluations of all the polynomials. The
+// first oracle of the FRI is F, whose evaluations the verifier computes from
+// the openings of the rows of the matrices.
+func (s radixTwoFri) BatchOpen(matrices []*Matrix, shifts []fr.Element, dataTranscript ...[]byte) (BatchOpeningProof, error) {
+	var proof BatchOpeningProof
+	commitments := make([]MatrixCommitment, len(matrices))
+	for m := range matrices {
+		commitments[m] = matrices[m].Commitment
+	}
+	t, err := s.newBatchTranscript(commitments, dataTranscript)
+	if err != nil {
+		return proof, err
+	}
+	if proof.Point, err = t.point(); err != nil {
+		return proof, err
+	}
+	points, err := s.deepPoints(proof.Point, shifts)
+	if err != nil {
+		return proof, err
+	}
+
+	// claimed values
+	proof.ClaimedValues = make([][][]fr.Element, len(matrices))
+	for m, matrix := range matrices {
+		proof.ClaimedValues[m] = make([][]fr.Element, len(matrix.polynomials))
+		parallel.Execute(len(matrix.polynomials), func(start, end int) {
+			for i := start; i < end; i++ {
+				proof.ClaimedValues[m][i] = make([]fr.Element, len(points))
+				for k := range points {
+					proof.ClaimedValues[m][i][k] = eval(matrix.polynomials[i], points[k])
+				}
+			}
+		})
+	}
+	gamma, seed, err := t.gamma(proof.ClaimedValues)
+	if err != nil {
+		return proof, err
+	}
+
+	// evaluations of F on the domain
+	n := int(s.domain.Cardinality)
+	invDiffs := make([][]fr.Element, len(points))
+	parallel.Execute(len(points), func(start, end int) {
+		for k := start; k < end; k++ {
+			invDiffs[k] = make([]fr.Element, n)
+			var x fr.Element
+			x.SetOne()
+			for j := range invDiffs[k] {
+				invDiffs[k][j].Sub(&x, &points[k])
+				x.Mul(&x, &s.domain.Generator)
+			}
+			invDiffs[k] = fr.BatchInvert(invDiffs[k])
+		}
+	})
+	f := make([]fr.Element, n)
+	var gammaK fr.Element
+	gammaK.SetOne()
+	for m, matrix := range matrices {
+		for i, codeword := range matrix.codewords {
+			// g^{D+1-dᵢ}, to compute the xʲ^{D+1-dᵢ}
+			var gE fr.Element
+			gE.Exp(s.domain.Generator, big.NewInt(int64(s.size()+1-matrix.Commitment.Sizes[i])))
+			coeffs := make([]fr.Element, len(points))
+			for k := range points {
+				coeffs[k].Set(&gammaK)
+				gammaK.Mul(&gammaK, &gamma)
+			}
+			values := proof.ClaimedValues[m][i]
+			parallel.Execute(n, func(start, end int) {
+				var xE, term, diff fr.Element
+				xE.Exp(gE, big.NewInt(int64(start)))
+				for j := start; j < end; j++ {
+					term.SetZero()
+					for k := range points {
+						diff.Sub(&codeword[j], &values[k]).Mul(&diff, &invDiffs[k][j]).Mul(&diff, &coeffs[k])
+						term.Add(&term, &diff)
+					}
+					term.Mul(&term, &xE)
+					f[j].Add(&f[j], &term)
+					xE.Mul(&xE, &gE)
+				}
+			})
+		}
+	}
+
+	// FRI on F, chained to the transcript by the combination challenge
+	var positions []uint64
+	if proof.ProofOfProximity, positions, err = s.proveProximity(f, seed, false); err != nil {
+		return proof, err
+	}
+	proof.Rows = make([][]MerkleProof, len(positions))
+	nbLeaves := s.domain.Cardinality / uint64(s.arities[0])
+	for q, pos := range positions {
+		proof.Rows[q] = make([]MerkleProof, len(matrices))
+		for m, matrix := range matrices {
+			proof.Rows[q][m] = MerkleProof{
+				MerkleRoot: matrix.Commitment.Root,
+				ProofSet:   matrix.tree.prove(int(pos % nbLeaves)),
+				numLeaves:  nbLeaves,
+			}
+		}
+	}
+	return proof, nil
+}
+
+// VerifyBatchOpening verifies a proof of BatchOpen against the commitments to
+// the matrices.
+func (s radixTwoFri) VerifyBatchOpening(commitments []MatrixCommitment, shifts []fr.Element, proof BatchOpeningProof, dataTranscript ...[]byte) error {
+	if len(proof.ClaimedValues) != len(commitments) {
+		return ErrNbMatrices
+	}
+	if len(proof.Rows) != s.params.NbQueries {
+		return ErrProofShape
+	}
+	for m, c := range commitments {
+		if len(c.Sizes) == 0 || len(proof.ClaimedValues[m]) != len(c.Sizes) {
+			return ErrEmptyMatrix
+		}
+		for i, size := range c.Sizes {
+			if size < 1 || size > s.size() {
+				return ErrLowDegree
+			}
+			if len(proof.ClaimedValues[m][i]) != len(shifts) {
+				return ErrProofShape
+			}
+		}
+	}
+	t, err := s.newBatchTranscript(commitments, dataTranscript)
+	if err != nil {
+		return err
+	}
+	z, err := t.point()
+	if err != nil {
+		return err
+	}
+	if !z.Equal(&proof.Point) {
+		return ErrPointMismatch
+	}
+	points, err := s.deepPoints(z, shifts)
+	if err != nil {
+		return err
+	}
+	gamma, seed, err := t.gamma(proof.ClaimedValues)
+	if err != nil {
+		return err
+	}
+
+	// F on the fiber of the leaf j, from the rows of the matrices
+	k := s.arities[0]
+	nbLeaves := s.domain.Cardinality / uint64(k)
+	firstFiber := func(q int, j uint64) ([]fr.Element, error) {
+		if len(proof.Rows[q]) != len(commitments) {
+			return nil, ErrProofShape
+		}
+		rows := make([][]fr.Element, len(commitments))
+		for m, c := range commitments {
+			row := proof.Rows[q][m]
+			if !merkletree.VerifyProof(s.h, c.Root, row.ProofSet, j, nbLeaves) {
+				return nil, ErrMerklePath
+			}
+			if rows[m], err = parseLeaf(row.ProofSet[0], k*len(c.Sizes)); err != nil {
+				return nil, err
+			}
+		}
+
+		res := make([]fr.Element, k)
+		var x fr.Element
+		x.Exp(s.domain.Generator, new(big.Int).SetUint64(j))
+		var step fr.Element
+		step.Exp(s.domain.Generator, new(big.Int).SetUint64(nbLeaves))
+		invDiffs := make([]fr.Element, len(points))
+		for tt := range res {
+			for l := range points {
+				invDiffs[l].Sub(&x, &points[l])
+			}
+			invDiffs = fr.BatchInvert(invDiffs)
+
+			var gammaK, term, diff, xE fr.Element
+			gammaK.SetOne()
+			for m, c := range commitments {
+				for i, size := range c.Sizes {
+					term.SetZero()
+					value := rows[m][tt*len(c.Sizes)+i]
+					for l := range points {
+						diff.Sub(&value, &proof.ClaimedValues[m][i][l]).Mul(&diff, &invDiffs[l]).Mul(&diff, &gammaK)
+						term.Add(&term, &diff)
+						gammaK.Mul(&gammaK, &gamma)
+					}
+					xE.Exp(x, big.NewInt(int64(s.size()+1-size)))
+					term.Mul(&term, &xE)
+					res[tt].Add(&res[tt], &term)
+				}
+			}
+			x.Mul(&x, &step)
+		}
+		return res, nil
+	}
+
+	return s.verifyProximity(proof.ProofOfProximity, seed, firstFiber)
+}
+
+// size returns the size D of the polynomials of the IOPP.
+func (s radixTwoFri) size() int {
+	return int(s.domain.Cardinality) / s.params.Rate
+}
+
+// eval returns p(x), p being in canonical form.
+func eval(p []fr.Element, x fr.Element) fr.Element {
+	var res fr.Element
+	for i := len(p) - 1; i >= 0; i-- {
+		res.Mul(&res, &x).Add(&res, &p[i])
+	}
+	return res
+}
@@ -0,0 +1,31 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Package eip4844 implements the KZG commitments to blobs of EIP-4844, as
+// specified in the polynomial commitments of the Deneb consensus specs.
+//
+// A blob is a polynomial of degree < 4096 in evaluation form, given by its
+// evaluations at the 4096-th roots of unity in bit-reversed order. Blobs are
+// committed to with the Lagrange basis of the Ethereum trusted setup, and
+// opened at a Fiat-Shamir challenge derived from the blob and its commitment.
+// The commitments and the proofs are those of the kzg package, and the
+// verifications rely on kzg.Verify and kzg.BatchVerifyMultiPoints.
+//
+// The package also implements the cells of EIP-7594 (PeerDAS), as specified
+// in the polynomial commitments sampling of the Fulu consensus specs. A blob
+// is extended to its evaluations at the 8192-th roots of unity, split in 128
+// cells of 64 evaluations on cosets of the 64-th roots of unity. The proofs
+// of all the cells are computed at once with the FK20 method of
+// kzg.CellProvingKey, and cells of several blobs are verified together with
+// a single pairing check. The recovery of the cells from a half of them,
+// recover_cells_and_kzg_proofs, is not implemented.
+//
+// The functions are tested against the reference tests of the consensus
+// specs.
+//
+// Documentation:
+//   - EIP-4844: https://eips.ethereum.org/EIPS/eip-4844
+//   - Deneb polynomial commitments: https://github.com/ethereum/consensus-specs/blob/dev/specs/deneb/polynomial-commitments.md
+//   - EIP-7594: https://eips.ethereum.org/EIPS/eip-7594
+//   - Fulu polynomial commitments sampling: https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/polynomial-commitments-sampling.md
+package eip4844
@@ -0,0 +1,361 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package eip4844
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+)
+
+const (
+	// ScalarsPerBlob is the number of field elements in a blob,
+	// FIELD_ELEMENTS_PER_BLOB.
+	ScalarsPerBlob    = 1 << logScalarsPerBlob
+	logScalarsPerBlob = 12
+	// SerializedScalarSize is the size in bytes of a serialized field element,
+	// BYTES_PER_FIELD_ELEMENT.
+	SerializedScalarSize = fr.Bytes
+	// BlobSize is the size in bytes of a blob, BYTES_PER_BLOB.
+	BlobSize = ScalarsPerBlob * SerializedScalarSize
+	// CompressedG1Size is the size in bytes of a commitment or a proof,
+	// BYTES_PER_COMMITMENT and BYTES_PER_PROOF.
+	CompressedG1Size = bls12381.SizeOfG1AffineCompressed
+)
+
+// fiatShamirDomain is FIAT_SHAMIR_PROTOCOL_DOMAIN, the domain separator of
+// the challenge of the blob proofs.
+const fiatShamirDomain = "FSBLOBVERIFY_V1_"
+
+var (
+	ErrInvalidScalar     = errors.New("scalar is not canonical")
+	ErrInvalidCommitment = errors.New("invalid commitment encoding")
+	ErrInvalidProof      = errors.New("invalid proof encoding")
+	ErrInvalidNbInputs   = errors.New("number of blobs, commitments and proofs differ")
+)
+
+// Blob is a polynomial of degree < ScalarsPerBlob in evaluation form: the
+// big endian evaluations at the roots of unity, in bit-reversed order.
+type Blob [BlobSize]byte
+
+// Scalar is a big endian field element.
+type Scalar [SerializedScalarSize]byte
+
+// KZGCommitment is a compressed commitment to a blob.
+type KZGCommitment [CompressedG1Size]byte
+
+// KZGProof is a compressed KZG opening proof.
+type KZGProof [CompressedG1Size]byte
+
+// Context holds the trusted setup and the evaluation domains of the blobs and
+// of the cells.
+type Context struct {
+	// pk is the Lagrange basis of the trusted setup, in bit-reversed order
+	pk kzg.ProvingKey
+	vk kzg.VerifyingKey
+
+	// domain is the roots of unity of order ScalarsPerBlob, in bit-reversed
+	// order
+	domain         [ScalarsPerBlob]fr.Element
+	cardinalityInv fr.Element
+
+	// pkMonomial is the monomial basis of the trusted setup, and cellVk the
+	// key of the cell proofs, with [τ^ScalarsPerCell]G₂
+	pkMonomial kzg.ProvingKey
+	cellVk     *kzg.CellVerifyingKey
+
+	blobDomain, extDomain *fft.Domain
+
+	// cellKey is computed on the first call to ComputeCellsAndKZGProofs
+	cellKey     *kzg.CellProvingKey
+	cellKeyErr  error
+	cellKeyOnce sync.Once
+}
+
+// BlobToKZGCommitment returns the commitment to the blob.
+//
+// blob_to_kzg_commitment
+func (ctx *Context) BlobToKZGCommitment(blob *Blob) (KZGCommitment, error) {
+	p, err := blobToPolynomial(blob)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	digest, err := kzg.Commit(p, ctx.pk)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	return digest.Bytes(), nil
+}
+
+// ComputeKZGProof returns the proof of the evaluation y of the blob at z, and
+// y.
+//
+// compute_kzg_proof
+func (ctx *Context) ComputeKZGProof(blob *Blob, z Scalar) (KZGProof, Scalar, error) {
+	p, err := blobToPolynomial(blob)
+	if err != nil {
+		return KZGProof{}, Scalar{}, err
+	}
+	var zElement fr.Element
+	if err := zElement.SetBytesCanonical(z[:]); err != nil {
+		return KZGProof{}, Scalar{}, ErrInvalidScalar
+	}
+	proof, err := ctx.open(p, zElement)
+	if err != nil {
+		return KZGProof{}, Scalar{}, err
+	}
+	return proof.H.Bytes(), proof.ClaimedValue.Bytes(), nil
+}
+
+// ComputeBlobKZGProof returns the proof of the evaluation of the blob at the
+// Fiat-Shamir challenge derived from the blob and its commitment.
+//
+// compute_blob_kzg_proof
+func (ctx *Context) ComputeBlobKZGProof(blob *Blob, commitment KZGCommitment) (KZGProof, error) {
+	p, err := blobToPolynomial(blob)
+	if err != nil {
+		return KZGProof{}, err
+	}
+	if _, err := decodeCommitment(commitment); err != nil {
+		return KZGProof{}, err
+	}
+	z := computeChallenge(blob, commitment)
+	proof, err := ctx.open(p, z)
+	if err != nil {
+		return KZGProof{}, err
+	}
+	return proof.H.Bytes(), nil
+}
+
+// VerifyKZGProof checks the proof that the polynomial committed to evaluates
+// to y at z. It returns kzg.ErrVerifyOpeningProof if the proof is invalid.
+//
+// verify_kzg_proof
+func (ctx *Context) VerifyKZGProof(commitment KZGCommitment, z, y Scalar, proof KZGProof) error {
+	digest, err := decodeCommitment(commitment)
+	if err != nil {
+		return err
+	}
+	var zElement fr.Element
+	if err := zElement.SetBytesCanonical(z[:]); err != nil {
+		return ErrInvalidScalar
+	}
+	openingProof, err := decodeProof(proof)
+	if err != nil {
+		return err
+	}
+	if err := openingProof.ClaimedValue.SetBytesCanonical(y[:]); err != nil {
+		return ErrInvalidScalar
+	}
+	return kzg.Verify(&digest, &openingProof, zElement, ctx.vk)
+}
+
+// VerifyBlobKZGProof checks the proof of the evaluation of the blob at the
+// Fiat-Shamir challenge derived from the blob and its commitment. It returns
+// kzg.ErrVerifyOpeningProof if the proof is invalid.
+//
+// verify_blob_kzg_proof
+func (ctx *Context) VerifyBlobKZGProof(blob *Blob, commitment KZGCommitment, proof KZGProof) error {
+	digest, z, openingProof, err := ctx.blobOpening(blob, commitment, proof)
+	if err != nil {
+		return err
+	}
+	return kzg.Verify(&digest, &openingProof, z, ctx.vk)
+}
+
+// VerifyBlobKZGProofBatch checks the proofs of the blobs as in
+// VerifyBlobKZGProof, with a single pairing check. It returns
+// kzg.ErrVerifyOpeningProof if one of the proofs is invalid.
+//
+// verify_blob_kzg_proof_batch
+func (ctx *Context) VerifyBlobKZGProofBatch(blobs []Blob, commitments []KZGCommitment, proofs []KZGProof) error {
+	n := len(blobs)
+	if n != len(commitments) || n != len(proofs) {
+		return ErrInvalidNbInputs
+	}
+	if n == 0 {
+		return nil
+	}
+	digests := make([]kzg.Digest, n)
+	points := make([]fr.Element, n)
+	openingProofs := make([]kzg.OpeningProof, n)
+	for i := range blobs {
+		var err error
+		digests[i], points[i], openingProofs[i], err = ctx.blobOpening(&blobs[i], commitments[i], proofs[i])
+		if err != nil {
+			return err
+		}
+	}
+	return kzg.BatchVerifyMultiPoints(digests, openingProofs, points, ctx.vk)
+}
+
+// blobOpening decodes the commitment and the proof of the blob, and computes
+// the challenge and the evaluation of the blob at the challenge.
+func (ctx *Context) blobOpening(blob *Blob, commitment KZGCommitment, proof KZGProof) (kzg.Digest, fr.Element, kzg.OpeningProof, error) {
+	p, err := blobToPolynomial(blob)
+	if err != nil {
+		return kzg.Digest{}, fr.Element{}, kzg.OpeningProof{}, err
+	}
+	digest, err := decodeCommitment(commitment)
+	if err != nil {
+		return kzg.Digest{}, fr.Element{}, kzg.OpeningProof{}, err
+	}
+	openingProof, err := decodeProof(proof)
+	if err != nil {
+		return kzg.Digest{}, fr.Element{}, kzg.OpeningProof{}, err
+	}
+	z := computeChallenge(blob, commitment)
+	openingProof.ClaimedValue = ctx.evaluate(p, z)
+	return digest, z, openingProof, nil
+}
+
+// open returns the opening proof of the polynomial p in evaluation form at z.
+// The quotient (p - p(z))/(X - z) is computed in evaluation form, and is
+// committed to with the Lagrange basis.
+//
+// compute_kzg_proof_impl
+func (ctx *Context) open(p []fr.Element, z fr.Element) (kzg.OpeningProof, error) {
+	y := ctx.evaluate(p, z)
+
+	// qᵢ = (pᵢ - y)/(ωᵢ - z), except at ωₘ = z
+	q := make([]fr.Element, ScalarsPerBlob)
+	m := -1
+	for i := range q {
+		q[i].Sub(&ctx.domain[i], &z)
+		if q[i].IsZero() {
+			m = i
+		}
+	}
+	q = fr.BatchInvert(q)
+	var tmp fr.Element
+	for i := range q {
+		if i == m {
+			continue
+		}
+		tmp.Sub(&p[i], &y)
+		q[i].Mul(&q[i], &tmp)
+	}
+
+	// qₘ = q(z) = ∑_{i≠m} (pᵢ - y)ωᵢ / (z(z - ωᵢ)), as (X - ωₘ) divides p - y
+	//
+	// compute_quotient_eval_within_domain
+	if m >= 0 {
+		var zInv fr.Element
+		zInv.Inverse(&z)
+		q[m].SetZero()
+		for i := range q {
+			if i == m {
+				continue
+			}
+			// qᵢ = (pᵢ - y)/(ωᵢ - z), so that (pᵢ - y)ωᵢ/(z(z - ωᵢ)) = -qᵢωᵢ/z
+			tmp.Mul(&q[i], &ctx.domain[i])
+			q[m].Sub(&q[m], &tmp)
+		}
+		q[m].Mul(&q[m], &zInv)
+	}
+
+	h, err := kzg.Commit(q, ctx.pk)
+	if err != nil {
+		return kzg.OpeningProof{}, err
+	}
+	return kzg.OpeningProof{H: h, ClaimedValue: y}, nil
+}
+
+// evaluate returns p(z) where p is in evaluation form, with the barycentric
+// formula
+//
+//	p(z) = (zⁿ - 1)/n ∑ pᵢωᵢ/(z - ωᵢ)
+//
+// evaluate_polynomial_in_evaluation_form
+func (ctx *Context) evaluate(p []fr.Element, z fr.Element) fr.Element {
+	denominators := make([]fr.Element, ScalarsPerBlob)
+	for i := range denominators {
+		denominators[i].Sub(&z, &ctx.domain[i])
+		if denominators[i].IsZero() {
+			return p[i]
+		}
+	}
+	denominators = fr.BatchInvert(denominators)
+
+	var res, tmp fr.Element
+	for i := range denominators {
+		tmp.Mul(&p[i], &ctx.domain[i]).Mul(&tmp, &denominators[i])
+		res.Add(&res, &tmp)
+	}
+	var zn, one fr.Element
+	zn.Set(&z)
+	for i := 0; i < logScalarsPerBlob; i++ {
+		zn.Square(&zn)
+	}
+	one.SetOne()
+	zn.Sub(&zn, &one)
+	res.Mul(&res, &zn).Mul(&res, &ctx.cardinalityInv)
+	return res
+}
+
+// computeChallenge returns the Fiat-Shamir challenge of the blob proofs,
+//
+//	SHA256(FIAT_SHAMIR_PROTOCOL_DOMAIN ∥ n ∥ blob ∥ commitment) mod r
+//
+// with n = ScalarsPerBlob as a 16 bytes big endian integer.
+//
+// compute_challenge
+func computeChallenge(blob *Blob, commitment KZGCommitment) fr.Element {
+	var degree [16]byte
+	binary.BigEndian.PutUint64(degree[8:], ScalarsPerBlob)
+
+	h := sha256.New()
+	h.Write([]byte(fiatShamirDomain))
+	h.Write(degree[:])
+	h.Write(blob[:])
+	h.Write(commitment[:])
+
+	var res fr.Element
+	res.SetBytes(h.Sum(nil))
+	return res
+}
+
+// blobToPolynomial decodes the evaluations of the blob, which must be
+// canonical.
+//
+// blob_to_polynomial
+func blobToPolynomial(blob *Blob) ([]fr.Element, error) {
+	p := make([]fr.Element, ScalarsPerBlob)
+	for i := range p {
+		if err := p[i].SetBytesCanonical(blob[i*SerializedScalarSize : (i+1)*SerializedScalarSize]); err != nil {
+			return nil, ErrInvalidScalar
+		}
+	}
+	return p, nil
+}
+
+// decodeCommitment decodes a compressed point in the subgroup, possibly the
+// point at infinity.
+//
+// bytes_to_kzg_commitment
+func decodeCommitment(commitment KZGCommitment) (kzg.Digest, error) {
+	var digest kzg.Digest
+	if _, err := digest.SetBytes(commitment[:]); err != nil {
+		return kzg.Digest{}, ErrInvalidCommitment
+	}
+	return digest, nil
+}
+
+// decodeProof decodes a compressed point in the subgroup, possibly the point
+// at infinity, as the quotient of an opening proof.
+//
+// bytes_to_kzg_proof
+func decodeProof(proof KZGProof) (kzg.OpeningProof, error) {
+	var res kzg.OpeningProof
+	if _, err := res.H.SetBytes(proof[:]); err != nil {
+		return kzg.OpeningProof{}, ErrInvalidProof
+	}
+	return res, nil
+}
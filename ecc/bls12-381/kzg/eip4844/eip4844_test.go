@@ -0,0 +1,341 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package eip4844
+
+import (
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	"gopkg.in/yaml.v2"
+)
+
+// The reference tests are the KZG tests of the consensus specs,
+// https://github.com/ethereum/consensus-spec-tests, in
+// tests/general/deneb/kzg and tests/general/fulu/kzg. A subset of them is in testdata, the whole suite
+// can be run by setting KZG_REFERENCE_TESTS to its directory.
+var referenceTestsDir = "testdata/reference_tests"
+
+func init() {
+	if dir := os.Getenv("KZG_REFERENCE_TESTS"); dir != "" {
+		referenceTestsDir = dir
+	}
+}
+
+var (
+	testCtx     *Context
+	testCtxOnce sync.Once
+)
+
+func getContext(t testing.TB) *Context {
+	testCtxOnce.Do(func() {
+		f, err := os.Open("testdata/trusted_setup.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		testCtx, err = NewContext(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	return testCtx
+}
+
+// referenceTests runs f on each reference test of the function, decoded in
+// test.
+func referenceTests[T any](t *testing.T, function string, f func(t *testing.T, test *T)) {
+	files, err := filepath.Glob(filepath.Join(referenceTestsDir, function, "*", "*", "data.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no reference test for", function)
+	}
+	for _, file := range files {
+		t.Run(filepath.Base(filepath.Dir(file)), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var test T
+			if err := yaml.Unmarshal(data, &test); err != nil {
+				t.Fatal(err)
+			}
+			f(t, &test)
+		})
+	}
+}
+
+func TestBlobToKZGCommitment(t *testing.T) {
+	ctx := getContext(t)
+	type testCase struct {
+		Input struct {
+			Blob string `yaml:"blob"`
+		}
+		Output *string `yaml:"output"`
+	}
+	referenceTests(t, "blob_to_kzg_commitment", func(t *testing.T, test *testCase) {
+		var blob Blob
+		var commitment KZGCommitment
+		err := decodeHexes(hexInput{test.Input.Blob, blob[:]})
+		if err == nil {
+			commitment, err = ctx.BlobToKZGCommitment(&blob)
+		}
+		checkOutput(t, err, test.Output, commitment[:])
+	})
+}
+
+func TestComputeKZGProof(t *testing.T) {
+	ctx := getContext(t)
+	type testCase struct {
+		Input struct {
+			Blob string `yaml:"blob"`
+			Z    string `yaml:"z"`
+		}
+		Output *[2]string `yaml:"output"`
+	}
+	referenceTests(t, "compute_kzg_proof", func(t *testing.T, test *testCase) {
+		var blob Blob
+		var z, y Scalar
+		var proof KZGProof
+		err := decodeHexes(hexInput{test.Input.Blob, blob[:]}, hexInput{test.Input.Z, z[:]})
+		if err == nil {
+			proof, y, err = ctx.ComputeKZGProof(&blob, z)
+		}
+		if test.Output == nil {
+			checkOutput(t, err, nil, nil)
+			return
+		}
+		checkOutput(t, err, &test.Output[0], proof[:])
+		checkOutput(t, err, &test.Output[1], y[:])
+	})
+}
+
+func TestComputeBlobKZGProof(t *testing.T) {
+	ctx := getContext(t)
+	type testCase struct {
+		Input struct {
+			Blob       string `yaml:"blob"`
+			Commitment string `yaml:"commitment"`
+		}
+		Output *string `yaml:"output"`
+	}
+	referenceTests(t, "compute_blob_kzg_proof", func(t *testing.T, test *testCase) {
+		var blob Blob
+		var commitment KZGCommitment
+		var proof KZGProof
+		err := decodeHexes(hexInput{test.Input.Blob, blob[:]}, hexInput{test.Input.Commitment, commitment[:]})
+		if err == nil {
+			proof, err = ctx.ComputeBlobKZGProof(&blob, commitment)
+		}
+		checkOutput(t, err, test.Output, proof[:])
+	})
+}
+
+func TestVerifyKZGProof(t *testing.T) {
+	ctx := getContext(t)
+	type testCase struct {
+		Input struct {
+			Commitment string `yaml:"commitment"`
+			Z          string `yaml:"z"`
+			Y          string `yaml:"y"`
+			Proof      string `yaml:"proof"`
+		}
+		Output *bool `yaml:"output"`
+	}
+	referenceTests(t, "verify_kzg_proof", func(t *testing.T, test *testCase) {
+		var commitment KZGCommitment
+		var z, y Scalar
+		var proof KZGProof
+		err := decodeHexes(hexInput{test.Input.Commitment, commitment[:]}, hexInput{test.Input.Z, z[:]},
+			hexInput{test.Input.Y, y[:]}, hexInput{test.Input.Proof, proof[:]})
+		if err == nil {
+			err = ctx.VerifyKZGProof(commitment, z, y, proof)
+		}
+		checkVerification(t, err, test.Output)
+	})
+}
+
+func TestVerifyBlobKZGProof(t *testing.T) {
+	ctx := getContext(t)
+	type testCase struct {
+		Input struct {
+			Blob       string `yaml:"blob"`
+			Commitment string `yaml:"commitment"`
+			Proof      string `yaml:"proof"`
+		}
+		Output *bool `yaml:"output"`
+	}
+	referenceTests(t, "verify_blob_kzg_proof", func(t *testing.T, test *testCase) {
+		var blob Blob
+		var commitment KZGCommitment
+		var proof KZGProof
+		err := decodeHexes(hexInput{test.Input.Blob, blob[:]}, hexInput{test.Input.Commitment, commitment[:]},
+			hexInput{test.Input.Proof, proof[:]})
+		if err == nil {
+			err = ctx.VerifyBlobKZGProof(&blob, commitment, proof)
+		}
+		checkVerification(t, err, test.Output)
+	})
+}
+
+func TestVerifyBlobKZGProofBatch(t *testing.T) {
+	ctx := getContext(t)
+	type testCase struct {
+		Input struct {
+			Blobs       []string `yaml:"blobs"`
+			Commitments []string `yaml:"commitments"`
+			Proofs      []string `yaml:"proofs"`
+		}
+		Output *bool `yaml:"output"`
+	}
+	referenceTests(t, "verify_blob_kzg_proof_batch", func(t *testing.T, test *testCase) {
+		blobs := make([]Blob, len(test.Input.Blobs))
+		commitments := make([]KZGCommitment, len(test.Input.Commitments))
+		proofs := make([]KZGProof, len(test.Input.Proofs))
+		var inputs []hexInput
+		for i := range blobs {
+			inputs = append(inputs, hexInput{test.Input.Blobs[i], blobs[i][:]})
+		}
+		for i := range commitments {
+			inputs = append(inputs, hexInput{test.Input.Commitments[i], commitments[i][:]})
+		}
+		for i := range proofs {
+			inputs = append(inputs, hexInput{test.Input.Proofs[i], proofs[i][:]})
+		}
+		err := decodeHexes(inputs...)
+		if err == nil {
+			err = ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+		}
+		checkVerification(t, err, test.Output)
+	})
+}
+
+func TestRoundTrip(t *testing.T) {
+	ctx := getContext(t)
+
+	// a blob with non-zero evaluations in [0, 2²⁵⁶)
+	var blob Blob
+	for i := 0; i < ScalarsPerBlob; i++ {
+		blob[i*SerializedScalarSize+SerializedScalarSize-1] = byte(i)
+		blob[i*SerializedScalarSize+SerializedScalarSize-2] = byte(i >> 8)
+	}
+	commitment, err := ctx.BlobToKZGCommitment(&blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := ctx.ComputeBlobKZGProof(&blob, commitment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.VerifyBlobKZGProof(&blob, commitment, proof); err != nil {
+		t.Fatal(err)
+	}
+
+	// opening at a root of unity returns the evaluation of the blob
+	var z Scalar
+	fifth := ctx.domain[5].Bytes()
+	copy(z[:], fifth[:])
+	proof, y, err := ctx.ComputeKZGProof(&blob, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(y[:]) != string(blob[5*SerializedScalarSize:6*SerializedScalarSize]) {
+		t.Fatal("wrong evaluation at a root of unity")
+	}
+	if err := ctx.VerifyKZGProof(commitment, z, y, proof); err != nil {
+		t.Fatal(err)
+	}
+	y[SerializedScalarSize-1] ^= 1
+	if err := ctx.VerifyKZGProof(commitment, z, y, proof); !errors.Is(err, kzg.ErrVerifyOpeningProof) {
+		t.Fatal("wrong evaluation accepted")
+	}
+}
+
+type hexInput struct {
+	s   string
+	dst []byte
+}
+
+// decodeHexes decodes the 0x-prefixed hexadecimal strings, which must have
+// the size of their destination.
+func decodeHexes(inputs ...hexInput) error {
+	for _, in := range inputs {
+		b, err := hex.DecodeString(strings.TrimPrefix(in.s, "0x"))
+		if err != nil {
+			return err
+		}
+		if len(b) != len(in.dst) {
+			return errors.New("wrong size")
+		}
+		copy(in.dst, b)
+	}
+	return nil
+}
+
+// checkOutput checks that the function failed if expected is nil, and
+// returned the expected bytes otherwise.
+func checkOutput(t *testing.T, err error, expected *string, output []byte) {
+	t.Helper()
+	if expected == nil {
+		if err == nil {
+			t.Fatal("invalid input accepted")
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if "0x"+hex.EncodeToString(output) != *expected {
+		t.Fatal("output mismatch")
+	}
+}
+
+// checkVerification checks that the verification failed on an invalid input
+// if expected is nil, and otherwise that it succeeded or returned
+// kzg.ErrVerifyOpeningProof as expected.
+func checkVerification(t *testing.T, err error, expected *bool) {
+	t.Helper()
+	switch {
+	case expected == nil:
+		if err == nil || errors.Is(err, kzg.ErrVerifyOpeningProof) {
+			t.Fatal("invalid input accepted")
+		}
+	case *expected:
+		if err != nil {
+			t.Fatal(err)
+		}
+	default:
+		if !errors.Is(err, kzg.ErrVerifyOpeningProof) {
+			t.Fatal("expected a verification failure, got", err)
+		}
+	}
+}
+
+func BenchmarkBlobToKZGCommitment(b *testing.B) {
+	ctx := getContext(b)
+	var blob Blob
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctx.BlobToKZGCommitment(&blob)
+	}
+}
+
+func BenchmarkVerifyBlobKZGProof(b *testing.B) {
+	ctx := getContext(b)
+	var blob Blob
+	blob[SerializedScalarSize-1] = 1
+	commitment, _ := ctx.BlobToKZGCommitment(&blob)
+	proof, _ := ctx.ComputeBlobKZGProof(&blob, commitment)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctx.VerifyBlobKZGProof(&blob, commitment, proof)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package eip4844
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+)
+
+const (
+	// ScalarsPerCell is the number of field elements in a cell,
+	// FIELD_ELEMENTS_PER_CELL.
+	ScalarsPerCell    = 1 << logScalarsPerCell
+	logScalarsPerCell = 6
+	// CellsPerExtBlob is the number of cells of an extended blob,
+	// CELLS_PER_EXT_BLOB.
+	CellsPerExtBlob = scalarsPerExtBlob / ScalarsPerCell
+	// CellSize is the size in bytes of a cell, BYTES_PER_CELL.
+	CellSize = ScalarsPerCell * SerializedScalarSize
+
+	scalarsPerExtBlob  = 2 * ScalarsPerBlob
+	logCellsPerExtBlob = logScalarsPerBlob + 1 - logScalarsPerCell
+)
+
+var ErrInvalidCellIndex = errors.New("cell index out of range")
+
+// Cell is the big endian evaluations of a blob polynomial on a coset of the
+// roots of unity of order ScalarsPerCell, in the order of the extended
+// domain of order 2⋅ScalarsPerBlob in bit-reversed order. The cell i holds
+// the evaluations of index [i⋅ScalarsPerCell, (i+1)⋅ScalarsPerCell) in this
+// order.
+type Cell [CellSize]byte
+
+// ComputeCells returns the cells of the extension of the blob, that is its
+// evaluations on the roots of unity of order 2⋅ScalarsPerBlob.
+//
+// compute_cells
+func (ctx *Context) ComputeCells(blob *Blob) ([]Cell, error) {
+	coefficients, err := ctx.blobCoefficients(blob)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.computeCells(coefficients), nil
+}
+
+// ComputeCellsAndKZGProofs returns the cells of the extension of the blob,
+// and the proofs of the cells with respect to the commitment to the blob. The
+// proofs are computed together with the FK20 method of
+// kzg.CellProvingKey.
+//
+// compute_cells_and_kzg_proofs
+func (ctx *Context) ComputeCellsAndKZGProofs(blob *Blob) ([]Cell, []KZGProof, error) {
+	coefficients, err := ctx.blobCoefficients(blob)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx.cellKeyOnce.Do(func() {
+		ctx.cellKey, ctx.cellKeyErr = kzg.NewCellProvingKey(ctx.pkMonomial, ScalarsPerBlob, scalarsPerExtBlob, ScalarsPerCell)
+	})
+	if ctx.cellKeyErr != nil {
+		return nil, nil, ctx.cellKeyErr
+	}
+	digests, err := ctx.cellKey.ComputeProofs(coefficients)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the coset ωʲ⋅⟨ω^{CellsPerExtBlob}⟩ of the j-th proof is the one of the
+	// cell brp(j)
+	proofs := make([]KZGProof, CellsPerExtBlob)
+	for j := range digests {
+		proofs[reverseCellIndex(uint64(j))] = digests[j].Bytes()
+	}
+	return ctx.computeCells(coefficients), proofs, nil
+}
+
+// VerifyCellKZGProofBatch checks the proofs of the cells, the k-th cell being
+// the cell of index cellIndices[k] of the blob committed to in
+// commitments[k]. The proofs are checked with a single pairing check, on a
+// random linear combination, by kzg.BatchVerifyCellProofs. It returns
+// kzg.ErrVerifyOpeningProof if one of the proofs is invalid.
+//
+// verify_cell_kzg_proof_batch
+func (ctx *Context) VerifyCellKZGProofBatch(commitments []KZGCommitment, cellIndices []uint64, cells []Cell, proofs []KZGProof) error {
+	n := len(cells)
+	if n != len(commitments) || n != len(cellIndices) || n != len(proofs) {
+		return ErrInvalidNbInputs
+	}
+	if n == 0 {
+		return nil
+	}
+	for _, index := range cellIndices {
+		if index >= CellsPerExtBlob {
+			return ErrInvalidCellIndex
+		}
+	}
+
+	// the cell i of the blob is the cell brp(i) of kzg.CellVerifyingKey, its
+	// evaluations being in bit-reversed order
+	digests := make([]kzg.Digest, n)
+	quotients := make([]kzg.Digest, n)
+	indices := make([]uint64, n)
+	values := make([][]fr.Element, n)
+	for k := range cells {
+		var err error
+		if digests[k], err = decodeCommitment(commitments[k]); err != nil {
+			return err
+		}
+		proof, err := decodeProof(proofs[k])
+		if err != nil {
+			return err
+		}
+		quotients[k] = proof.H
+		indices[k] = reverseCellIndex(cellIndices[k])
+		if values[k], err = cellToScalars(&cells[k]); err != nil {
+			return err
+		}
+		fft.BitReverse(values[k])
+	}
+	return kzg.BatchVerifyCellProofs(digests, quotients, indices, values, ctx.cellVk)
+}
+
+// blobCoefficients returns the coefficients of the blob polynomial.
+//
+// polynomial_eval_to_coeff
+func (ctx *Context) blobCoefficients(blob *Blob) ([]fr.Element, error) {
+	p, err := blobToPolynomial(blob)
+	if err != nil {
+		return nil, err
+	}
+	// the blob is in bit-reversed order
+	ctx.blobDomain.FFTInverse(p, fft.DIT)
+	return p, nil
+}
+
+// computeCells returns the evaluations of the polynomial on the extended
+// domain, in bit-reversed order.
+//
+// coset_evals_to_cell
+func (ctx *Context) computeCells(coefficients []fr.Element) []Cell {
+	evaluations := make([]fr.Element, scalarsPerExtBlob)
+	copy(evaluations, coefficients)
+	ctx.extDomain.FFT(evaluations, fft.DIF)
+
+	cells := make([]Cell, CellsPerExtBlob)
+	for i := range evaluations {
+		b := evaluations[i].Bytes()
+		offset := (i % ScalarsPerCell) * SerializedScalarSize
+		copy(cells[i/ScalarsPerCell][offset:offset+SerializedScalarSize], b[:])
+	}
+	return cells
+}
+
+// cellToScalars decodes the evaluations of the cell, which must be canonical.
+//
+// cell_to_coset_evals
+func cellToScalars(cell *Cell) ([]fr.Element, error) {
+	res := make([]fr.Element, ScalarsPerCell)
+	for i := range res {
+		if err := res[i].SetBytesCanonical(cell[i*SerializedScalarSize : (i+1)*SerializedScalarSize]); err != nil {
+			return nil, ErrInvalidScalar
+		}
+	}
+	return res, nil
+}
+
+// reverseCellIndex returns the bit reversal of the cell index.
+func reverseCellIndex(i uint64) uint64 {
+	return bits.Reverse64(i) >> (64 - logCellsPerExtBlob)
+}
@@ -0,0 +1,137 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package eip4844
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+)
+
+func TestComputeCellsAndKZGProofs(t *testing.T) {
+	ctx := getContext(t)
+	type testCase struct {
+		Input struct {
+			Blob string `yaml:"blob"`
+		}
+		Output *[2][]string `yaml:"output"`
+	}
+	referenceTests(t, "compute_cells_and_kzg_proofs", func(t *testing.T, test *testCase) {
+		var blob Blob
+		var cells []Cell
+		var proofs []KZGProof
+		err := decodeHexes(hexInput{test.Input.Blob, blob[:]})
+		if err == nil {
+			cells, proofs, err = ctx.ComputeCellsAndKZGProofs(&blob)
+		}
+		if test.Output == nil {
+			checkOutput(t, err, nil, nil)
+			return
+		}
+		if len(test.Output[0]) != len(cells) || len(test.Output[1]) != len(proofs) {
+			t.Fatal("wrong number of cells or proofs")
+		}
+		for i := range cells {
+			checkOutput(t, err, &test.Output[0][i], cells[i][:])
+			checkOutput(t, err, &test.Output[1][i], proofs[i][:])
+		}
+	})
+}
+
+func TestVerifyCellKZGProofBatch(t *testing.T) {
+	ctx := getContext(t)
+	type testCase struct {
+		Input struct {
+			Commitments []string `yaml:"commitments"`
+			CellIndices []uint64 `yaml:"cell_indices"`
+			Cells       []string `yaml:"cells"`
+			Proofs      []string `yaml:"proofs"`
+		}
+		Output *bool `yaml:"output"`
+	}
+	referenceTests(t, "verify_cell_kzg_proof_batch", func(t *testing.T, test *testCase) {
+		commitments := make([]KZGCommitment, len(test.Input.Commitments))
+		cells := make([]Cell, len(test.Input.Cells))
+		proofs := make([]KZGProof, len(test.Input.Proofs))
+		var inputs []hexInput
+		for i := range commitments {
+			inputs = append(inputs, hexInput{test.Input.Commitments[i], commitments[i][:]})
+		}
+		for i := range cells {
+			inputs = append(inputs, hexInput{test.Input.Cells[i], cells[i][:]})
+		}
+		for i := range proofs {
+			inputs = append(inputs, hexInput{test.Input.Proofs[i], proofs[i][:]})
+		}
+		err := decodeHexes(inputs...)
+		if err == nil {
+			err = ctx.VerifyCellKZGProofBatch(commitments, test.Input.CellIndices, cells, proofs)
+		}
+		checkVerification(t, err, test.Output)
+	})
+}
+
+func TestCellsRoundTrip(t *testing.T) {
+	ctx := getContext(t)
+
+	var blob Blob
+	for i := 0; i < ScalarsPerBlob; i++ {
+		blob[i*SerializedScalarSize+SerializedScalarSize-1] = byte(i)
+		blob[i*SerializedScalarSize+SerializedScalarSize-2] = byte(i >> 8)
+	}
+	commitment, err := ctx.BlobToKZGCommitment(&blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cells, proofs, err := ctx.ComputeCellsAndKZGProofs(&blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the extension starts with the blob, the even roots of unity of order
+	// 2⋅ScalarsPerBlob being the roots of unity of order ScalarsPerBlob
+	for i := 0; i < CellsPerExtBlob/2; i++ {
+		if string(cells[i][:]) != string(blob[i*CellSize:(i+1)*CellSize]) {
+			t.Fatal("the extension does not start with the blob")
+		}
+	}
+	onlyCells, err := ctx.ComputeCells(&blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range cells {
+		if onlyCells[i] != cells[i] {
+			t.Fatal("ComputeCells mismatch")
+		}
+	}
+
+	// verify all the cells at once
+	commitments := make([]KZGCommitment, CellsPerExtBlob)
+	indices := make([]uint64, CellsPerExtBlob)
+	for i := range indices {
+		commitments[i] = commitment
+		indices[i] = uint64(i)
+	}
+	if err := ctx.VerifyCellKZGProofBatch(commitments, indices, cells, proofs); err != nil {
+		t.Fatal(err)
+	}
+
+	// a proof of another cell is rejected
+	proofs[3], proofs[4] = proofs[4], proofs[3]
+	if err := ctx.VerifyCellKZGProofBatch(commitments, indices, cells, proofs); !errors.Is(err, kzg.ErrVerifyOpeningProof) {
+		t.Fatal("wrong proof accepted")
+	}
+}
+
+func BenchmarkComputeCellsAndKZGProofs(b *testing.B) {
+	ctx := getContext(b)
+	var blob Blob
+	blob[SerializedScalarSize-1] = 1
+	ctx.ComputeCellsAndKZGProofs(&blob)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctx.ComputeCellsAndKZGProofs(&blob)
+	}
+}
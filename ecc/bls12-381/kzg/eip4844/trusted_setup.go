@@ -0,0 +1,114 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package eip4844
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/bits"
+	"strings"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var errInvalidTrustedSetup = errors.New("invalid trusted setup")
+
+// trustedSetup is the JSON encoding of the Ethereum trusted setup, as in
+// trusted_setup.json of the consensus specs. The points are hexadecimal
+// compressed points, the Lagrange basis being in natural order.
+type trustedSetup struct {
+	G1Monomial []string `json:"g1_monomial"`
+	G1Lagrange []string `json:"g1_lagrange"`
+	G2Monomial []string `json:"g2_monomial"`
+}
+
+// NewContext reads the trusted setup in JSON from r, as in the
+// trusted_setup.json file of the consensus specs, and returns the context of
+// the blob and cell operations. All the points of the setup are checked to be in the
+// subgroup.
+func NewContext(r io.Reader) (*Context, error) {
+	var setup trustedSetup
+	if err := json.NewDecoder(r).Decode(&setup); err != nil {
+		return nil, err
+	}
+	if len(setup.G1Lagrange) != ScalarsPerBlob || len(setup.G1Monomial) != ScalarsPerBlob || len(setup.G2Monomial) <= ScalarsPerCell {
+		return nil, errInvalidTrustedSetup
+	}
+
+	ctx := new(Context)
+	ctx.pk.G1 = make([]bls12381.G1Affine, ScalarsPerBlob)
+	ctx.pkMonomial.G1 = make([]bls12381.G1Affine, ScalarsPerBlob)
+	var (
+		errOnce sync.Once
+		err     error
+	)
+	parallel.Execute(ScalarsPerBlob, func(start, end int) {
+		for i := start; i < end; i++ {
+			// KZG_SETUP_G1_LAGRANGE is bit-reversed as the blobs
+			j := bits.Reverse64(uint64(i)) >> (64 - logScalarsPerBlob)
+			if _err := decodeHex(&ctx.pk.G1[j], setup.G1Lagrange[i]); _err != nil {
+				errOnce.Do(func() { err = _err })
+			}
+			if _err := decodeHex(&ctx.pkMonomial.G1[i], setup.G1Monomial[i]); _err != nil {
+				errOnce.Do(func() { err = _err })
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	ctx.vk.G1 = ctx.pkMonomial.G1[0]
+	for i := range ctx.vk.G2 {
+		if err := decodeHex(&ctx.vk.G2[i], setup.G2Monomial[i]); err != nil {
+			return nil, err
+		}
+	}
+	ctx.vk.Lines[0] = bls12381.PrecomputeLines(ctx.vk.G2[0])
+	ctx.vk.Lines[1] = bls12381.PrecomputeLines(ctx.vk.G2[1])
+	var g2Cell bls12381.G2Affine
+	if err := decodeHex(&g2Cell, setup.G2Monomial[ScalarsPerCell]); err != nil {
+		return nil, err
+	}
+
+	// ROOTS_OF_UNITY, in bit-reversed order
+	domain := fft.NewDomain(ScalarsPerBlob)
+	ctx.domain[0].SetOne()
+	for i := 1; i < ScalarsPerBlob; i++ {
+		ctx.domain[i].Mul(&ctx.domain[i-1], &domain.Generator)
+	}
+	fft.BitReverse(ctx.domain[:])
+	ctx.cardinalityInv.SetUint64(ScalarsPerBlob).Inverse(&ctx.cardinalityInv)
+
+	// the cells are the cosets of the roots of unity of order ScalarsPerCell
+	// in the extended domain
+	ctx.blobDomain = domain
+	ctx.extDomain = fft.NewDomain(scalarsPerExtBlob)
+	if ctx.cellVk, err = kzg.NewCellVerifyingKey(ctx.pkMonomial, ctx.vk, g2Cell, scalarsPerExtBlob, ScalarsPerCell); err != nil {
+		return nil, err
+	}
+
+	return ctx, nil
+}
+
+// decodeHex sets p from its 0x-prefixed hexadecimal compressed encoding.
+func decodeHex(p interface{ SetBytes([]byte) (int, error) }, s string) error {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return err
+	}
+	n, err := p.SetBytes(b)
+	if err != nil {
+		return err
+	}
+	if n != len(b) {
+		return errInvalidTrustedSetup
+	}
+	return nil
+}
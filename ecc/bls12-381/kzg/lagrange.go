@@ -0,0 +1,198 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"math/big"
+	"math/bits"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// LagrangeProvingKey is the Lagrange basis of the SRS on an evaluation
+// domain, used to commit to and open polynomials given by their evaluations
+// on the domain, without converting them to canonical form.
+//
+// G1[i] is [Lᵢ(τ)]G₁, where Lᵢ is the Lagrange polynomial of the i-th point
+// of the domain. The domain is the coset Shift⋅⟨ω⟩ of the subgroup of order
+// len(G1), its i-th point being Shift⋅ωⁱ, or Shift⋅ω^{brp(i)} if BitReversed
+// is set.
+type LagrangeProvingKey struct {
+	G1          []curve.G1Affine
+	Shift       fr.Element
+	BitReversed bool
+}
+
+// LagrangeOption sets the layout of the domain of a LagrangeProvingKey.
+type LagrangeOption func(*lagrangeConfig)
+
+type lagrangeConfig struct {
+	onCoset     bool
+	bitReversed bool
+}
+
+// OnCoset sets the domain to the coset of the fft.Domain shifted by its
+// FrMultiplicativeGen, as the evaluations of the fft.OnCoset option.
+func OnCoset() LagrangeOption {
+	return func(cfg *lagrangeConfig) {
+		cfg.onCoset = true
+	}
+}
+
+// WithBitReversedOrder sets the order of the points of the domain to the
+// bit-reversed order, as the output of the fft.DIF FFT.
+func WithBitReversedOrder() LagrangeOption {
+	return func(cfg *lagrangeConfig) {
+		cfg.bitReversed = true
+	}
+}
+
+// NewLagrangeProvingKey returns the Lagrange basis of the SRS on the domain,
+// in natural order on the subgroup unless specified otherwise by the options.
+// The SRS must have at least domain.Cardinality points.
+func NewLagrangeProvingKey(pk ProvingKey, domain *fft.Domain, opts ...LagrangeOption) (LagrangeProvingKey, error) {
+	var cfg lagrangeConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	n := int(domain.Cardinality)
+	if n > len(pk.G1) {
+		return LagrangeProvingKey{}, ErrInvalidPolynomialSize
+	}
+
+	res := LagrangeProvingKey{BitReversed: cfg.bitReversed}
+	res.Shift.SetOne()
+	g1 := pk.G1[:n]
+	if cfg.onCoset {
+		// the Lagrange polynomials of the coset are the Lᵢ(X/Shift) of the
+		// subgroup, so that the basis is the one of the points [(τ/Shift)ʲ]G₁
+		res.Shift.Set(&domain.FrMultiplicativeGen)
+		scaled := make([]curve.G1Jac, n)
+		parallel.Execute(n, func(start, end int) {
+			var s fr.Element
+			var sBigInt big.Int
+			s.Exp(domain.FrMultiplicativeGenInv, big.NewInt(int64(start)))
+			for j := start; j < end; j++ {
+				scaled[j].FromAffine(&g1[j])
+				scaled[j].ScalarMultiplication(&scaled[j], s.BigInt(&sBigInt))
+				s.Mul(&s, &domain.FrMultiplicativeGenInv)
+			}
+		})
+		g1 = curve.BatchJacobianToAffineG1(scaled)
+	}
+
+	var err error
+	if res.G1, err = ToLagrangeG1(g1); err != nil {
+		return LagrangeProvingKey{}, err
+	}
+	if cfg.bitReversed {
+		bitReverse(res.G1)
+	}
+	return res, nil
+}
+
+// CommitLagrange commits to the polynomial given by its evaluations p on the
+// domain of the key, in the order of the key. As Commit, it is a
+// multi-exponentiation, and p may be shorter than the domain, the missing
+// evaluations being zero.
+func CommitLagrange(p []fr.Element, pk LagrangeProvingKey, nbTasks ...int) (Digest, error) {
+	return Commit(p, ProvingKey{G1: pk.G1}, nbTasks...)
+}
+
+// OpenLagrange computes an opening proof at point of the polynomial given by
+// its evaluations p on the domain of the key, in the order of the key. The
+// proof is the one of Open on the polynomial in canonical form, and is
+// verified with Verify.
+//
+// The claimed value is computed with the barycentric formula, and the
+// quotient (p - p(point))/(X - point) in evaluation form.
+func OpenLagrange(p []fr.Element, point fr.Element, pk LagrangeProvingKey) (OpeningProof, error) {
+	n := len(pk.G1)
+	if len(p) != n || bits.OnesCount(uint(n)) != 1 {
+		return OpeningProof{}, ErrInvalidPolynomialSize
+	}
+	points, err := pk.points()
+	if err != nil {
+		return OpeningProof{}, err
+	}
+
+	// dᵢ = 1/(point - xᵢ), except at xₘ = point
+	d := make([]fr.Element, n)
+	m := -1
+	for i := range d {
+		d[i].Sub(&point, &points[i])
+		if d[i].IsZero() {
+			m = i
+		}
+	}
+	d = fr.BatchInvert(d)
+
+	var res OpeningProof
+	var tmp fr.Element
+	if m >= 0 {
+		res.ClaimedValue = p[m]
+	} else {
+		// p(point) = (pointⁿ - sⁿ)/(n⋅sⁿ) ∑ pᵢxᵢ/(point - xᵢ), with s = Shift
+		for i := range p {
+			tmp.Mul(&p[i], &points[i]).Mul(&tmp, &d[i])
+			res.ClaimedValue.Add(&res.ClaimedValue, &tmp)
+		}
+		var shiftN, pointN, factor fr.Element
+		exponent := big.NewInt(int64(n))
+		shiftN.Exp(pk.Shift, exponent)
+		pointN.Exp(point, exponent)
+		factor.Sub(&pointN, &shiftN)
+		tmp.SetUint64(uint64(n)).Mul(&tmp, &shiftN).Inverse(&tmp)
+		factor.Mul(&factor, &tmp)
+		res.ClaimedValue.Mul(&res.ClaimedValue, &factor)
+	}
+
+	// qᵢ = (pᵢ - y)/(xᵢ - point), and at xₘ = point, as (X - xₘ) divides
+	// p - y and the xᵢⁿ are equal,
+	// qₘ = ∑_{i≠m} (pᵢ - y)xᵢ/(point(point - xᵢ))
+	q := make([]fr.Element, n)
+	for i := range q {
+		if i == m {
+			continue
+		}
+		q[i].Sub(&p[i], &res.ClaimedValue).Mul(&q[i], &d[i])
+		if m >= 0 {
+			tmp.Mul(&q[i], &points[i])
+			q[m].Add(&q[m], &tmp)
+		}
+		q[i].Neg(&q[i])
+	}
+	if m >= 0 {
+		var pointInv fr.Element
+		pointInv.Inverse(&point)
+		q[m].Mul(&q[m], &pointInv)
+	}
+
+	if res.H, err = CommitLagrange(q, pk); err != nil {
+		return OpeningProof{}, err
+	}
+	return res, nil
+}
+
+// points returns the points of the domain, in the order of the basis.
+func (pk *LagrangeProvingKey) points() ([]fr.Element, error) {
+	generator, err := fr.Generator(uint64(len(pk.G1)))
+	if err != nil {
+		return nil, err
+	}
+	points := make([]fr.Element, len(pk.G1))
+	points[0].Set(&pk.Shift)
+	for i := 1; i < len(points); i++ {
+		points[i].Mul(&points[i-1], &generator)
+	}
+	if pk.BitReversed {
+		bitReverse(points)
+	}
+	return points, nil
+}
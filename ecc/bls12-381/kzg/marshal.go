@@ -157,6 +157,165 @@ func (pk *ProvingKey) UnsafeReadFrom(r io.Reader) (int64, error) {
 	return dec.BytesRead(), nil
 }
 
+// WriteTo writes binary encoding of the LagrangeProvingKey
+func (pk *LagrangeProvingKey) WriteTo(w io.Writer) (int64, error) {
+	return pk.writeTo(w)
+}
+
+// WriteRawTo writes binary encoding of LagrangeProvingKey to w without point compression
+func (pk *LagrangeProvingKey) WriteRawTo(w io.Writer) (int64, error) {
+	return pk.writeTo(w, bls12381.RawEncoding())
+}
+
+func (pk *LagrangeProvingKey) writeTo(w io.Writer, options ...func(*bls12381.Encoder)) (int64, error) {
+	// encode the LagrangeProvingKey
+	enc := bls12381.NewEncoder(w, options...)
+	toEncode := []interface{}{
+		pk.G1,
+		&pk.Shift,
+		pk.BitReversed,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes LagrangeProvingKey data from reader.
+func (pk *LagrangeProvingKey) ReadFrom(r io.Reader) (int64, error) {
+	return pk.readFrom(r)
+}
+
+// UnsafeReadFrom decodes LagrangeProvingKey data from reader without checking
+// that point are in the correct subgroup.
+func (pk *LagrangeProvingKey) UnsafeReadFrom(r io.Reader) (int64, error) {
+	return pk.readFrom(r, bls12381.NoSubgroupChecks())
+}
+
+func (pk *LagrangeProvingKey) readFrom(r io.Reader, options ...func(*bls12381.Decoder)) (int64, error) {
+	// decode the LagrangeProvingKey
+	dec := bls12381.NewDecoder(r, options...)
+	toDecode := []interface{}{
+		&pk.G1,
+		&pk.Shift,
+		&pk.BitReversed,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+	return dec.BytesRead(), nil
+}
+
+// WriteTo writes binary encoding of the VectorProvingKey
+func (pk *VectorProvingKey) WriteTo(w io.Writer) (int64, error) {
+	return pk.writeTo(w)
+}
+
+// WriteRawTo writes binary encoding of VectorProvingKey to w without point compression
+func (pk *VectorProvingKey) WriteRawTo(w io.Writer) (int64, error) {
+	return pk.writeTo(w, bls12381.RawEncoding())
+}
+
+func (pk *VectorProvingKey) writeTo(w io.Writer, options ...func(*bls12381.Encoder)) (int64, error) {
+	// encode the VectorProvingKey
+	enc := bls12381.NewEncoder(w, options...)
+	toEncode := []interface{}{
+		pk.G1,
+		pk.Lagrange,
+		pk.A,
+		pk.U,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes VectorProvingKey data from reader.
+func (pk *VectorProvingKey) ReadFrom(r io.Reader) (int64, error) {
+	return pk.readFrom(r)
+}
+
+// UnsafeReadFrom decodes VectorProvingKey data from reader without checking
+// that point are in the correct subgroup.
+func (pk *VectorProvingKey) UnsafeReadFrom(r io.Reader) (int64, error) {
+	return pk.readFrom(r, bls12381.NoSubgroupChecks())
+}
+
+func (pk *VectorProvingKey) readFrom(r io.Reader, options ...func(*bls12381.Decoder)) (int64, error) {
+	// decode the VectorProvingKey
+	dec := bls12381.NewDecoder(r, options...)
+	toDecode := []interface{}{
+		&pk.G1,
+		&pk.Lagrange,
+		&pk.A,
+		&pk.U,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+	n := len(pk.G1)
+	if len(pk.Lagrange) != n || len(pk.A) != n || len(pk.U) != n {
+		return dec.BytesRead(), ErrInvalidVectorSize
+	}
+	return dec.BytesRead(), pk.precompute()
+}
+
+// WriteTo writes binary encoding of the AggregationVerifyingKey
+func (vk *AggregationVerifyingKey) WriteTo(w io.Writer) (int64, error) {
+	return vk.writeTo(w)
+}
+
+// WriteRawTo writes binary encoding of AggregationVerifyingKey to w without point compression
+func (vk *AggregationVerifyingKey) WriteRawTo(w io.Writer) (int64, error) {
+	return vk.writeTo(w, bls12381.RawEncoding())
+}
+
+func (vk *AggregationVerifyingKey) writeTo(w io.Writer, options ...func(*bls12381.Encoder)) (int64, error) {
+	// encode the AggregationVerifyingKey
+	enc := bls12381.NewEncoder(w, options...)
+	toEncode := []interface{}{
+		vk.G1,
+		vk.G2,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes AggregationVerifyingKey data from reader.
+func (vk *AggregationVerifyingKey) ReadFrom(r io.Reader) (int64, error) {
+	// decode the AggregationVerifyingKey
+	dec := bls12381.NewDecoder(r)
+	toDecode := []interface{}{
+		&vk.G1,
+		&vk.G2,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+	return dec.BytesRead(), nil
+}
+
 // ReadFrom decodes VerifyingKey data from reader.
 func (vk *VerifyingKey) ReadFrom(r io.Reader) (int64, error) {
 	// decode the VerifyingKey
@@ -278,3 +437,192 @@ func (proof *BatchOpeningProof) ReadFrom(r io.Reader) (int64, error) {
 
 	return dec.BytesRead(), nil
 }
+
+// WriteTo writes binary encoding of the HidingProvingKey
+func (pk *HidingProvingKey) WriteTo(w io.Writer) (int64, error) {
+	return pk.writeTo(w)
+}
+
+// WriteRawTo writes binary encoding of HidingProvingKey to w without point compression
+func (pk *HidingProvingKey) WriteRawTo(w io.Writer) (int64, error) {
+	return pk.writeTo(w, bls12381.RawEncoding())
+}
+
+func (pk *HidingProvingKey) writeTo(w io.Writer, options ...func(*bls12381.Encoder)) (int64, error) {
+	n, err := pk.ProvingKey.writeTo(w, options...)
+	if err != nil {
+		return n, err
+	}
+	enc := bls12381.NewEncoder(w, options...)
+	err = enc.Encode(pk.G1Blinding)
+	return n + enc.BytesWritten(), err
+}
+
+// ReadFrom decodes HidingProvingKey data from reader.
+func (pk *HidingProvingKey) ReadFrom(r io.Reader) (int64, error) {
+	return pk.readFrom(r)
+}
+
+// UnsafeReadFrom decodes HidingProvingKey data from reader without checking
+// that point are in the correct subgroup.
+func (pk *HidingProvingKey) UnsafeReadFrom(r io.Reader) (int64, error) {
+	return pk.readFrom(r, bls12381.NoSubgroupChecks())
+}
+
+func (pk *HidingProvingKey) readFrom(r io.Reader, options ...func(*bls12381.Decoder)) (int64, error) {
+	dec := bls12381.NewDecoder(r, options...)
+	if err := dec.Decode(&pk.G1); err != nil {
+		return dec.BytesRead(), err
+	}
+	if err := dec.Decode(&pk.G1Blinding); err != nil {
+		return dec.BytesRead(), err
+	}
+	return dec.BytesRead(), nil
+}
+
+// WriteTo writes binary encoding of the HidingVerifyingKey
+func (vk *HidingVerifyingKey) WriteTo(w io.Writer) (int64, error) {
+	return vk.writeTo(w)
+}
+
+// WriteRawTo writes binary encoding of HidingVerifyingKey to w without point compression
+func (vk *HidingVerifyingKey) WriteRawTo(w io.Writer) (int64, error) {
+	return vk.writeTo(w, bls12381.RawEncoding())
+}
+
+func (vk *HidingVerifyingKey) writeTo(w io.Writer, options ...func(*bls12381.Encoder)) (int64, error) {
+	n, err := vk.VerifyingKey.writeTo(w, options...)
+	if err != nil {
+		return n, err
+	}
+	enc := bls12381.NewEncoder(w, options...)
+	err = enc.Encode(&vk.H)
+	return n + enc.BytesWritten(), err
+}
+
+// ReadFrom decodes HidingVerifyingKey data from reader.
+func (vk *HidingVerifyingKey) ReadFrom(r io.Reader) (int64, error) {
+	n, err := vk.VerifyingKey.ReadFrom(r)
+	if err != nil {
+		return n, err
+	}
+	dec := bls12381.NewDecoder(r)
+	err = dec.Decode(&vk.H)
+	return n + dec.BytesRead(), err
+}
+
+// WriteTo writes binary encoding of the entire HidingSRS
+func (srs *HidingSRS) WriteTo(w io.Writer) (int64, error) {
+	var pn, vn int64
+	var err error
+	if pn, err = srs.Pk.WriteTo(w); err != nil {
+		return pn, err
+	}
+	vn, err = srs.Vk.WriteTo(w)
+	return pn + vn, err
+}
+
+// WriteRawTo writes binary encoding of the entire HidingSRS without point compression
+func (srs *HidingSRS) WriteRawTo(w io.Writer) (int64, error) {
+	var pn, vn int64
+	var err error
+	if pn, err = srs.Pk.WriteRawTo(w); err != nil {
+		return pn, err
+	}
+	vn, err = srs.Vk.WriteRawTo(w)
+	return pn + vn, err
+}
+
+// ReadFrom decodes HidingSRS data from reader.
+func (srs *HidingSRS) ReadFrom(r io.Reader) (int64, error) {
+	var pn, vn int64
+	var err error
+	if pn, err = srs.Pk.ReadFrom(r); err != nil {
+		return pn, err
+	}
+	vn, err = srs.Vk.ReadFrom(r)
+	return pn + vn, err
+}
+
+// UnsafeReadFrom decodes HidingSRS data from reader without sub group checks
+func (srs *HidingSRS) UnsafeReadFrom(r io.Reader) (int64, error) {
+	var pn, vn int64
+	var err error
+	if pn, err = srs.Pk.UnsafeReadFrom(r); err != nil {
+		return pn, err
+	}
+	vn, err = srs.Vk.ReadFrom(r)
+	return pn + vn, err
+}
+
+// WriteTo writes binary encoding of a HidingOpeningProof
+func (proof *HidingOpeningProof) WriteTo(w io.Writer) (int64, error) {
+	enc := bls12381.NewEncoder(w)
+
+	toEncode := []interface{}{
+		&proof.H,
+		&proof.BlindedValue,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes HidingOpeningProof data from reader.
+func (proof *HidingOpeningProof) ReadFrom(r io.Reader) (int64, error) {
+	dec := bls12381.NewDecoder(r)
+
+	toDecode := []interface{}{
+		&proof.H,
+		&proof.BlindedValue,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+
+// WriteTo writes binary encoding of a HidingBatchOpeningProof
+func (proof *HidingBatchOpeningProof) WriteTo(w io.Writer) (int64, error) {
+	enc := bls12381.NewEncoder(w)
+
+	toEncode := []interface{}{
+		&proof.H,
+		proof.BlindedValues,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes HidingBatchOpeningProof data from reader.
+func (proof *HidingBatchOpeningProof) ReadFrom(r io.Reader) (int64, error) {
+	dec := bls12381.NewDecoder(r)
+
+	toDecode := []interface{}{
+		&proof.H,
+		&proof.BlindedValues,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
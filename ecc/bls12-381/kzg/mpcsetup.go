@@ -17,6 +17,7 @@ import (
 	"github.com/consensys/gnark-crypto/utils"
 	"io"
 	"math/big"
+	"slices"
 )
 
 type MpcSetup struct {
@@ -168,3 +169,150 @@ func (s *MpcSetup) Seal(beaconChallenge []byte) SRS {
 
 	return s.srs
 }
+
+// HidingMpcSetup is the MPC setup of a HidingSRS. On top of the powers of τ
+// of MpcSetup, each contribution multiplies the blinding base H = [γ]G₁ by a
+// secret value, and updates its powers of τ.
+type HidingMpcSetup struct {
+	MpcSetup
+	g1Blinding    []curve.G1Affine // [H, [τ]H, [τ²]H, ... ]
+	blindingProof mpcsetup.UpdateProof
+}
+
+func InitializeHidingSetup(N int) HidingMpcSetup {
+	var res HidingMpcSetup
+	res.MpcSetup = InitializeSetup(N)
+	res.g1Blinding = slices.Clone(res.srs.Pk.G1)
+	return res
+}
+
+// WriteTo implements io.WriterTo
+func (s *HidingMpcSetup) WriteTo(w io.Writer) (int64, error) {
+	n, err := s.MpcSetup.WriteTo(w)
+	if err != nil {
+		return n, err
+	}
+	m, err := s.blindingProof.WriteTo(w)
+	n += m
+	if err != nil {
+		return n, err
+	}
+	enc := curve.NewEncoder(w)
+	err = enc.Encode(s.g1Blinding)
+	return n + enc.BytesWritten(), err
+}
+
+// ReadFrom implements io.ReaderFrom
+func (s *HidingMpcSetup) ReadFrom(r io.Reader) (int64, error) {
+	n, err := s.MpcSetup.ReadFrom(r)
+	if err != nil {
+		return n, err
+	}
+	m, err := s.blindingProof.ReadFrom(r)
+	n += m
+	if err != nil {
+		return n, err
+	}
+	dec := curve.NewDecoder(r)
+	err = dec.Decode(&s.g1Blinding)
+	return n + dec.BytesRead(), err
+}
+
+func (s *HidingMpcSetup) hash() []byte {
+	hsh := sha256.New()
+	if _, err := s.WriteTo(hsh); err != nil {
+		panic(err)
+	}
+	return hsh.Sum(nil)
+}
+
+func (s *HidingMpcSetup) Contribute() {
+	s.challenge = s.hash()
+	var contribution, blindingContribution fr.Element
+
+	s.proof = mpcsetup.UpdateValues(&contribution, append([]byte("KZG Setup"), s.challenge...), 0, &s.srs.Vk.G2[1])
+	mpcsetup.UpdateMonomialsG1(s.srs.Pk.G1, &contribution)
+
+	s.blindingProof = mpcsetup.UpdateValues(&blindingContribution, append([]byte("KZG Setup"), s.challenge...), 1, s.g1Blinding)
+	mpcsetup.UpdateMonomialsG1(s.g1Blinding, &contribution)
+}
+
+func (s *HidingMpcSetup) Verify(next *HidingMpcSetup) error {
+	challenge := s.hash()
+	if len(next.challenge) != 0 && !bytes.Equal(next.challenge, challenge) {
+		return errors.New("the challenge does not match the previous contribution's hash")
+	}
+	next.challenge = challenge
+
+	if len(s.srs.Pk.G1) != len(next.srs.Pk.G1) || len(next.g1Blinding) != len(next.srs.Pk.G1) {
+		return errors.New("different domain sizes")
+	}
+
+	if !next.srs.Vk.G2[1].IsInSubGroup() {
+		return errors.New("[x]₂ representation not in subgroup")
+	}
+
+	n := len(next.srs.Pk.G1)
+	wp := utils.NewWorkerPool()
+	defer wp.Stop()
+	fail := make(chan error, 2*wp.NbWorkers())
+
+	wp.Submit(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			if !next.srs.Pk.G1[i].IsInSubGroup() {
+				fail <- fmt.Errorf("[x^%d]₁ representation not in subgroup", i)
+				break
+			}
+			if !next.g1Blinding[i].IsInSubGroup() {
+				fail <- fmt.Errorf("[γx^%d]₁ representation not in subgroup", i)
+				break
+			}
+		}
+	}, n/wp.NbWorkers()+1).Wait()
+	close(fail)
+	for err := range fail {
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := next.proof.Verify(append([]byte("KZG Setup"), challenge...), 0, mpcsetup.ValueUpdate{
+		Previous: s.srs.Vk.G2[1],
+		Next:     next.srs.Vk.G2[1],
+	}); err != nil {
+		return err
+	}
+
+	// the blinding base is only updated by the blinding contribution
+	if err := next.blindingProof.Verify(append([]byte("KZG Setup"), challenge...), 1, mpcsetup.ValueUpdate{
+		Previous: s.g1Blinding[0],
+		Next:     next.g1Blinding[0],
+	}); err != nil {
+		return err
+	}
+
+	return mpcsetup.SameRatioMany(next.srs.Pk.G1, next.g1Blinding, next.srs.Vk.G2[:])
+}
+
+func (s *HidingMpcSetup) Seal(beaconChallenge []byte) HidingSRS {
+	contributions := mpcsetup.BeaconContributions(s.hash(), []byte("KZG Setup"), beaconChallenge, 2)
+	var I, J big.Int
+	contributions[0].BigInt(&I)
+	contributions[1].BigInt(&J)
+	s.srs.Vk.G2[1].ScalarMultiplication(&s.srs.Vk.G2[1], &I)
+	mpcsetup.UpdateMonomialsG1(s.srs.Pk.G1, &contributions[0])
+	for i := range s.g1Blinding {
+		s.g1Blinding[i].ScalarMultiplication(&s.g1Blinding[i], &J)
+	}
+	mpcsetup.UpdateMonomialsG1(s.g1Blinding, &contributions[0])
+
+	s.srs.Vk.Lines[0] = curve.PrecomputeLines(s.srs.Vk.G2[0])
+	s.srs.Vk.Lines[1] = curve.PrecomputeLines(s.srs.Vk.G2[1])
+
+	var res HidingSRS
+	res.Pk.ProvingKey = s.srs.Pk
+	res.Pk.G1Blinding = s.g1Blinding
+	res.Vk.VerifyingKey = s.srs.Vk
+	res.Vk.H = s.g1Blinding[0]
+	return res
+}
@@ -0,0 +1,16 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package pst provides a multilinear KZG commitment scheme, for the
+// multilinear polynomials of the polynomial.MultiLin type.
+//
+// The SRS is the Lagrange basis [eq(τ, b)]G₁ of the multilinear polynomials
+// over the Boolean hypercube b ∈ {0,1}ⁿ, so that a commitment to a polynomial
+// given by its evaluations on the hypercube is a single multi-exponentiation.
+// An opening proof at a point z is made of one commitment per variable, to the
+// quotients qᵢ of f - f(z) = ∑ᵢ (Xᵢ - zᵢ)qᵢ(Xᵢ₊₁, ..., Xₙ).
+//
+// See https://eprint.iacr.org/2011/587.pdf (Papamanthou, Shi, Tamassia).
+package pst
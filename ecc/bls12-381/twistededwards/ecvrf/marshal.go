@@ -0,0 +1,52 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecvrf
+
+import (
+	"io"
+)
+
+// Bytes returns the compressed representation of the public key, as
+// twistededwards.PointAffine.Bytes.
+func (pk *PublicKey) Bytes() []byte {
+	res := pk.Y.Bytes()
+	return res[:]
+}
+
+// SetBytes sets pk from its compressed representation in buf, and checks
+// that it is a valid public key. It returns the number of bytes read.
+func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePoint {
+		return 0, io.ErrShortBuffer
+	}
+	if err := stringToPoint(&pk.Y, buf[:sizePoint]); err != nil {
+		return 0, err
+	}
+	if err := validateKey(&pk.Y); err != nil {
+		return 0, err
+	}
+	return sizePoint, nil
+}
+
+// Bytes returns the secret key from which privKey is derived.
+func (privKey *PrivateKey) Bytes() []byte {
+	res := privKey.secretKey
+	return res[:]
+}
+
+// SetBytes sets privKey from the secret key in buf. It returns the number of
+// bytes read.
+func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < SizeSecretKey {
+		return 0, io.ErrShortBuffer
+	}
+	res, err := NewPrivateKey(buf[:SizeSecretKey])
+	if err != nil {
+		return 0, err
+	}
+	*privKey = *res
+	return SizeSecretKey, nil
+}
@@ -0,0 +1,28 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package frost provides FROST threshold Schnorr signatures on bls12-381's twistededwards curve.
+//
+// A group of maxSigners participants shares a secret key, generated by a
+// trusted dealer (TrustedDealerKeygen) or by a distributed key generation
+// (NewDKGParticipant). Any minSigners of them can sign with two rounds:
+//
+//  1. each signer calls Commit and sends its SigningCommitment to the
+//     coordinator, which builds a SigningPackage with the message;
+//  2. each signer computes its signature share with Sign, and the coordinator
+//     combines the shares with Aggregate.
+//
+// The aggregate signature is an EdDSA signature under the group public key:
+// the challenge is computed as in the eddsa package with the same hash
+// function, so that eddsa.PublicKey.Verify accepts it.
+//
+// The other hash functions of the ciphersuite (binding factors, nonces,
+// proofs of knowledge of the DKG) use BLAKE2b-512 with domain separation.
+//
+// # See also
+//
+// https://datatracker.ietf.org/doc/html/rfc9591
+// https://eprint.iacr.org/2020/852
+package frost
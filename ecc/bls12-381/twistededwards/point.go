@@ -168,6 +168,20 @@ func (p *PointAffine) IsOnCurve() bool {
 	return lhs.Equal(&rhs)
 }
 
+// IsInSubGroup returns true if p is in the prime order subgroup of the curve.
+// It does not check that p is on the curve.
+func (p *PointAffine) IsInSubGroup() bool {
+	initOnce.Do(initCurveParams)
+
+	// the GLV scalar multiplication reduces the scalar modulo the order, use
+	// double-and-add
+	var pExtended PointExtended
+	pExtended.FromAffine(p)
+	pExtended.scalarMulWindowed(&pExtended, &curveParams.Order)
+
+	return pExtended.IsZero()
+}
+
 // Neg sets p to -p1 and returns it
 func (p *PointAffine) Neg(p1 *PointAffine) *PointAffine {
 	p.X.Neg(&p1.X)
@@ -528,7 +542,8 @@ func (p *PointExtended) MixedAdd(p1 *PointExtended, p2 *PointAffine) *PointExten
 	B.Mul(&p2.Y, &p1.Z)
 
 	if p1.X.Equal(&A) && p1.Y.Equal(&B) {
-		p.MixedDouble(p1)
+		// MixedDouble assumes p1.Z = 1
+		p.Double(p1)
 		return p
 	}
 
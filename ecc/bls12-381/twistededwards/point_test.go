@@ -319,6 +319,22 @@ func TestOps(t *testing.T) {
 		genS1,
 	))
 
+	properties.Property("(affine) [s]Base is in the subgroup, [s]Base+(0,-1) is not", prop.ForAll(
+		func(s1 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2, lowOrder PointAffine
+			p1.ScalarMultiplication(&params.Base, &s1)
+			lowOrder.X.SetZero()
+			lowOrder.Y.SetOne().Neg(&lowOrder.Y)
+			p2.Add(&p1, &lowOrder)
+
+			return p1.IsInSubGroup() && p2.IsOnCurve() && !p2.IsInSubGroup()
+		},
+		genS1,
+	))
+
 	properties.Property("(affine) P+0=P", prop.ForAll(
 		func(s1 big.Int) bool {
 
@@ -631,7 +647,7 @@ func TestOps(t *testing.T) {
 			pAffine.ScalarMultiplication(&params.Base, &s)
 
 			p.MixedAdd(&pExtended, &pAffine)
-			p2.MixedDouble(&pExtended)
+			p2.Double(&pExtended)
 
 			return p.Equal(&p2)
 		},
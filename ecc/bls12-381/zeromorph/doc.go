@@ -0,0 +1,25 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package zeromorph provides the Zeromorph commitment scheme for multilinear
+// polynomials, on top of the univariate KZG scheme and its SRS.
+//
+// A multilinear polynomial f in n variables, given by its evaluations on the
+// Boolean hypercube as a polynomial.MultiLin, is committed to as the univariate
+// polynomial Uₙ(f) = ∑_b f[b]Xᵇ of degree less than N = 2ⁿ. The variable Xₖ of
+// weight 2ᵏ in the index b is the one of the coordinate point[n-1-k], as in
+// MultiLin.Evaluate. An opening at a point is reduced to the opening at a
+// random x of a univariate polynomial that must vanish there, which is done
+// with kzg, or batched with other claims with shplonk.
+//
+// The commitments to the quotients of the opening are tied to their degree
+// bounds by a batched quotient q̂ of degree less than N. Its degree is checked
+// with the commitment to Xᴰ⁺¹⁻ᴺq̂, where D+1 is the size of the SRS, against
+// the point [αᴰ⁺¹⁻ᴺ]G₂ of the VerifyingKey, so that an SRS larger than N is
+// sound. This needs an SRS built with NewSRS: the size of the proving key used
+// by the prover must be the one of the SRS.
+//
+// See https://eprint.iacr.org/2023/917.pdf (Kohrita, Towa).
+package zeromorph
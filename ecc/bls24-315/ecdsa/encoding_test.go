@@ -0,0 +1,145 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"golang.org/x/crypto/cryptobyte"
+	cbasn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+func TestEncoding(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS24-315] UnmarshalASN1(MarshalASN1()) should stay the same", prop.ForAll(
+		func(msg []byte) bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			sigBin, _ := privKey.Sign(msg, sha256.New())
+			var sig, end Signature
+			if _, err := sig.SetBytes(sigBin); err != nil {
+				return false
+			}
+			der, err := sig.MarshalASN1()
+			if err != nil || end.UnmarshalASN1(der) != nil {
+				return false
+			}
+			// trailing data and non minimal lengths are rejected
+			if end.UnmarshalASN1(append(der, 0)) == nil {
+				return false
+			}
+			return bytes.Equal(end.Bytes(), sigBin)
+		},
+		gen.SliceOf(gen.UInt8()),
+	))
+
+	properties.Property("[BLS24-315] UnmarshalSEC1(MarshalSEC1()) should stay the same", prop.ForAll(
+		func(compressed bool) bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			buf := privKey.PublicKey.MarshalSEC1(compressed)
+			var end PublicKey
+			if err := end.UnmarshalSEC1(buf); err != nil {
+				return false
+			}
+			// the parity of y is flipped
+			buf[0] ^= 1
+			var neg PublicKey
+			if compressed {
+				if err := neg.UnmarshalSEC1(buf); err != nil || neg.A.X != end.A.X || neg.A.Y == end.A.Y {
+					return false
+				}
+			} else if neg.UnmarshalSEC1(buf) == nil {
+				return false
+			}
+			return end.Equal(&privKey.PublicKey)
+		},
+		gen.Bool(),
+	))
+
+	properties.Property("[BLS24-315] ParsePEMPrivateKey(MarshalPEM()) should stay the same", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			pemBin, err := privKey.MarshalPEM()
+			if err != nil {
+				return false
+			}
+			end, err := ParsePEMPrivateKey(pemBin)
+			if err != nil {
+				return false
+			}
+			der, _ := privKey.MarshalPKCS8()
+			// the public key must match the private key
+			other, _ := GenerateKey(rand.Reader)
+			pub := privKey.PublicKey.MarshalSEC1(false)
+			i := bytes.Index(der, pub)
+			copy(der[i:], other.PublicKey.MarshalSEC1(false))
+			if _, err := ParsePKCS8PrivateKey(der); err == nil {
+				return false
+			}
+			return end.PublicKey.Equal(&privKey.PublicKey) && end.scalar == privKey.scalar
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestEncodingInvalid(t *testing.T) {
+	t.Parallel()
+	var pk PublicKey
+	for _, buf := range [][]byte{
+		nil,
+		{0x00},
+		append([]byte{sec1Compressed}, bytes.Repeat([]byte{0xff}, sizeFp)...),
+		append([]byte{sec1Uncompressed}, make([]byte, 2*sizeFp)...),
+	} {
+		if pk.UnmarshalSEC1(buf) == nil {
+			t.Fatal("invalid SEC 1 encoding accepted")
+		}
+	}
+	var sig Signature
+	// r = 0, s = 1
+	if sig.UnmarshalASN1([]byte{0x30, 0x06, 0x02, 0x01, 0x00, 0x02, 0x01, 0x01}) == nil {
+		t.Fatal("r = 0 accepted")
+	}
+	// r = order, s = 1 and r = 1, s = order
+	one := big.NewInt(1)
+	for _, rs := range [][2]*big.Int{
+		{fr.Modulus(), one},
+		{one, fr.Modulus()},
+	} {
+		var b cryptobyte.Builder
+		b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+			b.AddASN1BigInt(rs[0])
+			b.AddASN1BigInt(rs[1])
+		})
+		if sig.UnmarshalASN1(b.BytesOrPanic()) == nil {
+			t.Fatal("scalar equal to the order accepted")
+		}
+	}
+	// non minimal integer encoding
+	if sig.UnmarshalASN1([]byte{0x30, 0x07, 0x02, 0x02, 0x00, 0x01, 0x02, 0x01, 0x01}) == nil {
+		t.Fatal("non minimal DER accepted")
+	}
+	if _, err := ParsePEMPrivateKey([]byte("not a PEM")); err == nil {
+		t.Fatal("invalid PEM accepted")
+	}
+}
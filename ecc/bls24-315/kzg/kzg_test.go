@@ -154,6 +154,74 @@ func TestCommitLagrange(t *testing.T) {
 	t.Run("mpcsetup", test(mpcGetSrs(t)))
 }
 
+func TestLagrangeProvingKey(t *testing.T) {
+	const size = 32
+	domain := fft.NewDomain(size)
+
+	// random polynomial in canonical form
+	pol := make([]fr.Element, size)
+	for i := range pol {
+		pol[i].MustSetRandom()
+	}
+	digest, err := Commit(pol, testSrs.Pk)
+	require.NoError(t, err)
+
+	test := func(onCoset, bitReversed bool) func(*testing.T) {
+		return func(t *testing.T) {
+			assert := require.New(t)
+
+			var opts []LagrangeOption
+			var fftOpts []fft.Option
+			if onCoset {
+				opts = append(opts, OnCoset())
+				fftOpts = append(fftOpts, fft.OnCoset())
+			}
+			if bitReversed {
+				opts = append(opts, WithBitReversedOrder())
+			}
+			pk, err := NewLagrangeProvingKey(testSrs.Pk, domain, opts...)
+			assert.NoError(err)
+
+			// evaluations in the order of the key, the DIF FFT being in
+			// bit-reversed order
+			evaluations := slices.Clone(pol)
+			domain.FFT(evaluations, fft.DIF, fftOpts...)
+			if !bitReversed {
+				fft.BitReverse(evaluations)
+			}
+
+			digestLagrange, err := CommitLagrange(evaluations, pk)
+			assert.NoError(err)
+			assert.True(digest.Equal(&digestLagrange), "commitment mismatch")
+
+			// opening at a random point and at a point of the domain
+			points, err := pk.points()
+			assert.NoError(err)
+			var point fr.Element
+			point.MustSetRandom()
+			for _, point := range []fr.Element{point, points[5]} {
+				proof, err := OpenLagrange(evaluations, point, pk)
+				assert.NoError(err)
+				expected, err := Open(pol, point, testSrs.Pk)
+				assert.NoError(err)
+				assert.True(expected.ClaimedValue.Equal(&proof.ClaimedValue), "claimed value mismatch")
+				assert.True(expected.H.Equal(&proof.H), "quotient mismatch")
+				assert.NoError(Verify(&digest, &proof, point, testSrs.Vk))
+			}
+
+			t.Run("serialization", testutils.SerializationRoundTrip(&pk))
+			t.Run("serialization raw", testutils.SerializationRoundTripRaw(&pk))
+		}
+	}
+	t.Run("subgroup", test(false, false))
+	t.Run("coset", test(true, false))
+	t.Run("bit-reversed", test(false, true))
+	t.Run("bit-reversed coset", test(true, true))
+
+	_, err = NewLagrangeProvingKey(testSrs.Pk, fft.NewDomain(uint64(2*len(testSrs.Pk.G1))))
+	require.ErrorIs(t, err, ErrInvalidPolynomialSize)
+}
+
 func TestDividePolyByXminusA(t *testing.T) {
 
 	const pSize = 230
@@ -195,6 +263,274 @@ func TestDividePolyByXminusA(t *testing.T) {
 	}
 }
 
+func TestCellProofs(t *testing.T) {
+	const (
+		polynomialSize = 32
+		domainSize     = 64
+	)
+	pol := make([]fr.Element, polynomialSize-3)
+	for i := range pol {
+		pol[i].MustSetRandom()
+	}
+	omega := fft.NewDomain(domainSize).Generator
+
+	for _, cellSize := range []uint64{1, 4, polynomialSize} {
+		ck, err := NewCellProvingKey(testSrs.Pk, polynomialSize, domainSize, cellSize)
+		require.NoError(t, err)
+		proofs, err := ck.ComputeProofs(pol)
+		require.NoError(t, err)
+		require.Equal(t, domainSize/int(cellSize), len(proofs))
+
+		for i := range proofs {
+			// quotient of pol by X^ℓ - a with a = ω^{iℓ}
+			var a fr.Element
+			a.Exp(omega, big.NewInt(int64(i)*int64(cellSize)))
+			quotient := make([]fr.Element, len(pol))
+			for j := len(pol) - 1 - int(cellSize); j >= 0; j-- {
+				quotient[j] = pol[j+int(cellSize)]
+				if j+int(cellSize) < len(quotient) {
+					var t fr.Element
+					t.Mul(&a, &quotient[j+int(cellSize)])
+					quotient[j].Add(&quotient[j], &t)
+				}
+			}
+			expected, err := Commit(quotient, testSrs.Pk)
+			require.NoError(t, err)
+			require.True(t, expected.Equal(&proofs[i]), "cell size %d: proof %d mismatch", cellSize, i)
+		}
+
+		// the proofs on single points are the opening proofs
+		if cellSize == 1 {
+			var point fr.Element
+			point.Exp(omega, big.NewInt(5))
+			proof, err := Open(pol, point, testSrs.Pk)
+			require.NoError(t, err)
+			require.True(t, proof.H.Equal(&proofs[5]))
+		}
+
+		// the cell i holds the evaluations on ωⁱ⋅μʲ, with μ = ω^{N/ℓ}
+		var g2Cell curve.G2Affine
+		var bAlphaCell big.Int
+		bAlphaCell.Exp(bAlpha, big.NewInt(int64(cellSize)), fr.Modulus())
+		g2Cell.ScalarMultiplication(&testSrs.Vk.G2[0], &bAlphaCell)
+		vk, err := NewCellVerifyingKey(testSrs.Pk, testSrs.Vk, g2Cell, domainSize, cellSize)
+		require.NoError(t, err)
+		digest, err := Commit(pol, testSrs.Pk)
+		require.NoError(t, err)
+		var mu fr.Element
+		mu.Exp(omega, big.NewInt(domainSize/int64(cellSize)))
+		cells := make([][]fr.Element, len(proofs))
+		digests := make([]Digest, len(proofs))
+		indices := make([]uint64, len(proofs))
+		for i := range cells {
+			var x fr.Element
+			x.Exp(omega, big.NewInt(int64(i)))
+			cells[i] = make([]fr.Element, cellSize)
+			for j := range cells[i] {
+				cells[i][j] = eval(pol, x)
+				x.Mul(&x, &mu)
+			}
+			digests[i] = digest
+			indices[i] = uint64(i)
+			require.NoError(t, VerifyCellProof(&digest, &proofs[i], uint64(i), cells[i], vk), "cell size %d: cell %d", cellSize, i)
+		}
+		require.NoError(t, BatchVerifyCellProofs(digests, proofs, indices, cells, vk))
+
+		// the cells of the same index of two polynomials are batched together
+		other := make([]fr.Element, polynomialSize)
+		for i := range other {
+			other[i].MustSetRandom()
+		}
+		otherDigest, err := Commit(other, testSrs.Pk)
+		require.NoError(t, err)
+		otherProofs, err := ck.ComputeProofs(other)
+		require.NoError(t, err)
+		otherCell := make([]fr.Element, cellSize)
+		var x fr.Element
+		x.Exp(omega, big.NewInt(1))
+		for j := range otherCell {
+			otherCell[j] = eval(other, x)
+			x.Mul(&x, &mu)
+		}
+		require.NoError(t, BatchVerifyCellProofs(
+			append(slices.Clone(digests), otherDigest),
+			append(slices.Clone(proofs), otherProofs[1]),
+			append(slices.Clone(indices), 1),
+			append(slices.Clone(cells), otherCell),
+			vk,
+		))
+
+		// tampered cells
+		last := cellSize - 1
+		cells[1][last].Double(&cells[1][last])
+		require.ErrorIs(t, VerifyCellProof(&digest, &proofs[1], 1, cells[1], vk), ErrVerifyOpeningProof)
+		require.ErrorIs(t, BatchVerifyCellProofs(digests, proofs, indices, cells, vk), ErrVerifyOpeningProof)
+		cells[1][last].Halve()
+		require.NoError(t, VerifyCellProof(&digest, &proofs[1], 1, cells[1], vk))
+		require.ErrorIs(t, VerifyCellProof(&digest, &proofs[1], 0, cells[1], vk), ErrVerifyOpeningProof)
+		if cellSize < polynomialSize {
+			// otherwise all the quotients are 0
+			require.ErrorIs(t, VerifyCellProof(&digest, &proofs[0], 1, cells[1], vk), ErrVerifyOpeningProof)
+		}
+		require.ErrorIs(t, VerifyCellProof(&otherDigest, &proofs[1], 1, cells[1], vk), ErrVerifyOpeningProof)
+		indices[0], indices[1] = indices[1], indices[0]
+		require.ErrorIs(t, BatchVerifyCellProofs(digests, proofs, indices, cells, vk), ErrVerifyOpeningProof)
+		indices[0], indices[1] = indices[1], indices[0]
+
+		// invalid inputs
+		require.ErrorIs(t, VerifyCellProof(&digest, &proofs[1], uint64(len(proofs)), cells[1], vk), ErrInvalidCell)
+		require.ErrorIs(t, VerifyCellProof(&digest, &proofs[1], 1, append(cells[1], cells[1][0]), vk), ErrInvalidCell)
+		require.ErrorIs(t, BatchVerifyCellProofs(digests[1:], proofs, indices, cells, vk), ErrInvalidNbDigests)
+		require.ErrorIs(t, BatchVerifyCellProofs(nil, nil, nil, nil, vk), ErrZeroNbDigests)
+	}
+
+	_, err := NewCellProvingKey(testSrs.Pk, polynomialSize, domainSize, 3)
+	require.ErrorIs(t, err, ErrInvalidCellParameters)
+	_, err = NewCellProvingKey(testSrs.Pk, domainSize, polynomialSize, 1)
+	require.ErrorIs(t, err, ErrInvalidCellParameters)
+}
+
+func TestVectorCommitment(t *testing.T) {
+	const size = 16
+	pk, err := NewVectorProvingKey(testSrs.Pk, size)
+	require.NoError(t, err)
+
+	v := make([]fr.Element, size)
+	for i := range v {
+		v[i].MustSetRandom()
+	}
+	domain := fft.NewDomain(size)
+
+	// the commitment is the one of the polynomial in canonical form
+	p := slices.Clone(v)
+	domain.FFTInverse(p, fft.DIF)
+	fft.BitReverse(p)
+	expected, err := Commit(p, testSrs.Pk)
+	require.NoError(t, err)
+	commitment, err := pk.Commit(v)
+	require.NoError(t, err)
+	require.True(t, expected.Equal(&commitment))
+
+	// all the proofs at once are the proofs of each position
+	proofs, err := pk.OpenAll(v)
+	require.NoError(t, err)
+	for i := range v {
+		proof, err := pk.Open(v, i)
+		require.NoError(t, err)
+		require.True(t, proof.Equal(&proofs[i]), "proof %d", i)
+		require.NoError(t, VerifyVectorProof(&commitment, &proof, i, v[i], size, testSrs.Vk))
+	}
+	require.Error(t, VerifyVectorProof(&commitment, &proofs[0], 1, v[1], size, testSrs.Vk))
+
+	// updates of the commitment and of the proofs match the recomputations
+	var delta fr.Element
+	delta.MustSetRandom()
+	const i = 5
+	commitment, err = pk.UpdateCommitment(&commitment, i, delta)
+	require.NoError(t, err)
+	for j := range proofs {
+		proofs[j], err = pk.UpdateProof(&proofs[j], j, i, delta)
+		require.NoError(t, err)
+	}
+	v[i].Add(&v[i], &delta)
+	expected, err = pk.Commit(v)
+	require.NoError(t, err)
+	require.True(t, expected.Equal(&commitment))
+	expectedProofs, err := pk.OpenAll(v)
+	require.NoError(t, err)
+	for j := range proofs {
+		require.True(t, expectedProofs[j].Equal(&proofs[j]), "updated proof %d", j)
+	}
+
+	// aggregation of a subvector
+	positions := []int{1, 4, 5, 11}
+	values := make([]fr.Element, len(positions))
+	selected := make([]Digest, len(positions))
+	for k, j := range positions {
+		values[k] = v[j]
+		selected[k] = proofs[j]
+	}
+	aggregated, err := AggregateVectorProofs(selected, positions, size)
+	require.NoError(t, err)
+	avk, err := NewAggregationVerifyingKey(uint64(len(positions)), bAlpha)
+	require.NoError(t, err)
+	require.NoError(t, VerifyAggregatedVectorProof(&commitment, &aggregated, positions, values, size, avk))
+	values[2].Add(&values[2], &delta)
+	require.ErrorIs(t, VerifyAggregatedVectorProof(&commitment, &aggregated, positions, values, size, avk), ErrVerifyAggregatedProof)
+	require.ErrorIs(t, VerifyAggregatedVectorProof(&commitment, &aggregated, append(positions, 0), append(values, v[0]), size, avk), ErrAggregationKeyTooShort)
+	_, err = AggregateVectorProofs(selected, []int{1, 4, 4, 11}, size)
+	require.ErrorIs(t, err, ErrInvalidPosition)
+
+	_, err = NewVectorProvingKey(testSrs.Pk, 12)
+	require.ErrorIs(t, err, ErrInvalidVectorSize)
+}
+
+func TestCommittedVector(t *testing.T) {
+	const size = 8
+	pk, err := NewVectorProvingKey(testSrs.Pk, size)
+	require.NoError(t, err)
+
+	v := make([]fr.Element, size)
+	for i := range v {
+		v[i].MustSetRandom()
+	}
+	cv, err := NewCommittedVector(v, pk)
+	require.NoError(t, err)
+	_, err = cv.Proof(2)
+	require.NoError(t, err)
+
+	for round := 0; round < 2; round++ {
+		for _, i := range []int{2, 3, 7} {
+			var value fr.Element
+			value.MustSetRandom()
+			require.NoError(t, cv.Set(i, value))
+			v[i] = value
+		}
+		commitment := cv.Commitment()
+		expected, err := pk.Commit(v)
+		require.NoError(t, err)
+		require.True(t, expected.Equal(&commitment))
+		for i := range v {
+			value := cv.Value(i)
+			require.True(t, value.Equal(&v[i]))
+			proof, err := cv.Proof(i)
+			require.NoError(t, err)
+			require.NoError(t, VerifyVectorProof(&commitment, &proof, i, v[i], size, testSrs.Vk), "round %d position %d", round, i)
+		}
+		require.NoError(t, cv.ComputeAllProofs())
+	}
+	require.ErrorIs(t, cv.Set(size, fr.One()), ErrInvalidPosition)
+}
+
+func TestSerializationVectorKeys(t *testing.T) {
+	pk, err := NewVectorProvingKey(testSrs.Pk, 8)
+	require.NoError(t, err)
+	avk, err := NewAggregationVerifyingKey(3, bAlpha)
+	require.NoError(t, err)
+
+	v := make([]fr.Element, 8)
+	for i := range v {
+		v[i].MustSetRandom()
+	}
+
+	t.Run("proving key", testutils.SerializationRoundTrip(pk))
+	t.Run("proving key raw", testutils.SerializationRoundTripRaw(pk))
+	t.Run("verifying key", testutils.SerializationRoundTrip(&avk))
+	t.Run("verifying key raw", testutils.SerializationRoundTripRaw(&avk))
+
+	var buf bytes.Buffer
+	_, err = pk.WriteTo(&buf)
+	require.NoError(t, err)
+	var decoded VectorProvingKey
+	_, err = decoded.ReadFrom(&buf)
+	require.NoError(t, err)
+	expected, err := pk.OpenAll(v)
+	require.NoError(t, err)
+	proofs, err := decoded.OpenAll(v)
+	require.NoError(t, err)
+	require.Equal(t, expected, proofs)
+}
+
 func TestSerializationSRS(t *testing.T) {
 	// create a SRS
 	srs, err := NewSRS(64, new(big.Int).SetInt64(42))
@@ -493,6 +829,121 @@ func TestBatchVerifyMultiPoints(t *testing.T) {
 	t.Run("mpcsetup", test(mpcGetSrs(t)))
 }
 
+func mpcGenerateHidingSrs(t *testing.T) (srs *HidingSRS, phases [][]byte) {
+	const nbPhases = 2
+	p := InitializeHidingSetup(srsSize)
+
+	phases = make([][]byte, nbPhases)
+
+	var bb bytes.Buffer
+	for i := range phases {
+		p.Contribute()
+		bb.Reset()
+		n, err := p.WriteTo(&bb)
+		require.NoError(t, err)
+		require.Equal(t, n, int64(bb.Len()))
+		phases[i] = slices.Clone(bb.Bytes())
+	}
+
+	res := p.Seal([]byte("test"))
+	return &res, phases
+}
+
+func TestHidingMpcSetup(t *testing.T) {
+	_, phases := mpcGenerateHidingSrs(t)
+
+	prev := InitializeHidingSetup(srsSize)
+	for i := range phases {
+		var p HidingMpcSetup
+		n, err := p.ReadFrom(bytes.NewReader(phases[i]))
+		require.NoError(t, err)
+		require.Equal(t, int64(len(phases[i])), n)
+
+		require.NoError(t, prev.Verify(&p))
+		prev = p
+	}
+
+	// a contribution that does not update the blinding base consistently is rejected
+	var p HidingMpcSetup
+	_, err := p.ReadFrom(bytes.NewReader(phases[0]))
+	require.NoError(t, err)
+	p.g1Blinding[1] = p.g1Blinding[2]
+	prev = InitializeHidingSetup(srsSize)
+	require.Error(t, prev.Verify(&p))
+}
+
+func TestHiding(t *testing.T) {
+	test := func(srs *HidingSRS) func(*testing.T) {
+		return func(t *testing.T) {
+			assert := require.New(t)
+
+			const nbPolynomials = 3
+			polynomials := make([][]fr.Element, nbPolynomials)
+			blindings := make([][]fr.Element, nbPolynomials)
+			digests := make([]Digest, nbPolynomials)
+			for i := range polynomials {
+				polynomials[i] = make([]fr.Element, 60+i)
+				for j := range polynomials[i] {
+					polynomials[i][j].MustSetRandom()
+				}
+				var err error
+				digests[i], blindings[i], err = CommitHiding(polynomials[i], srs.Pk)
+				assert.NoError(err)
+			}
+
+			// the commitments are blinded
+			digest, _, err := CommitHiding(polynomials[0], srs.Pk)
+			assert.NoError(err)
+			assert.False(digest.Equal(&digests[0]), "commitment is not hiding")
+
+			var point fr.Element
+			point.MustSetRandom()
+
+			// single opening
+			proof, err := OpenHiding(polynomials[0], blindings[0], point, srs.Pk)
+			assert.NoError(err)
+			assert.NoError(VerifyHiding(&digests[0], &proof, point, srs.Vk))
+			assert.NoError(VerifyBlindedValue(&proof.BlindedValue, eval(polynomials[0], point), eval(blindings[0], point), srs.Vk))
+			assert.ErrorIs(VerifyBlindedValue(&proof.BlindedValue, eval(polynomials[1], point), eval(blindings[0], point), srs.Vk), ErrVerifyOpeningProof)
+			var otherPoint fr.Element
+			otherPoint.MustSetRandom()
+			assert.ErrorIs(VerifyHiding(&digests[0], &proof, otherPoint, srs.Vk), ErrVerifyOpeningProof)
+			assert.ErrorIs(VerifyHiding(&digests[1], &proof, point, srs.Vk), ErrVerifyOpeningProof)
+
+			// batch opening at a single point
+			batchProof, err := BatchOpenSinglePointHiding(polynomials, blindings, digests, point, sha256.New(), srs.Pk, []byte("transcript"))
+			assert.NoError(err)
+			assert.NoError(BatchVerifySinglePointHiding(digests, &batchProof, point, sha256.New(), srs.Vk, []byte("transcript")))
+			assert.NoError(VerifyBlindedValue(&batchProof.BlindedValues[2], eval(polynomials[2], point), eval(blindings[2], point), srs.Vk))
+			batchProof.BlindedValues[0], batchProof.BlindedValues[1] = batchProof.BlindedValues[1], batchProof.BlindedValues[0]
+			assert.ErrorIs(BatchVerifySinglePointHiding(digests, &batchProof, point, sha256.New(), srs.Vk, []byte("transcript")), ErrVerifyOpeningProof)
+
+			// batch verification at different points
+			proofs := make([]HidingOpeningProof, nbPolynomials)
+			points := make([]fr.Element, nbPolynomials)
+			for i := range proofs {
+				points[i].MustSetRandom()
+				proofs[i], err = OpenHiding(polynomials[i], blindings[i], points[i], srs.Pk)
+				assert.NoError(err)
+			}
+			assert.NoError(BatchVerifyMultiPointsHiding(digests, proofs, points, srs.Vk))
+			proofs[1].BlindedValue = proofs[2].BlindedValue
+			assert.ErrorIs(BatchVerifyMultiPointsHiding(digests, proofs, points, srs.Vk), ErrVerifyOpeningProof)
+
+			t.Run("serialization proof", testutils.SerializationRoundTrip(&proof))
+			t.Run("serialization batch proof", testutils.SerializationRoundTrip(&batchProof))
+		}
+	}
+	srs, err := NewHidingSRS(ecc.NextPowerOfTwo(srsSize), bAlpha, big.NewInt(43))
+	require.NoError(t, err)
+	t.Run("unsafe", test(srs))
+	mpcSrs, _ := mpcGenerateHidingSrs(t)
+	t.Run("mpcsetup", test(mpcSrs))
+
+	t.Run("serialization srs", testutils.SerializationRoundTrip(srs))
+	t.Run("serialization srs raw", testutils.SerializationRoundTripRaw(srs))
+}
+
 func TestUnsafeToBytesTruncating(t *testing.T) {
 	assert := require.New(t)
 	srs, err := NewSRS(ecc.NextPowerOfTwo(1<<10), big.NewInt(-1))
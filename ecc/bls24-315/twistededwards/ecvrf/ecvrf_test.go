@@ -0,0 +1,156 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecvrf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	_ "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/mimc"
+	_ "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/poseidon2"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/twistededwards"
+	"github.com/consensys/gnark-crypto/hash"
+)
+
+func TestECVRF(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var frAlpha fr.Element
+	frAlpha.MustSetRandom()
+	alpha := frAlpha.Marshal()
+	frAlpha.MustSetRandom()
+	otherAlpha := frAlpha.Marshal()
+
+	for name, opts := range map[string][]Option{
+		"SHA512":    nil,
+		"MiMC":      {WithSNARKHash(hash.MIMC_BLS24_315.New())},
+		"Poseidon2": {WithSNARKHash(hash.POSEIDON2_BLS24_315.New())},
+	} {
+		pi, err := Prove(privKey, alpha, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		beta, err := Verify(&privKey.PublicKey, alpha, pi, opts...)
+		if err != nil {
+			t.Fatalf("%s: valid proof rejected: %v", name, err)
+		}
+		if beta2, err := ProofToHash(pi, opts...); err != nil || !bytes.Equal(beta, beta2) {
+			t.Fatalf("%s: Verify and ProofToHash disagree", name)
+		}
+
+		// the proof is deterministic, the output depends on the input
+		if pi2, _ := Prove(privKey, alpha, opts...); !bytes.Equal(pi, pi2) {
+			t.Fatalf("%s: Prove is not deterministic", name)
+		}
+		pi2, _ := Prove(privKey, otherAlpha, opts...)
+		if beta2, _ := ProofToHash(pi2, opts...); bytes.Equal(beta, beta2) {
+			t.Fatalf("%s: same output for different inputs", name)
+		}
+
+		// wrong input or public key
+		if _, err := Verify(&privKey.PublicKey, otherAlpha, pi, opts...); err != ErrInvalidProof {
+			t.Fatalf("%s: proof accepted for another input", name)
+		}
+		if _, err := Verify(&otherKey.PublicKey, alpha, pi, opts...); err != ErrInvalidProof {
+			t.Fatalf("%s: proof accepted for another public key", name)
+		}
+
+		// tampered Gamma, c and s
+		for _, i := range []int{0, sizePoint, sizePoint + sizeChallenge} {
+			tampered := bytes.Clone(pi)
+			tampered[i] ^= 1
+			if _, err := Verify(&privKey.PublicKey, alpha, tampered, opts...); err == nil {
+				t.Fatalf("%s: tampered proof accepted", name)
+			}
+		}
+
+		// s >= q
+		curveParams := twistededwards.GetEdwardsCurve()
+		tampered := bytes.Clone(pi)
+		curveParams.Order.FillBytes(tampered[sizePoint+sizeChallenge:])
+		if _, err := Verify(&privKey.PublicKey, alpha, tampered, opts...); err != ErrInvalidProof {
+			t.Fatalf("%s: non-reduced s accepted", name)
+		}
+	}
+
+	// the ciphersuites are domain separated
+	pi, _ := Prove(privKey, alpha)
+	if _, err := Verify(&privKey.PublicKey, alpha, pi, WithSNARKHash(hash.MIMC_BLS24_315.New())); err != ErrInvalidProof {
+		t.Fatal("proof accepted in another ciphersuite")
+	}
+	if _, err := Prove(privKey, alpha, WithSNARKHash(sha512.New())); err != errHashSize {
+		t.Fatal("the SNARK-friendly ciphersuite needs a hash function over fr")
+	}
+}
+
+func TestPublicKeyValidation(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alpha := []byte("leader election")
+	pi, err := Prove(privKey, alpha)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a public key out of the prime order subgroup is rejected
+	var pk PublicKey
+	var lowOrder twistededwards.PointAffine
+	lowOrder.X.SetZero()
+	lowOrder.Y.SetOne().Neg(&lowOrder.Y)
+	pk.Y.Add(&privKey.PublicKey.Y, &lowOrder)
+	if _, err := Verify(&pk, alpha, pi); err != ErrInvalidPublicKey {
+		t.Fatal("public key out of the subgroup accepted")
+	}
+	if _, err := pk.SetBytes(pk.Bytes()); err != ErrInvalidPublicKey {
+		t.Fatal("public key out of the subgroup deserialized")
+	}
+
+	// serialization round trip
+	if _, err := pk.SetBytes(privKey.PublicKey.Bytes()); err != nil || !pk.Y.Equal(&privKey.PublicKey.Y) {
+		t.Fatal("public key serialization round trip failed")
+	}
+	var sk PrivateKey
+	if _, err := sk.SetBytes(privKey.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if pi2, _ := Prove(&sk, alpha); !bytes.Equal(pi, pi2) {
+		t.Fatal("private key serialization round trip failed")
+	}
+}
+
+func BenchmarkProve(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	alpha := []byte("leader election")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Prove(privKey, alpha)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	alpha := []byte("leader election")
+	pi, _ := Prove(privKey, alpha)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Verify(&privKey.PublicKey, alpha, pi)
+	}
+}
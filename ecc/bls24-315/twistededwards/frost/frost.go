@@ -0,0 +1,433 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package frost
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/twistededwards"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/twistededwards/eddsa"
+	"golang.org/x/crypto/blake2b"
+)
+
+// contextString is the domain separation tag of the hash functions of the
+// ciphersuite.
+const contextString = "FROST-BLS24-315-TWISTEDEDWARDS-BLAKE2B512-v1"
+
+// Identifier identifies a participant. It must be non-zero.
+type Identifier uint32
+
+var (
+	ErrInvalidIdentifier   = errors.New("identifiers must be non-zero")
+	ErrDuplicateIdentifier = errors.New("duplicate identifier")
+	ErrInvalidThreshold    = errors.New("invalid number of signers")
+	ErrInvalidPoint        = errors.New("point is the identity, not on the curve or not in the prime order subgroup")
+	ErrUsedNonces          = errors.New("signing nonces were already used")
+	ErrCommitmentMismatch  = errors.New("signing commitment does not match the signing nonces")
+	ErrMissingShare        = errors.New("missing signature share")
+	ErrInvalidSignature    = errors.New("invalid aggregate signature")
+	errHashNeeded          = errors.New("hFunc cannot be nil. We need a hash for Fiat-Shamir")
+	errIdentityCommitment  = errors.New("group commitment is the identity")
+)
+
+// InvalidShareError identifies the participant who sent an invalid signature
+// share, or an invalid secret share during the distributed key generation.
+type InvalidShareError struct {
+	ID Identifier
+}
+
+func (e *InvalidShareError) Error() string {
+	return fmt.Sprintf("share of participant %d is invalid", e.ID)
+}
+
+// KeyPackage is the key material of a participant.
+type KeyPackage struct {
+	ID             Identifier
+	SecretShare    big.Int
+	PublicShare    twistededwards.PointAffine // SecretShare*Base
+	GroupPublicKey twistededwards.PointAffine
+	MinSigners     int
+}
+
+// PublicKeyPackage is the public key material of the group, used to verify
+// signature shares and aggregate signatures.
+type PublicKeyPackage struct {
+	PublicShares   map[Identifier]twistededwards.PointAffine
+	GroupPublicKey twistededwards.PointAffine
+}
+
+// PublicKey returns the group public key as an EdDSA public key.
+func (p *PublicKeyPackage) PublicKey() *eddsa.PublicKey {
+	var pub eddsa.PublicKey
+	pub.A.Set(&p.GroupPublicKey)
+	return &pub
+}
+
+// SigningCommitment is the commitment to the nonces of a signer, sent to the
+// coordinator in the first round.
+type SigningCommitment struct {
+	ID      Identifier
+	Hiding  twistededwards.PointAffine
+	Binding twistededwards.PointAffine
+}
+
+// SigningNonces are the secret nonces of a signer. They must be used for a
+// single signature.
+type SigningNonces struct {
+	hiding, binding big.Int
+	commitment      SigningCommitment
+	used            bool
+}
+
+// SigningPackage is built by the coordinator from the commitments of the
+// signers and the message, and sent to the signers for the second round.
+type SigningPackage struct {
+	Commitments []SigningCommitment // sorted by identifier
+	Message     []byte
+}
+
+// Commit generates the nonces of a signer and their commitment. The nonces
+// are derived from fresh randomness and the secret share.
+//
+// RFC 9591, Section 5.1
+func Commit(kp *KeyPackage, rand io.Reader) (*SigningNonces, *SigningCommitment, error) {
+	curveParams := twistededwards.GetEdwardsCurve()
+	var nonces SigningNonces
+	if err := nonceGenerate(&nonces.hiding, &kp.SecretShare, rand); err != nil {
+		return nil, nil, err
+	}
+	if err := nonceGenerate(&nonces.binding, &kp.SecretShare, rand); err != nil {
+		return nil, nil, err
+	}
+	nonces.commitment.ID = kp.ID
+	nonces.commitment.Hiding.ScalarMultiplication(&curveParams.Base, &nonces.hiding)
+	nonces.commitment.Binding.ScalarMultiplication(&curveParams.Base, &nonces.binding)
+	commitment := nonces.commitment
+	return &nonces, &commitment, nil
+}
+
+// NewSigningPackage checks the commitments of the signers and returns the
+// signing package of the message.
+func NewSigningPackage(commitments []SigningCommitment, message []byte) (*SigningPackage, error) {
+	sp := &SigningPackage{
+		Commitments: make([]SigningCommitment, len(commitments)),
+		Message:     message,
+	}
+	copy(sp.Commitments, commitments)
+	sort.Slice(sp.Commitments, func(i, j int) bool {
+		return sp.Commitments[i].ID < sp.Commitments[j].ID
+	})
+	for i := range sp.Commitments {
+		c := &sp.Commitments[i]
+		if c.ID == 0 {
+			return nil, ErrInvalidIdentifier
+		}
+		if i > 0 && c.ID == sp.Commitments[i-1].ID {
+			return nil, ErrDuplicateIdentifier
+		}
+		if err := checkPoint(&c.Hiding); err != nil {
+			return nil, err
+		}
+		if err := checkPoint(&c.Binding); err != nil {
+			return nil, err
+		}
+	}
+	return sp, nil
+}
+
+// Sign computes the signature share of the signer kp in the second round.
+// The nonces are erased and cannot be reused. hFunc is the hash function of
+// the eddsa challenge.
+//
+// RFC 9591, Section 5.2
+func Sign(sp *SigningPackage, nonces *SigningNonces, kp *KeyPackage, hFunc hash.Hash) (*big.Int, error) {
+	if hFunc == nil {
+		return nil, errHashNeeded
+	}
+	if nonces.used {
+		return nil, ErrUsedNonces
+	}
+	if len(sp.Commitments) < kp.MinSigners {
+		return nil, ErrInvalidThreshold
+	}
+	i := sp.index(kp.ID)
+	if i < 0 || !sp.Commitments[i].Hiding.Equal(&nonces.commitment.Hiding) ||
+		!sp.Commitments[i].Binding.Equal(&nonces.commitment.Binding) {
+		return nil, ErrCommitmentMismatch
+	}
+
+	curveParams := twistededwards.GetEdwardsCurve()
+	order := &curveParams.Order
+	rho, _, c, err := sp.values(&kp.GroupPublicKey, hFunc)
+	if err != nil {
+		return nil, err
+	}
+	lambda := sp.lagrange(kp.ID)
+
+	// z = d + e*rho + lambda*s*c
+	var z big.Int
+	z.Mul(lambda, &kp.SecretShare).
+		Mul(&z, c).
+		Add(&z, &nonces.hiding)
+	var t big.Int
+	t.Mul(&nonces.binding, rho[i])
+	z.Add(&z, &t).Mod(&z, order)
+
+	nonces.hiding.SetUint64(0)
+	nonces.binding.SetUint64(0)
+	nonces.used = true
+	return &z, nil
+}
+
+// VerifySignatureShare checks the signature share of the participant id.
+//
+// RFC 9591, Section 5.4
+func VerifySignatureShare(sp *SigningPackage, pkp *PublicKeyPackage, id Identifier, share *big.Int, hFunc hash.Hash) error {
+	if hFunc == nil {
+		return errHashNeeded
+	}
+	i := sp.index(id)
+	publicShare, ok := pkp.PublicShares[id]
+	if i < 0 || !ok {
+		return ErrInvalidIdentifier
+	}
+	rho, _, c, err := sp.values(&pkp.GroupPublicKey, hFunc)
+	if err != nil {
+		return err
+	}
+	return sp.verifyShare(i, &publicShare, share, rho[i], c)
+}
+
+// Aggregate combines the signature shares into an EdDSA signature of the
+// message under the group public key. If the signature is invalid, an
+// *InvalidShareError identifies a participant who sent an invalid share.
+//
+// RFC 9591, Section 5.3
+func Aggregate(sp *SigningPackage, shares map[Identifier]*big.Int, pkp *PublicKeyPackage, hFunc hash.Hash) ([]byte, error) {
+	if hFunc == nil {
+		return nil, errHashNeeded
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+	order := &curveParams.Order
+	rho, R, c, err := sp.values(&pkp.GroupPublicKey, hFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	var z big.Int
+	for _, commitment := range sp.Commitments {
+		share, ok := shares[commitment.ID]
+		if !ok || share == nil {
+			return nil, ErrMissingShare
+		}
+		z.Add(&z, share)
+	}
+	z.Mod(&z, order)
+
+	var sig eddsa.Signature
+	sig.R.Set(R)
+	z.FillBytes(sig.S[:])
+	sigBin := sig.Bytes()
+
+	if ok, err := pkp.PublicKey().Verify(sigBin, sp.Message, hFunc); err == nil && ok {
+		return sigBin, nil
+	}
+	// find the invalid share
+	for i, commitment := range sp.Commitments {
+		publicShare, ok := pkp.PublicShares[commitment.ID]
+		if !ok || sp.verifyShare(i, &publicShare, shares[commitment.ID], rho[i], c) != nil {
+			return nil, &InvalidShareError{ID: commitment.ID}
+		}
+	}
+	return nil, ErrInvalidSignature
+}
+
+// index returns the position of the commitment of the participant id, or -1.
+func (sp *SigningPackage) index(id Identifier) int {
+	i := sort.Search(len(sp.Commitments), func(i int) bool {
+		return sp.Commitments[i].ID >= id
+	})
+	if i < len(sp.Commitments) && sp.Commitments[i].ID == id {
+		return i
+	}
+	return -1
+}
+
+// values returns the binding factors of the signers, the group commitment
+// R and the challenge c.
+//
+// RFC 9591, Sections 4.4 and 4.5
+func (sp *SigningPackage) values(groupPublicKey *twistededwards.PointAffine, hFunc hash.Hash) ([]*big.Int, *twistededwards.PointAffine, *big.Int, error) {
+	// binding factors rho_i = H1(PK || H4(msg) || H5(commitments) || i)
+	encodedCommitments := make([]byte, 0, len(sp.Commitments)*3*fr.Bytes)
+	for _, c := range sp.Commitments {
+		encodedCommitments = append(encodedCommitments, encodeIdentifier(c.ID)...)
+		encodedCommitments = append(encodedCommitments, encodePoint(&c.Hiding)...)
+		encodedCommitments = append(encodedCommitments, encodePoint(&c.Binding)...)
+	}
+	msgHash := blake2b.Sum512(append([]byte(contextString+"msg"), sp.Message...))
+	comHash := blake2b.Sum512(append([]byte(contextString+"com"), encodedCommitments...))
+	prefix := append(encodePoint(groupPublicKey), msgHash[:]...)
+	prefix = append(prefix, comHash[:]...)
+
+	rho := make([]*big.Int, len(sp.Commitments))
+	var R, t twistededwards.PointAffine
+	R.X.SetZero()
+	R.Y.SetOne()
+	for i, c := range sp.Commitments {
+		rho[i] = hashToScalar("rho", prefix, encodeIdentifier(c.ID))
+		// R = ∑ D_i + rho_i*E_i
+		t.ScalarMultiplication(&c.Binding, rho[i])
+		R.Add(&R, &t).Add(&R, &c.Hiding)
+	}
+	if R.IsZero() {
+		return nil, nil, nil, errIdentityCommitment
+	}
+	c, err := challenge(&R, groupPublicKey, sp.Message, hFunc)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return rho, &R, c, nil
+}
+
+// verifyShare checks z_i*Base = D_i + rho_i*E_i + (c*lambda_i)*Y_i.
+func (sp *SigningPackage) verifyShare(i int, publicShare *twistededwards.PointAffine, share, rho, c *big.Int) error {
+	curveParams := twistededwards.GetEdwardsCurve()
+	commitment := &sp.Commitments[i]
+	if share == nil || share.Sign() < 0 || share.Cmp(&curveParams.Order) >= 0 {
+		return &InvalidShareError{ID: commitment.ID}
+	}
+	var lhs, rhs, t twistededwards.PointAffine
+	lhs.ScalarMultiplication(&curveParams.Base, share)
+
+	var cl big.Int
+	cl.Mul(c, sp.lagrange(commitment.ID))
+	rhs.ScalarMultiplication(publicShare, &cl)
+	t.ScalarMultiplication(&commitment.Binding, rho)
+	rhs.Add(&rhs, &t).Add(&rhs, &commitment.Hiding)
+	if !lhs.Equal(&rhs) {
+		return &InvalidShareError{ID: commitment.ID}
+	}
+	return nil
+}
+
+// lagrange returns the Lagrange coefficient of the participant id at 0 over
+// the set of signers.
+//
+// RFC 9591, Section 4.2
+func (sp *SigningPackage) lagrange(id Identifier) *big.Int {
+	ids := make([]Identifier, len(sp.Commitments))
+	for i := range sp.Commitments {
+		ids[i] = sp.Commitments[i].ID
+	}
+	return lagrangeCoefficient(id, ids)
+}
+
+func lagrangeCoefficient(id Identifier, ids []Identifier) *big.Int {
+	curveParams := twistededwards.GetEdwardsCurve()
+	order := &curveParams.Order
+	num, den := big.NewInt(1), big.NewInt(1)
+	xi := new(big.Int).SetUint64(uint64(id))
+	var xj, t big.Int
+	for _, j := range ids {
+		if j == id {
+			continue
+		}
+		xj.SetUint64(uint64(j))
+		num.Mul(num, &xj).Mod(num, order)
+		t.Sub(&xj, xi)
+		den.Mul(den, &t).Mod(den, order)
+	}
+	den.ModInverse(den, order)
+	return num.Mul(num, den).Mod(num, order)
+}
+
+// challenge returns H(R, A, M) as in the eddsa package.
+func challenge(R, A *twistededwards.PointAffine, message []byte, hFunc hash.Hash) (*big.Int, error) {
+	hFunc.Reset()
+	RX := R.X.Bytes()
+	RY := R.Y.Bytes()
+	AX := A.X.Bytes()
+	AY := A.Y.Bytes()
+	toWrite := [][]byte{RX[:], RY[:], AX[:], AY[:], message}
+	for _, bytes := range toWrite {
+		if _, err := hFunc.Write(bytes); err != nil {
+			return nil, err
+		}
+	}
+	c := new(big.Int).SetBytes(hFunc.Sum(nil))
+	curveParams := twistededwards.GetEdwardsCurve()
+	return c.Mod(c, &curveParams.Order), nil
+}
+
+// nonceGenerate sets res to H3(random_bytes(32) || secret).
+//
+// RFC 9591, Section 4.1
+func nonceGenerate(res, secret *big.Int, rand io.Reader) error {
+	var randomBytes [32]byte
+	if _, err := io.ReadFull(rand, randomBytes[:]); err != nil {
+		return err
+	}
+	var secretBytes [fr.Bytes]byte
+	secret.FillBytes(secretBytes[:])
+	res.Set(hashToScalar("nonce", randomBytes[:], secretBytes[:]))
+	return nil
+}
+
+// hashToScalar returns BLAKE2b-512(contextString || label || msgs) mod order.
+func hashToScalar(label string, msgs ...[]byte) *big.Int {
+	h, _ := blake2b.New512(nil)
+	h.Write([]byte(contextString))
+	h.Write([]byte(label))
+	for _, m := range msgs {
+		h.Write(m)
+	}
+	res := new(big.Int).SetBytes(h.Sum(nil))
+	curveParams := twistededwards.GetEdwardsCurve()
+	return res.Mod(res, &curveParams.Order)
+}
+
+// randomScalar returns a uniformly random non-zero scalar.
+func randomScalar(rand io.Reader) (*big.Int, error) {
+	curveParams := twistededwards.GetEdwardsCurve()
+	order := &curveParams.Order
+	var buf [64]byte
+	for {
+		if _, err := io.ReadFull(rand, buf[:]); err != nil {
+			return nil, err
+		}
+		res := new(big.Int).SetBytes(buf[:])
+		if res.Mod(res, order).Sign() != 0 {
+			return res, nil
+		}
+	}
+}
+
+func encodeIdentifier(id Identifier) []byte {
+	var res [fr.Bytes]byte
+	new(big.Int).SetUint64(uint64(id)).FillBytes(res[:])
+	return res[:]
+}
+
+func encodePoint(p *twistededwards.PointAffine) []byte {
+	res := p.Bytes()
+	return res[:]
+}
+
+// checkPoint checks that p is on the curve, in the prime order subgroup and
+// not the identity.
+func checkPoint(p *twistededwards.PointAffine) error {
+	if !p.IsOnCurve() || p.IsZero() || !p.IsInSubGroup() {
+		return ErrInvalidPoint
+	}
+	return nil
+}
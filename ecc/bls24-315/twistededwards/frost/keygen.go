@@ -0,0 +1,340 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package frost
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/twistededwards"
+)
+
+var (
+	ErrInvalidProof = errors.New("invalid proof of knowledge of the secret")
+	ErrInvalidVSS   = errors.New("secret share does not match the commitment")
+	errWrongRound1  = errors.New("wrong number of round 1 packages")
+	errWrongRound2  = errors.New("wrong number of round 2 shares")
+)
+
+// SecretShare is the share of the group secret key of a participant, with
+// the commitment to the coefficients of the secret sharing polynomial
+// (verifiable secret sharing).
+type SecretShare struct {
+	ID         Identifier
+	Value      big.Int
+	Commitment []twistededwards.PointAffine
+}
+
+// TrustedDealerKeygen splits secret into maxSigners shares, for the
+// participants 1, …, maxSigners, so that any minSigners of them can sign. If
+// secret is nil, a random secret key is generated. The shares must be sent to
+// the participants over confidential channels.
+//
+// RFC 9591, Appendix C
+func TrustedDealerKeygen(secret *big.Int, maxSigners, minSigners int, rand io.Reader) ([]SecretShare, *PublicKeyPackage, error) {
+	if minSigners < 2 || maxSigners < minSigners {
+		return nil, nil, ErrInvalidThreshold
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+	order := &curveParams.Order
+	coefficients := make([]big.Int, minSigners)
+	if secret == nil {
+		s, err := randomScalar(rand)
+		if err != nil {
+			return nil, nil, err
+		}
+		coefficients[0].Set(s)
+	} else {
+		coefficients[0].Mod(secret, order)
+	}
+	for j := 1; j < minSigners; j++ {
+		c, err := randomScalar(rand)
+		if err != nil {
+			return nil, nil, err
+		}
+		coefficients[j].Set(c)
+	}
+	commitment := commit(coefficients)
+
+	shares := make([]SecretShare, maxSigners)
+	for i := range shares {
+		shares[i].ID = Identifier(i + 1)
+		shares[i].Value.Set(evalPolynomial(coefficients, shares[i].ID))
+		shares[i].Commitment = commitment
+	}
+	for i := range coefficients {
+		coefficients[i].SetUint64(0)
+	}
+
+	ids := make([]Identifier, maxSigners)
+	for i := range ids {
+		ids[i] = Identifier(i + 1)
+	}
+	return shares, publicKeyPackage(commitment, ids), nil
+}
+
+// Verify checks the share against the commitment.
+//
+// RFC 9591, Appendix C.2
+func (s *SecretShare) Verify() error {
+	if s.ID == 0 {
+		return ErrInvalidIdentifier
+	}
+	if len(s.Commitment) == 0 {
+		return ErrInvalidVSS
+	}
+	for i := range s.Commitment {
+		if !s.Commitment[i].IsOnCurve() {
+			return ErrInvalidPoint
+		}
+	}
+	var lhs twistededwards.PointAffine
+	curveParams := twistededwards.GetEdwardsCurve()
+	lhs.ScalarMultiplication(&curveParams.Base, &s.Value)
+	rhs := evalCommitment(s.Commitment, s.ID)
+	if !lhs.Equal(&rhs) {
+		return ErrInvalidVSS
+	}
+	return nil
+}
+
+// NewKeyPackage verifies the share received from the trusted dealer and
+// returns the key material of the participant.
+func NewKeyPackage(s *SecretShare) (*KeyPackage, error) {
+	if err := s.Verify(); err != nil {
+		return nil, err
+	}
+	kp := &KeyPackage{
+		ID:         s.ID,
+		MinSigners: len(s.Commitment),
+	}
+	kp.SecretShare.Set(&s.Value)
+	curveParams := twistededwards.GetEdwardsCurve()
+	kp.PublicShare.ScalarMultiplication(&curveParams.Base, &s.Value)
+	kp.GroupPublicKey.Set(&s.Commitment[0])
+	return kp, nil
+}
+
+// DKGRound1Package is broadcast by each participant in the first round of
+// the distributed key generation.
+type DKGRound1Package struct {
+	ID         Identifier
+	Commitment []twistededwards.PointAffine // commitment to the coefficients of the secret polynomial
+	ProofR     twistededwards.PointAffine   // proof of knowledge of the secret, R = k*Base
+	ProofZ     big.Int                      // z = k + a_0*c
+}
+
+// DKGParticipant is the state of a participant of the distributed key
+// generation.
+type DKGParticipant struct {
+	id                     Identifier
+	maxSigners, minSigners int
+	coefficients           []big.Int
+	round1                 DKGRound1Package
+}
+
+// NewDKGParticipant starts the distributed key generation (Pedersen DKG with
+// proofs of knowledge) for the participant id. The returned package must be
+// broadcast to the other participants.
+//
+// https://eprint.iacr.org/2020/852, Figure 1
+func NewDKGParticipant(id Identifier, maxSigners, minSigners int, rand io.Reader) (*DKGParticipant, *DKGRound1Package, error) {
+	if id == 0 {
+		return nil, nil, ErrInvalidIdentifier
+	}
+	if minSigners < 2 || maxSigners < minSigners {
+		return nil, nil, ErrInvalidThreshold
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+	p := &DKGParticipant{
+		id:           id,
+		maxSigners:   maxSigners,
+		minSigners:   minSigners,
+		coefficients: make([]big.Int, minSigners),
+	}
+	for j := range p.coefficients {
+		c, err := randomScalar(rand)
+		if err != nil {
+			return nil, nil, err
+		}
+		p.coefficients[j].Set(c)
+	}
+
+	// proof of knowledge of a_0
+	k, err := randomScalar(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	p.round1.ID = id
+	p.round1.Commitment = commit(p.coefficients)
+	p.round1.ProofR.ScalarMultiplication(&curveParams.Base, k)
+	c := dkgChallenge(id, &p.round1.Commitment[0], &p.round1.ProofR)
+	p.round1.ProofZ.Mul(&p.coefficients[0], c).
+		Add(&p.round1.ProofZ, k).
+		Mod(&p.round1.ProofZ, &curveParams.Order)
+
+	round1 := p.round1
+	return p, &round1, nil
+}
+
+// Round2 verifies the packages broadcast by the other participants and
+// returns the secret shares to send to each of them over confidential
+// channels.
+func (p *DKGParticipant) Round2(round1 []*DKGRound1Package) (map[Identifier]*big.Int, error) {
+	if err := p.checkRound1(round1); err != nil {
+		return nil, err
+	}
+	shares := make(map[Identifier]*big.Int, len(round1))
+	for _, pkg := range round1 {
+		shares[pkg.ID] = evalPolynomial(p.coefficients, pkg.ID)
+	}
+	return shares, nil
+}
+
+// Finalize verifies the secret shares received from the other participants
+// in the second round and returns the key material of the participant and
+// of the group. An *InvalidShareError identifies a participant who sent an
+// invalid share.
+func (p *DKGParticipant) Finalize(round1 []*DKGRound1Package, round2 map[Identifier]*big.Int) (*KeyPackage, *PublicKeyPackage, error) {
+	if err := p.checkRound1(round1); err != nil {
+		return nil, nil, err
+	}
+	if len(round2) != len(round1) {
+		return nil, nil, errWrongRound2
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	kp := &KeyPackage{ID: p.id, MinSigners: p.minSigners}
+	kp.SecretShare.Set(evalPolynomial(p.coefficients, p.id))
+
+	// commitment to the sum of the polynomials
+	commitment := make([]twistededwards.PointAffine, p.minSigners)
+	copy(commitment, p.round1.Commitment)
+	ids := []Identifier{p.id}
+
+	var lhs twistededwards.PointAffine
+	for _, pkg := range round1 {
+		share, ok := round2[pkg.ID]
+		if !ok || share == nil {
+			return nil, nil, errWrongRound2
+		}
+		lhs.ScalarMultiplication(&curveParams.Base, share)
+		rhs := evalCommitment(pkg.Commitment, p.id)
+		if !lhs.Equal(&rhs) {
+			return nil, nil, &InvalidShareError{ID: pkg.ID}
+		}
+		kp.SecretShare.Add(&kp.SecretShare, share)
+		for j := range commitment {
+			commitment[j].Add(&commitment[j], &pkg.Commitment[j])
+		}
+		ids = append(ids, pkg.ID)
+	}
+	kp.SecretShare.Mod(&kp.SecretShare, &curveParams.Order)
+	kp.PublicShare.ScalarMultiplication(&curveParams.Base, &kp.SecretShare)
+	kp.GroupPublicKey.Set(&commitment[0])
+
+	for i := range p.coefficients {
+		p.coefficients[i].SetUint64(0)
+	}
+	return kp, publicKeyPackage(commitment, ids), nil
+}
+
+// checkRound1 checks the packages of the other participants and their proofs
+// of knowledge.
+func (p *DKGParticipant) checkRound1(round1 []*DKGRound1Package) error {
+	if len(round1) != p.maxSigners-1 {
+		return errWrongRound1
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+	seen := map[Identifier]bool{p.id: true}
+	var lhs, rhs twistededwards.PointAffine
+	for _, pkg := range round1 {
+		if pkg.ID == 0 {
+			return ErrInvalidIdentifier
+		}
+		if seen[pkg.ID] {
+			return ErrDuplicateIdentifier
+		}
+		seen[pkg.ID] = true
+		if len(pkg.Commitment) != p.minSigners {
+			return &InvalidShareError{ID: pkg.ID}
+		}
+		for j := range pkg.Commitment {
+			if err := checkPoint(&pkg.Commitment[j]); err != nil {
+				return err
+			}
+		}
+		if err := checkPoint(&pkg.ProofR); err != nil {
+			return err
+		}
+		// z*Base = R + c*C_0
+		c := dkgChallenge(pkg.ID, &pkg.Commitment[0], &pkg.ProofR)
+		lhs.ScalarMultiplication(&curveParams.Base, &pkg.ProofZ)
+		rhs.ScalarMultiplication(&pkg.Commitment[0], c).
+			Add(&rhs, &pkg.ProofR)
+		if !lhs.Equal(&rhs) {
+			return ErrInvalidProof
+		}
+	}
+	return nil
+}
+
+// dkgChallenge returns the challenge of the proof of knowledge of the
+// participant id.
+func dkgChallenge(id Identifier, C0, R *twistededwards.PointAffine) *big.Int {
+	return hashToScalar("dkg", encodeIdentifier(id), encodePoint(C0), encodePoint(R))
+}
+
+// commit returns [a_j*Base]_j.
+func commit(coefficients []big.Int) []twistededwards.PointAffine {
+	curveParams := twistededwards.GetEdwardsCurve()
+	base := &curveParams.Base
+	res := make([]twistededwards.PointAffine, len(coefficients))
+	for j := range coefficients {
+		res[j].ScalarMultiplication(base, &coefficients[j])
+	}
+	return res
+}
+
+// evalPolynomial returns ∑ a_j*x^j mod order.
+func evalPolynomial(coefficients []big.Int, x Identifier) *big.Int {
+	curveParams := twistededwards.GetEdwardsCurve()
+	order := &curveParams.Order
+	bx := new(big.Int).SetUint64(uint64(x))
+	res := new(big.Int)
+	for j := len(coefficients) - 1; j >= 0; j-- {
+		res.Mul(res, bx).
+			Add(res, &coefficients[j]).
+			Mod(res, order)
+	}
+	return res
+}
+
+// evalCommitment returns ∑ x^j*C_j.
+func evalCommitment(commitment []twistededwards.PointAffine, x Identifier) twistededwards.PointAffine {
+	bx := new(big.Int).SetUint64(uint64(x))
+	var res twistededwards.PointAffine
+	res.Set(&commitment[len(commitment)-1])
+	for j := len(commitment) - 2; j >= 0; j-- {
+		res.ScalarMultiplication(&res, bx).
+			Add(&res, &commitment[j])
+	}
+	return res
+}
+
+// publicKeyPackage returns the public shares of the participants ids and the
+// group public key from the commitment to the secret polynomial.
+func publicKeyPackage(commitment []twistededwards.PointAffine, ids []Identifier) *PublicKeyPackage {
+	pkp := &PublicKeyPackage{
+		PublicShares: make(map[Identifier]twistededwards.PointAffine, len(ids)),
+	}
+	pkp.GroupPublicKey.Set(&commitment[0])
+	for _, id := range ids {
+		pkp.PublicShares[id] = evalCommitment(commitment, id)
+	}
+	return pkp
+}
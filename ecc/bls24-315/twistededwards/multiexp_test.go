@@ -0,0 +1,75 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestMultiExp(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("MultiExp should match the sum of the scalar multiplications", prop.ForAll(
+		func(n int, s big.Int) bool {
+			params := GetEdwardsCurve()
+
+			points := make([]PointAffine, n)
+			scalars := make([]big.Int, n)
+			var expected, tmp PointExtended
+			expected.setInfinity()
+			for i := 0; i < n; i++ {
+				// distinct points and scalars, with a few zeros
+				scalars[i].SetInt64(int64(i + 1))
+				points[i].ScalarMultiplication(&params.Base, scalars[i].Mul(&scalars[i], &s))
+				scalars[i].Rsh(&s, uint(7*i))
+				tmp.FromAffine(&points[i])
+				tmp.ScalarMultiplication(&tmp, &scalars[i])
+				expected.Add(&expected, &tmp)
+			}
+
+			var res PointExtended
+			if _, err := res.MultiExp(points, scalars); err != nil {
+				return false
+			}
+			return res.Equal(&expected)
+		},
+		gen.IntRange(0, 70),
+		GenBigInt(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func BenchmarkMultiExp(b *testing.B) {
+	const nbPoints = 1 << 10
+	params := GetEdwardsCurve()
+	points := make([]PointAffine, nbPoints)
+	scalars := make([]big.Int, nbPoints)
+	genS := GenBigInt()
+	for i := range points {
+		s, _ := genS.Sample()
+		scalars[i] = s.(big.Int)
+		points[i].ScalarMultiplication(&params.Base, &scalars[i])
+	}
+	var res PointExtended
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		res.MultiExp(points, scalars)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package zeromorph
+
+import (
+	"io"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls24-315"
+)
+
+// WriteTo writes binary encoding of a Reduction
+func (r *Reduction) WriteTo(w io.Writer) (int64, error) {
+	enc := curve.NewEncoder(w)
+
+	toEncode := []interface{}{
+		r.ClaimedValues,
+		r.Quotients,
+		&r.BatchedQuotient,
+		&r.ShiftedBatchedQuotient,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes Reduction data from reader.
+func (r *Reduction) ReadFrom(reader io.Reader) (int64, error) {
+	dec := curve.NewDecoder(reader)
+
+	toDecode := []interface{}{
+		&r.ClaimedValues,
+		&r.Quotients,
+		&r.BatchedQuotient,
+		&r.ShiftedBatchedQuotient,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+
+// WriteTo writes binary encoding of a OpeningProof
+func (proof *OpeningProof) WriteTo(w io.Writer) (int64, error) {
+	n, err := proof.Reduction.WriteTo(w)
+	if err != nil {
+		return n, err
+	}
+	enc := curve.NewEncoder(w)
+	err = enc.Encode(&proof.H)
+	return n + enc.BytesWritten(), err
+}
+
+// ReadFrom decodes OpeningProof data from reader.
+func (proof *OpeningProof) ReadFrom(r io.Reader) (int64, error) {
+	n, err := proof.Reduction.ReadFrom(r)
+	if err != nil {
+		return n, err
+	}
+	dec := curve.NewDecoder(r)
+	err = dec.Decode(&proof.H)
+	return n + dec.BytesRead(), err
+}
+
+// WriteTo writes binary encoding of a VerifyingKey
+func (vk *VerifyingKey) WriteTo(w io.Writer) (int64, error) {
+	n, err := vk.VerifyingKey.WriteTo(w)
+	if err != nil {
+		return n, err
+	}
+	enc := curve.NewEncoder(w)
+	err = enc.Encode(vk.G2Shifts)
+	return n + enc.BytesWritten(), err
+}
+
+// ReadFrom decodes VerifyingKey data from reader.
+func (vk *VerifyingKey) ReadFrom(r io.Reader) (int64, error) {
+	n, err := vk.VerifyingKey.ReadFrom(r)
+	if err != nil {
+		return n, err
+	}
+	dec := curve.NewDecoder(r)
+	err = dec.Decode(&vk.G2Shifts)
+	return n + dec.BytesRead(), err
+}
@@ -0,0 +1,521 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package zeromorph
+
+import (
+	"errors"
+	"hash"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/polynomial"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/kzg"
+	"github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidNbDigests      = errors.New("number of digests is not the same as the number of polynomials")
+	ErrZeroNbDigests         = errors.New("number of digests is zero")
+	ErrInvalidPolynomialSize = errors.New("the size of the polynomials is not 2 to the number of coordinates of the point")
+	ErrInvalidNbQuotients    = errors.New("number of quotients is not the number of coordinates of the point")
+	ErrVerifyOpeningProof    = errors.New("can't verify opening proof")
+	ErrDegreeCheck           = errors.New("the batched quotient does not pass the degree check")
+)
+
+// VerifyingKey is the KZG verifying key, with the points of G₂ needed for the
+// degree check of the batched quotient.
+//
+// implements io.ReaderFrom and io.WriterTo
+type VerifyingKey struct {
+	kzg.VerifyingKey
+
+	// G2Shifts[n] = [αᴰ⁺¹⁻²ⁿ]G₂, where D+1 is the size of the SRS, is used for
+	// the polynomials in n variables
+	G2Shifts []curve.G2Affine
+}
+
+// SRS is a KZG SRS, with the verifying key of the Zeromorph degree checks.
+type SRS struct {
+	Pk kzg.ProvingKey
+	Vk VerifyingKey
+}
+
+// NewSRS returns a new SRS of the given size, using bAlpha as randomness
+// source, for the polynomials in up to log₂(size) variables. The proofs must
+// be computed with the whole Pk, whose size sets the degree checks.
+//
+// In production, an SRS generated through MPC should be used.
+func NewSRS(size uint64, bAlpha *big.Int) (*SRS, error) {
+	kzgSrs, err := kzg.NewSRS(size, bAlpha)
+	if err != nil {
+		return nil, err
+	}
+	var alpha fr.Element
+	if bAlpha.Cmp(big.NewInt(-1)) == 0 {
+		// kzg.NewSRS then uses α of order 4
+		if alpha, err = fr.Generator(4); err != nil {
+			return nil, err
+		}
+	} else {
+		alpha.SetBigInt(bAlpha)
+	}
+
+	srs := SRS{Pk: kzgSrs.Pk, Vk: VerifyingKey{VerifyingKey: kzgSrs.Vk}}
+	srs.Vk.G2Shifts = make([]curve.G2Affine, bits.Len64(size))
+	var e, bShift big.Int
+	var shift fr.Element
+	for n := range srs.Vk.G2Shifts {
+		e.SetUint64(size - 1<<n)
+		shift.Exp(alpha, &e)
+		shift.BigInt(&bShift)
+		srs.Vk.G2Shifts[n].ScalarMultiplication(&srs.Vk.G2[0], &bShift)
+	}
+	return &srs, nil
+}
+
+// Reduction is the part of a Zeromorph opening proof that reduces the opening
+// of multilinear polynomials at a point to the one of a univariate polynomial,
+// at a point x derived from the transcript, where it must vanish.
+//
+// implements io.ReaderFrom and io.WriterTo
+type Reduction struct {
+	// ClaimedValues purported values of the polynomials at the point
+	ClaimedValues []fr.Element
+
+	// Quotients commitments to the Uₖ(qₖ), where qₖ are the quotients of
+	// f - f(u) = ∑ₖ(Xₖ - uₖ)qₖ(X₀, ..., Xₖ₋₁) for f = ∑ᵢγⁱfᵢ
+	Quotients []kzg.Digest
+
+	// BatchedQuotient commitment to q̂ = ∑ₖyᵏXᴺ⁻²ᵏUₖ(qₖ), of degree less than N,
+	// for the degree check of the quotients
+	BatchedQuotient kzg.Digest
+
+	// ShiftedBatchedQuotient commitment to Xᴰ⁺¹⁻ᴺq̂, where D+1 is the size of
+	// the SRS, which exists only if q̂ is of degree less than N
+	ShiftedBatchedQuotient kzg.Digest
+}
+
+// OpeningProof Zeromorph proof of the opening of one or several multilinear
+// polynomials at the same point.
+//
+// implements io.ReaderFrom and io.WriterTo
+type OpeningProof struct {
+	Reduction
+
+	// H commitment to the KZG quotient of the univariate polynomial of the
+	// reduction, which is opened at x to 0
+	H kzg.Digest
+}
+
+// Commit commits to the multilinear polynomial p given by its evaluations on
+// the Boolean hypercube, as the univariate polynomial ∑_b p[b]Xᵇ.
+func Commit(p polynomial.MultiLin, pk kzg.ProvingKey, nbTasks ...int) (kzg.Digest, error) {
+	return kzg.Commit(p, pk, nbTasks...)
+}
+
+// Open computes an opening proof of the multilinear polynomial p at point.
+//
+// * digest is the commitment to p, needed to derive the challenges using Fiat Shamir.
+// * dataTranscript extra data that might be needed to derive the challenges.
+func Open(p polynomial.MultiLin, digest kzg.Digest, point []fr.Element, hf hash.Hash, pk kzg.ProvingKey, dataTranscript ...[]byte) (OpeningProof, error) {
+	return BatchOpenSinglePoint([]polynomial.MultiLin{p}, []kzg.Digest{digest}, point, hf, pk, dataTranscript...)
+}
+
+// Verify verifies a Zeromorph opening proof of the polynomial committed to by
+// digest at point.
+func Verify(digest *kzg.Digest, proof *OpeningProof, point []fr.Element, hf hash.Hash, vk VerifyingKey, dataTranscript ...[]byte) error {
+	return BatchVerifySinglePoint([]kzg.Digest{*digest}, proof, point, hf, vk, dataTranscript...)
+}
+
+// BatchOpenSinglePoint computes an opening proof of the multilinear
+// polynomials at point. The polynomials are folded with a random γ into
+// ∑ᵢγⁱfᵢ, which is opened.
+//
+// * digests are the commitments to the polynomials, needed to derive the challenges using Fiat Shamir.
+// * dataTranscript extra data that might be needed to derive the challenges.
+func BatchOpenSinglePoint(polynomials []polynomial.MultiLin, digests []kzg.Digest, point []fr.Element, hf hash.Hash, pk kzg.ProvingKey, dataTranscript ...[]byte) (OpeningProof, error) {
+	var res OpeningProof
+	var (
+		h   []fr.Element
+		x   fr.Element
+		err error
+	)
+	if res.Reduction, h, x, err = Reduce(polynomials, digests, point, hf, pk, dataTranscript...); err != nil {
+		return OpeningProof{}, err
+	}
+
+	proof, err := kzg.Open(h, x, pk)
+	if err != nil {
+		return OpeningProof{}, err
+	}
+	res.H = proof.H
+
+	return res, nil
+}
+
+// BatchVerifySinglePoint verifies a Zeromorph opening proof of the polynomials
+// committed to by digests at point.
+func BatchVerifySinglePoint(digests []kzg.Digest, proof *OpeningProof, point []fr.Element, hf hash.Hash, vk VerifyingKey, dataTranscript ...[]byte) error {
+	digest, x, err := VerifyReduction(digests, &proof.Reduction, point, hf, vk, dataTranscript...)
+	if err != nil {
+		return err
+	}
+
+	// the polynomial of the reduction vanishes at x
+	kzgProof := kzg.OpeningProof{H: proof.H}
+	if err = kzg.Verify(&digest, &kzgProof, x, vk.VerifyingKey); err != nil {
+		if errors.Is(err, kzg.ErrVerifyOpeningProof) {
+			return ErrVerifyOpeningProof
+		}
+		return err
+	}
+	return nil
+}
+
+// Reduce reduces the opening of the multilinear polynomials at point to the
+// one of a univariate polynomial at a point x.
+//
+// It returns the reduction, and the univariate polynomial in canonical form
+// together with x, at which it vanishes. The polynomial can then be opened
+// with kzg.Open, or with shplonk.BatchOpen together with other polynomials.
+// Its commitment is computed by the verifier with VerifyReduction.
+//
+// pk must be the whole proving key of the SRS, as its size sets the degree
+// check.
+func Reduce(polynomials []polynomial.MultiLin, digests []kzg.Digest, point []fr.Element, hf hash.Hash, pk kzg.ProvingKey, dataTranscript ...[]byte) (Reduction, []fr.Element, fr.Element, error) {
+
+	// check for invalid sizes
+	nbDigests := len(digests)
+	if nbDigests != len(polynomials) {
+		return Reduction{}, nil, fr.Element{}, ErrInvalidNbDigests
+	}
+	if nbDigests == 0 {
+		return Reduction{}, nil, fr.Element{}, ErrZeroNbDigests
+	}
+	n := len(point)
+	N := 1 << n
+	for i := range polynomials {
+		if len(polynomials[i]) != N {
+			return Reduction{}, nil, fr.Element{}, ErrInvalidPolynomialSize
+		}
+	}
+	if N > len(pk.G1) {
+		return Reduction{}, nil, fr.Element{}, kzg.ErrInvalidPolynomialSize
+	}
+
+	var res Reduction
+	res.ClaimedValues = make([]fr.Element, nbDigests)
+	parallel.Execute(nbDigests, func(start, end int) {
+		for i := start; i < end; i++ {
+			res.ClaimedValues[i] = polynomials[i].Evaluate(point, nil)
+		}
+	}, nbDigests)
+
+	fs := fiatshamir.NewTranscript(hf, "gamma", "y", "x", "z")
+
+	// fold the polynomials into f = ∑ᵢγⁱfᵢ, of value v = ∑ᵢγⁱvᵢ
+	gamma, err := deriveGamma(fs, point, digests, res.ClaimedValues, dataTranscript...)
+	if err != nil {
+		return Reduction{}, nil, fr.Element{}, err
+	}
+	f := polynomials[0].Clone()
+	gammai := gamma
+	for i := 1; i < nbDigests; i++ {
+		parallel.Execute(N, func(start, end int) {
+			var t fr.Element
+			for j := start; j < end; j++ {
+				t.Mul(&polynomials[i][j], &gammai)
+				f[j].Add(&f[j], &t)
+			}
+		})
+		gammai.Mul(&gammai, &gamma)
+	}
+	v := foldValues(res.ClaimedValues, gamma)
+
+	// compute the quotients qₖ, from the one of the most significant variable:
+	// f = f(Xₖ = uₖ) + (Xₖ - uₖ)qₖ, where qₖ = f(Xₖ = 1) - f(Xₖ = 0), of size 2ᵏ
+	quotients := make([][]fr.Element, n)
+	folded := f.Clone()
+	for k := n - 1; k >= 0; k-- {
+		mid := len(folded) / 2
+		bottom, top := folded[:mid], folded[mid:]
+		q := make([]fr.Element, mid)
+		parallel.Execute(mid, func(start, end int) {
+			var t fr.Element
+			for j := start; j < end; j++ {
+				q[j].Sub(&top[j], &bottom[j])
+				t.Mul(&q[j], &point[n-1-k])
+				bottom[j].Add(&bottom[j], &t)
+			}
+		})
+		folded = bottom
+		quotients[k] = q
+	}
+	res.Quotients = make([]kzg.Digest, n)
+	for k := range quotients {
+		if res.Quotients[k], err = kzg.Commit(quotients[k], pk); err != nil {
+			return Reduction{}, nil, fr.Element{}, err
+		}
+	}
+
+	// degree check: q̂ = ∑ₖyᵏXᴺ⁻²ᵏUₖ(qₖ)
+	y, err := deriveChallenge(fs, "y", res.Quotients...)
+	if err != nil {
+		return Reduction{}, nil, fr.Element{}, err
+	}
+	yk := make([]fr.Element, n)
+	batchedQuotient := make([]fr.Element, N)
+	for k := range quotients {
+		if k == 0 {
+			yk[k].SetOne()
+		} else {
+			yk[k].Mul(&yk[k-1], &y)
+		}
+		shifted := batchedQuotient[N-len(quotients[k]):]
+		parallel.Execute(len(shifted), func(start, end int) {
+			var t fr.Element
+			for j := start; j < end; j++ {
+				t.Mul(&quotients[k][j], &yk[k])
+				shifted[j].Add(&shifted[j], &t)
+			}
+		})
+	}
+	if res.BatchedQuotient, err = kzg.Commit(batchedQuotient, pk); err != nil {
+		return Reduction{}, nil, fr.Element{}, err
+	}
+	shifted := kzg.ProvingKey{G1: pk.G1[len(pk.G1)-N:]}
+	if res.ShiftedBatchedQuotient, err = kzg.Commit(batchedQuotient, shifted); err != nil {
+		return Reduction{}, nil, fr.Element{}, err
+	}
+
+	x, z, err := deriveXZ(fs, &res.BatchedQuotient, &res.ShiftedBatchedQuotient)
+	if err != nil {
+		return Reduction{}, nil, fr.Element{}, err
+	}
+
+	// h = ζₓ + zZₓ, where
+	// ζₓ = q̂ - ∑ₖyᵏxᴺ⁻²ᵏUₖ(qₖ) and
+	// Zₓ = Uₙ(f) - vΦₙ(x) - ∑ₖ(x²ᵏΦₙ₋ₖ₋₁(x²ᵏ⁺¹) - uₖΦₙ₋ₖ(x²ᵏ))Uₖ(qₖ)
+	// both vanish at x
+	phi, scalars := reductionScalars(x, z, point, yk)
+	h := batchedQuotient
+	parallel.Execute(N, func(start, end int) {
+		var t fr.Element
+		for j := start; j < end; j++ {
+			t.Mul(&f[j], &z)
+			h[j].Add(&h[j], &t)
+		}
+	})
+	for k := range quotients {
+		parallel.Execute(len(quotients[k]), func(start, end int) {
+			var t fr.Element
+			for j := start; j < end; j++ {
+				t.Mul(&quotients[k][j], &scalars[k])
+				h[j].Sub(&h[j], &t)
+			}
+		})
+	}
+	var t fr.Element
+	t.Mul(&z, &v).Mul(&t, &phi)
+	h[0].Sub(&h[0], &t)
+
+	return res, h, x, nil
+}
+
+// VerifyReduction checks the sizes of the reduction and the degree of the
+// batched quotient, and returns the commitment to the univariate polynomial of
+// the reduction together with the point x at which it must vanish, for the
+// polynomials committed to by digests to open to reduction.ClaimedValues at
+// point.
+//
+// The opening of the univariate polynomial to 0 must then be verified with
+// kzg.Verify, or with shplonk.BatchVerify together with other polynomials.
+func VerifyReduction(digests []kzg.Digest, reduction *Reduction, point []fr.Element, hf hash.Hash, vk VerifyingKey, dataTranscript ...[]byte) (kzg.Digest, fr.Element, error) {
+
+	// check for invalid sizes
+	nbDigests := len(digests)
+	if nbDigests != len(reduction.ClaimedValues) {
+		return kzg.Digest{}, fr.Element{}, ErrInvalidNbDigests
+	}
+	if nbDigests == 0 {
+		return kzg.Digest{}, fr.Element{}, ErrZeroNbDigests
+	}
+	n := len(point)
+	if len(reduction.Quotients) != n {
+		return kzg.Digest{}, fr.Element{}, ErrInvalidNbQuotients
+	}
+	if n >= len(vk.G2Shifts) {
+		return kzg.Digest{}, fr.Element{}, kzg.ErrInvalidPolynomialSize
+	}
+
+	// degree check: e([Xᴰ⁺¹⁻ᴺq̂], G₂) = e([q̂], [αᴰ⁺¹⁻ᴺ]G₂)
+	var negBatchedQuotient kzg.Digest
+	negBatchedQuotient.Neg(&reduction.BatchedQuotient)
+	check, err := curve.PairingCheck(
+		[]curve.G1Affine{reduction.ShiftedBatchedQuotient, negBatchedQuotient},
+		[]curve.G2Affine{vk.G2[0], vk.G2Shifts[n]},
+	)
+	if err != nil {
+		return kzg.Digest{}, fr.Element{}, err
+	}
+	if !check {
+		return kzg.Digest{}, fr.Element{}, ErrDegreeCheck
+	}
+
+	fs := fiatshamir.NewTranscript(hf, "gamma", "y", "x", "z")
+
+	gamma, err := deriveGamma(fs, point, digests, reduction.ClaimedValues, dataTranscript...)
+	if err != nil {
+		return kzg.Digest{}, fr.Element{}, err
+	}
+	v := foldValues(reduction.ClaimedValues, gamma)
+
+	y, err := deriveChallenge(fs, "y", reduction.Quotients...)
+	if err != nil {
+		return kzg.Digest{}, fr.Element{}, err
+	}
+	yk := make([]fr.Element, n)
+	for k := range yk {
+		if k == 0 {
+			yk[k].SetOne()
+		} else {
+			yk[k].Mul(&yk[k-1], &y)
+		}
+	}
+
+	x, z, err := deriveXZ(fs, &reduction.BatchedQuotient, &reduction.ShiftedBatchedQuotient)
+	if err != nil {
+		return kzg.Digest{}, fr.Element{}, err
+	}
+
+	// [h] = [q̂] + z∑ᵢγⁱ[fᵢ] - zvΦₙ(x)G₁ - ∑ₖ(yᵏxᴺ⁻²ᵏ + z(x²ᵏΦₙ₋ₖ₋₁(x²ᵏ⁺¹) - uₖΦₙ₋ₖ(x²ᵏ)))[Uₖ(qₖ)]
+	phi, scalars := reductionScalars(x, z, point, yk)
+	bases := make([]kzg.Digest, 0, nbDigests+n+2)
+	coefficients := make([]fr.Element, 0, nbDigests+n+2)
+
+	bases = append(bases, reduction.BatchedQuotient, vk.G1)
+	var t fr.Element
+	t.Mul(&z, &v).Mul(&t, &phi).Neg(&t)
+	coefficients = append(coefficients, fr.One(), t)
+
+	t = z
+	for i := range digests {
+		bases = append(bases, digests[i])
+		coefficients = append(coefficients, t)
+		t.Mul(&t, &gamma)
+	}
+	for k := range scalars {
+		bases = append(bases, reduction.Quotients[k])
+		coefficients = append(coefficients, *t.Neg(&scalars[k]))
+	}
+
+	var digest kzg.Digest
+	if _, err = digest.MultiExp(bases, coefficients, ecc.MultiExpConfig{}); err != nil {
+		return kzg.Digest{}, fr.Element{}, err
+	}
+
+	return digest, x, nil
+}
+
+// reductionScalars returns Φₙ(x), and the scalars yᵏxᴺ⁻²ᵏ + zcₖ of the
+// quotients in ζₓ + zZₓ, where cₖ = x²ᵏΦₙ₋ₖ₋₁(x²ᵏ⁺¹) - uₖΦₙ₋ₖ(x²ᵏ) and
+// Φₘ(X) = ∑_{i<2ᵐ}Xⁱ.
+func reductionScalars(x, z fr.Element, point []fr.Element, yk []fr.Element) (fr.Element, []fr.Element) {
+	n := len(point)
+
+	// sₖ = x²ᵏ, Φₙ₋ₖ(sₖ) = ∏_{k≤j<n}(1 + sⱼ) and xᴺ⁻²ᵏ = ∏_{k≤j<n}sⱼ
+	s := make([]fr.Element, n)
+	for k := range s {
+		if k == 0 {
+			s[k] = x
+		} else {
+			s[k].Square(&s[k-1])
+		}
+	}
+
+	scalars := make([]fr.Element, n)
+	var phi, shift, one, c, t fr.Element
+	phi.SetOne() // Φₙ₋ₖ₋₁(sₖ₊₁)
+	shift.SetOne()
+	one.SetOne()
+	for k := n - 1; k >= 0; k-- {
+		c.Mul(&s[k], &phi)
+		t.Add(&one, &s[k])
+		phi.Mul(&phi, &t)
+		shift.Mul(&shift, &s[k])
+
+		// cₖ = sₖΦₙ₋ₖ₋₁(sₖ₊₁) - uₖΦₙ₋ₖ(sₖ), where uₖ is the coordinate of the
+		// variable of weight 2ᵏ
+		t.Mul(&point[n-1-k], &phi)
+		c.Sub(&c, &t)
+
+		scalars[k].Mul(&yk[k], &shift)
+		c.Mul(&c, &z)
+		scalars[k].Add(&scalars[k], &c)
+	}
+
+	return phi, scalars
+}
+
+// foldValues returns ∑ᵢγⁱvᵢ.
+func foldValues(values []fr.Element, gamma fr.Element) fr.Element {
+	res := values[len(values)-1]
+	for i := len(values) - 2; i >= 0; i-- {
+		res.Mul(&res, &gamma).Add(&res, &values[i])
+	}
+	return res
+}
+
+// deriveGamma derives the challenge γ used to fold the polynomials, binded to
+// the point, the digests, the claimed values and dataTranscript.
+func deriveGamma(fs *fiatshamir.Transcript, point []fr.Element, digests []kzg.Digest, claimedValues []fr.Element, dataTranscript ...[]byte) (fr.Element, error) {
+	for i := range point {
+		if err := fs.Bind("gamma", point[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	for i := range claimedValues {
+		if err := fs.Bind("gamma", claimedValues[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	for i := range dataTranscript {
+		if err := fs.Bind("gamma", dataTranscript[i]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	return deriveChallenge(fs, "gamma", digests...)
+}
+
+// deriveXZ derives the evaluation point x, and the challenge z used to
+// combine ζₓ and Zₓ.
+func deriveXZ(fs *fiatshamir.Transcript, batchedQuotient, shiftedBatchedQuotient *kzg.Digest) (x, z fr.Element, err error) {
+	if x, err = deriveChallenge(fs, "x", *batchedQuotient, *shiftedBatchedQuotient); err != nil {
+		return
+	}
+	z, err = deriveChallenge(fs, "z")
+	return
+}
+
+// deriveChallenge binds the digests and computes the challenge.
+func deriveChallenge(fs *fiatshamir.Transcript, name string, digests ...kzg.Digest) (fr.Element, error) {
+	for i := range digests {
+		if err := fs.Bind(name, digests[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	b, err := fs.ComputeChallenge(name)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var res fr.Element
+	res.SetBytes(b)
+	return res, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bulletproofs
+
+import (
+	"crypto/sha256"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls24-317"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+
+	"github.com/consensys/gnark-crypto/utils/testutils"
+)
+
+// Test generators re-used across tests of the range proofs
+var testGens *Generators
+
+const maxBits = 256
+
+func init() {
+	testGens, _ = NewGenerators(maxBits, []byte("test"))
+}
+
+func randomBlindings(m int) []fr.Element {
+	res := make([]fr.Element, m)
+	for i := range res {
+		res[i].MustSetRandom()
+	}
+	return res
+}
+
+func TestGenerators(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Len(testGens.Gs, maxBits)
+	assert.Len(testGens.Hs, maxBits)
+
+	// the generators are derived deterministically from the seed
+	gens, err := NewGenerators(5, []byte("test"))
+	assert.NoError(err)
+	assert.Len(gens.Gs, 8)
+	assert.Equal(testGens.Gs[:8], gens.Gs)
+	assert.Equal(testGens.Hs[:8], gens.Hs)
+	assert.True(gens.G.Equal(&testGens.G) && gens.H.Equal(&testGens.H) && gens.U.Equal(&testGens.U))
+
+	gens, err = NewGenerators(8, []byte("other"))
+	assert.NoError(err)
+	assert.False(gens.G.Equal(&testGens.G))
+	assert.False(gens.Gs[0].Equal(&testGens.Gs[0]))
+
+	_, err = NewGenerators(0, []byte("test"))
+	assert.ErrorIs(err, ErrMinGeneratorsSize)
+}
+
+func TestRangeProof(t *testing.T) {
+	assert := require.New(t)
+
+	for _, nbBits := range []int{64, 32, 8, 1} {
+		for _, value := range []uint64{0, 1, math.MaxUint64 >> (64 - nbBits)} {
+			blindings := randomBlindings(1)
+			proof, commitments, err := Prove([]uint64{value}, blindings, nbBits, testGens, sha256.New(), []byte("test"))
+			assert.NoError(err)
+			assert.Len(commitments, 1)
+			expected := Commit(value, blindings[0], testGens)
+			assert.True(expected.Equal(&commitments[0]))
+
+			// verify correct proof
+			assert.NoError(Verify(commitments, &proof, nbBits, testGens, sha256.New(), []byte("test")), "nbBits=%d, value=%d", nbBits, value)
+
+			// verify wrong proofs
+			assert.ErrorIs(Verify(commitments, &proof, nbBits, testGens, sha256.New(), []byte("wrong")), ErrVerifyRangeProof)
+
+			wrongCommitment := Commit(value+1, blindings[0], testGens)
+			assert.ErrorIs(Verify([]curve.G1Affine{wrongCommitment}, &proof, nbBits, testGens, sha256.New(), []byte("test")), ErrVerifyRangeProof)
+
+			proof.THat.Double(&proof.THat)
+			assert.ErrorIs(Verify(commitments, &proof, nbBits, testGens, sha256.New(), []byte("test")), ErrVerifyRangeProof)
+		}
+	}
+
+	// values out of range
+	_, _, err := Prove([]uint64{256}, randomBlindings(1), 8, testGens, sha256.New())
+	assert.ErrorIs(err, ErrValueOutOfRange)
+	_, _, err = Prove([]uint64{1}, randomBlindings(1), 12, testGens, sha256.New())
+	assert.ErrorIs(err, ErrInvalidNbBits)
+	_, _, err = Prove([]uint64{1, 2, 3}, randomBlindings(3), 8, testGens, sha256.New())
+	assert.ErrorIs(err, ErrInvalidNbValues)
+	_, _, err = Prove(make([]uint64, 8), randomBlindings(8), 64, testGens, sha256.New())
+	assert.ErrorIs(err, ErrGeneratorsTooSmall)
+}
+
+func TestAggregatedRangeProof(t *testing.T) {
+	assert := require.New(t)
+
+	const nbBits = 32
+	values := []uint64{0, 42, 1 << 31, 1<<32 - 1}
+	blindings := randomBlindings(len(values))
+	proof, commitments, err := Prove(values, blindings, nbBits, testGens, sha256.New())
+	assert.NoError(err)
+	assert.Len(proof.InnerProduct.L, 7)
+
+	// verify correct proof
+	assert.NoError(Verify(commitments, &proof, nbBits, testGens, sha256.New()))
+
+	// verify wrong proofs
+	commitments[1], commitments[2] = commitments[2], commitments[1]
+	assert.ErrorIs(Verify(commitments, &proof, nbBits, testGens, sha256.New()), ErrVerifyRangeProof)
+	commitments[1], commitments[2] = commitments[2], commitments[1]
+
+	assert.ErrorIs(Verify(commitments[:2], &proof, nbBits, testGens, sha256.New()), ErrInvalidProofSize)
+
+	proof.InnerProduct.A.Double(&proof.InnerProduct.A)
+	assert.ErrorIs(Verify(commitments, &proof, nbBits, testGens, sha256.New()), ErrVerifyRangeProof)
+}
+
+func TestBatchVerify(t *testing.T) {
+	assert := require.New(t)
+
+	// proofs for different numbers of values
+	const nbBits = 16
+	nbValues := []int{1, 4, 2, 1}
+
+	proofs := make([]Proof, len(nbValues))
+	commitments := make([][]curve.G1Affine, len(nbValues))
+	for i, m := range nbValues {
+		values := make([]uint64, m)
+		for j := range values {
+			values[j] = uint64(1000*i + j)
+		}
+		var err error
+		proofs[i], commitments[i], err = Prove(values, randomBlindings(m), nbBits, testGens, sha256.New())
+		assert.NoError(err)
+	}
+
+	// verify correct proofs
+	assert.NoError(BatchVerify(commitments, proofs, nbBits, testGens, sha256.New()))
+
+	// verify wrong proofs
+	commitments[0], commitments[3] = commitments[3], commitments[0]
+	assert.ErrorIs(BatchVerify(commitments, proofs, nbBits, testGens, sha256.New()), ErrVerifyRangeProof)
+	commitments[0], commitments[3] = commitments[3], commitments[0]
+
+	proofs[2].Mu.Double(&proofs[2].Mu)
+	assert.ErrorIs(BatchVerify(commitments, proofs, nbBits, testGens, sha256.New()), ErrVerifyRangeProof)
+
+	assert.ErrorIs(BatchVerify(commitments[1:], proofs, nbBits, testGens, sha256.New()), ErrInvalidNbCommitments)
+}
+
+func TestSerialization(t *testing.T) {
+	t.Parallel()
+
+	proof, _, err := Prove([]uint64{3, 5}, randomBlindings(2), 8, testGens, sha256.New())
+	require.NoError(t, err)
+	gens, err := NewGenerators(16, []byte("test"))
+	require.NoError(t, err)
+
+	t.Run("proof round trip", testutils.SerializationRoundTrip(&proof))
+	t.Run("generators round trip", testutils.SerializationRoundTrip(gens))
+}
+
+func BenchmarkProve(b *testing.B) {
+	gens, err := NewGenerators(64*8, []byte("bench"))
+	require.NoError(b, err)
+	values := []uint64{1, 2, 3, 4, 5, 6, 7, 8}
+	blindings := randomBlindings(len(values))
+
+	b.Run("single", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, _ = Prove(values[:1], blindings[:1], 64, gens, sha256.New())
+		}
+	})
+	b.Run("aggregated", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, _ = Prove(values, blindings, 64, gens, sha256.New())
+		}
+	})
+}
+
+func BenchmarkVerify(b *testing.B) {
+	gens, err := NewGenerators(64*8, []byte("bench"))
+	require.NoError(b, err)
+	values := []uint64{1, 2, 3, 4, 5, 6, 7, 8}
+	blindings := randomBlindings(len(values))
+
+	proof, commitments, err := Prove(values[:1], blindings[:1], 64, gens, sha256.New())
+	require.NoError(b, err)
+	b.Run("single", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Verify(commitments, &proof, 64, gens, sha256.New())
+		}
+	})
+
+	proof, commitments, err = Prove(values, blindings, 64, gens, sha256.New())
+	require.NoError(b, err)
+	b.Run("aggregated", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Verify(commitments, &proof, 64, gens, sha256.New())
+		}
+	})
+}
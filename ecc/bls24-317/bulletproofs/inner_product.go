@@ -0,0 +1,186 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bulletproofs
+
+import (
+	"math/big"
+	"math/bits"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls24-317"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// InnerProductProof proves the knowledge of vectors a, b of size n such that
+// P = ⟨a, G⟩ + ⟨b, H⟩ + ⟨a, b⟩⋅Q, with log(n) pairs of points.
+type InnerProductProof struct {
+	// L, R are the cross terms of the folding rounds
+	L, R []curve.G1Affine
+
+	// A, B are the vectors a, b folded down to scalars
+	A, B fr.Element
+}
+
+// proveInnerProduct computes an inner product proof for the vectors a, b, of
+// size a power of two, with the bases g and hFactors[i]⋅h[i], and the base q
+// of the inner product. a and b are modified.
+func proveInnerProduct(fs *fiatshamir.Transcript, q *curve.G1Affine, g, h []curve.G1Affine, hFactors, a, b []fr.Element) (InnerProductProof, error) {
+	n := len(a)
+	nbRounds := bits.TrailingZeros(uint(n))
+
+	var proof InnerProductProof
+	proof.L = make([]curve.G1Affine, nbRounds)
+	proof.R = make([]curve.G1Affine, nbRounds)
+
+	// the bases are folded in place
+	g = append([]curve.G1Affine(nil), g...)
+	h = append([]curve.G1Affine(nil), h...)
+
+	bases := make([]curve.G1Affine, 0, n+1)
+	scalars := make([]fr.Element, 0, n+1)
+	crossTerm := func(a []fr.Element, g []curve.G1Affine, b []fr.Element, h []curve.G1Affine, factors []fr.Element) (curve.G1Affine, error) {
+		// ⟨a, g⟩ + ⟨b∘factors, h⟩ + ⟨a, b⟩⋅q
+		bases = append(bases[:0], g...)
+		bases = append(bases, h...)
+		bases = append(bases, *q)
+		scalars = append(scalars[:0], a...)
+		for i := range b {
+			scalars = append(scalars, b[i])
+			if factors != nil {
+				scalars[len(scalars)-1].Mul(&b[i], &factors[i])
+			}
+		}
+		scalars = append(scalars, innerProduct(a, b))
+		return multiExp(bases, scalars)
+	}
+
+	var err error
+	var u, uInv, t fr.Element
+	for round := 0; round < nbRounds; round++ {
+		m := len(a) / 2
+		aLo, aHi := a[:m], a[m:]
+		bLo, bHi := b[:m], b[m:]
+		gLo, gHi := g[:m], g[m:]
+		hLo, hHi := h[:m], h[m:]
+		var fLo, fHi []fr.Element
+		if hFactors != nil {
+			fLo, fHi = hFactors[:m], hFactors[m:]
+		}
+
+		// L = ⟨a_lo, g_hi⟩ + ⟨b_hi, h_lo⟩ + ⟨a_lo, b_hi⟩⋅q
+		// R = ⟨a_hi, g_lo⟩ + ⟨b_lo, h_hi⟩ + ⟨a_hi, b_lo⟩⋅q
+		if proof.L[round], err = crossTerm(aLo, gHi, bHi, hLo, fLo); err != nil {
+			return InnerProductProof{}, err
+		}
+		if proof.R[round], err = crossTerm(aHi, gLo, bLo, hHi, fHi); err != nil {
+			return InnerProductProof{}, err
+		}
+
+		if u, err = deriveU(fs, round, &proof.L[round], &proof.R[round]); err != nil {
+			return InnerProductProof{}, err
+		}
+		uInv.Inverse(&u)
+
+		// a ← u⋅a_lo + u⁻¹⋅a_hi, b ← u⁻¹⋅b_lo + u⋅b_hi
+		for i := 0; i < m; i++ {
+			aLo[i].Mul(&aLo[i], &u)
+			t.Mul(&aHi[i], &uInv)
+			aLo[i].Add(&aLo[i], &t)
+
+			bLo[i].Mul(&bLo[i], &uInv)
+			t.Mul(&bHi[i], &u)
+			bLo[i].Add(&bLo[i], &t)
+		}
+
+		// g ← u⁻¹⋅g_lo + u⋅g_hi, h ← u⋅h_lo + u⁻¹⋅h_hi
+		if round < nbRounds-1 {
+			foldBases(gLo, gLo, gHi, &uInv, &u, nil, nil)
+			foldBases(hLo, hLo, hHi, &u, &uInv, fLo, fHi)
+		}
+		a, b, g, h = aLo, bLo, gLo, hLo
+		hFactors = nil
+	}
+	proof.A, proof.B = a[0], b[0]
+
+	return proof, nil
+}
+
+// foldBases sets res[i] = a⋅leftFactors[i]⋅left[i] + b⋅rightFactors[i]⋅right[i],
+// the factors being omitted when nil. res may be left.
+func foldBases(res, left, right []curve.G1Affine, a, b *fr.Element, leftFactors, rightFactors []fr.Element) {
+	resJac := make([]curve.G1Jac, len(res))
+	parallel.Execute(len(res), func(start, end int) {
+		var s1, s2 fr.Element
+		var b1, b2 big.Int
+		for i := start; i < end; i++ {
+			s1, s2 = *a, *b
+			if leftFactors != nil {
+				s1.Mul(&s1, &leftFactors[i])
+				s2.Mul(&s2, &rightFactors[i])
+			}
+			resJac[i].JointScalarMultiplication(&left[i], &right[i], s1.BigInt(&b1), s2.BigInt(&b2))
+		}
+	})
+	copy(res, curve.BatchJacobianToAffineG1(resJac))
+}
+
+// multiExp returns ∑ᵢ scalars[i]⋅bases[i]
+func multiExp(bases []curve.G1Affine, scalars []fr.Element) (curve.G1Affine, error) {
+	var res curve.G1Affine
+	_, err := res.MultiExp(bases, scalars, ecc.MultiExpConfig{})
+	return res, err
+}
+
+// innerProduct returns ⟨a, b⟩
+func innerProduct(a, b []fr.Element) fr.Element {
+	var res, t fr.Element
+	for i := range a {
+		t.Mul(&a[i], &b[i])
+		res.Add(&res, &t)
+	}
+	return res
+}
+
+// pointBytes returns the binary encoding of p used in the transcripts and
+// the proofs.
+func pointBytes(p *curve.G1Affine) []byte {
+	b := p.Bytes()
+	return b[:]
+}
+
+// challengeNames returns the names of the challenges of a proof whose inner
+// product argument has nbRounds rounds.
+func challengeNames(nbRounds int) []string {
+	res := []string{"y", "z", "x", "w"}
+	for j := 0; j < nbRounds; j++ {
+		res = append(res, "u"+strconv.Itoa(j))
+	}
+	return res
+}
+
+// deriveU returns the challenge of a round of the inner product argument,
+// bound to its cross terms.
+func deriveU(fs *fiatshamir.Transcript, round int, l, r *curve.G1Affine) (fr.Element, error) {
+	return deriveChallenge(fs, "u"+strconv.Itoa(round), pointBytes(l), pointBytes(r))
+}
+
+func deriveChallenge(fs *fiatshamir.Transcript, name string, bindings ...[]byte) (fr.Element, error) {
+	for i := range bindings {
+		if err := fs.Bind(name, bindings[i]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	b, err := fs.ComputeChallenge(name)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var res fr.Element
+	res.SetBytes(b)
+	return res, nil
+}
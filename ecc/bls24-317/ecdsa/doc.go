@@ -10,8 +10,20 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 //
+// Besides the randomized nonces of Sign, SignDeterministic derives the nonce
+// from the private key and the message as in RFC 6979. Signatures can be
+// encoded in ASN.1 DER, public keys in the SEC 1 compressed and uncompressed
+// formats, and private keys in PKCS #8 (DER or PEM).
+//
+// The base field of bls24-317 is much larger than its scalar field, so that
+// the point R of a signature cannot be recovered from its x coordinate r
+// modulo the order with a small hint: there is neither public key recovery
+// nor batch verification on this curve.
+//
 // Documentation:
 // - Wikipedia: https://en.wikipedia.org/wiki/Elliptic_Curve_Digital_Signature_Algorithm
 // - FIPS 186-4: https://nvlpubs.nist.gov/nistpubs/FIPS/NIST.FIPS.186-4.pdf
 // - SEC 1, v-2: https://www.secg.org/sec1-v2.pdf
+// - RFC 6979: https://datatracker.ietf.org/doc/html/rfc6979
+// - RFC 5915: https://datatracker.ietf.org/doc/html/rfc5915
 package ecdsa
@@ -0,0 +1,58 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestSignDeterministic(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS24-317] RFC 6979 signatures are deterministic and valid", prop.ForAll(
+		func(msg []byte) bool {
+			privKey, _ := GenerateKey(rand.Reader)
+
+			sig1, err := privKey.SignDeterministic(msg, sha256.New)
+			if err != nil {
+				return false
+			}
+			sig2, err := privKey.SignDeterministic(msg, sha256.New)
+			if err != nil || !bytes.Equal(sig1, sig2) {
+				return false
+			}
+			ok, err := privKey.PublicKey.Verify(sig1, msg, sha256.New())
+			return err == nil && ok
+		},
+		gen.SliceOf(gen.UInt8()),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func BenchmarkSignDeterministic(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	msg := []byte("benchmarking ECDSA sign()")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.SignDeterministic(msg, sha256.New)
+	}
+}
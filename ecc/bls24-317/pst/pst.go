@@ -0,0 +1,549 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pst
+
+import (
+	"errors"
+	"hash"
+	"math/big"
+	"math/bits"
+	"slices"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls24-317"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/polynomial"
+	"github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidNbDigests      = errors.New("number of digests is not the same as the number of polynomials")
+	ErrZeroNbDigests         = errors.New("number of digests is zero")
+	ErrInvalidPolynomialSize = errors.New("invalid polynomial size (not a power of two or larger than SRS)")
+	ErrInvalidPointSize      = errors.New("number of coordinates of the point is not the number of variables")
+	ErrVerifyOpeningProof    = errors.New("can't verify opening proof")
+	ErrMinSRSSize            = errors.New("minimum number of variables is 1")
+)
+
+// Digest commitment of a multilinear polynomial.
+type Digest = curve.G1Affine
+
+// ProvingKey used to create or open commitments
+type ProvingKey struct {
+	// G1[i] is the Lagrange basis [eq((τᵢ₊₁, ..., τₙ), b)]G₁, b ∈ {0,1}ⁿ⁻ⁱ, of
+	// the multilinear polynomials in the last n-i variables
+	G1 [][]curve.G1Affine
+}
+
+// VerifyingKey used to verify opening proofs
+type VerifyingKey struct {
+	G1    curve.G1Affine
+	G2    []curve.G2Affine                                         // [G₂, [τ₁]G₂, ..., [τₙ]G₂]
+	Lines [][2][len(curve.LoopCounter) - 1]curve.LineEvaluationAff // precomputed pairing lines corresponding to G2
+}
+
+// SRS must be computed through MPC and comprises the ProvingKey and the VerifyingKey
+type SRS struct {
+	Pk ProvingKey
+	Vk VerifyingKey
+}
+
+// OpeningProof multilinear KZG proof for opening at a single point.
+//
+// implements io.ReaderFrom and io.WriterTo
+type OpeningProof struct {
+	// Quotients commitments to the quotients qᵢ of f - f(z) = ∑ᵢ (Xᵢ - zᵢ)qᵢ
+	Quotients []curve.G1Affine
+
+	// ClaimedValue purported value
+	ClaimedValue fr.Element
+}
+
+// BatchOpeningProof opening proof for many polynomials at the same point
+//
+// implements io.ReaderFrom and io.WriterTo
+type BatchOpeningProof struct {
+	// Quotients commitments to the quotients of ∑ⱼγʲfⱼ
+	Quotients []curve.G1Affine
+
+	// ClaimedValues purported values
+	ClaimedValues []fr.Element
+}
+
+// NewSRS returns a new SRS for the multilinear polynomials in len(tau)
+// variables, using tau as randomness source.
+//
+// In production, a SRS generated through MPC should be used.
+//
+// implements io.ReaderFrom and io.WriterTo
+func NewSRS(tau []fr.Element) (*SRS, error) {
+	n := len(tau)
+	if n < 1 {
+		return nil, ErrMinSRSSize
+	}
+
+	// eq((τᵢ₊₁, ..., τₙ), b) = eq(τ, (0, b)) + eq(τ, (1, b)) for the first i
+	// variables, as eq(τᵢ, 0) + eq(τᵢ, 1) = 1: each basis is the sum of the
+	// two halves of the previous one
+	scalars := make([]fr.Element, 1<<(n+1)-1)
+	level := polynomial.MultiLin(scalars[:1<<n])
+	level[0].SetOne()
+	level.Eq(tau)
+	offset := len(level)
+	for range n {
+		next := scalars[offset : offset+len(level)/2]
+		for j := range next {
+			next[j].Add(&level[j], &level[j+len(next)])
+		}
+		offset += len(next)
+		level = next
+	}
+
+	_, _, gen1Aff, gen2Aff := curve.Generators()
+
+	var srs SRS
+	g1s := curve.BatchScalarMultiplicationG1(&gen1Aff, scalars)
+	srs.Pk.G1 = make([][]curve.G1Affine, n+1)
+	offset = 0
+	for i := range srs.Pk.G1 {
+		srs.Pk.G1[i] = g1s[offset : offset+1<<(n-i)]
+		offset += len(srs.Pk.G1[i])
+	}
+
+	srs.Vk.G1 = gen1Aff
+	srs.Vk.G2 = make([]curve.G2Affine, n+1)
+	srs.Vk.G2[0] = gen2Aff
+	var bTau big.Int
+	for i := range tau {
+		srs.Vk.G2[i+1].ScalarMultiplication(&gen2Aff, tau[i].BigInt(&bTau))
+	}
+	srs.Vk.precomputeLines()
+
+	return &srs, nil
+}
+
+// NbVariables returns the number of variables n of the SRS.
+func (pk *ProvingKey) NbVariables() int {
+	return len(pk.G1) - 1
+}
+
+// basis returns the Lagrange basis of the multilinear polynomials of the
+// given size, that is in the last log₂(size) variables.
+func (pk *ProvingKey) basis(size int) ([]curve.G1Affine, error) {
+	k := bits.TrailingZeros(uint(size))
+	if size == 0 || size != 1<<k || k >= len(pk.G1) {
+		return nil, ErrInvalidPolynomialSize
+	}
+	return pk.G1[len(pk.G1)-1-k], nil
+}
+
+// precomputeLines precomputes the pairing lines of the points of vk.G2.
+func (vk *VerifyingKey) precomputeLines() {
+	vk.Lines = vk.Lines[:0]
+	for i := range vk.G2 {
+		vk.Lines = append(vk.Lines, curve.PrecomputeLines(vk.G2[i]))
+	}
+}
+
+// Commit commits to a multilinear polynomial given by its evaluations on the
+// Boolean hypercube, using a multi exponentiation with the Lagrange basis of
+// the SRS.
+//
+// A polynomial in k < n variables is committed to as a polynomial in the last k
+// variables of the SRS.
+func Commit(p polynomial.MultiLin, pk ProvingKey, nbTasks ...int) (Digest, error) {
+	basis, err := pk.basis(len(p))
+	if err != nil {
+		return Digest{}, err
+	}
+
+	var res Digest
+
+	config := ecc.MultiExpConfig{}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+	if _, err := res.MultiExp(basis, p, config); err != nil {
+		return Digest{}, err
+	}
+
+	return res, nil
+}
+
+// Open computes an opening proof of the multilinear polynomial p at point,
+// which has one coordinate per variable of p.
+func Open(p polynomial.MultiLin, point []fr.Element, pk ProvingKey) (OpeningProof, error) {
+	if _, err := pk.basis(len(p)); err != nil {
+		return OpeningProof{}, err
+	}
+	if len(point) != p.NumVars() {
+		return OpeningProof{}, ErrInvalidPointSize
+	}
+
+	var res OpeningProof
+	var err error
+	if res.Quotients, res.ClaimedValue, err = open(p.Clone(), point, pk); err != nil {
+		return OpeningProof{}, err
+	}
+
+	return res, nil
+}
+
+// open returns the commitments to the quotients of p at point, and p(point).
+// p is folded in place.
+func open(p polynomial.MultiLin, point []fr.Element, pk ProvingKey) ([]curve.G1Affine, fr.Element, error) {
+	quotients := make([]curve.G1Affine, len(point))
+	q := make([]fr.Element, len(p)/2)
+	for i := range point {
+		// p = p(zᵢ, Xᵢ₊₁, ...) + (Xᵢ - zᵢ)qᵢ, where qᵢ = p(1, Xᵢ₊₁, ...) - p(0, Xᵢ₊₁, ...)
+		mid := len(p) / 2
+		bottom, top := p[:mid], p[mid:]
+		parallel.Execute(mid, func(start, end int) {
+			var t fr.Element
+			for j := start; j < end; j++ {
+				q[j].Sub(&top[j], &bottom[j])
+				t.Mul(&q[j], &point[i])
+				bottom[j].Add(&bottom[j], &t)
+			}
+		})
+		p = bottom
+
+		var err error
+		if quotients[i], err = Commit(q[:mid], pk); err != nil {
+			return nil, fr.Element{}, err
+		}
+	}
+
+	return quotients, p[0], nil
+}
+
+// Verify verifies a multilinear KZG opening proof at a single point
+func Verify(commitment *Digest, proof *OpeningProof, point []fr.Element, vk VerifyingKey) error {
+	k := len(point)
+	if len(proof.Quotients) != k || k >= len(vk.G2) {
+		return ErrInvalidPointSize
+	}
+
+	// [f(τ) - f(z) + ∑ᵢzᵢqᵢ(τ)]G₁
+	bases := make([]curve.G1Affine, k+1)
+	scalars := make([]fr.Element, k+1)
+	bases[0] = vk.G1
+	scalars[0].Neg(&proof.ClaimedValue)
+	copy(bases[1:], proof.Quotients)
+	copy(scalars[1:], point)
+
+	pairingPoints := make([]curve.G1Affine, k+1)
+	if _, err := pairingPoints[0].MultiExp(bases, scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	pairingPoints[0].Add(&pairingPoints[0], commitment)
+
+	// e([f(τ) - f(z) + ∑ᵢzᵢqᵢ(τ)]G₁, G₂).∏ᵢe(-[qᵢ(τ)]G₁, [τᵢ]G₂) == 1
+	for i := range proof.Quotients {
+		pairingPoints[i+1].Neg(&proof.Quotients[i])
+	}
+	// the lines are copied, as they are modified by the pairing check
+	lines := append(vk.Lines[:1:1], vk.Lines[len(vk.G2)-k:]...)
+	check, err := curve.PairingCheckFixedQ(pairingPoints, lines)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// BatchOpenSinglePoint creates a batch opening proof at point of a list of multilinear polynomials.
+// It's an interactive protocol, made non-interactive using Fiat Shamir.
+//
+// * point is the point at which the polynomials are opened.
+// * digests is the list of committed polynomials to open, need to derive the challenge using Fiat Shamir.
+// * polynomials is the list of polynomials to open, they must have the same number of variables.
+// * dataTranscript extra data that might be needed to derive the challenge used for folding
+func BatchOpenSinglePoint(polynomials []polynomial.MultiLin, digests []Digest, point []fr.Element, hf hash.Hash, pk ProvingKey, dataTranscript ...[]byte) (BatchOpeningProof, error) {
+
+	// check for invalid sizes
+	nbDigests := len(digests)
+	if nbDigests != len(polynomials) {
+		return BatchOpeningProof{}, ErrInvalidNbDigests
+	}
+	if nbDigests == 0 {
+		return BatchOpeningProof{}, ErrZeroNbDigests
+	}
+	for _, p := range polynomials {
+		if len(p) != len(polynomials[0]) {
+			return BatchOpeningProof{}, ErrInvalidPolynomialSize
+		}
+	}
+	if _, err := pk.basis(len(polynomials[0])); err != nil {
+		return BatchOpeningProof{}, err
+	}
+	if len(point) != polynomials[0].NumVars() {
+		return BatchOpeningProof{}, ErrInvalidPointSize
+	}
+
+	var res BatchOpeningProof
+
+	// compute the purported values
+	res.ClaimedValues = make([]fr.Element, nbDigests)
+	var wg sync.WaitGroup
+	wg.Add(nbDigests)
+	for i := range polynomials {
+		go func(i int) {
+			res.ClaimedValues[i] = polynomials[i].Evaluate(point, nil)
+			wg.Done()
+		}(i)
+	}
+	wg.Wait()
+
+	// derive the challenge γ, binded to the point and the commitments
+	gamma, err := deriveGamma(point, digests, res.ClaimedValues, hf, dataTranscript...)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+
+	// compute ∑ⱼγʲfⱼ
+	folded := polynomials[0].Clone()
+	gammaj := gamma
+	for j := 1; j < nbDigests; j++ {
+		parallel.Execute(len(folded), func(start, end int) {
+			var t fr.Element
+			for i := start; i < end; i++ {
+				t.Mul(&polynomials[j][i], &gammaj)
+				folded[i].Add(&folded[i], &t)
+			}
+		})
+		gammaj.Mul(&gammaj, &gamma)
+	}
+
+	if res.Quotients, _, err = open(folded, point, pk); err != nil {
+		return BatchOpeningProof{}, err
+	}
+
+	return res, nil
+}
+
+// FoldProof fold the digests and the proofs in batchOpeningProof using Fiat Shamir
+// to obtain an opening proof at a single point.
+//
+// * digests list of digests on which batchOpeningProof is based
+// * batchOpeningProof opening proof of digests
+// * transcript extra data needed to derive the challenge used for folding.
+// * returns the folded version of batchOpeningProof, Digest, the folded version of digests
+func FoldProof(digests []Digest, batchOpeningProof *BatchOpeningProof, point []fr.Element, hf hash.Hash, dataTranscript ...[]byte) (OpeningProof, Digest, error) {
+
+	nbDigests := len(digests)
+
+	// check consistency between numbers of claims vs number of digests
+	if nbDigests != len(batchOpeningProof.ClaimedValues) {
+		return OpeningProof{}, Digest{}, ErrInvalidNbDigests
+	}
+	if nbDigests == 0 {
+		return OpeningProof{}, Digest{}, ErrZeroNbDigests
+	}
+
+	// derive the challenge γ, binded to the point and the commitments
+	gamma, err := deriveGamma(point, digests, batchOpeningProof.ClaimedValues, hf, dataTranscript...)
+	if err != nil {
+		return OpeningProof{}, Digest{}, err
+	}
+
+	// fold the claimed values and digests
+	// gammai = [1,γ,γ²,..,γⁿ⁻¹]
+	gammai := make([]fr.Element, nbDigests)
+	gammai[0].SetOne()
+	for i := 1; i < nbDigests; i++ {
+		gammai[i].Mul(&gammai[i-1], &gamma)
+	}
+
+	foldedDigests, foldedEvaluations, err := fold(digests, batchOpeningProof.ClaimedValues, gammai)
+	if err != nil {
+		return OpeningProof{}, Digest{}, err
+	}
+
+	// create the folded opening proof
+	res := OpeningProof{
+		Quotients:    batchOpeningProof.Quotients,
+		ClaimedValue: foldedEvaluations,
+	}
+
+	return res, foldedDigests, nil
+}
+
+// BatchVerifySinglePoint verifies a batched opening proof at a single point of a list of polynomials.
+//
+// * digests list of digests on which opening proof is done
+// * batchOpeningProof proof of correct opening on the digests
+// * dataTranscript extra data that might be needed to derive the challenge used for the folding
+func BatchVerifySinglePoint(digests []Digest, batchOpeningProof *BatchOpeningProof, point []fr.Element, hf hash.Hash, vk VerifyingKey, dataTranscript ...[]byte) error {
+
+	// fold the proof
+	foldedProof, foldedDigest, err := FoldProof(digests, batchOpeningProof, point, hf, dataTranscript...)
+	if err != nil {
+		return err
+	}
+
+	// verify the foldedProof against the foldedDigest
+	return Verify(&foldedDigest, &foldedProof, point, vk)
+}
+
+// BatchVerifyMultiPoints batch verifies a list of opening proofs at different points.
+// The purpose of the batching is to have only one pairing check, of n+1 pairings,
+// for verifying several proofs.
+//
+// * digests list of committed polynomials
+// * proofs list of opening proofs, one for each digest
+// * points the list of points at which the opening are done
+func BatchVerifyMultiPoints(digests []Digest, proofs []OpeningProof, points [][]fr.Element, vk VerifyingKey) error {
+
+	// check consistency nb proofs vs nb digests
+	if len(digests) != len(proofs) || len(digests) != len(points) {
+		return ErrInvalidNbDigests
+	}
+
+	// len(digests) should be nonzero because of randomNumbers
+	if len(digests) == 0 {
+		return ErrZeroNbDigests
+	}
+
+	// if only one digest, call Verify
+	if len(digests) == 1 {
+		return Verify(&digests[0], &proofs[0], points[0], vk)
+	}
+
+	n := len(vk.G2) - 1
+	for i := range proofs {
+		if len(points[i]) != len(proofs[i].Quotients) || len(points[i]) > n {
+			return ErrInvalidPointSize
+		}
+	}
+
+	// sample random numbers λⱼ for sampling
+	randomNumbers := make([]fr.Element, len(digests))
+	randomNumbers[0].SetOne()
+	for i := 1; i < len(randomNumbers); i++ {
+		if _, err := randomNumbers[i].SetRandom(); err != nil {
+			return err
+		}
+	}
+
+	// gather the terms of [∑ⱼλⱼ(fⱼ(τ) - fⱼ(zⱼ) + ∑ᵢzⱼᵢqⱼᵢ(τ))]G₁, and for each
+	// variable Xᵢ, the quotients of the proofs to fold as [∑ⱼλⱼqⱼᵢ(τ)]G₁
+	var bases []curve.G1Affine
+	var scalars []fr.Element
+	quotients := make([][]curve.G1Affine, n)
+	lambdas := make([][]fr.Element, n)
+	var foldedEvals, t fr.Element
+	for j := range proofs {
+		t.Mul(&randomNumbers[j], &proofs[j].ClaimedValue)
+		foldedEvals.Add(&foldedEvals, &t)
+		bases = append(bases, digests[j])
+		scalars = append(scalars, randomNumbers[j])
+
+		// the polynomial is in the last len(points[j]) variables
+		offset := n - len(points[j])
+		for i := range points[j] {
+			bases = append(bases, proofs[j].Quotients[i])
+			scalars = append(scalars, *t.Mul(&randomNumbers[j], &points[j][i]))
+			quotients[offset+i] = append(quotients[offset+i], proofs[j].Quotients[i])
+			lambdas[offset+i] = append(lambdas[offset+i], randomNumbers[j])
+		}
+	}
+	bases = append(bases, vk.G1)
+	scalars = append(scalars, *foldedEvals.Neg(&foldedEvals))
+
+	config := ecc.MultiExpConfig{}
+	pairingPoints := make([]curve.G1Affine, n+1)
+	if _, err := pairingPoints[0].MultiExp(bases, scalars, config); err != nil {
+		return err
+	}
+	for i := range quotients {
+		// the point at infinity if no polynomial depends on Xᵢ
+		if len(quotients[i]) == 0 {
+			continue
+		}
+		if _, err := pairingPoints[i+1].MultiExp(quotients[i], lambdas[i], config); err != nil {
+			return err
+		}
+		pairingPoints[i+1].Neg(&pairingPoints[i+1])
+	}
+
+	// pairing check
+	// e([∑ⱼλⱼ(fⱼ(τ) - fⱼ(zⱼ) + ∑ᵢzⱼᵢqⱼᵢ(τ))]G₁, G₂).∏ᵢe(-[∑ⱼλⱼqⱼᵢ(τ)]G₁, [τᵢ]G₂) == 1
+	check, err := curve.PairingCheckFixedQ(pairingPoints, slices.Clone(vk.Lines))
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// fold folds digests and evaluations using the list of factors as random numbers.
+//
+// * digests list of digests to fold
+// * evaluations list of evaluations to fold
+// * factors list of multiplicative factors used for the folding (in Montgomery form)
+//
+// * Returns ∑ᵢcᵢdᵢ, ∑ᵢcᵢf(aᵢ)
+func fold(di []Digest, fai []fr.Element, ci []fr.Element) (Digest, fr.Element, error) {
+
+	// fold the claimed values ∑ᵢcᵢf(aᵢ)
+	var foldedEvaluations, tmp fr.Element
+	for i := range di {
+		tmp.Mul(&fai[i], &ci[i])
+		foldedEvaluations.Add(&foldedEvaluations, &tmp)
+	}
+
+	// fold the digests ∑ᵢ[cᵢ]([fᵢ(τ)]G₁)
+	var foldedDigests Digest
+	if _, err := foldedDigests.MultiExp(di, ci, ecc.MultiExpConfig{}); err != nil {
+		return foldedDigests, foldedEvaluations, err
+	}
+
+	return foldedDigests, foldedEvaluations, nil
+}
+
+// deriveGamma derives a challenge using Fiat Shamir to fold proofs.
+func deriveGamma(point []fr.Element, digests []Digest, claimedValues []fr.Element, hf hash.Hash, dataTranscript ...[]byte) (fr.Element, error) {
+
+	// derive the challenge gamma, binded to the point and the commitments
+	fs := fiatshamir.NewTranscript(hf, "gamma")
+	for i := range point {
+		if err := fs.Bind("gamma", point[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	for i := range digests {
+		if err := fs.Bind("gamma", digests[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	for i := range claimedValues {
+		if err := fs.Bind("gamma", claimedValues[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+
+	for i := 0; i < len(dataTranscript); i++ {
+		if err := fs.Bind("gamma", dataTranscript[i]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+
+	gammaByte, err := fs.ComputeChallenge("gamma")
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var gamma fr.Element
+	gamma.SetBytes(gammaByte)
+
+	return gamma, nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package frost
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	_ "github.com/consensys/gnark-crypto/ecc/bls24-317/fr/mimc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/twistededwards"
+	"github.com/consensys/gnark-crypto/hash"
+)
+
+// sign runs the two rounds of FROST with the given signers and returns the
+// aggregate signature.
+func sign(t *testing.T, signers []*KeyPackage, pkp *PublicKeyPackage, msg []byte, corrupt Identifier) ([]byte, error) {
+	hFunc := hash.MIMC_BLS24_317.New()
+
+	// round 1
+	nonces := make([]*SigningNonces, len(signers))
+	commitments := make([]SigningCommitment, len(signers))
+	for i, kp := range signers {
+		n, c, err := Commit(kp, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonces[i], commitments[i] = n, *c
+	}
+	sp, err := NewSigningPackage(commitments, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// round 2
+	shares := make(map[Identifier]*big.Int, len(signers))
+	for i, kp := range signers {
+		share, err := Sign(sp, nonces[i], kp, hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kp.ID == corrupt {
+			share.Add(share, big.NewInt(1))
+		}
+		if err := VerifySignatureShare(sp, pkp, kp.ID, share, hFunc); (err != nil) != (kp.ID == corrupt) {
+			t.Fatal("wrong signature share verification")
+		}
+		shares[kp.ID] = share
+	}
+	if _, err := Sign(sp, nonces[0], signers[0], hFunc); err != ErrUsedNonces {
+		t.Fatal("nonces can be reused")
+	}
+
+	return Aggregate(sp, shares, pkp, hFunc)
+}
+
+func checkSignature(t *testing.T, pkp *PublicKeyPackage, sig, msg []byte) {
+	ok, err := pkp.PublicKey().Verify(sig, msg, hash.MIMC_BLS24_317.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("eddsa verification of the FROST signature failed")
+	}
+	if ok, _ := pkp.PublicKey().Verify(sig, msg, sha256.New()); ok {
+		t.Fatal("the signature should not verify with another hash function")
+	}
+}
+
+func TestTrustedDealer(t *testing.T) {
+	t.Parallel()
+	const maxSigners, minSigners = 5, 3
+
+	secretShares, pkp, err := TrustedDealerKeygen(nil, maxSigners, minSigners, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := make([]*KeyPackage, maxSigners)
+	for i := range secretShares {
+		if keys[i], err = NewKeyPackage(&secretShares[i]); err != nil {
+			t.Fatal(err)
+		}
+		publicShare := pkp.PublicShares[keys[i].ID]
+		if !keys[i].PublicShare.Equal(&publicShare) || !keys[i].GroupPublicKey.Equal(&pkp.GroupPublicKey) {
+			t.Fatal("public key package mismatch")
+		}
+	}
+
+	// a corrupted share is detected
+	secretShares[0].Value.Add(&secretShares[0].Value, big.NewInt(1))
+	if _, err := NewKeyPackage(&secretShares[0]); err != ErrInvalidVSS {
+		t.Fatal("invalid share accepted")
+	}
+
+	var frMsg fr.Element
+	frMsg.MustSetRandom()
+	msg := frMsg.Marshal()
+
+	// any minSigners participants can sign
+	for _, signers := range [][]*KeyPackage{
+		keys[:minSigners],
+		{keys[4], keys[1], keys[3]},
+		keys,
+	} {
+		sig, err := sign(t, signers, pkp, msg, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkSignature(t, pkp, sig, msg)
+	}
+
+	// not enough signers
+	nonces, c, err := Commit(keys[0], rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp, _ := NewSigningPackage([]SigningCommitment{*c}, msg)
+	if _, err := Sign(sp, nonces, keys[0], hash.MIMC_BLS24_317.New()); err != ErrInvalidThreshold {
+		t.Fatal("signature with less than minSigners signers")
+	}
+
+	// commitments out of the prime order subgroup are rejected
+	var lowOrder twistededwards.PointAffine
+	lowOrder.X.SetZero()
+	lowOrder.Y.SetOne().Neg(&lowOrder.Y)
+	c.Hiding.Add(&c.Hiding, &lowOrder)
+	if _, err := NewSigningPackage([]SigningCommitment{*c}, msg); err != ErrInvalidPoint {
+		t.Fatal("commitment out of the subgroup accepted")
+	}
+
+	// the culprit of an invalid aggregate signature is identified
+	_, err = sign(t, keys[1:4], pkp, msg, keys[2].ID)
+	var shareErr *InvalidShareError
+	if !errors.As(err, &shareErr) || shareErr.ID != keys[2].ID {
+		t.Fatal("the invalid signature share should be identified")
+	}
+}
+
+func TestDKG(t *testing.T) {
+	t.Parallel()
+	const maxSigners, minSigners = 4, 3
+
+	participants := make([]*DKGParticipant, maxSigners)
+	round1 := make([]*DKGRound1Package, maxSigners)
+	for i := range participants {
+		var err error
+		participants[i], round1[i], err = NewDKGParticipant(Identifier(i+1), maxSigners, minSigners, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// others returns the round 1 packages of the other participants
+	others := func(i int) []*DKGRound1Package {
+		res := make([]*DKGRound1Package, 0, maxSigners-1)
+		res = append(res, round1[:i]...)
+		return append(res, round1[i+1:]...)
+	}
+
+	// received[i][j] is the share sent by j to i
+	received := make([]map[Identifier]*big.Int, maxSigners)
+	for i := range received {
+		received[i] = make(map[Identifier]*big.Int)
+	}
+	for j, p := range participants {
+		shares, err := p.Round2(others(j))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range participants {
+			if i != j {
+				received[i][Identifier(j+1)] = shares[Identifier(i+1)]
+			}
+		}
+	}
+
+	keys := make([]*KeyPackage, maxSigners)
+	var pkp *PublicKeyPackage
+	for i, p := range participants {
+		kp, pkpi, err := p.Finalize(others(i), received[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pkp != nil && !pkp.GroupPublicKey.Equal(&pkpi.GroupPublicKey) {
+			t.Fatal("participants disagree on the group public key")
+		}
+		keys[i], pkp = kp, pkpi
+	}
+
+	var frMsg fr.Element
+	frMsg.MustSetRandom()
+	msg := frMsg.Marshal()
+	sig, err := sign(t, keys[1:], pkp, msg, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSignature(t, pkp, sig, msg)
+
+	// invalid proof of knowledge
+	round1[1].ProofZ.Add(&round1[1].ProofZ, big.NewInt(1))
+	if _, err := participants[0].Round2(others(0)); err != ErrInvalidProof {
+		t.Fatal("invalid proof of knowledge accepted")
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package bls provides BLS signatures on the bn254 curve.
+//
+// Public keys are in G1 and signatures in G2. Messages are hashed to G2
+// with the domain separation tag BLS_SIG_BN254G2_XMD:SHA-256_SVDW_RO_NUL_,
+// following the basic scheme of the IETF draft: aggregate signatures are
+// only accepted over distinct messages.
+//
+// Documentation:
+// - IETF draft: https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05
+// - Hash to curve: https://datatracker.ietf.org/doc/html/rfc9380
+package bls
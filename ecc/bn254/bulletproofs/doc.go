@@ -0,0 +1,20 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package bulletproofs provides Bulletproofs range proofs on bn254.
+//
+// A range proof shows that a value v committed to in a Pedersen commitment
+// V = v⋅G + γ⋅H lies in [0, 2ⁿ), without revealing v nor the blinding factor γ.
+// The proof has 2⋅log(n) + 4 group elements and 5 scalars, and the range
+// proofs of m values are aggregated in a single proof of 2⋅log(n⋅m) + 4 group
+// elements. The setup is transparent: the generators are derived by hashing
+// to the curve.
+//
+// The verification is a single multi-exponentiation of size 2⋅n⋅m, and
+// several proofs can be batch verified with a single multi-exponentiation.
+//
+// See https://eprint.iacr.org/2017/1066.pdf (Bünz, Bootle, Boneh, Poelstra,
+// Wuille, Maxwell), sections 4.2 and 4.3.
+package bulletproofs
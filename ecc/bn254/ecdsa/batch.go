@@ -0,0 +1,144 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// InvalidSignatureError is returned by BatchVerify to report the first
+// invalid signature of the batch.
+type InvalidSignatureError struct {
+	Index int   // index of the signature in the batch
+	Err   error // deserialization error, nil if the signature is well-formed but wrong
+}
+
+func (e *InvalidSignatureError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("signature %d is invalid: %v", e.Index, e.Err)
+	}
+	return fmt.Sprintf("signature %d is invalid", e.Index)
+}
+
+func (e *InvalidSignatureError) Unwrap() error {
+	return e.Err
+}
+
+// BatchVerify verifies the signatures sigs[i] of the messages msgs[i] under
+// the public keys pubs[i], where vs[i] is the recovery information returned
+// by SignForRecover. It returns true if all the signatures are valid.
+//
+// The recovery information gives the full point R_i = u1_i ⋅ G + u2_i ⋅ Q_i,
+// where u1_i = m_i/s_i and u2_i = r_i/s_i, and not only its x-coordinate r_i.
+// The equations are combined with random 128-bit coefficients z_i into a
+// single multi-scalar multiplication
+//
+//	(∑ z_i ⋅ u1_i) ⋅ G + ∑ (z_i ⋅ u2_i) ⋅ Q_i - ∑ z_i ⋅ R_i ?= 0
+//
+// If the batch does not verify, the signatures are verified one by one with
+// Verify, so that a wrong recovery information does not reject a valid
+// signature, and an *InvalidSignatureError reports the first invalid one.
+func BatchVerify(pubs []*PublicKey, msgs, sigs [][]byte, vs []uint, hFunc hash.Hash) (bool, error) {
+	n := len(pubs)
+	if n != len(msgs) || n != len(sigs) || n != len(vs) {
+		return false, errors.New("inputs of different lengths")
+	}
+	if n == 0 {
+		return true, nil
+	}
+
+	if !batchVerify(pubs, msgs, sigs, vs, hFunc) {
+		// find the invalid signature
+		for i := range sigs {
+			ok, err := pubs[i].Verify(sigs[i], msgs[i], hFunc)
+			if err != nil || !ok {
+				return false, &InvalidSignatureError{Index: i, Err: err}
+			}
+		}
+	}
+	return true, nil
+}
+
+// batchVerify returns true if the combined verification equation holds.
+func batchVerify(pubs []*PublicKey, msgs, sigs [][]byte, vs []uint, hFunc hash.Hash) bool {
+	n := len(pubs)
+
+	// points = [G, Q_0, R_0, Q_1, R_1, ...]
+	_, _, g, _ := bn254.Generators()
+	points := make([]bn254.G1Affine, 2*n+1)
+	scalars := make([]fr.Element, 2*n+1)
+	points[0] = g
+
+	// z_i ⋅ m_i and s_i, before the batch inversion of the s_i
+	zm := make([]fr.Element, n)
+	ss := make([]fr.Element, n)
+
+	var sig Signature
+	var r, m, z fr.Element
+	var zBin [16]byte
+	for i := 0; i < n; i++ {
+		if pubs[i].A.IsInfinity() || !pubs[i].A.IsOnCurve() {
+			return false
+		}
+		if _, err := sig.SetBytes(sigs[i]); err != nil {
+			return false
+		}
+		R, err := recoverP(vs[i], new(big.Int).SetBytes(sig.R[:sizeFr]))
+		if err != nil {
+			return false
+		}
+
+		var mInt *big.Int
+		if hFunc != nil {
+			// compute the hash of the message as an integer
+			hFunc.Reset()
+			if _, err := hFunc.Write(msgs[i]); err != nil {
+				return false
+			}
+			mInt = HashToInt(hFunc.Sum(nil))
+		} else {
+			mInt = HashToInt(msgs[i])
+		}
+		m.SetBigInt(mInt)
+		r.SetBytes(sig.R[:sizeFr])
+		ss[i].SetBytes(sig.S[:sizeFr])
+
+		if _, err := rand.Read(zBin[:]); err != nil {
+			return false
+		}
+		z.SetBytes(zBin[:])
+
+		zm[i].Mul(&z, &m)
+		points[2*i+1] = pubs[i].A
+		scalars[2*i+1].Mul(&z, &r)
+		points[2*i+2] = *R
+		scalars[2*i+2].Neg(&z)
+	}
+
+	sInv := fr.BatchInvert(ss)
+	var u fr.Element
+	for i := 0; i < n; i++ {
+		// z ⋅ u1 = z ⋅ m / s
+		u.Mul(&zm[i], &sInv[i])
+		scalars[0].Add(&scalars[0], &u)
+		// z ⋅ u2 = z ⋅ r / s
+		scalars[2*i+1].Mul(&scalars[2*i+1], &sInv[i])
+	}
+
+	var res bn254.G1Jac
+	if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		return false
+	}
+	return res.Z.IsZero()
+}
@@ -125,27 +125,27 @@ func recoverP(v uint, r *big.Int) (*bn254.G1Affine, error) {
 	kn := big.NewInt(int64(xChoice))
 	kn.Mul(kn, fr.Modulus())
 	x.Add(x, kn)
+	if x.Cmp(fp.Modulus()) >= 0 {
+		return nil, errors.New("x is larger than modulus")
+	}
+	var P bn254.G1Affine
+	P.X.SetBigInt(x)
 	// y^2 = x^3+ax+b
 	a, b := bn254.CurveCoefficients()
-	y := new(big.Int).Exp(x, big.NewInt(3), fp.Modulus())
-	if !a.IsZero() {
-		y.Add(y, new(big.Int).Mul(a.BigInt(new(big.Int)), x))
-	}
-	y.Add(y, b.BigInt(new(big.Int)))
-	y.Mod(y, fp.Modulus())
+	var y2, ax fp.Element
+	y2.Square(&P.X).Mul(&y2, &P.X).Add(&y2, &b)
+	ax.Mul(&a, &P.X)
+	y2.Add(&y2, &ax)
 	// y = sqrt(y^2)
-	if y.ModSqrt(y, fp.Modulus()) == nil {
+	if P.Y.Sqrt(&y2) == nil {
 		// there is no square root, return error constant
 		return nil, ErrNoSqrtR
 	}
 	// check that y has same oddity as defined by v
-	if y.Bit(0) != yChoice {
-		y = y.Sub(fp.Modulus(), y)
+	if P.Y.BigInt(new(big.Int)).Bit(0) != yChoice {
+		P.Y.Neg(&P.Y)
 	}
-	return &bn254.G1Affine{
-		X: *new(fp.Element).SetBigInt(x),
-		Y: *new(fp.Element).SetBigInt(y),
-	}, nil
+	return &P, nil
 }
 
 type zr struct{}
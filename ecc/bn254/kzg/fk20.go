@@ -0,0 +1,344 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"runtime"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidCellParameters = errors.New("invalid cell parameters: sizes must be powers of 2 with cellSize ≤ polynomialSize ≤ domainSize")
+	ErrInvalidCell           = errors.New("invalid cell: index out of the domain or wrong number of values")
+)
+
+// CellProvingKey is the precomputed data of the FK20 method to compute the
+// opening proofs of a polynomial on all the cells of an evaluation domain with
+// O(n log n) group operations, instead of O(n²) for n calls to Open.
+//
+// The domain is the subgroup ⟨ω⟩ of order N, and a cell is a coset of its
+// subgroup of order ℓ: the cell i is ωⁱ⋅⟨ω^{N/ℓ}⟩, for i < N/ℓ. The proof of
+// the cell i is the commitment to the quotient of the polynomial by
+// X^ℓ - ω^{iℓ}. With ℓ = 1 the cells are the points of the domain, and the
+// proofs are the OpeningProof.H of Open at ωⁱ.
+//
+// See Feist and Khovratovich, Fast amortized KZG proofs,
+// https://eprint.iacr.org/2023/033
+type CellProvingKey struct {
+	polynomialSize, cellSize, nbCells uint64
+
+	// srsFFT[i][r] is the i-th coefficient of the FFT of the SRS points of
+	// the r-th Toeplitz product, of size 2m with m = polynomialSize/cellSize
+	srsFFT [][]curve.G1Affine
+
+	toeplitzDomain *fft.Domain
+	twiddlesInv    []*big.Int // twiddles of the inverse G1 FFT of size 2m
+	twiddlesCells  []*big.Int // twiddles of the G1 FFT of size N/ℓ
+}
+
+// NewCellProvingKey returns the key to compute the proofs of polynomials of
+// size polynomialSize on the cells of size cellSize of the domain of size
+// domainSize. The sizes must be powers of 2 with
+// cellSize ≤ polynomialSize ≤ domainSize and polynomialSize ≤ len(pk.G1).
+func NewCellProvingKey(pk ProvingKey, polynomialSize, domainSize, cellSize uint64) (*CellProvingKey, error) {
+	for _, size := range []uint64{polynomialSize, domainSize, cellSize} {
+		if size == 0 || bits.OnesCount64(size) != 1 {
+			return nil, ErrInvalidCellParameters
+		}
+	}
+	if cellSize > polynomialSize || polynomialSize > domainSize {
+		return nil, ErrInvalidCellParameters
+	}
+	if polynomialSize > uint64(len(pk.G1)) {
+		return nil, ErrInvalidPolynomialSize
+	}
+
+	m := polynomialSize / cellSize
+	ck := &CellProvingKey{
+		polynomialSize: polynomialSize,
+		cellSize:       cellSize,
+		nbCells:        domainSize / cellSize,
+		toeplitzDomain: fft.NewDomain(2 * m),
+	}
+	twiddles, err := computeTwiddles(int(2*m), false)
+	if err != nil {
+		return nil, err
+	}
+	if ck.twiddlesInv, err = computeTwiddles(int(2*m), true); err != nil {
+		return nil, err
+	}
+	if ck.nbCells > 1 {
+		if ck.twiddlesCells, err = computeTwiddles(int(ck.nbCells), false); err != nil {
+			return nil, err
+		}
+	}
+
+	// the r-th Toeplitz product uses the points bᵥ = [τ^{(m-1-v)ℓ+r}]G₁ for
+	// 0 < v < m, the other points being the point at infinity
+	ck.srsFFT = make([][]curve.G1Affine, 2*m)
+	for i := range ck.srsFFT {
+		ck.srsFFT[i] = make([]curve.G1Affine, cellSize)
+	}
+	b := make([]curve.G1Jac, 2*m)
+	for r := uint64(0); r < cellSize; r++ {
+		for v := range b {
+			b[v] = curve.G1Jac{}
+		}
+		for v := uint64(1); v < m; v++ {
+			b[v].FromAffine(&pk.G1[(m-1-v)*cellSize+r])
+		}
+		fftG1(b, twiddles)
+		bAffine := curve.BatchJacobianToAffineG1(b)
+		for i := range bAffine {
+			ck.srsFFT[i][r] = bAffine[i]
+		}
+	}
+
+	return ck, nil
+}
+
+// ComputeProofs returns the proofs of the polynomial p in canonical form on
+// all the cells, the i-th proof being the commitment to the quotient of p by
+// X^ℓ - ω^{iℓ}.
+//
+// The commitment to the quotient by X^ℓ - a is ∑ₖ aᵏ⋅hₖ, where
+//
+//	hₖ = ∑_{j > k} ∑_{r < ℓ} p_{jℓ+r}⋅[τ^{(j-k-1)ℓ+r}]G₁, for k < m-1
+//
+// so that the proofs are the FFT of the hₖ over the N/ℓ-th roots of unity. The
+// hₖ are the sums of ℓ Toeplitz matrix-vector products, computed with FFTs of
+// size 2m.
+func (ck *CellProvingKey) ComputeProofs(p []fr.Element) ([]Digest, error) {
+	if len(p) == 0 || uint64(len(p)) > ck.polynomialSize {
+		return nil, ErrInvalidPolynomialSize
+	}
+	m := ck.polynomialSize / ck.cellSize
+
+	// scalars[i][r] = FFT(a⁽ʳ⁾)ᵢ/2m, with a⁽ʳ⁾ⱼ = p_{jℓ+r}; the inverse FFT
+	// scaling is applied on the scalars
+	scalars := make([][]fr.Element, 2*m)
+	for i := range scalars {
+		scalars[i] = make([]fr.Element, ck.cellSize)
+	}
+	parallel.Execute(int(ck.cellSize), func(start, end int) {
+		a := make([]fr.Element, 2*m)
+		for r := start; r < end; r++ {
+			for j := range a {
+				a[j].SetZero()
+				if idx := uint64(j)*ck.cellSize + uint64(r); uint64(j) < m && idx < uint64(len(p)) {
+					a[j] = p[idx]
+				}
+			}
+			ck.toeplitzDomain.FFT(a, fft.DIF)
+			fft.BitReverse(a)
+			for i := range a {
+				scalars[i][r].Mul(&a[i], &ck.toeplitzDomain.CardinalityInv)
+			}
+		}
+	})
+
+	// Toeplitz products in the Fourier domain
+	y := make([]curve.G1Jac, 2*m)
+	var err error
+	parallel.Execute(len(y), func(start, end int) {
+		for i := start; i < end; i++ {
+			if _, _err := y[i].MultiExp(ck.srsFFT[i], scalars[i], ecc.MultiExpConfig{NbTasks: 1}); _err != nil {
+				err = _err
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	fftG1(y, ck.twiddlesInv)
+
+	// hₖ = y_{m+k}
+	h := make([]curve.G1Jac, ck.nbCells)
+	copy(h, y[m:2*m-1])
+	fftG1(h, ck.twiddlesCells)
+
+	return curve.BatchJacobianToAffineG1(h), nil
+}
+
+// CellVerifyingKey is the data needed to verify the proofs of the cells of
+// CellProvingKey.ComputeProofs.
+type CellVerifyingKey struct {
+	cellSize, nbCells uint64
+
+	// g1 are the points [τʲ]G₁ for j < ℓ, to commit to the interpolations of
+	// the cells, and vk is the verifying key with [τ^ℓ]G₂ instead of [τ]G₂
+	g1 []curve.G1Affine
+	vk VerifyingKey
+
+	cellDomain *fft.Domain
+	omega      fr.Element // generator of the domain of order N
+}
+
+// NewCellVerifyingKey returns the key to verify the proofs on the cells of
+// size cellSize of the domain of size domainSize, for the SRS of pk and vk.
+// g2Cell is the point [τ^cellSize]G₂, which is not part of the SRS of NewSRS.
+// The sizes must be powers of 2 with cellSize ≤ domainSize and
+// cellSize ≤ len(pk.G1).
+func NewCellVerifyingKey(pk ProvingKey, vk VerifyingKey, g2Cell curve.G2Affine, domainSize, cellSize uint64) (*CellVerifyingKey, error) {
+	for _, size := range []uint64{domainSize, cellSize} {
+		if size == 0 || bits.OnesCount64(size) != 1 {
+			return nil, ErrInvalidCellParameters
+		}
+	}
+	if cellSize > domainSize {
+		return nil, ErrInvalidCellParameters
+	}
+	if cellSize > uint64(len(pk.G1)) {
+		return nil, ErrInvalidPolynomialSize
+	}
+
+	ck := &CellVerifyingKey{
+		cellSize:   cellSize,
+		nbCells:    domainSize / cellSize,
+		g1:         pk.G1[:cellSize],
+		vk:         vk,
+		cellDomain: fft.NewDomain(cellSize),
+		omega:      fft.NewDomain(domainSize).Generator,
+	}
+	ck.vk.G2[1] = g2Cell
+	ck.vk.Lines[1] = curve.PrecomputeLines(g2Cell)
+	return ck, nil
+}
+
+// VerifyCellProof checks the proof of the cell of index cellIndex of the
+// polynomial committed to in commitment. cell holds the evaluations of the
+// polynomial on the cell ωⁱ⋅⟨μ⟩, with μ = ω^{N/ℓ}, in the order of the powers
+// of μ.
+func VerifyCellProof(commitment, proof *Digest, cellIndex uint64, cell []fr.Element, vk *CellVerifyingKey) error {
+	return BatchVerifyCellProofs([]Digest{*commitment}, []Digest{*proof}, []uint64{cellIndex}, [][]fr.Element{cell}, vk)
+}
+
+// BatchVerifyCellProofs checks the proofs of the cells, the k-th cell being
+// the one of index cellIndices[k] of the polynomial committed to in
+// commitments[k], as in VerifyCellProof. The proofs are checked with a single
+// pairing check, on a random linear combination. It returns
+// ErrVerifyOpeningProof if one of the proofs is invalid.
+//
+// Let Iₖ be the interpolation of the k-th cell on its coset hₖ⋅⟨μ⟩. The proof
+// πₖ is the commitment to the quotient of the polynomial by X^ℓ - hₖ^ℓ, so for
+// a random r the check is
+//
+//	e(∑ rᵏ⋅(Cₖ - [Iₖ(τ)]G₁ + hₖ^ℓ⋅πₖ), G₂) = e(∑ rᵏ⋅πₖ, [τ^ℓ]G₂)
+func BatchVerifyCellProofs(commitments, proofs []Digest, cellIndices []uint64, cells [][]fr.Element, vk *CellVerifyingKey) error {
+	n := len(cells)
+	if n != len(commitments) || n != len(proofs) || n != len(cellIndices) {
+		return ErrInvalidNbDigests
+	}
+	if n == 0 {
+		return ErrZeroNbDigests
+	}
+	for k := range cells {
+		if cellIndices[k] >= vk.nbCells || uint64(len(cells[k])) != vk.cellSize {
+			return ErrInvalidCell
+		}
+	}
+
+	// points are the commitments and the proofs, scalars are rᵏ and rᵏ⋅hₖ^ℓ
+	points := make([]curve.G1Affine, 2*n)
+	scalars := make([]fr.Element, 2*n)
+	var r fr.Element
+	if _, err := r.SetRandom(); err != nil {
+		return err
+	}
+
+	// evaluations[i] is ∑ rᵏ⋅cellₖ over the cells of index i
+	evaluations := make(map[uint64][]fr.Element)
+	var t fr.Element
+	var exponent big.Int
+	for k := range cells {
+		points[k] = commitments[k]
+		points[n+k] = proofs[k]
+		if k == 0 {
+			scalars[k].SetOne()
+		} else {
+			scalars[k].Mul(&scalars[k-1], &r)
+		}
+		index := cellIndices[k]
+		exponent.SetUint64(index * vk.cellSize)
+		scalars[n+k].Exp(vk.omega, &exponent).
+			Mul(&scalars[n+k], &scalars[k])
+
+		values, ok := evaluations[index]
+		if !ok {
+			values = make([]fr.Element, vk.cellSize)
+			evaluations[index] = values
+		}
+		for j := range cells[k] {
+			t.Mul(&cells[k][j], &scalars[k])
+			values[j].Add(&values[j], &t)
+		}
+	}
+
+	// ∑ rᵏ⋅Iₖ, the interpolations being summed by coset; the interpolation on
+	// h⋅⟨μ⟩ is J(h⁻¹X), with J the one of the same values on ⟨μ⟩
+	interpolation := make([]fr.Element, vk.cellSize)
+	var shiftInv, shiftInvPower fr.Element
+	for index, coefficients := range evaluations {
+		vk.cellDomain.FFTInverse(coefficients, fft.DIF)
+		fft.BitReverse(coefficients)
+		exponent.SetUint64(index)
+		shiftInv.Exp(vk.omega, &exponent).Inverse(&shiftInv)
+		shiftInvPower.SetOne()
+		for j := range coefficients {
+			coefficients[j].Mul(&coefficients[j], &shiftInvPower)
+			interpolation[j].Add(&interpolation[j], &coefficients[j])
+			shiftInvPower.Mul(&shiftInvPower, &shiftInv)
+		}
+	}
+
+	config := ecc.MultiExpConfig{}
+	var interpolationDigest, lhs, rhs curve.G1Affine
+	if _, err := interpolationDigest.MultiExp(vk.g1, interpolation, config); err != nil {
+		return err
+	}
+	if _, err := lhs.MultiExp(points, scalars, config); err != nil {
+		return err
+	}
+	lhs.Sub(&lhs, &interpolationDigest)
+	if _, err := rhs.MultiExp(points[n:], scalars[:n], config); err != nil {
+		return err
+	}
+	rhs.Neg(&rhs)
+
+	// the Miller loop modifies the lines, which are copied
+	lines := vk.vk.Lines
+	check, err := curve.PairingCheckFixedQ(
+		[]curve.G1Affine{lhs, rhs},
+		lines[:],
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// fftG1 computes in place the FFT of a with the twiddles of computeTwiddles,
+// the input and the output being in natural order.
+func fftG1(a []curve.G1Jac, twiddles []*big.Int) {
+	if len(a) == 1 {
+		return
+	}
+	numCPU := uint64(runtime.NumCPU())
+	maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(numCPU)) << 1
+	difFFTG1(a, twiddles, 0, maxSplits, nil)
+	bitReverse(a)
+}
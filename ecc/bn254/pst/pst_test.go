@@ -0,0 +1,266 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pst
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/polynomial"
+
+	"github.com/consensys/gnark-crypto/utils/testutils"
+)
+
+// Test SRS re-used across tests of the multilinear KZG scheme
+var (
+	testSrs *SRS
+	tau     []fr.Element
+)
+
+const nbVariables = 8
+
+func init() {
+	tau = make([]fr.Element, nbVariables)
+	for i := range tau {
+		tau[i].SetUint64(uint64(42 + i))
+	}
+	testSrs, _ = NewSRS(tau)
+}
+
+func randomMultiLin(nbVariables int) polynomial.MultiLin {
+	p := make(polynomial.MultiLin, 1<<nbVariables)
+	for i := range p {
+		p[i].MustSetRandom()
+	}
+	return p
+}
+
+func randomPoint(nbVariables int) []fr.Element {
+	point := make([]fr.Element, nbVariables)
+	for i := range point {
+		point[i].MustSetRandom()
+	}
+	return point
+}
+
+func TestSRS(t *testing.T) {
+	assert := require.New(t)
+
+	_, _, g1, _ := curve.Generators()
+	var expected curve.G1Affine
+	var bEq big.Int
+
+	// the bases are the [eq((τᵢ₊₁, ..., τₙ), b)]G₁
+	for i := range testSrs.Pk.G1 {
+		assert.Len(testSrs.Pk.G1[i], 1<<(nbVariables-i))
+		for _, b := range []int{0, 1, len(testSrs.Pk.G1[i]) - 1} {
+			if b >= len(testSrs.Pk.G1[i]) {
+				continue
+			}
+			bits := make([]fr.Element, nbVariables-i)
+			for j := range bits {
+				if b>>(len(bits)-1-j)&1 == 1 {
+					bits[j].SetOne()
+				}
+			}
+			eq := fr.One()
+			if len(bits) > 0 {
+				eq = polynomial.EvalEq(tau[i:], bits)
+			}
+			expected.ScalarMultiplication(&g1, eq.BigInt(&bEq))
+			assert.True(expected.Equal(&testSrs.Pk.G1[i][b]), "basis %d, point %d", i, b)
+		}
+	}
+
+	_, err := NewSRS(nil)
+	assert.ErrorIs(err, ErrMinSRSSize)
+}
+
+func TestCommit(t *testing.T) {
+	assert := require.New(t)
+
+	_, _, g1, _ := curve.Generators()
+	var expected curve.G1Affine
+	var bEval big.Int
+
+	// the commitment is [f(τ)]G₁, in the last variables for the smaller polynomials
+	for _, k := range []int{nbVariables, 3, 0} {
+		p := randomMultiLin(k)
+		digest, err := Commit(p, testSrs.Pk)
+		assert.NoError(err)
+
+		eval := p.Evaluate(tau[nbVariables-k:], nil)
+		expected.ScalarMultiplication(&g1, eval.BigInt(&bEval))
+		assert.True(expected.Equal(&digest), "k=%d", k)
+	}
+
+	_, err := Commit(make(polynomial.MultiLin, 3), testSrs.Pk)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+	_, err = Commit(make(polynomial.MultiLin, 1<<(nbVariables+1)), testSrs.Pk)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+}
+
+func TestVerifySinglePoint(t *testing.T) {
+	assert := require.New(t)
+
+	for _, k := range []int{nbVariables, 5, 1} {
+		p := randomMultiLin(k)
+		digest, err := Commit(p, testSrs.Pk)
+		assert.NoError(err)
+
+		point := randomPoint(k)
+		proof, err := Open(p, point, testSrs.Pk)
+		assert.NoError(err)
+		assert.Len(proof.Quotients, k)
+
+		expected := p.Evaluate(point, nil)
+		assert.True(expected.Equal(&proof.ClaimedValue), "wrong claimed value")
+
+		// verify correct proof
+		assert.NoError(Verify(&digest, &proof, point, testSrs.Vk))
+
+		// verify wrong proofs
+		wrongPoint := randomPoint(k)
+		assert.ErrorIs(Verify(&digest, &proof, wrongPoint, testSrs.Vk), ErrVerifyOpeningProof)
+
+		proof.ClaimedValue.Double(&proof.ClaimedValue)
+		assert.ErrorIs(Verify(&digest, &proof, point, testSrs.Vk), ErrVerifyOpeningProof)
+	}
+
+	p := randomMultiLin(3)
+	_, err := Open(p, randomPoint(4), testSrs.Pk)
+	assert.ErrorIs(err, ErrInvalidPointSize)
+}
+
+func TestBatchVerifySinglePoint(t *testing.T) {
+	assert := require.New(t)
+
+	const nbPolynomials = 5
+	const k = 6
+
+	polynomials := make([]polynomial.MultiLin, nbPolynomials)
+	digests := make([]Digest, nbPolynomials)
+	for i := range polynomials {
+		polynomials[i] = randomMultiLin(k)
+		var err error
+		digests[i], err = Commit(polynomials[i], testSrs.Pk)
+		assert.NoError(err)
+	}
+
+	point := randomPoint(k)
+	proof, err := BatchOpenSinglePoint(polynomials, digests, point, sha256.New(), testSrs.Pk, []byte("test"))
+	assert.NoError(err)
+
+	for i := range polynomials {
+		expected := polynomials[i].Evaluate(point, nil)
+		assert.True(expected.Equal(&proof.ClaimedValues[i]), "wrong claimed value")
+	}
+
+	// verify correct proof
+	assert.NoError(BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs.Vk, []byte("test")))
+
+	// verify wrong proofs
+	assert.Error(BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs.Vk, []byte("wrong")))
+
+	proof.ClaimedValues[0].Double(&proof.ClaimedValues[0])
+	assert.ErrorIs(BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs.Vk, []byte("test")), ErrVerifyOpeningProof)
+}
+
+func TestBatchVerifyMultiPoints(t *testing.T) {
+	assert := require.New(t)
+
+	// polynomials of different number of variables
+	nbVars := []int{nbVariables, 4, 4, 7, 2}
+
+	digests := make([]Digest, len(nbVars))
+	proofs := make([]OpeningProof, len(nbVars))
+	points := make([][]fr.Element, len(nbVars))
+	for i, k := range nbVars {
+		p := randomMultiLin(k)
+		var err error
+		digests[i], err = Commit(p, testSrs.Pk)
+		assert.NoError(err)
+		points[i] = randomPoint(k)
+		proofs[i], err = Open(p, points[i], testSrs.Pk)
+		assert.NoError(err)
+	}
+
+	// verify correct proofs
+	assert.NoError(BatchVerifyMultiPoints(digests, proofs, points, testSrs.Vk))
+
+	// verify wrong proofs
+	points[1], points[2] = points[2], points[1]
+	assert.ErrorIs(BatchVerifyMultiPoints(digests, proofs, points, testSrs.Vk), ErrVerifyOpeningProof)
+	points[1], points[2] = points[2], points[1]
+
+	proofs[3].ClaimedValue.Double(&proofs[3].ClaimedValue)
+	assert.ErrorIs(BatchVerifyMultiPoints(digests, proofs, points, testSrs.Vk), ErrVerifyOpeningProof)
+}
+
+func TestSerialization(t *testing.T) {
+	t.Parallel()
+
+	p := randomMultiLin(5)
+	point := randomPoint(5)
+	proof, err := Open(p, point, testSrs.Pk)
+	require.NoError(t, err)
+
+	digest, err := Commit(p, testSrs.Pk)
+	require.NoError(t, err)
+	batchProof, err := BatchOpenSinglePoint([]polynomial.MultiLin{p, p}, []Digest{digest, digest}, point, sha256.New(), testSrs.Pk)
+	require.NoError(t, err)
+
+	t.Run("opening proof round trip", testutils.SerializationRoundTrip(&proof))
+	t.Run("batch opening proof round trip", testutils.SerializationRoundTrip(&batchProof))
+	t.Run("srs round trip", testutils.SerializationRoundTrip(testSrs))
+	t.Run("srs raw round trip", testutils.SerializationRoundTripRaw(testSrs))
+}
+
+const benchNbVariables = 16
+
+func BenchmarkCommit(b *testing.B) {
+	srs, err := NewSRS(randomPoint(benchNbVariables))
+	require.NoError(b, err)
+	p := randomMultiLin(benchNbVariables)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Commit(p, srs.Pk)
+	}
+}
+
+func BenchmarkOpen(b *testing.B) {
+	srs, err := NewSRS(randomPoint(benchNbVariables))
+	require.NoError(b, err)
+	p := randomMultiLin(benchNbVariables)
+	point := randomPoint(benchNbVariables)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Open(p, point, srs.Pk)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	srs, err := NewSRS(randomPoint(benchNbVariables))
+	require.NoError(b, err)
+	p := randomMultiLin(benchNbVariables)
+	point := randomPoint(benchNbVariables)
+	digest, err := Commit(p, srs.Pk)
+	require.NoError(b, err)
+	proof, err := Open(p, point, srs.Pk)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Verify(&digest, &proof, point, srs.Vk)
+	}
+}
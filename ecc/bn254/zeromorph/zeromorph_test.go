@@ -0,0 +1,365 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package zeromorph
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/polynomial"
+	"github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/consensys/gnark-crypto/ecc/bn254/shplonk"
+	"github.com/consensys/gnark-crypto/fiat-shamir"
+
+	"github.com/consensys/gnark-crypto/utils/testutils"
+)
+
+// Test SRS re-used across tests of the Zeromorph scheme
+var testSrs *SRS
+
+const nbVariables = 7
+
+func init() {
+	testSrs, _ = NewSRS(1<<nbVariables, big.NewInt(42))
+}
+
+func randomMultiLin(nbVariables int) polynomial.MultiLin {
+	p := make(polynomial.MultiLin, 1<<nbVariables)
+	for i := range p {
+		p[i].MustSetRandom()
+	}
+	return p
+}
+
+func randomPoint(nbVariables int) []fr.Element {
+	point := make([]fr.Element, nbVariables)
+	for i := range point {
+		point[i].MustSetRandom()
+	}
+	return point
+}
+
+func TestVerifySinglePoint(t *testing.T) {
+	assert := require.New(t)
+
+	for _, n := range []int{nbVariables, 3, 1} {
+		p := randomMultiLin(n)
+		digest, err := Commit(p, testSrs.Pk)
+		assert.NoError(err)
+
+		point := randomPoint(n)
+		proof, err := Open(p, digest, point, sha256.New(), testSrs.Pk, []byte("test"))
+		assert.NoError(err)
+		assert.Len(proof.Quotients, n)
+
+		expected := p.Evaluate(point, nil)
+		assert.True(expected.Equal(&proof.ClaimedValues[0]), "wrong claimed value")
+
+		// verify correct proof
+		assert.NoError(Verify(&digest, &proof, point, sha256.New(), testSrs.Vk, []byte("test")))
+
+		// verify wrong proofs
+		proof.ClaimedValues[0].Double(&proof.ClaimedValues[0])
+		assert.ErrorIs(Verify(&digest, &proof, point, sha256.New(), testSrs.Vk, []byte("test")), ErrVerifyOpeningProof)
+		proof.ClaimedValues[0] = expected
+
+		if n < 2 {
+			continue
+		}
+		wrongPoint := randomPoint(n)
+		assert.ErrorIs(Verify(&digest, &proof, wrongPoint, sha256.New(), testSrs.Vk, []byte("test")), ErrVerifyOpeningProof)
+
+		proof.Quotients[0], proof.Quotients[1] = proof.Quotients[1], proof.Quotients[0]
+		assert.ErrorIs(Verify(&digest, &proof, point, sha256.New(), testSrs.Vk, []byte("test")), ErrVerifyOpeningProof)
+		proof.Quotients[0], proof.Quotients[1] = proof.Quotients[1], proof.Quotients[0]
+
+		proof.BatchedQuotient = digest
+		assert.ErrorIs(Verify(&digest, &proof, point, sha256.New(), testSrs.Vk, []byte("test")), ErrDegreeCheck)
+	}
+
+	p := randomMultiLin(3)
+	_, err := Open(p, kzg.Digest{}, randomPoint(4), sha256.New(), testSrs.Pk)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+	p = randomMultiLin(nbVariables + 1)
+	_, err = Open(p, kzg.Digest{}, randomPoint(nbVariables+1), sha256.New(), testSrs.Pk)
+	assert.ErrorIs(err, kzg.ErrInvalidPolynomialSize)
+}
+
+func TestBatchVerifySinglePoint(t *testing.T) {
+	assert := require.New(t)
+
+	const nbPolynomials = 4
+	const n = 5
+
+	polynomials := make([]polynomial.MultiLin, nbPolynomials)
+	digests := make([]kzg.Digest, nbPolynomials)
+	for i := range polynomials {
+		polynomials[i] = randomMultiLin(n)
+		var err error
+		digests[i], err = Commit(polynomials[i], testSrs.Pk)
+		assert.NoError(err)
+	}
+
+	point := randomPoint(n)
+	proof, err := BatchOpenSinglePoint(polynomials, digests, point, sha256.New(), testSrs.Pk)
+	assert.NoError(err)
+
+	for i := range polynomials {
+		expected := polynomials[i].Evaluate(point, nil)
+		assert.True(expected.Equal(&proof.ClaimedValues[i]), "wrong claimed value")
+	}
+
+	// verify correct proof
+	assert.NoError(BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs.Vk))
+
+	// verify wrong proofs
+	digests[1], digests[2] = digests[2], digests[1]
+	assert.ErrorIs(BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs.Vk), ErrVerifyOpeningProof)
+	digests[1], digests[2] = digests[2], digests[1]
+
+	proof.ClaimedValues[3].Double(&proof.ClaimedValues[3])
+	assert.ErrorIs(BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs.Vk), ErrVerifyOpeningProof)
+
+	assert.ErrorIs(BatchVerifySinglePoint(digests[1:], &proof, point, sha256.New(), testSrs.Vk), ErrInvalidNbDigests)
+	assert.ErrorIs(BatchVerifySinglePoint(digests, &proof, point[1:], sha256.New(), testSrs.Vk), ErrInvalidNbQuotients)
+}
+
+func TestDegreeCheck(t *testing.T) {
+	assert := require.New(t)
+
+	// with quotients of degree too large, f in 2 variables opens to any value:
+	// the quotients q₀, q₁ of degrees 1 and 2 are the solution of
+	// Uₙ(f) - vΦₙ = c₀q₀ + c₁q₁, where c₀ = XΦ₁(X²) - u₀Φ₂ and c₁ = X² - u₁Φ₁(X²)
+	const n, N = 2, 4
+	p := randomMultiLin(n)
+	digest, err := Commit(p, testSrs.Pk)
+	assert.NoError(err)
+	point := randomPoint(n)
+	v := p.Evaluate(point, nil)
+	one := fr.One()
+	v.Add(&v, &one)
+
+	u0, u1 := point[1], point[0]
+	var c0, c1 [4]fr.Element
+	c0[0].Neg(&u0)
+	c0[1].Sub(&one, &u0)
+	c0[2].Neg(&u0)
+	c0[3].Sub(&one, &u0)
+	c1[0].Neg(&u1)
+	c1[2].Sub(&one, &u1)
+
+	// columns of the unknowns q₀[0], q₀[1], q₁[0], q₁[1], q₁[2]
+	m := make([][]fr.Element, N+1)
+	rhs := make([]fr.Element, N+1)
+	for i := range m {
+		m[i] = make([]fr.Element, N+1)
+		for j := 0; j < 2; j++ {
+			if i-j >= 0 && i-j < len(c0) {
+				m[i][j] = c0[i-j]
+			}
+		}
+		for j := 0; j < 3; j++ {
+			if i-j >= 0 && i-j < len(c1) {
+				m[i][2+j] = c1[i-j]
+			}
+		}
+		if i < N {
+			rhs[i].Sub(&p[i], &v)
+		}
+	}
+	sol := solve(m, rhs)
+	quotients := [][]fr.Element{sol[:2], sol[2:]}
+
+	// the proof is computed as in Reduce, with q̂ of degree N
+	var reduction Reduction
+	reduction.ClaimedValues = []fr.Element{v}
+	fs := fiatshamir.NewTranscript(sha256.New(), "gamma", "y", "x", "z")
+	_, err = deriveGamma(fs, point, []kzg.Digest{digest}, reduction.ClaimedValues)
+	assert.NoError(err)
+	reduction.Quotients = make([]kzg.Digest, n)
+	for k := range quotients {
+		reduction.Quotients[k], err = kzg.Commit(quotients[k], testSrs.Pk)
+		assert.NoError(err)
+	}
+	y, err := deriveChallenge(fs, "y", reduction.Quotients...)
+	assert.NoError(err)
+	yk := []fr.Element{one, y}
+	batchedQuotient := make([]fr.Element, N+1)
+	var tmp fr.Element
+	for k := range quotients {
+		for j := range quotients[k] {
+			tmp.Mul(&quotients[k][j], &yk[k])
+			batchedQuotient[N-(1<<k)+j].Add(&batchedQuotient[N-(1<<k)+j], &tmp)
+		}
+	}
+	reduction.BatchedQuotient, err = kzg.Commit(batchedQuotient, testSrs.Pk)
+	assert.NoError(err)
+
+	// Xᴰ⁺¹⁻ᴺq̂ is of degree D+1, so the prover commits to Xᴰ⁻ᴺq̂ instead
+	shift := len(testSrs.Pk.G1) - N
+	_, err = kzg.Commit(batchedQuotient, kzg.ProvingKey{G1: testSrs.Pk.G1[shift:]})
+	assert.ErrorIs(err, kzg.ErrInvalidPolynomialSize)
+	reduction.ShiftedBatchedQuotient, err = kzg.Commit(batchedQuotient, kzg.ProvingKey{G1: testSrs.Pk.G1[shift-1:]})
+	assert.NoError(err)
+
+	x, z, err := deriveXZ(fs, &reduction.BatchedQuotient, &reduction.ShiftedBatchedQuotient)
+	assert.NoError(err)
+	phi, scalars := reductionScalars(x, z, point, yk)
+	h := polynomial.Polynomial(batchedQuotient)
+	for j := range p {
+		tmp.Mul(&p[j], &z)
+		h[j].Add(&h[j], &tmp)
+	}
+	for k := range quotients {
+		for j := range quotients[k] {
+			tmp.Mul(&quotients[k][j], &scalars[k])
+			h[j].Sub(&h[j], &tmp)
+		}
+	}
+	tmp.Mul(&z, &v).Mul(&tmp, &phi)
+	h[0].Sub(&h[0], &tmp)
+	hx := h.Eval(&x)
+	assert.True(hx.IsZero(), "the polynomial of the reduction should vanish at x")
+	kzgProof, err := kzg.Open(h, x, testSrs.Pk)
+	assert.NoError(err)
+	proof := OpeningProof{Reduction: reduction, H: kzgProof.H}
+
+	assert.ErrorIs(Verify(&digest, &proof, point, sha256.New(), testSrs.Vk), ErrDegreeCheck)
+
+	// the degree check is the only one that fails: the proof passes with the
+	// verifying key of an SRS of one point less, with which the prover could
+	// have committed to q̂
+	smaller, err := NewSRS(uint64(len(testSrs.Pk.G1)-1), big.NewInt(42))
+	assert.NoError(err)
+	assert.NoError(Verify(&digest, &proof, point, sha256.New(), smaller.Vk))
+}
+
+// solve returns the solution of the linear system m·x = b, for m invertible.
+func solve(m [][]fr.Element, b []fr.Element) []fr.Element {
+	n := len(b)
+	var t fr.Element
+	for i := 0; i < n; i++ {
+		pivot := i
+		for m[pivot][i].IsZero() {
+			pivot++
+		}
+		m[i], m[pivot] = m[pivot], m[i]
+		b[i], b[pivot] = b[pivot], b[i]
+		var inv fr.Element
+		inv.Inverse(&m[i][i])
+		for j := i; j < n; j++ {
+			m[i][j].Mul(&m[i][j], &inv)
+		}
+		b[i].Mul(&b[i], &inv)
+		for r := 0; r < n; r++ {
+			if r == i || m[r][i].IsZero() {
+				continue
+			}
+			c := m[r][i]
+			for j := i; j < n; j++ {
+				t.Mul(&c, &m[i][j])
+				m[r][j].Sub(&m[r][j], &t)
+			}
+			t.Mul(&c, &b[i])
+			b[r].Sub(&b[r], &t)
+		}
+	}
+	return b
+}
+
+func TestShplonk(t *testing.T) {
+	assert := require.New(t)
+
+	// the univariate opening of the reduction is batched with the one of a
+	// univariate polynomial at another point
+	const n = 6
+	p := randomMultiLin(n)
+	digest, err := Commit(p, testSrs.Pk)
+	assert.NoError(err)
+	point := randomPoint(n)
+
+	g := make([]fr.Element, 50)
+	for i := range g {
+		g[i].MustSetRandom()
+	}
+	gDigest, err := kzg.Commit(g, testSrs.Pk)
+	assert.NoError(err)
+	var a fr.Element
+	a.MustSetRandom()
+
+	// prover
+	reduction, h, x, err := Reduce([]polynomial.MultiLin{p}, []kzg.Digest{digest}, point, sha256.New(), testSrs.Pk)
+	assert.NoError(err)
+	hDigest, err := kzg.Commit(h, testSrs.Pk)
+	assert.NoError(err)
+	points := [][]fr.Element{{x}, {a}}
+	proof, err := shplonk.BatchOpen([][]fr.Element{h, g}, []kzg.Digest{hDigest, gDigest}, points, sha256.New(), testSrs.Pk)
+	assert.NoError(err)
+
+	// verifier
+	reducedDigest, reducedPoint, err := VerifyReduction([]kzg.Digest{digest}, &reduction, point, sha256.New(), testSrs.Vk)
+	assert.NoError(err)
+	assert.True(reducedDigest.Equal(&hDigest))
+	assert.True(reducedPoint.Equal(&points[0][0]))
+	assert.True(proof.ClaimedValues[0][0].IsZero())
+	assert.NoError(shplonk.BatchVerify(proof, []kzg.Digest{reducedDigest, gDigest}, points, sha256.New(), testSrs.Vk.VerifyingKey))
+
+	// a wrong claimed value changes the univariate claim
+	reduction.ClaimedValues[0].Double(&reduction.ClaimedValues[0])
+	reducedDigest, _, err = VerifyReduction([]kzg.Digest{digest}, &reduction, point, sha256.New(), testSrs.Vk)
+	assert.NoError(err)
+	assert.Error(shplonk.BatchVerify(proof, []kzg.Digest{reducedDigest, gDigest}, points, sha256.New(), testSrs.Vk.VerifyingKey))
+}
+
+func TestSerialization(t *testing.T) {
+	t.Parallel()
+
+	p := randomMultiLin(4)
+	digest, err := Commit(p, testSrs.Pk)
+	require.NoError(t, err)
+	proof, err := Open(p, digest, randomPoint(4), sha256.New(), testSrs.Pk)
+	require.NoError(t, err)
+
+	t.Run("opening proof round trip", testutils.SerializationRoundTrip(&proof))
+	t.Run("reduction round trip", testutils.SerializationRoundTrip(&proof.Reduction))
+	t.Run("verifying key round trip", testutils.SerializationRoundTrip(&testSrs.Vk))
+}
+
+const benchNbVariables = 16
+
+func BenchmarkOpen(b *testing.B) {
+	srs, err := NewSRS(1<<benchNbVariables, big.NewInt(-1))
+	require.NoError(b, err)
+	p := randomMultiLin(benchNbVariables)
+	point := randomPoint(benchNbVariables)
+	digest, err := Commit(p, srs.Pk)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Open(p, digest, point, sha256.New(), srs.Pk)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	srs, err := NewSRS(1<<benchNbVariables, big.NewInt(-1))
+	require.NoError(b, err)
+	p := randomMultiLin(benchNbVariables)
+	point := randomPoint(benchNbVariables)
+	digest, err := Commit(p, srs.Pk)
+	require.NoError(b, err)
+	proof, err := Open(p, digest, point, sha256.New(), srs.Pk)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Verify(&digest, &proof, point, sha256.New(), srs.Vk)
+	}
+}
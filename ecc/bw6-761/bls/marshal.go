@@ -0,0 +1,101 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+	"math/big"
+)
+
+var errWrongSize = errors.New("wrong size buffer")
+var errScalar = errors.New("scalar must be in [1, r_mod)")
+var errPublicKeyMismatch = errors.New("public key does not match the scalar")
+
+// Bytes returns the binary representation of the public key
+// as the compressed encoding of the point in G1.
+func (pk *PublicKey) Bytes() []byte {
+	var res [sizePublicKey]byte
+	pkBin := pk.A.Bytes()
+	subtle.ConstantTimeCopy(1, res[:sizePublicKey], pkBin[:])
+	return res[:]
+}
+
+// SetBytes sets pk from the compressed encoding of a point in G1.
+// It checks that the point is in the prime order subgroup.
+// It returns the number of bytes read from the buffer.
+func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePublicKey {
+		return 0, io.ErrShortBuffer
+	}
+	if _, err := pk.A.SetBytes(buf[:sizePublicKey]); err != nil {
+		return 0, err
+	}
+	return sizePublicKey, nil
+}
+
+// Bytes returns the binary representation of pk,
+// as byte array publicKey||scalar
+// where publicKey is as publicKey.Bytes(), and
+// scalar is in big endian, of size sizeFr.
+func (privKey *PrivateKey) Bytes() []byte {
+	var res [sizePrivateKey]byte
+	pubkBin := privKey.PublicKey.A.Bytes()
+	subtle.ConstantTimeCopy(1, res[:sizePublicKey], pubkBin[:])
+	subtle.ConstantTimeCopy(1, res[sizePublicKey:sizePrivateKey], privKey.scalar[:])
+	return res[:]
+}
+
+// SetBytes sets pk from buf, where buf is interpreted
+// as  publicKey||scalar
+// where publicKey is as publicKey.Bytes(), and
+// scalar is in big endian, of size sizeFr.
+// It checks that the public key matches the scalar.
+// It returns the number byte read.
+func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePrivateKey {
+		return 0, io.ErrShortBuffer
+	}
+	var pk PublicKey
+	if _, err := pk.SetBytes(buf[:sizePublicKey]); err != nil {
+		return 0, err
+	}
+	scalar := new(big.Int).SetBytes(buf[sizePublicKey:sizePrivateKey])
+	if scalar.Sign() == 0 || scalar.Cmp(order) >= 0 {
+		return 0, errScalar
+	}
+	var expected PublicKey
+	expected.A.ScalarMultiplicationBase(scalar)
+	if !expected.A.Equal(&pk.A) {
+		return 0, errPublicKeyMismatch
+	}
+	privKey.PublicKey = pk
+	subtle.ConstantTimeCopy(1, privKey.scalar[:], buf[sizePublicKey:sizePrivateKey])
+	return sizePrivateKey, nil
+}
+
+// Bytes returns the binary representation of sig
+// as the compressed encoding of the point in G2.
+func (sig *Signature) Bytes() []byte {
+	var res [sizeSignature]byte
+	sigBin := sig.S.Bytes()
+	subtle.ConstantTimeCopy(1, res[:], sigBin[:])
+	return res[:]
+}
+
+// SetBytes sets sig from the compressed encoding of a point in G2.
+// It checks that the point is in the prime order subgroup.
+// It returns the number of bytes read from buf.
+func (sig *Signature) SetBytes(buf []byte) (int, error) {
+	if len(buf) != sizeSignature {
+		return 0, errWrongSize
+	}
+	if _, err := sig.S.SetBytes(buf); err != nil {
+		return 0, err
+	}
+	return sizeSignature, nil
+}
@@ -6,6 +6,7 @@
 package fri
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"fmt"
 	"math/big"
@@ -15,21 +16,9 @@ import (
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
 	"github.com/leanovate/gopter/prop"
+	"github.com/stretchr/testify/require"
 )
 
-// logFiber returns u, v such that {g^u, g^v} = f⁻¹((g²)^{_p})
-func logFiber(_p, _n int) (_u, _v big.Int) {
-	if _p%2 == 0 {
-		_u.SetInt64(int64(_p / 2))
-		_v.SetInt64(int64(_p/2 + _n/2))
-	} else {
-		l := (_n - 1 - _p) / 2
-		_u.SetInt64(int64(_n - 1 - l))
-		_v.SetInt64(int64(_n - 1 - l - _n/2))
-	}
-	return
-}
-
 func randomPolynomial(size uint64, seed int32) []fr.Element {
 	p := make([]fr.Element, size)
 	p[0].SetUint64(uint64(seed))
@@ -39,18 +28,6 @@ func randomPolynomial(size uint64, seed int32) []fr.Element {
 	return p
 }
 
-// convertSortedCanonical convert the index i, an entry in a
-// sorted polynomial, to the corresponding entry in canonical
-// representation. n is the size of the polynomial.
-func convertSortedCanonical(i, n int) int {
-	if i%2 == 0 {
-		return i / 2
-	} else {
-		l := (n - 1 - i) / 2
-		return n - 1 - l
-	}
-}
-
 func TestFRI(t *testing.T) {
 
 	parameters := gopter.DefaultTestParameters()
@@ -144,41 +121,6 @@ func TestFRI(t *testing.T) {
 		gen.Int32Range(0, int32(rho*size)),
 	))
 
-	properties.Property("Derive queries position: points should belong the correct fiber", prop.ForAll(
-
-		func(m int32) bool {
-
-			_s := RADIX_2_FRI.New(uint64(size), sha256.New())
-			s := _s.(radixTwoFri)
-
-			var g fr.Element
-
-			_m := int(m) % size
-			pos := s.deriveQueriesPositions(_m, int(s.domain.Cardinality))
-			g.Set(&s.domain.Generator)
-			n := int(s.domain.Cardinality)
-
-			for i := 0; i < len(pos)-1; i++ {
-
-				u, v := logFiber(pos[i], n)
-
-				var g1, g2, g3 fr.Element
-				g1.Exp(g, &u).Square(&g1)
-				g2.Exp(g, &v).Square(&g2)
-				nextPos := convertSortedCanonical(pos[i+1], n/2)
-				g3.Square(&g).Exp(g3, big.NewInt(int64(nextPos)))
-
-				if !g1.Equal(&g2) || !g1.Equal(&g3) {
-					return false
-				}
-				g.Square(&g)
-				n = n >> 1
-			}
-			return true
-		},
-		gen.Int32Range(0, int32(rho*size)),
-	))
-
 	properties.Property("verifying a correctly formed proof should succeed", prop.ForAll(
 
 		func(s int32) bool {
@@ -201,6 +143,238 @@ func TestFRI(t *testing.T) {
 
 }
 
+func TestFoldFiber(t *testing.T) {
+	const k = 8
+
+	// p = ∑ₛ Xˢ⋅pₛ(Xᵏ) with the pₛ of degree 1
+	var ps [k][2]fr.Element
+	for s := range ps {
+		ps[s][0].MustSetRandom()
+		ps[s][1].MustSetRandom()
+	}
+	evalP := func(x fr.Element) fr.Element {
+		var xk, xs, res, t fr.Element
+		xk.Exp(x, big.NewInt(k))
+		xs.SetOne()
+		for s := range ps {
+			t.Mul(&ps[s][1], &xk).Add(&t, &ps[s][0]).Mul(&t, &xs)
+			res.Add(&res, &t)
+			xs.Mul(&xs, &x)
+		}
+		return res
+	}
+
+	var x, beta fr.Element
+	x.MustSetRandom()
+	beta.MustSetRandom()
+	zetaInv, kInv, err := foldParameters(k)
+	require.NoError(t, err)
+	zeta, err := fr.Generator(k)
+	require.NoError(t, err)
+	e := make([]fr.Element, k)
+	xzt := x
+	for i := range e {
+		e[i] = evalP(xzt)
+		xzt.Mul(&xzt, &zeta)
+	}
+	var xInv fr.Element
+	xInv.Inverse(&x)
+	folded := foldFiber(e, zetaInv, &xInv, &beta, &kInv)
+
+	// ∑ₛ βˢ⋅pₛ(xᵏ)
+	var xk, betaS, expected, tmp fr.Element
+	xk.Exp(x, big.NewInt(k))
+	betaS.SetOne()
+	for s := range ps {
+		tmp.Mul(&ps[s][1], &xk).Add(&tmp, &ps[s][0]).Mul(&tmp, &betaS)
+		expected.Add(&expected, &tmp)
+		betaS.Mul(&betaS, &beta)
+	}
+	require.True(t, expected.Equal(&folded))
+}
+
+func TestFRIParameters(t *testing.T) {
+	const size = 1000
+
+	p := make([]fr.Element, size)
+	for i := range p {
+		p[i].MustSetRandom()
+	}
+
+	for _, params := range []Parameters{
+		DefaultParameters(),
+		{Rate: 2, NbQueries: 20, FoldingArity: 2, FinalPolynomialSize: 1},
+		{Rate: 4, NbQueries: 10, FoldingArity: 4, FinalPolynomialSize: 8, GrindingBits: 8},
+		{Rate: 8, NbQueries: 5, FoldingArity: 8, FinalPolynomialSize: 4},
+		{Rate: 16, NbQueries: 3, FoldingArity: 16, FinalPolynomialSize: 1, GrindingBits: 4},
+		{Rate: 2, NbQueries: 4, FoldingArity: 16, FinalPolynomialSize: 2048},
+	} {
+		t.Run(fmt.Sprintf("%+v", params), func(t *testing.T) {
+			iop, err := RADIX_2_FRI.NewWithParameters(size, sha256.New(), params)
+			require.NoError(t, err)
+			proof, err := iop.BuildProofOfProximity(p)
+			require.NoError(t, err)
+			require.Len(t, proof.Rounds, params.NbQueries)
+			require.LessOrEqual(t, len(proof.FinalPolynomial), params.FinalPolynomialSize)
+			require.NoError(t, iop.VerifyProofOfProximity(proof))
+
+			// serialization
+			var buf bytes.Buffer
+			_, err = proof.WriteTo(&buf)
+			require.NoError(t, err)
+			var decoded ProofOfProximity
+			_, err = decoded.ReadFrom(&buf)
+			require.NoError(t, err)
+			require.Equal(t, proof, decoded)
+			require.NoError(t, iop.VerifyProofOfProximity(decoded))
+
+			// openings
+			openingProof, err := iop.Open(p, 17)
+			require.NoError(t, err)
+			require.NoError(t, iop.VerifyOpening(17, openingProof, proof))
+
+			// tampered final polynomial
+			one := fr.One()
+			proof.FinalPolynomial[0].Add(&proof.FinalPolynomial[0], &one)
+			require.Error(t, iop.VerifyProofOfProximity(proof))
+			proof.FinalPolynomial[0].Sub(&proof.FinalPolynomial[0], &one)
+
+			// tampered proof of work
+			if params.GrindingBits > 0 {
+				proof.Nonce++
+				require.Error(t, iop.VerifyProofOfProximity(proof))
+				proof.Nonce--
+			}
+
+			// tampered leaf
+			leaf := proof.Rounds[0].Interactions[0].ProofSet[0]
+			leaf[len(leaf)-1] ^= 1
+			require.Error(t, iop.VerifyProofOfProximity(proof))
+		})
+	}
+
+	// a polynomial of too high degree is rejected by the verifier
+	iop, err := RADIX_2_FRI.NewWithParameters(size/2, sha256.New(), Parameters{Rate: 2, NbQueries: 64, FoldingArity: 4, FinalPolynomialSize: 1})
+	require.NoError(t, err)
+	proof, err := iop.BuildProofOfProximity(p)
+	require.NoError(t, err)
+	require.Error(t, iop.VerifyProofOfProximity(proof))
+
+	for _, params := range []Parameters{
+		{Rate: 3, NbQueries: 1, FoldingArity: 2, FinalPolynomialSize: 1},
+		{Rate: 2, NbQueries: 0, FoldingArity: 2, FinalPolynomialSize: 1},
+		{Rate: 2, NbQueries: 1, FoldingArity: 32, FinalPolynomialSize: 1},
+		{Rate: 2, NbQueries: 1, FoldingArity: 2, FinalPolynomialSize: 3},
+	} {
+		_, err := RADIX_2_FRI.NewWithParameters(size, sha256.New(), params)
+		require.ErrorIs(t, err, ErrInvalidParameters)
+	}
+
+	require.Equal(t, 34, NbQueriesForSecurity(100, 8, 0))
+	require.Equal(t, 40, NbQueriesForSecurity(100, 4, 20))
+}
+
+func TestBatchOpening(t *testing.T) {
+	const size = 256
+
+	// two matrices of polynomials of different sizes
+	sizes := [][]int{
+		{size, 3, size - 5},
+		{1, size / 2},
+	}
+	polynomials := make([][][]fr.Element, len(sizes))
+	for m := range sizes {
+		polynomials[m] = make([][]fr.Element, len(sizes[m]))
+		for i, d := range sizes[m] {
+			polynomials[m][i] = make([]fr.Element, d)
+			for j := range polynomials[m][i] {
+				polynomials[m][i][j].MustSetRandom()
+			}
+		}
+	}
+	data := []byte("data")
+
+	for _, params := range []Parameters{
+		DefaultParameters(),
+		{Rate: 2, NbQueries: 10, FoldingArity: 4, FinalPolynomialSize: 8, GrindingBits: 4},
+		{Rate: 4, NbQueries: 5, FoldingArity: 16, FinalPolynomialSize: 1},
+		{Rate: 2, NbQueries: 3, FoldingArity: 8, FinalPolynomialSize: size},
+	} {
+		t.Run(fmt.Sprintf("%+v", params), func(t *testing.T) {
+			iop, err := RADIX_2_FRI.NewWithParameters(size, sha256.New(), params)
+			require.NoError(t, err)
+			s := iop.(radixTwoFri)
+			shifts := []fr.Element{fr.One(), s.domain.Generator}
+
+			matrices := make([]*Matrix, len(polynomials))
+			commitments := make([]MatrixCommitment, len(polynomials))
+			for m := range polynomials {
+				matrices[m], err = iop.CommitMatrix(polynomials[m])
+				require.NoError(t, err)
+				commitments[m] = matrices[m].Commitment
+			}
+
+			proof, err := iop.BatchOpen(matrices, shifts, data)
+			require.NoError(t, err)
+			require.NoError(t, iop.VerifyBatchOpening(commitments, shifts, proof, data))
+
+			// claimed values
+			for m := range polynomials {
+				for i := range polynomials[m] {
+					for k := range shifts {
+						var x fr.Element
+						x.Mul(&proof.Point, &shifts[k])
+						expected := eval(polynomials[m][i], x)
+						require.True(t, expected.Equal(&proof.ClaimedValues[m][i][k]))
+					}
+				}
+			}
+
+			// serialization
+			var buf bytes.Buffer
+			_, err = proof.WriteTo(&buf)
+			require.NoError(t, err)
+			var decoded BatchOpeningProof
+			_, err = decoded.ReadFrom(&buf)
+			require.NoError(t, err)
+			require.Equal(t, proof, decoded)
+			require.NoError(t, iop.VerifyBatchOpening(commitments, shifts, decoded, data))
+
+			// wrong data transcript
+			require.Error(t, iop.VerifyBatchOpening(commitments, shifts, proof, []byte("wrong")))
+
+			// tampered claimed value
+			one := fr.One()
+			proof.ClaimedValues[1][0][1].Add(&proof.ClaimedValues[1][0][1], &one)
+			require.Error(t, iop.VerifyBatchOpening(commitments, shifts, proof, data))
+			proof.ClaimedValues[1][0][1].Sub(&proof.ClaimedValues[1][0][1], &one)
+
+			// tampered row
+			leaf := proof.Rows[0][1].ProofSet[0]
+			leaf[len(leaf)-1] ^= 1
+			require.Error(t, iop.VerifyBatchOpening(commitments, shifts, proof, data))
+			leaf[len(leaf)-1] ^= 1
+			require.NoError(t, iop.VerifyBatchOpening(commitments, shifts, proof, data))
+		})
+	}
+
+	// a polynomial larger than its declared size is rejected
+	params := Parameters{Rate: 2, NbQueries: 64, FoldingArity: 4, FinalPolynomialSize: 1}
+	iop, err := RADIX_2_FRI.NewWithParameters(size, sha256.New(), params)
+	require.NoError(t, err)
+	shifts := []fr.Element{fr.One()}
+	matrix, err := iop.CommitMatrix(polynomials[0])
+	require.NoError(t, err)
+	matrix.Commitment.Sizes[1] = 1
+	proof, err := iop.BatchOpen([]*Matrix{matrix}, shifts)
+	require.NoError(t, err)
+	require.Error(t, iop.VerifyBatchOpening([]MatrixCommitment{matrix.Commitment}, shifts, proof))
+
+	// polynomials too large for the domain are rejected
+	_, err = iop.CommitMatrix([][]fr.Element{make([]fr.Element, size+1)})
+	require.ErrorIs(t, err, ErrLowDegree)
+}
+
 // Benchmarks
 
 func BenchmarkProximityVerification(b *testing.B) {
@@ -225,3 +399,22 @@ func BenchmarkProximityVerification(b *testing.B) {
 
 	}
 }
+
+func BenchmarkBuildProofOfProximity(b *testing.B) {
+	const size = 1 << 14
+	p := make(fr.Vector, size)
+	p.MustSetRandom()
+
+	for _, arity := range []int{2, 4, 8, 16} {
+		params := Parameters{Rate: 4, NbQueries: NbQueriesForSecurity(100, 4, 16), FoldingArity: arity, FinalPolynomialSize: 16, GrindingBits: 16}
+		iop, err := RADIX_2_FRI.NewWithParameters(size, sha256.New(), params)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("arity %d", arity), func(b *testing.B) {
+			for l := 0; l < b.N; l++ {
+				iop.BuildProofOfProximity(p)
+			}
+		})
+	}
+}
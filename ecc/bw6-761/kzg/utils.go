@@ -31,7 +31,7 @@ func ToLagrangeG1(coeffs []curve.G1Affine) ([]curve.G1Affine, error) {
 	numCPU := uint64(runtime.NumCPU())
 	maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(numCPU)) << 1
 
-	twiddlesInv, err := computeTwiddlesInv(size)
+	twiddlesInv, err := computeTwiddles(size, true)
 	if err != nil {
 		return nil, err
 	}
@@ -63,14 +63,18 @@ func ToLagrangeG1(coeffs []curve.G1Affine) ([]curve.G1Affine, error) {
 	return curve.BatchJacobianToAffineG1(jCoeffs), nil
 }
 
-func computeTwiddlesInv(cardinality int) ([]*big.Int, error) {
+// computeTwiddles returns the powers of the generator of the subgroup of
+// order cardinality used by difFFTG1, or of its inverse.
+func computeTwiddles(cardinality int, inverse bool) ([]*big.Int, error) {
 	generator, err := fr.Generator(uint64(cardinality))
 	if err != nil {
 		return nil, err
 	}
 
 	// inverse the generator
-	generator.Inverse(&generator)
+	if inverse {
+		generator.Inverse(&generator)
+	}
 
 	// nb fft stages
 	nbStages := uint64(bits.TrailingZeros64(uint64(cardinality)))
@@ -0,0 +1,112 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// newBatch returns n signatures of random messages, with some public keys
+// signing several messages.
+func newBatch(tb testing.TB, n int) (pubs []*PublicKey, msgs, sigs [][]byte, vs []uint) {
+	hFunc := sha256.New()
+	pubs = make([]*PublicKey, n)
+	msgs = make([][]byte, n)
+	sigs = make([][]byte, n)
+	vs = make([]uint, n)
+	var privKey *PrivateKey
+	for i := 0; i < n; i++ {
+		if i%3 != 2 {
+			var err error
+			if privKey, err = GenerateKey(rand.Reader); err != nil {
+				tb.Fatal(err)
+			}
+		}
+		pubs[i] = &privKey.PublicKey
+		msgs[i] = []byte(fmt.Sprintf("transaction %d", i))
+		v, r, s, err := privKey.SignForRecover(msgs[i], hFunc)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		var sig Signature
+		r.FillBytes(sig.R[:sizeFr])
+		s.FillBytes(sig.S[:sizeFr])
+		sigs[i], vs[i] = sig.Bytes(), v
+	}
+	return
+}
+
+func TestBatchVerify(t *testing.T) {
+	t.Parallel()
+	hFunc := sha256.New()
+	pubs, msgs, sigs, vs := newBatch(t, 20)
+
+	// valid batch
+	res, err := BatchVerify(pubs, msgs, sigs, vs, hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("BatchVerify of correct signatures should return true")
+	}
+
+	// a wrong recovery information does not reject valid signatures
+	vs[4] ^= 1
+	if res, err := BatchVerify(pubs, msgs, sigs, vs, hFunc); err != nil || !res {
+		t.Fatal("BatchVerify should fall back to Verify")
+	}
+	if batchVerify(pubs, msgs, sigs, vs, hFunc) {
+		t.Fatal("the combined equation should not hold with a wrong R")
+	}
+	vs[4] ^= 1
+
+	// wrong message
+	msgs[7], msgs[8] = msgs[8], msgs[7]
+	res, err = BatchVerify(pubs, msgs, sigs, vs, hFunc)
+	var sigErr *InvalidSignatureError
+	if res || !errors.As(err, &sigErr) || sigErr.Index != 7 {
+		t.Fatal("BatchVerify should report the first wrong signature")
+	}
+	msgs[7], msgs[8] = msgs[8], msgs[7]
+
+	// malformed signature
+	sigs[3] = sigs[3][:sizeFr]
+	res, err = BatchVerify(pubs, msgs, sigs, vs, hFunc)
+	if res || !errors.As(err, &sigErr) || sigErr.Index != 3 || !errors.Is(err, errWrongSize) {
+		t.Fatal("BatchVerify should report the malformed signature")
+	}
+
+	// inputs of different lengths
+	if _, err := BatchVerify(pubs, msgs, sigs, vs[1:], hFunc); err == nil {
+		t.Fatal("BatchVerify should fail on inputs of different lengths")
+	}
+}
+
+func BenchmarkBatchVerify(b *testing.B) {
+	hFunc := sha256.New()
+	pubs, msgs, sigs, vs := newBatch(b, 1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BatchVerify(pubs, msgs, sigs, vs, hFunc)
+	}
+}
+
+// BenchmarkVerifyOneByOne is the baseline of BenchmarkBatchVerify: the same
+// signatures verified with Verify.
+func BenchmarkVerifyOneByOne(b *testing.B) {
+	hFunc := sha256.New()
+	pubs, msgs, sigs, _ := newBatch(b, 1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range pubs {
+			pubs[j].Verify(sigs[j], msgs[j], hFunc)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"crypto/rand"
 	"crypto/sha512"
 	"crypto/subtle"
+	"errors"
 	"hash"
 	"io"
 	"math/big"
@@ -30,6 +31,13 @@ const (
 	sizeSignature  = 2 * sizeFr
 )
 
+var (
+	// ErrNoSqrtR is returned when x^3+ax+b is not a square in the field. This
+	// is used for public key recovery and allows to detect if the signature is
+	// valid or not.
+	ErrNoSqrtR = errors.New("x^3+ax+b is not a square in the field")
+)
+
 var order = fr.Modulus()
 
 // PublicKey represents an ECDSA public key
@@ -97,6 +105,49 @@ func HashToInt(hash []byte) *big.Int {
 	return ret
 }
 
+// recoverP recovers the value P (prover commitment) when creating a signature.
+// It uses the recovery information v and part of the decomposed signature r. It
+// is used internally for recovering the public key.
+func recoverP(v uint, r *big.Int) (*grumpkin.G1Affine, error) {
+	if r.Cmp(fr.Modulus()) >= 0 {
+		return nil, errors.New("r is larger than modulus")
+	}
+	if r.Cmp(big.NewInt(0)) <= 0 {
+		return nil, errors.New("r is negative")
+	}
+	x := new(big.Int).Set(r)
+	// if x is r or r+N
+	xChoice := (v & 2) >> 1
+	// if y is y or -y
+	yChoice := v & 1
+	// decompose limbs into big.Int value
+	// conditional +n based on xChoice
+	kn := big.NewInt(int64(xChoice))
+	kn.Mul(kn, fr.Modulus())
+	x.Add(x, kn)
+	if x.Cmp(fp.Modulus()) >= 0 {
+		return nil, errors.New("x is larger than modulus")
+	}
+	var P grumpkin.G1Affine
+	P.X.SetBigInt(x)
+	// y^2 = x^3+ax+b
+	a, b := grumpkin.CurveCoefficients()
+	var y2, ax fp.Element
+	y2.Square(&P.X).Mul(&y2, &P.X).Add(&y2, &b)
+	ax.Mul(&a, &P.X)
+	y2.Add(&y2, &ax)
+	// y = sqrt(y^2)
+	if P.Y.Sqrt(&y2) == nil {
+		// there is no square root, return error constant
+		return nil, ErrNoSqrtR
+	}
+	// check that y has same oddity as defined by v
+	if P.Y.BigInt(new(big.Int)).Bit(0) != yChoice {
+		P.Y.Neg(&P.Y)
+	}
+	return &P, nil
+}
+
 type zr struct{}
 
 // Read replaces the contents of dst with zeros. It is safe for concurrent use.
@@ -172,27 +223,29 @@ func (privKey *PrivateKey) Public() signature.PublicKey {
 	return &pub
 }
 
-// Sign performs the ECDSA signature
+// SignForRecover performs the ECDSA signature and returns public key recovery information
 //
 // k ← 𝔽r (random)
 // P = k ⋅ g1Gen
 // r = x_P (mod order)
 // s = k⁻¹ . (m + sk ⋅ r)
-// signature = {r, s}
+// v = (div(x_P, order)<<1) || y_P[-1]
 //
 // SEC 1, Version 2.0, Section 4.1.3
-func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
-	scalar, r, s, kInv := new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+func (privKey *PrivateKey) SignForRecover(message []byte, hFunc hash.Hash) (v uint, r, s *big.Int, err error) {
+	r, s = new(big.Int), new(big.Int)
+
+	scalar, kInv := new(big.Int), new(big.Int)
 	scalar.SetBytes(privKey.scalar[:sizeFr])
 	for {
 		for {
 			csprng, err := nonce(privKey, message)
 			if err != nil {
-				return nil, err
+				return 0, nil, nil, err
 			}
 			k, err := randFieldElement(csprng)
 			if err != nil {
-				return nil, err
+				return 0, nil, nil, err
 			}
 
 			var P grumpkin.G1Affine
@@ -200,6 +253,10 @@ func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error)
 			kInv.ModInverse(k, order)
 
 			P.X.BigInt(r)
+			// set how many times we overflow the scalar field
+			v |= (uint(new(big.Int).Div(r, order).Uint64())) << 1
+			// set if y is even or odd
+			v |= P.Y.BigInt(new(big.Int)).Bit(0)
 
 			r.Mod(r, order)
 			if r.Sign() != 0 {
@@ -216,7 +273,7 @@ func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error)
 			hFunc.Reset()
 			_, err := hFunc.Write(dataToHash[:])
 			if err != nil {
-				return nil, err
+				return 0, nil, nil, err
 			}
 			hramBin := hFunc.Sum(nil)
 			m = HashToInt(hramBin)
@@ -232,6 +289,23 @@ func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error)
 		}
 	}
 
+	return v, r, s, nil
+}
+
+// Sign performs the ECDSA signature
+//
+// k ← 𝔽r (random)
+// P = k ⋅ g1Gen
+// r = x_P (mod order)
+// s = k⁻¹ . (m + sk ⋅ r)
+// signature = {r, s}
+//
+// SEC 1, Version 2.0, Section 4.1.3
+func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	_, r, s, err := privKey.SignForRecover(message, hFunc)
+	if err != nil {
+		return nil, err
+	}
 	var sig Signature
 	r.FillBytes(sig.R[:sizeFr])
 	s.FillBytes(sig.S[:sizeFr])
@@ -8,6 +8,8 @@ package ecdsa
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"github.com/consensys/gnark-crypto/ecc/grumpkin"
+	"github.com/consensys/gnark-crypto/ecc/grumpkin/fp"
 	"github.com/consensys/gnark-crypto/ecc/grumpkin/fr"
 	"math/big"
 	"testing"
@@ -53,6 +55,58 @@ func TestECDSA(t *testing.T) {
 
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
+func TestRecoverPublicKey(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	properties := gopter.NewProperties(parameters)
+	properties.Property("[GRUMPKIN] test public key recover", prop.ForAll(
+		func() bool {
+			sk, err := GenerateKey(rand.Reader)
+			if err != nil {
+				return false
+			}
+			pk := sk.PublicKey
+			msg := []byte("test")
+			v, r, s, err := sk.SignForRecover(msg, nil)
+			if err != nil {
+				return false
+			}
+			var recovered PublicKey
+			if err = recovered.RecoverFrom(msg, v, r, s); err != nil {
+				return false
+			}
+			return pk.Equal(&recovered)
+		},
+	))
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestRecoverPOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	// for r close to n, r+n ≥ p cannot be the x coordinate of R, even when
+	// r+n-p is the x coordinate of a point of the curve
+	r := new(big.Int).Sub(fr.Modulus(), big.NewInt(1))
+	a, b := grumpkin.CurveCoefficients()
+	for {
+		var x, y2, ax fp.Element
+		x.SetBigInt(new(big.Int).Add(r, fr.Modulus()))
+		y2.Square(&x).Mul(&y2, &x).Add(&y2, &b)
+		ax.Mul(&a, &x)
+		if y2.Add(&y2, &ax); y2.Legendre() == 1 {
+			break
+		}
+		r.Sub(r, big.NewInt(1))
+	}
+	if new(big.Int).Add(r, fr.Modulus()).Cmp(fp.Modulus()) < 0 {
+		t.Skip("r+n is always smaller than p")
+	}
+	for v := uint(2); v < 4; v++ {
+		if _, err := recoverP(v, r); err == nil {
+			t.Fatal("recovered a point whose x coordinate r+n is not smaller than p")
+		}
+	}
+}
 
 func TestNonMalleability(t *testing.T) {
 
@@ -153,3 +207,20 @@ func BenchmarkVerifyECDSA(b *testing.B) {
 		privKey.PublicKey.Verify(sig, msg, nil)
 	}
 }
+func BenchmarkRecoverPublicKey(b *testing.B) {
+	sk, err := GenerateKey(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	msg := []byte("bench")
+	v, r, s, err := sk.SignForRecover(msg, sha256.New())
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < b.N; i++ {
+		var recovered PublicKey
+		if err = recovered.RecoverFrom(msg, v, r, s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ipa
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/grumpkin"
+	"github.com/consensys/gnark-crypto/ecc/grumpkin/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// sizePoint is the size in bytes of a compressed point
+const sizePoint = curve.SizeOfG1AffineCompressed
+
+// multiExp returns ∑ᵢ scalars[i]⋅points[i]
+func multiExp(points []curve.G1Affine, scalars []fr.Element) (curve.G1Affine, error) {
+	var res curve.G1Affine
+	_, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{})
+	return res, err
+}
+
+// foldBases sets res[i] = left[i] + x⋅right[i]. res may be left.
+func foldBases(res, left, right []curve.G1Affine, x *fr.Element) {
+	var xBig big.Int
+	x.BigInt(&xBig)
+	resJac := make([]curve.G1Jac, len(res))
+	parallel.Execute(len(res), func(start, end int) {
+		for i := start; i < end; i++ {
+			resJac[i].FromAffine(&right[i])
+			resJac[i].ScalarMultiplication(&resJac[i], &xBig)
+			resJac[i].AddMixed(&left[i])
+		}
+	})
+	copy(res, curve.BatchJacobianToAffineG1(resJac))
+}
+
+// hashToGroup hashes msg to a point of the group
+func hashToGroup(msg, dst []byte) (curve.G1Affine, error) {
+	res, err := curve.HashToG1(msg, dst)
+	if err != nil {
+		return curve.G1Affine{}, err
+	}
+	if res.IsInfinity() {
+		return curve.G1Affine{}, errors.New("hashed to the point at infinity")
+	}
+	return res, nil
+}
+
+// setBytes decodes a compressed point, which must be in the prime order
+// subgroup.
+func setBytes(p *curve.G1Affine, buf []byte) error {
+	_, err := p.SetBytes(buf)
+	return err
+}
@@ -0,0 +1,486 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ipa
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/grumpkin"
+	"github.com/consensys/gnark-crypto/ecc/grumpkin/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidPolynomialSize = errors.New("invalid polynomial size (larger than SRS or == 0)")
+	ErrInvalidNbDigests      = errors.New("number of digests is not the same as the number of proofs")
+	ErrInvalidNbPoints       = errors.New("number of points is not the same as the number of proofs")
+	ErrInvalidProofSize      = errors.New("invalid number of rounds in the opening proof")
+	ErrVerifyOpeningProof    = errors.New("can't verify opening proof")
+	ErrMinSRSSize            = errors.New("minimum srs size is 1")
+)
+
+// Digest commitment of a polynomial.
+type Digest = curve.G1Affine
+
+// SRS is the transparent setup of the scheme, shared by the prover and the
+// verifier.
+type SRS struct {
+	// G are the bases of the commitments to the coefficients
+	G []curve.G1Affine
+	// U is the base of the inner products
+	U curve.G1Affine
+}
+
+// OpeningProof proof that the polynomial committed to in a digest evaluates
+// to ClaimedValue at a point.
+type OpeningProof struct {
+	// L, R are the cross terms of the folding rounds
+	L, R []curve.G1Affine
+
+	// A is the coefficient of the polynomial folded down to a constant
+	A fr.Element
+
+	// ClaimedValue purported value
+	ClaimedValue fr.Element
+}
+
+// NewSRS returns an SRS of size the next power of two of size. The bases are
+// derived by hashing to the curve, seed being the domain separation tag, so
+// that there is no trusted setup.
+func NewSRS(size uint64, seed []byte) (*SRS, error) {
+	if size == 0 {
+		return nil, ErrMinSRSSize
+	}
+	size = ecc.NextPowerOfTwo(size)
+
+	var srs SRS
+	var err error
+	if srs.U, err = hashToGroup([]byte("U"), seed); err != nil {
+		return nil, err
+	}
+
+	srs.G = make([]curve.G1Affine, size)
+	var lock sync.Mutex
+	parallel.Execute(len(srs.G), func(start, end int) {
+		var msg [8]byte
+		for i := start; i < end; i++ {
+			binary.BigEndian.PutUint64(msg[:], uint64(i))
+			g, errG := hashToGroup(msg[:], seed)
+			if errG != nil {
+				lock.Lock()
+				err = errG
+				lock.Unlock()
+				return
+			}
+			srs.G[i] = g
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &srs, nil
+}
+
+// Commit commits to a polynomial given by its coefficients in canonical
+// basis, as the Pedersen vector commitment ∑ᵢ p[i]⋅G[i].
+func Commit(p []fr.Element, srs *SRS) (Digest, error) {
+	if len(p) == 0 || len(p) > len(srs.G) {
+		return Digest{}, ErrInvalidPolynomialSize
+	}
+	return multiExp(srs.G[:len(p)], p)
+}
+
+// Open computes an opening proof of the polynomial p, committed to in digest,
+// at the given point.
+//
+// The coefficients of p are padded with zeroes to the next power of two n,
+// which must not be larger than the size of the SRS, and the proof has log(n)
+// rounds. The transcript is bound to the digest, the point, the claimed value
+// and the optional dataTranscript.
+func Open(p []fr.Element, digest Digest, point fr.Element, hf hash.Hash, srs *SRS, dataTranscript ...[]byte) (OpeningProof, error) {
+	if len(p) == 0 || ecc.NextPowerOfTwo(uint64(len(p))) > uint64(len(srs.G)) {
+		return OpeningProof{}, ErrInvalidPolynomialSize
+	}
+	n := int(ecc.NextPowerOfTwo(uint64(len(p))))
+	nbRounds := bits.TrailingZeros(uint(n))
+
+	// the argument is that C = ⟨a, G⟩ + ⟨a, b⟩⋅w⋅U, with a the coefficients
+	// and b the powers of the point
+	a := make([]fr.Element, n)
+	copy(a, p)
+	b := make([]fr.Element, n)
+	b[0].SetOne()
+	for i := 1; i < n; i++ {
+		b[i].Mul(&b[i-1], &point)
+	}
+
+	var proof OpeningProof
+	proof.ClaimedValue = innerProduct(a, b)
+
+	fs := fiatshamir.NewTranscript(hf, challengeNames(nbRounds)...)
+	u, err := deriveU(fs, &digest, &point, &proof.ClaimedValue, &srs.U, dataTranscript)
+	if err != nil {
+		return OpeningProof{}, err
+	}
+	challenge := func(round int, l, r *curve.G1Affine) (fr.Element, error) {
+		return deriveX(fs, round, l, r)
+	}
+	if proof.L, proof.R, proof.A, err = ProveInnerProduct(a, b, srs.G[:n], &u, challenge); err != nil {
+		return OpeningProof{}, err
+	}
+
+	return proof, nil
+}
+
+// Challenger returns the challenge of a folding round of an inner product
+// argument, bound to the cross terms l, r of the round.
+type Challenger func(round int, l, r *curve.G1Affine) (fr.Element, error)
+
+// ProveInnerProduct runs the folding rounds of an inner product argument for
+// C = ⟨a, g⟩ + ⟨a, b⟩⋅u, with a, b and g of the same power of two size n. It
+// returns the cross terms L, R of the log(n) rounds and a folded down to a
+// scalar.
+//
+// The folding is the one of go-ipa: with x the challenge of a round,
+// L = ⟨a_hi, g_lo⟩ + ⟨a_hi, b_lo⟩⋅u, R = ⟨a_lo, g_hi⟩ + ⟨a_lo, b_hi⟩⋅u,
+// and a ← a_lo + x⋅a_hi, b ← b_lo + x⁻¹⋅b_hi, g ← g_lo + x⁻¹⋅g_hi, so that
+// C + x⋅L + x⁻¹⋅R = ⟨a, g⟩ + ⟨a, b⟩⋅u after the round. The inputs are not
+// modified.
+func ProveInnerProduct(a, b []fr.Element, g []curve.G1Affine, u *curve.G1Affine, challenge Challenger) (l, r []curve.G1Affine, a0 fr.Element, err error) {
+	n := len(a)
+	if n == 0 || n&(n-1) != 0 || len(b) != n || len(g) != n {
+		return nil, nil, fr.Element{}, ErrInvalidPolynomialSize
+	}
+	nbRounds := bits.TrailingZeros(uint(n))
+	a = append([]fr.Element(nil), a...)
+	b = append([]fr.Element(nil), b...)
+	g = append([]curve.G1Affine(nil), g...)
+	l = make([]curve.G1Affine, nbRounds)
+	r = make([]curve.G1Affine, nbRounds)
+
+	var x, xInv, t fr.Element
+	for round := 0; round < nbRounds; round++ {
+		m := len(a) / 2
+		aLo, aHi := a[:m], a[m:]
+		bLo, bHi := b[:m], b[m:]
+		gLo, gHi := g[:m], g[m:]
+
+		if l[round], err = crossTerm(aHi, gLo, bLo, u); err != nil {
+			return nil, nil, fr.Element{}, err
+		}
+		if r[round], err = crossTerm(aLo, gHi, bHi, u); err != nil {
+			return nil, nil, fr.Element{}, err
+		}
+
+		if x, err = challenge(round, &l[round], &r[round]); err != nil {
+			return nil, nil, fr.Element{}, err
+		}
+		xInv.Inverse(&x)
+
+		for i := 0; i < m; i++ {
+			t.Mul(&aHi[i], &x)
+			aLo[i].Add(&aLo[i], &t)
+			t.Mul(&bHi[i], &xInv)
+			bLo[i].Add(&bLo[i], &t)
+		}
+		if round < nbRounds-1 {
+			foldBases(gLo, gLo, gHi, &xInv)
+		}
+		a, b, g = aLo, bLo, gLo
+	}
+
+	return l, r, a[0], nil
+}
+
+// FoldingScalars returns the coefficients s of the bases G in the base
+// ∑ᵢ sᵢ⋅Gᵢ folded by ProveInnerProduct, from the inverses of the challenges of
+// the rounds: sᵢ is the product of the xⱼ⁻¹ of the rounds j where i was in the
+// upper half.
+func FoldingScalars(xInv []fr.Element) []fr.Element {
+	nbRounds := len(xInv)
+	s := make([]fr.Element, 1<<nbRounds)
+	s[0].SetOne()
+	for j := nbRounds - 1; j >= 0; j-- {
+		half := 1 << (nbRounds - 1 - j)
+		for i := 0; i < half; i++ {
+			s[half+i].Mul(&s[i], &xInv[j])
+		}
+	}
+	return s
+}
+
+// Verify verifies an opening proof of the polynomial committed to in digest
+// at the given point.
+func Verify(digest *Digest, proof *OpeningProof, point fr.Element, hf hash.Hash, srs *SRS, dataTranscript ...[]byte) error {
+	scalars, err := computeVerifierScalars(digest, proof, point, hf, srs, dataTranscript)
+	if err != nil {
+		return err
+	}
+
+	// C = ∑ᵢ a⋅sᵢ⋅Gᵢ + (a⋅b - v)⋅w⋅U - ∑ⱼ (xⱼ⋅Lⱼ + xⱼ⁻¹⋅Rⱼ)
+	n := len(scalars.s)
+	bases := make([]curve.G1Affine, 0, n+1+2*len(proof.L))
+	bases = append(bases, srs.G[:n]...)
+	bases = append(bases, srs.U)
+	bases = append(bases, proof.L...)
+	bases = append(bases, proof.R...)
+	s := make([]fr.Element, 0, len(bases))
+	s = append(s, scalars.s...)
+	s = append(s, scalars.u)
+	s = append(s, scalars.l...)
+	s = append(s, scalars.r...)
+
+	c, err := multiExp(bases, s)
+	if err != nil {
+		return err
+	}
+	if !c.Equal(digest) {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// BatchVerify verifies a list of opening proofs at different points, of
+// polynomials of possibly different sizes, with a single multi-exponentiation.
+//
+// The verification equations are combined with random coefficients, so that
+// the sum of the multi-exponentiations over the bases G of the SRS is computed
+// once.
+func BatchVerify(digests []Digest, proofs []OpeningProof, points []fr.Element, hf hash.Hash, srs *SRS, dataTranscript ...[]byte) error {
+	if len(digests) != len(proofs) {
+		return ErrInvalidNbDigests
+	}
+	if len(points) != len(proofs) {
+		return ErrInvalidNbPoints
+	}
+	if len(proofs) == 0 {
+		return nil
+	}
+
+	// ∑ⱼ rⱼ⋅Cⱼ = ∑ⱼ rⱼ⋅(∑ᵢ aⱼ⋅sⱼᵢ⋅Gᵢ + (aⱼ⋅bⱼ - vⱼ)⋅wⱼ⋅U - ∑ₖ (xⱼₖ⋅Lⱼₖ + xⱼₖ⁻¹⋅Rⱼₖ))
+	// with r₀ = 1, all the terms but C₀ are gathered in a single multi-exponentiation
+	var gScalars []fr.Element
+	var uScalar, r fr.Element
+	var bases []curve.G1Affine
+	var s []fr.Element
+	for j := range proofs {
+		scalars, err := computeVerifierScalars(&digests[j], &proofs[j], points[j], hf, srs, dataTranscript)
+		if err != nil {
+			return err
+		}
+		if j == 0 {
+			r.SetOne()
+		} else if _, err = r.SetRandom(); err != nil {
+			return err
+		}
+
+		for len(gScalars) < len(scalars.s) {
+			gScalars = append(gScalars, fr.Element{})
+		}
+		var t fr.Element
+		for i := range scalars.s {
+			t.Mul(&scalars.s[i], &r)
+			gScalars[i].Add(&gScalars[i], &t)
+		}
+		t.Mul(&scalars.u, &r)
+		uScalar.Add(&uScalar, &t)
+
+		bases = append(bases, proofs[j].L...)
+		bases = append(bases, proofs[j].R...)
+		for i := range scalars.l {
+			scalars.l[i].Mul(&scalars.l[i], &r)
+			scalars.r[i].Mul(&scalars.r[i], &r)
+		}
+		s = append(s, scalars.l...)
+		s = append(s, scalars.r...)
+		if j > 0 {
+			bases = append(bases, digests[j])
+			t.Neg(&r)
+			s = append(s, t)
+		}
+	}
+	bases = append(bases, srs.U)
+	s = append(s, uScalar)
+	bases = append(bases, srs.G[:len(gScalars)]...)
+	s = append(s, gScalars...)
+
+	c, err := multiExp(bases, s)
+	if err != nil {
+		return err
+	}
+	if !c.Equal(&digests[0]) {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// verifierScalars are the scalars of the verification equation of an opening
+// proof of log(n) rounds,
+//
+//	C = ∑ᵢ a⋅sᵢ⋅Gᵢ + (a⋅b - v)⋅w⋅U - ∑ⱼ (xⱼ⋅Lⱼ + xⱼ⁻¹⋅Rⱼ)
+//
+// where sᵢ is the coefficient of Gᵢ in the folded base, see FoldingScalars, and
+// b = ⟨s, (1, z, ..., zⁿ⁻¹)⟩.
+type verifierScalars struct {
+	s    []fr.Element // a⋅sᵢ
+	u    fr.Element   // (a⋅b - v)⋅w
+	l, r []fr.Element // -xⱼ, -xⱼ⁻¹
+}
+
+// computeVerifierScalars replays the transcript of an opening proof and
+// returns the scalars of its verification equation.
+func computeVerifierScalars(digest *Digest, proof *OpeningProof, point fr.Element, hf hash.Hash, srs *SRS, dataTranscript [][]byte) (verifierScalars, error) {
+	nbRounds := len(proof.L)
+	if len(proof.R) != nbRounds || nbRounds >= bits.UintSize-1 || 1<<nbRounds > len(srs.G) {
+		return verifierScalars{}, ErrInvalidProofSize
+	}
+
+	fs := fiatshamir.NewTranscript(hf, challengeNames(nbRounds)...)
+	w, err := deriveChallenge(fs, "w", digest, &point, &proof.ClaimedValue, dataTranscript)
+	if err != nil {
+		return verifierScalars{}, err
+	}
+	x := make([]fr.Element, nbRounds)
+	for j := range x {
+		if x[j], err = deriveX(fs, j, &proof.L[j], &proof.R[j]); err != nil {
+			return verifierScalars{}, err
+		}
+		if x[j].IsZero() {
+			return verifierScalars{}, ErrVerifyOpeningProof
+		}
+	}
+	xInv := fr.BatchInvert(x)
+
+	var res verifierScalars
+	res.s = FoldingScalars(xInv)
+	for i := range res.s {
+		res.s[i].Mul(&res.s[i], &proof.A)
+	}
+
+	// b = ∏ⱼ (1 + xⱼ⁻¹⋅z^{2^{log(n)-1-j}})
+	var b, zPow, t, one fr.Element
+	one.SetOne()
+	b.SetOne()
+	zPow.Set(&point)
+	for j := nbRounds - 1; j >= 0; j-- {
+		t.Mul(&xInv[j], &zPow).Add(&t, &one)
+		b.Mul(&b, &t)
+		zPow.Square(&zPow)
+	}
+	res.u.Mul(&proof.A, &b).
+		Sub(&res.u, &proof.ClaimedValue).
+		Mul(&res.u, &w)
+
+	res.l = make([]fr.Element, nbRounds)
+	res.r = make([]fr.Element, nbRounds)
+	for j := range x {
+		res.l[j].Neg(&x[j])
+		res.r[j].Neg(&xInv[j])
+	}
+
+	return res, nil
+}
+
+// crossTerm returns ⟨a, g⟩ + ⟨a, b⟩⋅u
+func crossTerm(a []fr.Element, g []curve.G1Affine, b []fr.Element, u *curve.G1Affine) (curve.G1Affine, error) {
+	res, err := multiExp(g, a)
+	if err != nil {
+		return curve.G1Affine{}, err
+	}
+	var c big.Int
+	ab := innerProduct(a, b)
+	ab.BigInt(&c)
+	var cu curve.G1Affine
+	cu.ScalarMultiplication(u, &c)
+	res.Add(&res, &cu)
+	return res, nil
+}
+
+// innerProduct returns ⟨a, b⟩
+func innerProduct(a, b []fr.Element) fr.Element {
+	var res, t fr.Element
+	for i := range a {
+		t.Mul(&a[i], &b[i])
+		res.Add(&res, &t)
+	}
+	return res
+}
+
+// challengeNames returns the names of the challenges of a proof of nbRounds
+// rounds: w, then one challenge xⱼ per round.
+func challengeNames(nbRounds int) []string {
+	res := make([]string, nbRounds+1)
+	res[0] = "w"
+	for j := 0; j < nbRounds; j++ {
+		res[j+1] = "x" + strconv.Itoa(j)
+	}
+	return res
+}
+
+// deriveU returns w⋅U, where the challenge w is bound to the digest, the point
+// and the claimed value.
+func deriveU(fs *fiatshamir.Transcript, digest *Digest, point, claimedValue *fr.Element, u *curve.G1Affine, dataTranscript [][]byte) (curve.G1Affine, error) {
+	w, err := deriveChallenge(fs, "w", digest, point, claimedValue, dataTranscript)
+	if err != nil {
+		return curve.G1Affine{}, err
+	}
+	var wBig big.Int
+	w.BigInt(&wBig)
+	var res curve.G1Affine
+	res.ScalarMultiplication(u, &wBig)
+	return res, nil
+}
+
+// deriveX returns the challenge of a round, bound to its cross terms.
+func deriveX(fs *fiatshamir.Transcript, round int, l, r *curve.G1Affine) (fr.Element, error) {
+	name := "x" + strconv.Itoa(round)
+	if err := fs.Bind(name, l.Marshal()); err != nil {
+		return fr.Element{}, err
+	}
+	if err := fs.Bind(name, r.Marshal()); err != nil {
+		return fr.Element{}, err
+	}
+	b, err := fs.ComputeChallenge(name)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var x fr.Element
+	x.SetBytes(b)
+	return x, nil
+}
+
+func deriveChallenge(fs *fiatshamir.Transcript, name string, digest *Digest, point, claimedValue *fr.Element, dataTranscript [][]byte) (fr.Element, error) {
+	if err := fs.Bind(name, digest.Marshal()); err != nil {
+		return fr.Element{}, err
+	}
+	if err := fs.Bind(name, point.Marshal()); err != nil {
+		return fr.Element{}, err
+	}
+	if err := fs.Bind(name, claimedValue.Marshal()); err != nil {
+		return fr.Element{}, err
+	}
+	for i := range dataTranscript {
+		if err := fs.Bind(name, dataTranscript[i]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	b, err := fs.ComputeChallenge(name)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var res fr.Element
+	res.SetBytes(b)
+	return res, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package pedersen
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// blake3 is a minimal, single-threaded implementation of the BLAKE3 hash
+// function with the default 32 bytes output. Barretenberg derives its
+// generators with BLAKE3, which is not in the standard library.
+//
+// https://github.com/BLAKE3-team/BLAKE3-specs/blob/master/blake3.pdf
+
+const (
+	blake3BlockLen = 64
+	blake3ChunkLen = 1024
+
+	flagChunkStart = 1 << 0
+	flagChunkEnd   = 1 << 1
+	flagParent     = 1 << 2
+	flagRoot       = 1 << 3
+)
+
+var blake3IV = [8]uint32{
+	0x6A09E667, 0xBB67AE85, 0x3C6EF372, 0xA54FF53A,
+	0x510E527F, 0x9B05688C, 0x1F83D9AB, 0x5BE0CD19,
+}
+
+var blake3Permutation = [16]int{2, 6, 3, 10, 7, 0, 4, 13, 1, 11, 12, 5, 9, 14, 15, 8}
+
+// blake3Sum returns the 32 bytes BLAKE3 hash of data.
+func blake3Sum(data []byte) [32]byte {
+	cv := blake3Node(data, 0, true)
+	var res [32]byte
+	for i := range cv {
+		binary.LittleEndian.PutUint32(res[4*i:], cv[i])
+	}
+	return res
+}
+
+// blake3Node returns the chaining value of the subtree of the input, whose
+// first chunk has index counter. The left subtree holds the largest power of
+// two number of chunks that leaves at least one byte to the right subtree.
+func blake3Node(input []byte, counter uint64, root bool) [8]uint32 {
+	if len(input) <= blake3ChunkLen {
+		return blake3Chunk(input, counter, root)
+	}
+	nbChunks := uint64((len(input) + blake3ChunkLen - 1) / blake3ChunkLen)
+	nbLeft := uint64(1) << (bits.Len64(nbChunks-1) - 1)
+	left := blake3Node(input[:nbLeft*blake3ChunkLen], counter, false)
+	right := blake3Node(input[nbLeft*blake3ChunkLen:], counter+nbLeft, false)
+
+	var block [16]uint32
+	copy(block[:8], left[:])
+	copy(block[8:], right[:])
+	flags := uint32(flagParent)
+	if root {
+		flags |= flagRoot
+	}
+	return blake3Compress(&blake3IV, &block, 0, blake3BlockLen, flags)
+}
+
+// blake3Chunk returns the chaining value of a chunk of at most 1024 bytes.
+func blake3Chunk(chunk []byte, counter uint64, root bool) [8]uint32 {
+	cv := blake3IV
+	nbBlocks := max((len(chunk)+blake3BlockLen-1)/blake3BlockLen, 1)
+	for i := 0; i < nbBlocks; i++ {
+		var buf [blake3BlockLen]byte
+		n := copy(buf[:], chunk[i*blake3BlockLen:])
+		var block [16]uint32
+		for j := range block {
+			block[j] = binary.LittleEndian.Uint32(buf[4*j:])
+		}
+		var flags uint32
+		if i == 0 {
+			flags |= flagChunkStart
+		}
+		if i == nbBlocks-1 {
+			flags |= flagChunkEnd
+			if root {
+				flags |= flagRoot
+			}
+		}
+		cv = blake3Compress(&cv, &block, counter, uint32(n), flags)
+	}
+	return cv
+}
+
+// blake3Compress returns the first half of the output of the compression
+// function, which is the only one needed for a 32 bytes output.
+func blake3Compress(cv *[8]uint32, block *[16]uint32, counter uint64, blockLen, flags uint32) [8]uint32 {
+	s := [16]uint32{
+		cv[0], cv[1], cv[2], cv[3], cv[4], cv[5], cv[6], cv[7],
+		blake3IV[0], blake3IV[1], blake3IV[2], blake3IV[3],
+		uint32(counter), uint32(counter >> 32), blockLen, flags,
+	}
+	m := *block
+	for r := 0; r < 7; r++ {
+		blake3G(&s, 0, 4, 8, 12, m[0], m[1])
+		blake3G(&s, 1, 5, 9, 13, m[2], m[3])
+		blake3G(&s, 2, 6, 10, 14, m[4], m[5])
+		blake3G(&s, 3, 7, 11, 15, m[6], m[7])
+		blake3G(&s, 0, 5, 10, 15, m[8], m[9])
+		blake3G(&s, 1, 6, 11, 12, m[10], m[11])
+		blake3G(&s, 2, 7, 8, 13, m[12], m[13])
+		blake3G(&s, 3, 4, 9, 14, m[14], m[15])
+
+		var permuted [16]uint32
+		for i, j := range blake3Permutation {
+			permuted[i] = m[j]
+		}
+		m = permuted
+	}
+	var res [8]uint32
+	for i := range res {
+		res[i] = s[i] ^ s[i+8]
+	}
+	return res
+}
+
+// blake3G is the quarter-round function.
+func blake3G(s *[16]uint32, a, b, c, d int, mx, my uint32) {
+	s[a] += s[b] + mx
+	s[d] = bits.RotateLeft32(s[d]^s[a], -16)
+	s[c] += s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], -12)
+	s[a] += s[b] + my
+	s[d] = bits.RotateLeft32(s[d]^s[a], -8)
+	s[c] += s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], -7)
+}
@@ -0,0 +1,18 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Package pedersen implements the Pedersen commitment and hash on the grumpkin
+// curve, as in Barretenberg and in the Noir standard library
+// (std::hash::pedersen_commitment and std::hash::pedersen_hash).
+//
+// The inputs are elements of the base field of grumpkin, i.e. of the scalar
+// field of bn254, so that the outputs can be consumed by bn254 circuits. The
+// generators are derived deterministically from a domain separator string with
+// BLAKE3, there is no trusted setup.
+//
+// Pedersen commitments are binding but not hiding, and the Pedersen hash is
+// collision resistant but is not a random oracle.
+//
+// Documentation:
+//   - Barretenberg: https://github.com/AztecProtocol/aztec-packages/tree/master/barretenberg/cpp/src/barretenberg/crypto/pedersen_hash
+package pedersen
@@ -0,0 +1,145 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package pedersen
+
+import (
+	"encoding/binary"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/grumpkin"
+	"github.com/consensys/gnark-crypto/ecc/grumpkin/fp"
+)
+
+// DefaultDomainSeparator is the domain separator of the generators of Commit
+// and Hash.
+const DefaultDomainSeparator = "DEFAULT_DOMAIN_SEPARATOR"
+
+// lengthDomainSeparator is the domain separator of the generator multiplied by
+// the number of inputs in Hash.
+const lengthDomainSeparator = "pedersen_hash_length"
+
+var (
+	lengthGeneratorOnce sync.Once
+	lengthGenerator     grumpkin.G1Affine
+)
+
+// DeriveGenerators returns the generators of indices startingIndex, …,
+// startingIndex+n-1 of the domain separator.
+//
+// The generator of index i is HashToCurve(BLAKE3(domainSeparator) ∥ i), with i
+// a big endian uint32 followed by 28 zero bytes.
+func DeriveGenerators(domainSeparator []byte, n int, startingIndex uint32) []grumpkin.G1Affine {
+	domainHash := blake3Sum(domainSeparator)
+	var seed [2 * len(domainHash)]byte
+	copy(seed[:], domainHash[:])
+
+	res := make([]grumpkin.G1Affine, n)
+	for i := range res {
+		binary.BigEndian.PutUint32(seed[len(domainHash):len(domainHash)+4], startingIndex+uint32(i))
+		res[i] = HashToCurve(seed[:])
+	}
+	return res
+}
+
+// HashToCurve maps seed to a point of the curve by try-and-increment, as
+// Barretenberg's affine_element::hash_to_curve. At attempt a = 0, 1, …
+//
+//	hi = BLAKE3(seed ∥ a ∥ 0x00), lo = BLAKE3(seed ∥ a ∥ 0x01)
+//	x  = (hi ∥ lo) mod p
+//
+// and the first x on the curve is returned, with the y-coordinate whose parity
+// is the most significant bit of hi.
+//
+// This is not a constant time function and it is not a random oracle in the
+// sense of RFC 9380, it is only meant to derive public generators.
+func HashToCurve(seed []byte) grumpkin.G1Affine {
+	buf := make([]byte, len(seed)+2)
+	copy(buf, seed)
+
+	_, b := grumpkin.CurveCoefficients()
+	var x, y big.Int
+	var p grumpkin.G1Affine
+	modulus := fp.Modulus()
+	for attempt := 0; ; attempt++ {
+		buf[len(seed)] = byte(attempt)
+		buf[len(seed)+1] = 0
+		hi := blake3Sum(buf)
+		buf[len(seed)+1] = 1
+		lo := blake3Sum(buf)
+
+		x.SetBytes(append(hi[:], lo[:]...)).Mod(&x, modulus)
+		p.X.SetBigInt(&x)
+
+		// y² = x³ + b
+		var y2 fp.Element
+		y2.Square(&p.X).Mul(&y2, &p.X).Add(&y2, &b)
+		if p.Y.Sqrt(&y2) == nil {
+			continue
+		}
+		p.Y.BigInt(&y)
+		if sign := hi[0] > 127; (y.Bit(0) == 1) != sign {
+			p.Y.Neg(&p.Y)
+		}
+		return p
+	}
+}
+
+// Commit returns the Pedersen commitment ∑ inputs[i] ⋅ G_i, where G_i are
+// the generators of the default domain separator.
+func Commit(inputs []fp.Element) grumpkin.G1Affine {
+	return CommitWithSeparator(inputs, 0)
+}
+
+// CommitWithSeparator returns the Pedersen commitment ∑ inputs[i] ⋅ G_i,
+// where G_i are the generators of the default domain separator starting at
+// the index separator.
+func CommitWithSeparator(inputs []fp.Element, separator uint32) grumpkin.G1Affine {
+	generators := DeriveGenerators([]byte(DefaultDomainSeparator), len(inputs), separator)
+	var res grumpkin.G1Affine
+	res.FromJacobian(commit(inputs, generators))
+	return res
+}
+
+// Hash returns the Pedersen hash of the inputs, the x-coordinate of
+//
+//	len(inputs) ⋅ L + ∑ inputs[i] ⋅ G_i
+//
+// where G_i are the generators of the default domain separator and L is the
+// generator of the domain separator "pedersen_hash_length".
+func Hash(inputs []fp.Element) fp.Element {
+	return HashWithSeparator(inputs, 0)
+}
+
+// HashWithSeparator returns the Pedersen hash of the inputs, with generators
+// of the default domain separator starting at the index separator.
+func HashWithSeparator(inputs []fp.Element, separator uint32) fp.Element {
+	lengthGeneratorOnce.Do(func() {
+		lengthGenerator = DeriveGenerators([]byte(lengthDomainSeparator), 1, 0)[0]
+	})
+	generators := DeriveGenerators([]byte(DefaultDomainSeparator), len(inputs), separator)
+	res := commit(inputs, generators)
+
+	var length grumpkin.G1Jac
+	length.FromAffine(&lengthGenerator)
+	length.ScalarMultiplication(&length, big.NewInt(int64(len(inputs))))
+	res.AddAssign(&length)
+
+	var resAff grumpkin.G1Affine
+	resAff.FromJacobian(res)
+	return resAff.X
+}
+
+// commit returns ∑ inputs[i] ⋅ generators[i]. The inputs are elements of the
+// base field, smaller than the order of the curve, used as integers.
+func commit(inputs []fp.Element, generators []grumpkin.G1Affine) *grumpkin.G1Jac {
+	var res, tmp grumpkin.G1Jac
+	var s big.Int
+	for i := range inputs {
+		tmp.FromAffine(&generators[i])
+		tmp.ScalarMultiplication(&tmp, inputs[i].BigInt(&s))
+		res.AddAssign(&tmp)
+	}
+	return &res
+}
@@ -0,0 +1,124 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package pedersen
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/grumpkin/fp"
+)
+
+// The expected values are the test vectors of Barretenberg, in
+// crypto/generators/generator_data.test.cpp and
+// crypto/pedersen_hash/pedersen.test.cpp.
+
+func TestDeriveGenerators(t *testing.T) {
+	t.Parallel()
+	expected := [][2]string{
+		{"083e7911d835097629f0067531fc15cafd79a89beecb39903f69572c636f4a5a", "1a7f5efaad7f315c25a918f30cc8d7333fccab7ad7c90f14de81bcc528f9935d"},
+		{"054aa86a73cb8a34525e5bbed6e43ba1198e860f5f3950268f71df4591bde402", "209dcfbf2cfb57f9f6046f44d71ac6faf87254afc7407c04eb621a6287cac126"},
+	}
+	generators := DeriveGenerators([]byte(DefaultDomainSeparator), len(expected), 0)
+	for i := range expected {
+		if !generators[i].IsOnCurve() || !generators[i].IsInSubGroup() {
+			t.Fatal("generator not in the group")
+		}
+		if hexString(&generators[i].X) != expected[i][0] || hexString(&generators[i].Y) != expected[i][1] {
+			t.Fatalf("generator %d mismatch", i)
+		}
+	}
+
+	// the starting index offsets the generators
+	shifted := DeriveGenerators([]byte(DefaultDomainSeparator), 1, 1)
+	if !shifted[0].Equal(&generators[1]) {
+		t.Fatal("starting index mismatch")
+	}
+}
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+	var one fp.Element
+	one.SetOne()
+	inputs := []fp.Element{one, one}
+
+	h := Hash(inputs)
+	if hexString(&h) != "07ebfbf4df29888c6cd6dca13d4bb9d1a923013ddbbcbdc3378ab8845463297b" {
+		t.Fatal("hash mismatch")
+	}
+	h = HashWithSeparator(inputs, 5)
+	if hexString(&h) != "1c446df60816b897cda124524e6b03f36df0cec333fad87617aab70d7861daa6" {
+		t.Fatal("hash with separator mismatch")
+	}
+
+	// the length generator separates inputs padded with zeros
+	h2 := Hash([]fp.Element{one, one, {}})
+	if h2.Equal(&h) {
+		t.Fatal("hash is not length dependent")
+	}
+}
+
+func TestCommit(t *testing.T) {
+	t.Parallel()
+	var one fp.Element
+	one.SetOne()
+
+	c := Commit([]fp.Element{one, one})
+	if hexString(&c.X) != "2f7a8f9a6c96926682205fb73ee43215bf13523c19d7afe36f12760266cdfe15" ||
+		hexString(&c.Y) != "01916b316adbbf0e10e39b18c1d24b33ec84b46daddf72f43878bcc92b6057e6" {
+		t.Fatal("commitment mismatch")
+	}
+
+	// the commitment is additively homomorphic
+	var two fp.Element
+	two.Double(&one)
+	c1 := Commit([]fp.Element{one, {}})
+	c2 := Commit([]fp.Element{one, two})
+	c1.Add(&c1, &c2)
+	c2 = Commit([]fp.Element{two, two})
+	if !c1.Equal(&c2) {
+		t.Fatal("commitment is not homomorphic")
+	}
+}
+
+// https://github.com/BLAKE3-team/BLAKE3/blob/master/test_vectors/test_vectors.json,
+// the input of length n being the bytes i mod 251 for i < n.
+func TestBlake3(t *testing.T) {
+	t.Parallel()
+	vectors := []struct {
+		length int
+		digest string
+	}{
+		{0, "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"},
+		{1, "2d3adedff11b61f14c886e35afa036736dcd87a74d27b5c1510225d0f592e213"},
+		{1024, "42214739f095a406f3fc83deb889744ac00df831c10daa55189b5d121c855af7"},
+		{1025, "d00278ae47eb27b34faecf67b4fe263f82d5412916c1ffd97c8cb7fb814b8444"},
+	}
+	for _, v := range vectors {
+		input := make([]byte, v.length)
+		for i := range input {
+			input[i] = byte(i % 251)
+		}
+		digest := blake3Sum(input)
+		if hex.EncodeToString(digest[:]) != v.digest {
+			t.Fatalf("BLAKE3 mismatch for length %d", v.length)
+		}
+	}
+}
+
+func hexString(x *fp.Element) string {
+	b := x.Bytes()
+	return hex.EncodeToString(b[:])
+}
+
+func BenchmarkHash(b *testing.B) {
+	inputs := make([]fp.Element, 3)
+	for i := range inputs {
+		inputs[i].SetRandom()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Hash(inputs)
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Package schnorr implements the Schnorr signature scheme on the grumpkin
+// curve used by Barretenberg and by the Noir standard library, so that
+// signatures are interoperable with Noir circuits.
+//
+// A public key is the point P = x ⋅ G, serialized as the big endian x||y
+// coordinates, as Noir takes it. A signature of the message m is the 64 bytes
+// s||e, where
+//
+//	e = Blake2s(PedersenHash(R.x, P.x, P.y) ∥ m), R = k ⋅ G
+//	s = k - e ⋅ x mod n
+//
+// PedersenHash is the grumpkin Pedersen hash of the pedersen package, e is
+// kept as the raw 32 bytes digest and is reduced modulo n when used as a
+// scalar.
+//
+// Documentation:
+//   - Barretenberg: https://github.com/AztecProtocol/aztec-packages/tree/master/barretenberg/cpp/src/barretenberg/crypto/schnorr
+//   - Noir: https://github.com/noir-lang/schnorr
+package schnorr
@@ -0,0 +1,72 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package schnorr
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+)
+
+var errPublicKeyMismatch = errors.New("public key does not match the secret key")
+
+// Bytes returns the binary representation of the public key as x||y, where
+// x, y are the big endian coordinates of the point.
+func (pk *PublicKey) Bytes() []byte {
+	var res [SizePublicKey]byte
+	x := pk.A.X.Bytes()
+	y := pk.A.Y.Bytes()
+	copy(res[:sizeFp], x[:])
+	copy(res[sizeFp:], y[:])
+	return res[:]
+}
+
+// SetBytes sets pk from its binary representation x||y in buf. It fails if
+// a coordinate is not smaller than the field modulus or if the point is not
+// on the curve or is the point at infinity. It returns the number of bytes
+// read from the buffer.
+func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < SizePublicKey {
+		return 0, io.ErrShortBuffer
+	}
+	if err := pk.A.X.SetBytesCanonical(buf[:sizeFp]); err != nil {
+		return 0, ErrInvalidPublicKey
+	}
+	if err := pk.A.Y.SetBytesCanonical(buf[sizeFp:SizePublicKey]); err != nil {
+		return 0, ErrInvalidPublicKey
+	}
+	if pk.A.IsInfinity() || !pk.A.IsOnCurve() {
+		return 0, ErrInvalidPublicKey
+	}
+	return SizePublicKey, nil
+}
+
+// Bytes returns the binary representation of privKey as publicKey||scalar,
+// where publicKey is as in PublicKey.Bytes() and scalar is the big endian
+// secret key.
+func (privKey *PrivateKey) Bytes() []byte {
+	var res [sizePrivateKey]byte
+	pubBin := privKey.PublicKey.Bytes()
+	subtle.ConstantTimeCopy(1, res[:SizePublicKey], pubBin)
+	subtle.ConstantTimeCopy(1, res[SizePublicKey:], privKey.scalar[:])
+	return res[:]
+}
+
+// SetBytes sets privKey from buf, interpreted as publicKey||scalar. It checks
+// that the public key matches the secret key. It returns the number of bytes
+// read from the buffer.
+func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePrivateKey {
+		return 0, io.ErrShortBuffer
+	}
+	expected, err := NewPrivateKey(buf[SizePublicKey:sizePrivateKey])
+	if err != nil {
+		return 0, err
+	}
+	if subtle.ConstantTimeCompare(expected.PublicKey.Bytes(), buf[:SizePublicKey]) != 1 {
+		return 0, errPublicKeyMismatch
+	}
+	*privKey = *expected
+	return sizePrivateKey, nil
+}
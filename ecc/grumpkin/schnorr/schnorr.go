@@ -0,0 +1,215 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package schnorr
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/grumpkin"
+	"github.com/consensys/gnark-crypto/ecc/grumpkin/fp"
+	"github.com/consensys/gnark-crypto/ecc/grumpkin/fr"
+	"github.com/consensys/gnark-crypto/ecc/grumpkin/pedersen"
+	"github.com/consensys/gnark-crypto/signature"
+	"golang.org/x/crypto/blake2s"
+)
+
+const (
+	sizeFr = fr.Bytes
+	sizeFp = fp.Bytes
+
+	// SizePublicKey is the size in bytes of a public key x||y.
+	SizePublicKey = 2 * sizeFp
+	// SizeSecretKey is the size in bytes of a secret key.
+	SizeSecretKey = sizeFr
+	// SizeSignature is the size in bytes of a signature s||e.
+	SizeSignature = sizeFr + blake2s.Size
+
+	sizePrivateKey = SizePublicKey + SizeSecretKey
+)
+
+var (
+	ErrInvalidSecretKey = errors.New("secret key must be a non-zero scalar smaller than the group order")
+	ErrInvalidPublicKey = errors.New("public key is not on the curve or is the point at infinity")
+	ErrInvalidSignature = errors.New("invalid signature")
+	errWrongSize        = errors.New("wrong size buffer")
+)
+
+// PublicKey represents a Schnorr public key.
+type PublicKey struct {
+	A grumpkin.G1Affine
+}
+
+// PrivateKey represents a Schnorr private key.
+type PrivateKey struct {
+	PublicKey PublicKey
+	scalar    [sizeFr]byte // secret scalar, in big Endian
+}
+
+// GenerateKey generates a public and private key pair.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	var sk [SizeSecretKey]byte
+	for {
+		if _, err := io.ReadFull(rand, sk[:]); err != nil {
+			return nil, err
+		}
+		privKey, err := NewPrivateKey(sk[:])
+		if err == nil {
+			return privKey, nil
+		}
+	}
+}
+
+// NewPrivateKey returns the private key of the 32 bytes secret key sk, which
+// must be a big endian integer in [1, n-1].
+func NewPrivateKey(sk []byte) (*PrivateKey, error) {
+	if len(sk) != SizeSecretKey {
+		return nil, ErrInvalidSecretKey
+	}
+	var x fr.Element
+	if err := x.SetBytesCanonical(sk); err != nil || x.IsZero() {
+		return nil, ErrInvalidSecretKey
+	}
+	privKey := new(PrivateKey)
+	copy(privKey.scalar[:], sk)
+	privKey.PublicKey.A.ScalarMultiplicationBase(x.BigInt(new(big.Int)))
+	return privKey, nil
+}
+
+// Public returns the public key associated to the private key.
+func (privKey *PrivateKey) Public() signature.PublicKey {
+	var pub PublicKey
+	pub.A.Set(&privKey.PublicKey.A)
+	return &pub
+}
+
+// Sign signs the message with a nonce derived from the secret key, the
+// message and 32 bytes read from crypto/rand. If hFunc is provided, the
+// message is hashed with hFunc first.
+//
+// k = SHA-512(x ∥ rand ∥ m) mod n, R = k ⋅ G
+// e = Blake2s(PedersenHash(R.x, P.x, P.y) ∥ m)
+// signature = (k - e ⋅ x) ∥ e
+func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	message, err := prehash(message, hFunc)
+	if err != nil {
+		return nil, err
+	}
+	var x fr.Element
+	if err := x.SetBytesCanonical(privKey.scalar[:]); err != nil || x.IsZero() {
+		return nil, ErrInvalidSecretKey
+	}
+	var entropy [32]byte
+
+	for {
+		if _, err := io.ReadFull(rand.Reader, entropy[:]); err != nil {
+			return nil, err
+		}
+		h := sha512.New()
+		h.Write(privKey.scalar[:])
+		h.Write(entropy[:])
+		h.Write(message)
+		var k fr.Element
+		k.SetBytes(h.Sum(nil))
+		if k.IsZero() {
+			continue
+		}
+
+		var R grumpkin.G1Affine
+		R.ScalarMultiplicationBase(k.BigInt(new(big.Int)))
+		eBin := challenge(&R, &privKey.PublicKey.A, message)
+		var e, s fr.Element
+		e.SetBytes(eBin[:])
+		s.Mul(&e, &x).Sub(&k, &s)
+		if e.IsZero() || s.IsZero() {
+			continue
+		}
+
+		sig := make([]byte, SizeSignature)
+		sBin := s.Bytes()
+		copy(sig[:sizeFr], sBin[:])
+		copy(sig[sizeFr:], eBin[:])
+		return sig, nil
+	}
+}
+
+// Verify checks the signature of the message. If hFunc is provided, the
+// message is hashed with hFunc first.
+//
+// As Barretenberg and Noir, s and e are reduced modulo n and must not be zero,
+// the public key must be on the curve and not be the point at infinity, and
+//
+// R = s ⋅ G + e ⋅ P, R ?≠ ∞, Blake2s(PedersenHash(R.x, P.x, P.y) ∥ m) ?= e
+func (publicKey *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
+	if len(sigBin) != SizeSignature {
+		return false, errWrongSize
+	}
+	message, err := prehash(message, hFunc)
+	if err != nil {
+		return false, err
+	}
+	P := &publicKey.A
+	if P.IsInfinity() || !P.IsOnCurve() {
+		return false, nil
+	}
+
+	var s, e fr.Element
+	s.SetBytes(sigBin[:sizeFr])
+	e.SetBytes(sigBin[sizeFr:])
+	if s.IsZero() || e.IsZero() {
+		return false, nil
+	}
+
+	var RJac grumpkin.G1Jac
+	RJac.JointScalarMultiplicationBase(P, s.BigInt(new(big.Int)), e.BigInt(new(big.Int)))
+	if RJac.Z.IsZero() {
+		return false, nil
+	}
+	var R grumpkin.G1Affine
+	R.FromJacobian(&RJac)
+
+	// compare the hashes rather than the reduced scalars
+	expected := challenge(&R, P, message)
+	return subtle.ConstantTimeCompare(expected[:], sigBin[sizeFr:]) == 1, nil
+}
+
+// challenge returns Blake2s(PedersenHash(R.x, P.x, P.y) ∥ m), the Pedersen
+// hash being serialized in big endian.
+func challenge(R, P *grumpkin.G1Affine, message []byte) [blake2s.Size]byte {
+	ph := pedersen.Hash([]fp.Element{R.X, P.X, P.Y})
+	phBin := ph.Bytes()
+	h, _ := blake2s.New256(nil)
+	h.Write(phBin[:])
+	h.Write(message)
+	var res [blake2s.Size]byte
+	h.Sum(res[:0])
+	return res
+}
+
+// Equal compares 2 public keys
+func (pub *PublicKey) Equal(x signature.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+	bpk := pub.Bytes()
+	bxx := xx.Bytes()
+	return subtle.ConstantTimeCompare(bpk, bxx) == 1
+}
+
+func prehash(message []byte, hFunc hash.Hash) ([]byte, error) {
+	if hFunc == nil {
+		return message, nil
+	}
+	hFunc.Reset()
+	if _, err := hFunc.Write(message); err != nil {
+		return nil, err
+	}
+	return hFunc.Sum(nil), nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package schnorr
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/grumpkin/fr"
+)
+
+func TestSchnorr(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey := privKey.Public()
+	msg := []byte("testing Schnorr")
+
+	sig, err := privKey.Sign(msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := publicKey.Verify(sig, msg, nil); err != nil || !ok {
+		t.Fatal("valid signature rejected")
+	}
+	if ok, _ := publicKey.Verify(sig, []byte("another message"), nil); ok {
+		t.Fatal("signature accepted for another message")
+	}
+	if ok, _ := otherKey.Public().Verify(sig, msg, nil); ok {
+		t.Fatal("signature accepted for another public key")
+	}
+	for _, i := range []int{0, sizeFr - 1, sizeFr, SizeSignature - 1} {
+		tampered := bytes.Clone(sig)
+		tampered[i] ^= 1
+		if ok, _ := publicKey.Verify(tampered, msg, nil); ok {
+			t.Fatal("tampered signature accepted")
+		}
+	}
+
+	// zero s or e
+	for _, i := range []int{0, sizeFr} {
+		zero := bytes.Clone(sig)
+		copy(zero[i:i+sizeFr], make([]byte, sizeFr))
+		if ok, _ := publicKey.Verify(zero, msg, nil); ok {
+			t.Fatal("signature with a zero scalar accepted")
+		}
+	}
+
+	// s is reduced modulo n, as in Barretenberg
+	nonReduced := bytes.Clone(sig)
+	s := new(big.Int).SetBytes(sig[:sizeFr])
+	s.Add(s, fr.Modulus()).FillBytes(nonReduced[:sizeFr])
+	if ok, _ := publicKey.Verify(nonReduced, msg, nil); !ok {
+		t.Fatal("signature with a non-reduced s rejected")
+	}
+
+	// prehashed message
+	sig, err = privKey.Sign(msg, sha256.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := publicKey.Verify(sig, msg, sha256.New()); err != nil || !ok {
+		t.Fatal("valid signature of a prehashed message rejected")
+	}
+	if ok, _ := publicKey.Verify(sig, msg, nil); ok {
+		t.Fatal("signature of a prehashed message accepted for the raw message")
+	}
+}
+
+// TestNoir checks a signature produced by Barretenberg, from the Noir test
+// program test_programs/execution_success/schnorr.
+func TestNoir(t *testing.T) {
+	t.Parallel()
+	pk, _ := hex.DecodeString("04b260954662e97f00cab9adb773a259097f7a274b83b113532bce27fa3fb96a" +
+		"2fd51571db6c08666b0edfbfbc57d432068bccd0110a39b166ab243da0037197")
+	sig := []byte{
+		1, 13, 119, 112, 212, 39, 233, 41, 84, 235, 255, 93, 245, 172, 186, 83,
+		157, 253, 76, 77, 33, 128, 178, 15, 214, 67, 105, 107, 177, 234, 77, 48,
+		27, 237, 155, 84, 39, 84, 247, 27, 22, 8, 176, 230, 24, 115, 145, 220,
+		254, 122, 135, 179, 171, 4, 214, 202, 64, 199, 19, 84, 239, 138, 124, 12,
+	}
+	msg := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	var publicKey PublicKey
+	if _, err := publicKey.SetBytes(pk); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := publicKey.Verify(sig, msg, nil); err != nil || !ok {
+		t.Fatal("Noir signature rejected")
+	}
+	if ok, _ := publicKey.Verify(sig, msg[1:], nil); ok {
+		t.Fatal("Noir signature accepted for another message")
+	}
+
+	// the all-zero signature is rejected, as in the Noir standard library
+	if ok, _ := publicKey.Verify(make([]byte, SizeSignature), msg, nil); ok {
+		t.Fatal("zero signature accepted")
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pk PublicKey
+	if _, err := pk.SetBytes(privKey.PublicKey.Bytes()); err != nil || !pk.Equal(&privKey.PublicKey) {
+		t.Fatal("public key serialization round trip failed")
+	}
+	var sk PrivateKey
+	if _, err := sk.SetBytes(privKey.Bytes()); err != nil || !sk.PublicKey.Equal(&privKey.PublicKey) {
+		t.Fatal("private key serialization round trip failed")
+	}
+
+	// invalid encodings
+	invalid := privKey.PublicKey.Bytes()
+	invalid[SizePublicKey-1] ^= 1
+	if _, err := pk.SetBytes(invalid); err != ErrInvalidPublicKey {
+		t.Fatal("point not on the curve accepted")
+	}
+	if _, err := pk.SetBytes(make([]byte, SizePublicKey)); err != ErrInvalidPublicKey {
+		t.Fatal("point at infinity accepted")
+	}
+	invalid = privKey.Bytes()
+	invalid[0] ^= 1
+	if _, err := sk.SetBytes(invalid); err != errPublicKeyMismatch {
+		t.Fatal("mismatching private key accepted")
+	}
+	if _, err := NewPrivateKey(make([]byte, SizeSecretKey)); err != ErrInvalidSecretKey {
+		t.Fatal("zero secret key accepted")
+	}
+}
+
+func BenchmarkSign(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	msg := []byte("benchmarking Schnorr")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.Sign(msg, nil)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	msg := []byte("benchmarking Schnorr")
+	sig, _ := privKey.Sign(msg, nil)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.PublicKey.Verify(sig, msg, nil)
+	}
+}
@@ -10,8 +10,25 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 //
+// The package also supports the Ethereum conventions: 65 bytes r||s||v
+// signatures with public key recovery, low-S normalization (EIP-2) and
+// Keccak-256 addresses.
+//
+// Besides the randomized nonces of Sign, SignDeterministic derives the nonce
+// from the private key and the message as in RFC 6979. Signatures can be
+// encoded in ASN.1 DER, public keys in the SEC 1 compressed and uncompressed
+// formats, and private keys in PKCS #8 (DER or PEM).
+//
+// SignForRecover returns the information needed to recover the point R of a
+// signature, from which PublicKey.RecoverFrom recovers the public key, and
+// with which BatchVerify checks many signatures with a single multi-scalar
+// multiplication.
+//
 // Documentation:
 // - Wikipedia: https://en.wikipedia.org/wiki/Elliptic_Curve_Digital_Signature_Algorithm
 // - FIPS 186-4: https://nvlpubs.nist.gov/nistpubs/FIPS/NIST.FIPS.186-4.pdf
 // - SEC 1, v-2: https://www.secg.org/sec1-v2.pdf
+// - RFC 6979: https://datatracker.ietf.org/doc/html/rfc6979
+// - RFC 5915: https://datatracker.ietf.org/doc/html/rfc5915
+// - EIP-2: https://eips.ethereum.org/EIPS/eip-2
 package ecdsa
@@ -8,6 +8,8 @@ package ecdsa
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fp"
 	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
 	"math/big"
 	"testing"
@@ -79,6 +81,33 @@ func TestRecoverPublicKey(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestRecoverPOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	// for r close to n, r+n ≥ p cannot be the x coordinate of R, even when
+	// r+n-p is the x coordinate of a point of the curve
+	r := new(big.Int).Sub(fr.Modulus(), big.NewInt(1))
+	a, b := secp256k1.CurveCoefficients()
+	for {
+		var x, y2, ax fp.Element
+		x.SetBigInt(new(big.Int).Add(r, fr.Modulus()))
+		y2.Square(&x).Mul(&y2, &x).Add(&y2, &b)
+		ax.Mul(&a, &x)
+		if y2.Add(&y2, &ax); y2.Legendre() == 1 {
+			break
+		}
+		r.Sub(r, big.NewInt(1))
+	}
+	if new(big.Int).Add(r, fr.Modulus()).Cmp(fp.Modulus()) < 0 {
+		t.Skip("r+n is always smaller than p")
+	}
+	for v := uint(2); v < 4; v++ {
+		if _, err := recoverP(v, r); err == nil {
+			t.Fatal("recovered a point whose x coordinate r+n is not smaller than p")
+		}
+	}
+}
+
 func TestNonMalleability(t *testing.T) {
 
 	// buffer too big
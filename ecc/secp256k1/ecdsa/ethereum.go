@@ -0,0 +1,143 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"errors"
+	"hash"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// SizeEthereumSignature is the size in bytes of a signature r||s||v in
+	// the Ethereum format.
+	SizeEthereumSignature = sizeSignature + 1
+	// SizeAddress is the size in bytes of an Ethereum address.
+	SizeAddress = 20
+	// sizeHash is the size in bytes of the message hashes signed in
+	// Ethereum.
+	sizeHash = 32
+)
+
+var (
+	// ErrHighS is returned when a signature is malleable, i.e. s > order/2.
+	ErrHighS             = errors.New("s is larger than order/2")
+	errInvalidRecoveryID = errors.New("invalid recovery id")
+	errInvalidHashSize   = errors.New("message hash must be 32 bytes")
+)
+
+var halfOrder = new(big.Int).Rsh(order, 1)
+
+// RecoverPublicKey recovers the public key from the message hash msgHash, the
+// recovery information v and the decomposed signature {r,s}, as returned by
+// SignForRecover.
+func RecoverPublicKey(msgHash []byte, v uint, r, s *big.Int) (*PublicKey, error) {
+	pk := new(PublicKey)
+	if err := pk.RecoverFrom(msgHash, v, r, s); err != nil {
+		return nil, err
+	}
+	if pk.A.IsInfinity() {
+		return nil, errors.New("recovered public key is infinity")
+	}
+	return pk, nil
+}
+
+// IsLowS returns true if s <= order/2.
+func (sig *Signature) IsLowS() bool {
+	s := new(big.Int).SetBytes(sig.S[:sizeFr])
+	return s.Cmp(halfOrder) <= 0
+}
+
+// NormalizeS replaces s by order-s when s > order/2, so that the signature
+// is accepted by VerifyLowS (EIP-2). Both signatures are valid for the same
+// message and key, but recovering the key from the normalized signature
+// requires flipping the parity bit of v. It returns true if the signature
+// was modified.
+func (sig *Signature) NormalizeS() bool {
+	if sig.IsLowS() {
+		return false
+	}
+	s := new(big.Int).SetBytes(sig.S[:sizeFr])
+	s.Sub(order, s)
+	s.FillBytes(sig.S[:sizeFr])
+	return true
+}
+
+// VerifyLowS is Verify, but also rejects the malleable signatures with
+// s > order/2, as Ethereum does since EIP-2.
+func (publicKey *PublicKey) VerifyLowS(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+	if !sig.IsLowS() {
+		return false, nil
+	}
+	return publicKey.Verify(sigBin, message, hFunc)
+}
+
+// SignEthereum signs the 32 bytes message hash msgHash and returns the
+// signature in the Ethereum format r||s||v, where v is the recovery id in
+// {0, 1}. The signature is normalized to s <= order/2 (EIP-2).
+func (privKey *PrivateKey) SignEthereum(msgHash []byte) ([]byte, error) {
+	if len(msgHash) != sizeHash {
+		return nil, errInvalidHashSize
+	}
+	v, r, s, err := privKey.SignForRecover(msgHash, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.Cmp(halfOrder) > 0 {
+		// -k ⋅ G has the same x-coordinate and the opposite y-coordinate
+		s.Sub(order, s)
+		v ^= 1
+	}
+	res := make([]byte, SizeEthereumSignature)
+	r.FillBytes(res[:sizeFr])
+	s.FillBytes(res[sizeFr:sizeSignature])
+	res[sizeSignature] = byte(v)
+	return res, nil
+}
+
+// RecoverEthereum recovers the public key from the 32 bytes message hash
+// msgHash and the signature r||s||v in the Ethereum format. v can either be
+// the recovery id in {0, 1, 2, 3} or the legacy value 27 + recovery id.
+// Malleable signatures with s > order/2 are rejected (EIP-2).
+func RecoverEthereum(msgHash, sig []byte) (*PublicKey, error) {
+	if len(msgHash) != sizeHash {
+		return nil, errInvalidHashSize
+	}
+	if len(sig) != SizeEthereumSignature {
+		return nil, errWrongSize
+	}
+	v := uint(sig[sizeSignature])
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 3 {
+		return nil, errInvalidRecoveryID
+	}
+	r := new(big.Int).SetBytes(sig[:sizeFr])
+	s := new(big.Int).SetBytes(sig[sizeFr:sizeSignature])
+	if s.Cmp(halfOrder) > 0 {
+		return nil, ErrHighS
+	}
+	return RecoverPublicKey(msgHash, v, r, s)
+}
+
+// EthereumAddress returns the Ethereum address of the public key, i.e. the
+// last 20 bytes of the Keccak-256 hash of x||y.
+func (pk *PublicKey) EthereumAddress() [SizeAddress]byte {
+	pkBin := pk.A.RawBytes()
+	h := sha3.NewLegacyKeccak256()
+	h.Write(pkBin[:])
+	digest := h.Sum(nil)
+	var res [SizeAddress]byte
+	copy(res[:], digest[len(digest)-SizeAddress:])
+	return res
+}
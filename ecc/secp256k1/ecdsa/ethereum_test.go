@@ -0,0 +1,128 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+// test vectors from go-ethereum/crypto
+const (
+	testPrivHex   = "289c2857d4598e37fb9647507e47a309d6133539bf21a8b9cb6df88fd5232032"
+	testAddrHex   = "970e8128ab834e8eac17ab8e3812f010678cf791"
+	testMsgHex    = "ce0677bb30baa8cf067c88db9811f4333d131bf8bcf12fe7065d211dce971008"
+	testSigHex    = "90f27b8b488db00b00606796d2987f6a5f59ae62ea05effe84fef5b8b0e549984a691139ad57a3f0b906637673aa2f63d1f55cb1a69199d4009eea23ceaddc9301"
+	testPubKeyHex = "e32df42865e97135acfb65f3bae71bdc86f4d49150ad6a440b6f15878109880a0a2b2667f7e725ceea70c673093bf67663e0312623c8e091b13cf2c0f11ef652"
+)
+
+func TestEthereumVectors(t *testing.T) {
+	t.Parallel()
+	msg, _ := hex.DecodeString(testMsgHex)
+	sig, _ := hex.DecodeString(testSigHex)
+	pkBin, _ := hex.DecodeString(testPubKeyHex)
+
+	pk, err := RecoverEthereum(msg, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(pk.Bytes(), pkBin) {
+		t.Fatal("recovered public key mismatch")
+	}
+	ok, err := pk.VerifyLowS(sig[:sizeSignature], msg, nil)
+	if err != nil || !ok {
+		t.Fatal("valid signature rejected")
+	}
+
+	// legacy v = 27 + recovery id
+	sig[sizeSignature] += 27
+	if pk2, err := RecoverEthereum(msg, sig); err != nil || !pk2.Equal(pk) {
+		t.Fatal("legacy recovery id not supported")
+	}
+	sig[sizeSignature] = 4
+	if _, err := RecoverEthereum(msg, sig); err != errInvalidRecoveryID {
+		t.Fatal("invalid recovery id accepted")
+	}
+
+	// address
+	scalar, _ := hex.DecodeString(testPrivHex)
+	var privKey PrivateKey
+	copy(privKey.scalar[:], scalar)
+	privKey.PublicKey.A.ScalarMultiplicationBase(new(big.Int).SetBytes(scalar))
+	addr := privKey.PublicKey.EthereumAddress()
+	if hex.EncodeToString(addr[:]) != testAddrHex {
+		t.Fatal("address mismatch")
+	}
+}
+
+func TestEthereumSignature(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[SECP256K1] recover the public key of an Ethereum signature", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			msgHash := make([]byte, sizeHash)
+			rand.Read(msgHash)
+
+			sig, err := privKey.SignEthereum(msgHash)
+			if err != nil || len(sig) != SizeEthereumSignature {
+				return false
+			}
+			pk, err := RecoverEthereum(msgHash, sig)
+			if err != nil || !pk.Equal(&privKey.PublicKey) {
+				return false
+			}
+			v, r, s := uint(sig[sizeSignature]), new(big.Int).SetBytes(sig[:sizeFr]), new(big.Int).SetBytes(sig[sizeFr:sizeSignature])
+			pk, err = RecoverPublicKey(msgHash, v, r, s)
+			if err != nil || !pk.Equal(&privKey.PublicKey) {
+				return false
+			}
+			ok, err := privKey.PublicKey.VerifyLowS(sig[:sizeSignature], msgHash, nil)
+			return err == nil && ok
+		},
+	))
+
+	properties.Property("[SECP256K1] malleable signatures are rejected", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			msgHash := make([]byte, sizeHash)
+			rand.Read(msgHash)
+
+			sigBin, _ := privKey.SignEthereum(msgHash)
+			var sig Signature
+			if _, err := sig.SetBytes(sigBin[:sizeSignature]); err != nil || !sig.IsLowS() {
+				return false
+			}
+			// s' = order - s
+			s := new(big.Int).SetBytes(sig.S[:])
+			s.Sub(order, s).FillBytes(sig.S[:])
+			if sig.IsLowS() {
+				return false
+			}
+			high := sig.Bytes()
+			if ok, _ := privKey.PublicKey.Verify(high, msgHash, nil); !ok {
+				return false
+			}
+			if ok, _ := privKey.PublicKey.VerifyLowS(high, msgHash, nil); ok {
+				return false
+			}
+			if _, err := RecoverEthereum(msgHash, append(high, sigBin[sizeSignature]^1)); err != ErrHighS {
+				return false
+			}
+			return sig.NormalizeS() && bytes.Equal(sig.Bytes(), sigBin[:sizeSignature])
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
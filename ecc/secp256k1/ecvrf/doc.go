@@ -0,0 +1,23 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Package ecvrf implements the ECVRF verifiable random function
+// ECVRF-SECP256K1-SHA256-TAI on the secp256k1 curve.
+//
+// The owner of a private key computes with Prove a proof pi for an input
+// alpha. Anyone can check the proof with Verify and the public key, and
+// derive the pseudorandom output beta = ProofToHash(pi), which is unique for
+// a given public key and input.
+//
+// RFC 9381 defines no ciphersuite on secp256k1. ECVRF-SECP256K1-SHA256-TAI,
+// of suite_string 0xfe, is the one of draft-irtf-cfrg-vrf-06 used by other
+// implementations, such as github.com/vechain/go-ecvrf: SHA-256, RFC 6979
+// nonces, SEC 1 compressed points and the try and increment hash to the
+// curve. Its proofs differ from the RFC 9381 ones, whose hashes also take
+// the public key in the challenge and end with a 0x00 byte.
+//
+// Documentation:
+//   - draft-irtf-cfrg-vrf-06: https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-vrf-06
+//   - RFC 9381: https://datatracker.ietf.org/doc/html/rfc9381
+//   - RFC 6979: https://datatracker.ietf.org/doc/html/rfc6979
+package ecvrf
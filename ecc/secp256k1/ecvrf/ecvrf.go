@@ -0,0 +1,295 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package ecvrf
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fp"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark-crypto/internal/rfc6979"
+)
+
+const (
+	sizeFr        = fr.Bytes
+	sizeFp        = fp.Bytes
+	sizePoint     = 1 + sizeFp // ptLen, SEC 1 compressed point
+	sizeChallenge = 16         // cLen
+	sizeScalar    = sizeFr     // qLen
+
+	// SizeSecretKey is the size in bytes of a secret key.
+	SizeSecretKey = sizeFr
+	// SizePublicKey is the size in bytes of a compressed public key.
+	SizePublicKey = sizePoint
+	// SizeProof is the size in bytes of a proof Gamma || c || s.
+	SizeProof = sizePoint + sizeChallenge + sizeScalar
+	// SizeOutput is the size in bytes of the output beta.
+	SizeOutput = sha256.Size
+)
+
+// suiteString is the suite_string of ECVRF-SECP256K1-SHA256-TAI.
+const suiteString byte = 0xfe
+
+// domain separators of the hash functions, draft-irtf-cfrg-vrf-06 Section 5
+const (
+	domainHashToCurve byte = 0x01
+	domainChallenge   byte = 0x02
+	domainProofToHash byte = 0x03
+)
+
+var (
+	ErrInvalidProof     = errors.New("invalid proof")
+	ErrInvalidSecretKey = errors.New("secret key must be a non-zero scalar smaller than the group order")
+	ErrInvalidPublicKey = errors.New("public key is not on the curve or is the point at infinity")
+	errNotOnCurve       = errors.New("invalid SEC 1 compressed point")
+	errWrongSize        = errors.New("wrong size buffer")
+	errHashToCurve      = errors.New("no valid point found")
+)
+
+// order of the group
+var order = fr.Modulus()
+
+// PublicKey is an ECVRF public key Y = x*G.
+type PublicKey struct {
+	Y secp256k1.G1Affine
+}
+
+// PrivateKey is an ECVRF private key.
+type PrivateKey struct {
+	PublicKey PublicKey
+	scalar    [sizeFr]byte // secret scalar x, in big Endian
+}
+
+// GenerateKey generates a public and private key pair.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	var sk [SizeSecretKey]byte
+	for {
+		if _, err := io.ReadFull(rand, sk[:]); err != nil {
+			return nil, err
+		}
+		privKey, err := NewPrivateKey(sk[:])
+		if err == nil {
+			return privKey, nil
+		}
+	}
+}
+
+// NewPrivateKey returns the private key of the 32 bytes secret key sk, which
+// must be a big endian integer in [1, n-1].
+func NewPrivateKey(sk []byte) (*PrivateKey, error) {
+	if len(sk) != SizeSecretKey {
+		return nil, ErrInvalidSecretKey
+	}
+	var x fr.Element
+	if err := x.SetBytesCanonical(sk); err != nil || x.IsZero() {
+		return nil, ErrInvalidSecretKey
+	}
+	privKey := new(PrivateKey)
+	copy(privKey.scalar[:], sk)
+	privKey.PublicKey.Y.ScalarMultiplicationBase(x.BigInt(new(big.Int)))
+	return privKey, nil
+}
+
+// Prove returns the proof pi that beta = ProofToHash(pi) is the output of
+// the VRF on the input alpha.
+//
+// draft-irtf-cfrg-vrf-06, Section 5.1
+func Prove(privKey *PrivateKey, alpha []byte) ([]byte, error) {
+	Y := &privKey.PublicKey.Y
+	H, err := hashToCurve(Y, alpha)
+	if err != nil {
+		return nil, err
+	}
+	x := new(big.Int).SetBytes(privKey.scalar[:])
+
+	var Gamma, U, V secp256k1.G1Affine
+	Gamma.ScalarMultiplication(H, x)
+	k := nonceGeneration(x, pointToString(H))
+	U.ScalarMultiplicationBase(k)
+	V.ScalarMultiplication(H, k)
+	c := challenge(H, &Gamma, &U, &V)
+
+	// s = k + c*x mod n
+	var s big.Int
+	s.Mul(c, x).
+		Add(&s, k).
+		Mod(&s, order)
+
+	pi := make([]byte, SizeProof)
+	copy(pi[:sizePoint], pointToString(&Gamma))
+	c.FillBytes(pi[sizePoint : sizePoint+sizeChallenge])
+	s.FillBytes(pi[sizePoint+sizeChallenge:])
+	return pi, nil
+}
+
+// Verify checks the proof pi that beta is the output of the VRF on the input
+// alpha under the public key, and returns beta. It returns ErrInvalidProof if
+// the proof is invalid.
+//
+// draft-irtf-cfrg-vrf-06, Section 5.3
+func Verify(publicKey *PublicKey, alpha, pi []byte) ([]byte, error) {
+	Y := &publicKey.Y
+	if err := validateKey(Y); err != nil {
+		return nil, err
+	}
+	Gamma, c, s, err := decodeProof(pi)
+	if err != nil {
+		return nil, err
+	}
+	H, err := hashToCurve(Y, alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	// U = s*G - c*Y, V = s*H - c*Gamma
+	var negC big.Int
+	negC.Neg(c)
+	var UJac, VJac secp256k1.G1Jac
+	var U, V secp256k1.G1Affine
+	U.FromJacobian(UJac.JointScalarMultiplicationBase(Y, s, &negC))
+	V.FromJacobian(VJac.JointScalarMultiplication(H, Gamma, s, &negC))
+
+	if challenge(H, Gamma, &U, &V).Cmp(c) != 0 {
+		return nil, ErrInvalidProof
+	}
+	return proofToHash(Gamma), nil
+}
+
+// ProofToHash returns the output beta of the VRF from the proof pi. It does
+// not verify the proof: pi must come from Prove or have been checked with
+// Verify.
+//
+// draft-irtf-cfrg-vrf-06, Section 5.2
+func ProofToHash(pi []byte) ([]byte, error) {
+	Gamma, _, _, err := decodeProof(pi)
+	if err != nil {
+		return nil, err
+	}
+	return proofToHash(Gamma), nil
+}
+
+// hashToCurve hashes the public key and alpha to a point with the try and
+// increment method: the first hash
+//
+//	SHA-256(suite_string || 0x01 || point_to_string(Y) || alpha || ctr)
+//
+// which is the x coordinate of a point, for ctr = 0, 1, …, gives the point
+// of even y. The cofactor is 1.
+//
+// draft-irtf-cfrg-vrf-06, Section 5.4.1.1
+func hashToCurve(Y *secp256k1.G1Affine, alpha []byte) (*secp256k1.G1Affine, error) {
+	pk := pointToString(Y)
+	h := sha256.New()
+	buf := make([]byte, sizePoint)
+	buf[0] = 0x02
+	var H secp256k1.G1Affine
+	for ctr := 0; ctr < 256; ctr++ {
+		h.Reset()
+		h.Write([]byte{suiteString, domainHashToCurve})
+		h.Write(pk)
+		h.Write(alpha)
+		h.Write([]byte{byte(ctr)})
+		h.Sum(buf[:1])
+		if stringToPoint(&H, buf) == nil {
+			return &H, nil
+		}
+	}
+	return nil, errHashToCurve
+}
+
+// nonceGeneration returns the RFC 6979 nonce of the secret scalar x and the
+// message h_string, with SHA-256.
+//
+// draft-irtf-cfrg-vrf-06, Section 5.4.2.1
+func nonceGeneration(x *big.Int, hString []byte) *big.Int {
+	h1 := sha256.Sum256(hString)
+	return rfc6979.New(sha256.New, order, x, h1[:]).Next()
+}
+
+// challenge returns the truncated hash of the points.
+//
+// draft-irtf-cfrg-vrf-06, Section 5.4.3
+func challenge(points ...*secp256k1.G1Affine) *big.Int {
+	h := sha256.New()
+	h.Write([]byte{suiteString, domainChallenge})
+	for _, p := range points {
+		h.Write(pointToString(p))
+	}
+	return new(big.Int).SetBytes(h.Sum(nil)[:sizeChallenge])
+}
+
+// proofToHash returns the hash of Gamma, the cofactor being 1.
+func proofToHash(Gamma *secp256k1.G1Affine) []byte {
+	h := sha256.New()
+	h.Write([]byte{suiteString, domainProofToHash})
+	h.Write(pointToString(Gamma))
+	return h.Sum(nil)
+}
+
+// decodeProof returns Gamma, c and s from the proof pi.
+//
+// draft-irtf-cfrg-vrf-06, Section 5.4.4
+func decodeProof(pi []byte) (*secp256k1.G1Affine, *big.Int, *big.Int, error) {
+	if len(pi) != SizeProof {
+		return nil, nil, nil, errWrongSize
+	}
+	var Gamma secp256k1.G1Affine
+	if err := stringToPoint(&Gamma, pi[:sizePoint]); err != nil {
+		return nil, nil, nil, err
+	}
+	c := new(big.Int).SetBytes(pi[sizePoint : sizePoint+sizeChallenge])
+	s := new(big.Int).SetBytes(pi[sizePoint+sizeChallenge:])
+	if s.Cmp(order) >= 0 {
+		return nil, nil, nil, ErrInvalidProof
+	}
+	return &Gamma, c, s, nil
+}
+
+// validateKey checks that the public key is on the curve and is not the
+// point at infinity.
+//
+// draft-irtf-cfrg-vrf-06, Section 5.6.1
+func validateKey(Y *secp256k1.G1Affine) error {
+	if Y.IsInfinity() || !Y.IsOnCurve() {
+		return ErrInvalidPublicKey
+	}
+	return nil
+}
+
+// pointToString returns the SEC 1 compressed encoding of p.
+func pointToString(p *secp256k1.G1Affine) []byte {
+	res := make([]byte, sizePoint)
+	res[0] = 0x02
+	if p.Y.Bits()[0]&1 == 1 {
+		res[0] = 0x03
+	}
+	x := p.X.Bytes()
+	copy(res[1:], x[:])
+	return res
+}
+
+// stringToPoint decodes the SEC 1 compressed point b.
+func stringToPoint(p *secp256k1.G1Affine, b []byte) error {
+	if len(b) != sizePoint || (b[0] != 0x02 && b[0] != 0x03) {
+		return errNotOnCurve
+	}
+	if err := p.X.SetBytesCanonical(b[1:]); err != nil {
+		return errNotOnCurve
+	}
+	// y² = x³ + 7
+	_, bCoeff := secp256k1.CurveCoefficients()
+	var y2 fp.Element
+	y2.Square(&p.X).Mul(&y2, &p.X).Add(&y2, &bCoeff)
+	if p.Y.Sqrt(&y2) == nil {
+		return errNotOnCurve
+	}
+	if byte(p.Y.Bits()[0]&1) != b[0]&1 {
+		p.Y.Neg(&p.Y)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package ecvrf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestECVRF(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alpha := []byte("sample")
+	otherAlpha := []byte("test")
+
+	pi, err := Prove(privKey, alpha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	beta, err := Verify(&privKey.PublicKey, alpha, pi)
+	if err != nil {
+		t.Fatal("valid proof rejected:", err)
+	}
+	if beta2, err := ProofToHash(pi); err != nil || !bytes.Equal(beta, beta2) {
+		t.Fatal("Verify and ProofToHash disagree")
+	}
+
+	// the proof is deterministic, the output depends on the input
+	if pi2, _ := Prove(privKey, alpha); !bytes.Equal(pi, pi2) {
+		t.Fatal("Prove is not deterministic")
+	}
+	pi2, _ := Prove(privKey, otherAlpha)
+	if beta2, _ := ProofToHash(pi2); bytes.Equal(beta, beta2) {
+		t.Fatal("same output for different inputs")
+	}
+
+	// wrong input or public key
+	if _, err := Verify(&privKey.PublicKey, otherAlpha, pi); err != ErrInvalidProof {
+		t.Fatal("proof accepted for another input")
+	}
+	if _, err := Verify(&otherKey.PublicKey, alpha, pi); err != ErrInvalidProof {
+		t.Fatal("proof accepted for another public key")
+	}
+
+	// tampered Gamma, c and s
+	for _, i := range []int{0, sizePoint - 1, sizePoint, sizePoint + sizeChallenge} {
+		tampered := bytes.Clone(pi)
+		tampered[i] ^= 1
+		if _, err := Verify(&privKey.PublicKey, alpha, tampered); err == nil {
+			t.Fatal("tampered proof accepted")
+		}
+	}
+
+	// s >= n
+	tampered := bytes.Clone(pi)
+	order.FillBytes(tampered[sizePoint+sizeChallenge:])
+	if _, err := Verify(&privKey.PublicKey, alpha, tampered); err != ErrInvalidProof {
+		t.Fatal("non-reduced s accepted")
+	}
+}
+
+// The ECVRF-SECP256K1-SHA256-TAI vectors in testdata are from
+// https://github.com/vechain/go-ecvrf, an independent implementation.
+func TestVectors(t *testing.T) {
+	t.Parallel()
+	data, err := os.ReadFile(filepath.Join("testdata", "secp256_k1_sha256_tai.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vectors []struct {
+		SK, PK, Alpha, Pi, Beta string
+	}
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatal(err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("no test vectors")
+	}
+	for _, v := range vectors {
+		sk, _ := new(big.Int).SetString(v.SK, 16)
+		privKey, err := NewPrivateKey(sk.FillBytes(make([]byte, SizeSecretKey)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(privKey.PublicKey.Bytes()) != v.PK {
+			t.Fatalf("public key mismatch for the secret key %s", v.SK)
+		}
+		alpha, _ := hex.DecodeString(v.Alpha)
+		pi, err := Prove(privKey, alpha)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(pi) != v.Pi {
+			t.Fatalf("proof mismatch for the secret key %s and the input %s", v.SK, v.Alpha)
+		}
+		beta, err := Verify(&privKey.PublicKey, alpha, pi)
+		if err != nil || hex.EncodeToString(beta) != v.Beta {
+			t.Fatalf("output mismatch for the secret key %s and the input %s", v.SK, v.Alpha)
+		}
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pk PublicKey
+	if _, err := pk.SetBytes(privKey.PublicKey.Bytes()); err != nil || !pk.Y.Equal(&privKey.PublicKey.Y) {
+		t.Fatal("public key serialization round trip failed")
+	}
+	var sk PrivateKey
+	if _, err := sk.SetBytes(privKey.Bytes()); err != nil || !sk.PublicKey.Y.Equal(&privKey.PublicKey.Y) {
+		t.Fatal("private key serialization round trip failed")
+	}
+
+	// invalid encodings
+	invalid := bytes.Repeat([]byte{0xff}, SizePublicKey)
+	invalid[0] = 0x02
+	if _, err := pk.SetBytes(invalid); err == nil {
+		t.Fatal("invalid public key accepted")
+	}
+	if _, err := sk.SetBytes(make([]byte, SizeSecretKey)); err != ErrInvalidSecretKey {
+		t.Fatal("zero secret key accepted")
+	}
+}
+
+func BenchmarkProve(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	alpha := []byte("leader election")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Prove(privKey, alpha)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	alpha := []byte("leader election")
+	pi, _ := Prove(privKey, alpha)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Verify(&privKey.PublicKey, alpha, pi)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package ecvrf
+
+import (
+	"io"
+)
+
+// Bytes returns the SEC 1 compressed encoding of the public key.
+func (pk *PublicKey) Bytes() []byte {
+	return pointToString(&pk.Y)
+}
+
+// SetBytes sets pk from its SEC 1 compressed encoding in buf. It returns the
+// number of bytes read.
+func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < SizePublicKey {
+		return 0, io.ErrShortBuffer
+	}
+	if err := stringToPoint(&pk.Y, buf[:SizePublicKey]); err != nil {
+		return 0, err
+	}
+	return SizePublicKey, nil
+}
+
+// Bytes returns the 32 bytes secret key.
+func (privKey *PrivateKey) Bytes() []byte {
+	res := privKey.scalar
+	return res[:]
+}
+
+// SetBytes sets privKey from the 32 bytes secret key in buf. It returns the
+// number of bytes read.
+func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < SizeSecretKey {
+		return 0, io.ErrShortBuffer
+	}
+	res, err := NewPrivateKey(buf[:SizeSecretKey])
+	if err != nil {
+		return 0, err
+	}
+	*privKey = *res
+	return SizeSecretKey, nil
+}
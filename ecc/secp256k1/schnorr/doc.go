@@ -0,0 +1,20 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Package schnorr implements the BIP-340 Schnorr signature scheme on the
+// secp256k1 curve, and the MuSig2 multi-signature protocol of BIP-327 on top
+// of it.
+//
+// Public keys are x-only: they are serialized as the 32 bytes x-coordinate of
+// the point with an even y-coordinate. Signatures are 64 bytes R.x||s and
+// messages can be of arbitrary length. All the hashes are the tagged hashes
+// of BIP-340.
+//
+// MuSig2 aggregates the public keys of n signers into a single x-only public
+// key, and lets them produce in two rounds a signature which is
+// indistinguishable from a regular BIP-340 signature under the aggregate key.
+//
+// Documentation:
+//   - BIP-340: https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki
+//   - BIP-327: https://github.com/bitcoin/bips/blob/master/bip-0327.mediawiki
+package schnorr
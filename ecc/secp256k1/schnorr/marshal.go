@@ -0,0 +1,67 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package schnorr
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+)
+
+var errPublicKeyMismatch = errors.New("public key does not match the secret key")
+
+// Bytes returns the x-only representation of the public key, i.e. the big
+// endian x-coordinate of the point.
+func (pk *PublicKey) Bytes() []byte {
+	res := pk.A.X.Bytes()
+	return res[:]
+}
+
+// SetBytes sets pk from its x-only representation in buf. It fails if the
+// x-coordinate is not smaller than the field modulus or is not the abscissa
+// of a point of the curve. It returns the number of bytes read from the
+// buffer.
+//
+// BIP-340, lift_x
+func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < SizePublicKey {
+		return 0, io.ErrShortBuffer
+	}
+	A, err := liftX(buf[:SizePublicKey])
+	if err != nil {
+		return 0, ErrInvalidPublicKey
+	}
+	pk.A = A
+	return SizePublicKey, nil
+}
+
+// Bytes returns the binary representation of privKey as publicKey||scalar,
+// where publicKey is as in PublicKey.Bytes() and scalar is the big endian
+// secret key.
+func (privKey *PrivateKey) Bytes() []byte {
+	var res [sizePrivateKey]byte
+	pubBin := privKey.PublicKey.A.X.Bytes()
+	subtle.ConstantTimeCopy(1, res[:SizePublicKey], pubBin[:])
+	subtle.ConstantTimeCopy(1, res[SizePublicKey:], privKey.scalar[:])
+	return res[:]
+}
+
+// SetBytes sets privKey from buf, interpreted as publicKey||scalar. It checks
+// that the public key matches the secret key. It returns the number of bytes
+// read from the buffer.
+func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePrivateKey {
+		return 0, io.ErrShortBuffer
+	}
+	expected, err := NewPrivateKey(buf[SizePublicKey:sizePrivateKey])
+	if err != nil {
+		return 0, err
+	}
+	pubBin := expected.PublicKey.A.X.Bytes()
+	if subtle.ConstantTimeCompare(pubBin[:], buf[:SizePublicKey]) != 1 {
+		return 0, errPublicKeyMismatch
+	}
+	*privKey = *expected
+	return sizePrivateKey, nil
+}
@@ -0,0 +1,597 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package schnorr
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+)
+
+const (
+	// SizePlainPublicKey is the size in bytes of a compressed public key, as
+	// used by the individual signers of MuSig2.
+	SizePlainPublicKey = 1 + sizeFp
+	// SizePubNonce is the size in bytes of a public nonce or of an aggregate
+	// nonce.
+	SizePubNonce = 2 * SizePlainPublicKey
+	// SizeSecNonce is the size in bytes of a secret nonce.
+	SizeSecNonce = 2*sizeFr + SizePlainPublicKey
+	// SizePartialSignature is the size in bytes of a partial signature.
+	SizePartialSignature = sizeFr
+)
+
+// tags of the BIP-327 hashes
+const (
+	tagKeyAggList  = "KeyAgg list"
+	tagKeyAggCoeff = "KeyAgg coefficient"
+	tagMuSigAux    = "MuSig/aux"
+	tagMuSigNonce  = "MuSig/nonce"
+	tagNonceCoeff  = "MuSig/noncecoef"
+)
+
+var (
+	ErrTweakOutOfRange     = errors.New("the tweak must be less than n")
+	ErrTweakInfinity       = errors.New("the result of tweaking cannot be infinity")
+	ErrSignerNotInSession  = errors.New("the signer's pubkey must be included in the list of pubkeys")
+	ErrInvalidSecNonce     = errors.New("secret nonce value is out of range, which may indicate nonce reuse")
+	ErrSecNonceMismatch    = errors.New("public key of the secret nonce does not match the secret key")
+	ErrAggregateInfinity   = errors.New("the aggregate public key cannot be infinity")
+	errInvalidPoint        = errors.New("invalid compressed point")
+	errMismatchedTweakSize = errors.New("tweaks and isXOnly have different lengths")
+)
+
+// InvalidContributionError reports an invalid value provided by one of the
+// participants, so that a misbehaving signer can be identified.
+type InvalidContributionError struct {
+	// Signer is the index of the faulty signer, or -1 if the value was
+	// provided by the nonce aggregator.
+	Signer int
+	// Contrib is the invalid value: "pubkey", "pubnonce", "aggnonce" or
+	// "psig".
+	Contrib string
+}
+
+func (e *InvalidContributionError) Error() string {
+	if e.Signer < 0 {
+		return fmt.Sprintf("invalid %s from the aggregator", e.Contrib)
+	}
+	return fmt.Sprintf("invalid %s from signer %d", e.Contrib, e.Signer)
+}
+
+// KeyAggContext is the result of the aggregation of the public keys of the
+// signers, and of the tweaks applied to the aggregate key.
+type KeyAggContext struct {
+	q    secp256k1.G1Affine // aggregate public key
+	gacc fr.Element         // accumulated sign of the aggregate key
+	tacc fr.Element         // accumulated tweak
+}
+
+// KeySort sorts the compressed public keys in lexicographical order. It
+// returns a new slice.
+//
+// BIP-327, "Key Sorting"
+func KeySort(pubKeys [][]byte) [][]byte {
+	res := slices.Clone(pubKeys)
+	slices.SortFunc(res, bytes.Compare)
+	return res
+}
+
+// KeyAgg aggregates the compressed public keys of the signers. The order of
+// the keys matters, see KeySort.
+//
+// Q = ∑ a_i ⋅ P_i with a_i = hash_keyagg_coeff(L ∥ pk_i), L = hash_keyagg_list(pk_1 ∥ … ∥ pk_u)
+// and a_i = 1 for the first key different from pk_1.
+//
+// BIP-327, "Key Aggregation"
+func KeyAgg(pubKeys [][]byte) (*KeyAggContext, error) {
+	points := make([]secp256k1.G1Affine, len(pubKeys))
+	for i := range pubKeys {
+		var err error
+		if points[i], err = cpoint(pubKeys[i]); err != nil {
+			return nil, &InvalidContributionError{Signer: i, Contrib: "pubkey"}
+		}
+	}
+	L := hashKeys(pubKeys)
+	pk2 := secondKey(pubKeys)
+	coeffs := make([]fr.Element, len(pubKeys))
+	for i := range pubKeys {
+		coeffs[i] = keyAggCoeff(L, pk2, pubKeys[i])
+	}
+
+	var Q secp256k1.G1Jac
+	if _, err := Q.MultiExp(points, coeffs, ecc.MultiExpConfig{}); err != nil {
+		return nil, err
+	}
+	if Q.Z.IsZero() {
+		return nil, ErrAggregateInfinity
+	}
+	ctx := new(KeyAggContext)
+	ctx.q.FromJacobian(&Q)
+	ctx.gacc.SetOne()
+	return ctx, nil
+}
+
+// ApplyTweak tweaks the aggregate public key with the 32 bytes big endian
+// scalar tweak. A plain tweak adds tweak ⋅ G to the aggregate key, an x-only
+// tweak first negates the aggregate key if its y-coordinate is odd, as
+// required by BIP-341 taproot outputs.
+//
+// BIP-327, "Applying Tweaks"
+func (ctx *KeyAggContext) ApplyTweak(tweak []byte, isXOnly bool) error {
+	if len(tweak) != sizeFr {
+		return errWrongSize
+	}
+	var t fr.Element
+	if err := t.SetBytesCanonical(tweak); err != nil {
+		return ErrTweakOutOfRange
+	}
+	var Q secp256k1.G1Jac
+	Q.FromAffine(&ctx.q)
+	if isXOnly && !hasEvenY(&ctx.q) {
+		Q.Neg(&Q)
+		ctx.gacc.Neg(&ctx.gacc)
+		ctx.tacc.Neg(&ctx.tacc)
+	}
+	var T secp256k1.G1Affine
+	T.ScalarMultiplicationBase(t.BigInt(new(big.Int)))
+	Q.AddMixed(&T)
+	if Q.Z.IsZero() {
+		return ErrTweakInfinity
+	}
+	ctx.q.FromJacobian(&Q)
+	ctx.tacc.Add(&ctx.tacc, &t)
+	return nil
+}
+
+// PublicKey returns the x-only aggregate public key, under which the final
+// signature verifies.
+func (ctx *KeyAggContext) PublicKey() *PublicKey {
+	var pub PublicKey
+	pub.A.Set(&ctx.q)
+	if !hasEvenY(&pub.A) {
+		pub.A.Neg(&pub.A)
+	}
+	return &pub
+}
+
+// PlainPublicKey returns the compressed aggregate public key.
+func (ctx *KeyAggContext) PlainPublicKey() []byte {
+	return cbytes(&ctx.q)
+}
+
+// NonceGen generates the secret and public nonces of a signer. pk is the
+// compressed public key of the signer and is required. The secret key sk,
+// the x-only aggregate public key aggPk, the message msg and the extra input
+// extraIn are optional (nil) and only add protection against a weak source
+// of randomness. A nil msg is different from an empty message.
+//
+// The secret nonce must be used at most once, and kept secret. PartialSign
+// erases it.
+//
+// BIP-327, "Nonce Generation"
+func NonceGen(sk, pk, aggPk, msg, extraIn []byte) (secNonce, pubNonce []byte, err error) {
+	var randPrime [32]byte
+	if _, err := io.ReadFull(rand.Reader, randPrime[:]); err != nil {
+		return nil, nil, err
+	}
+	return nonceGen(randPrime[:], sk, pk, aggPk, msg, extraIn)
+}
+
+func nonceGen(randPrime, sk, pk, aggPk, msg, extraIn []byte) (secNonce, pubNonce []byte, err error) {
+	if len(pk) != SizePlainPublicKey {
+		return nil, nil, errWrongSize
+	}
+	if (sk != nil && len(sk) != SizeSecretKey) || (aggPk != nil && len(aggPk) != SizePublicKey) {
+		return nil, nil, errWrongSize
+	}
+	seed := make([]byte, len(randPrime))
+	copy(seed, randPrime)
+	if sk != nil {
+		h := TaggedHash(tagMuSigAux, randPrime)
+		for i := range seed {
+			seed[i] = sk[i] ^ h[i]
+		}
+	}
+
+	var msgPrefixed []byte
+	if msg == nil {
+		msgPrefixed = []byte{0}
+	} else {
+		msgPrefixed = make([]byte, 9, 9+len(msg))
+		msgPrefixed[0] = 1
+		binary.BigEndian.PutUint64(msgPrefixed[1:], uint64(len(msg)))
+		msgPrefixed = append(msgPrefixed, msg...)
+	}
+	var extraLen [4]byte
+	binary.BigEndian.PutUint32(extraLen[:], uint32(len(extraIn)))
+
+	secNonce = make([]byte, SizeSecNonce)
+	pubNonce = make([]byte, SizePubNonce)
+	for i := 0; i < 2; i++ {
+		h := TaggedHash(tagMuSigNonce,
+			seed,
+			[]byte{byte(len(pk))}, pk,
+			[]byte{byte(len(aggPk))}, aggPk,
+			msgPrefixed,
+			extraLen[:], extraIn,
+			[]byte{byte(i)},
+		)
+		var k fr.Element
+		k.SetBytes(h[:])
+		if k.IsZero() {
+			return nil, nil, errZeroNonce
+		}
+		kBin := k.Bytes()
+		copy(secNonce[i*sizeFr:], kBin[:])
+		var R secp256k1.G1Affine
+		R.ScalarMultiplicationBase(k.BigInt(new(big.Int)))
+		copy(pubNonce[i*SizePlainPublicKey:], cbytes(&R))
+	}
+	copy(secNonce[2*sizeFr:], pk)
+	return secNonce, pubNonce, nil
+}
+
+// NonceAgg aggregates the public nonces of the signers.
+//
+// BIP-327, "Nonce Aggregation"
+func NonceAgg(pubNonces [][]byte) ([]byte, error) {
+	aggNonce := make([]byte, SizePubNonce)
+	for j := 0; j < 2; j++ {
+		var R secp256k1.G1Jac
+		R.Z.SetZero()
+		for i := range pubNonces {
+			if len(pubNonces[i]) != SizePubNonce {
+				return nil, &InvalidContributionError{Signer: i, Contrib: "pubnonce"}
+			}
+			Rij, err := cpoint(pubNonces[i][j*SizePlainPublicKey : (j+1)*SizePlainPublicKey])
+			if err != nil {
+				return nil, &InvalidContributionError{Signer: i, Contrib: "pubnonce"}
+			}
+			R.AddMixed(&Rij)
+		}
+		var Raff secp256k1.G1Affine
+		Raff.FromJacobian(&R)
+		copy(aggNonce[j*SizePlainPublicKey:], cbytesExt(&Raff))
+	}
+	return aggNonce, nil
+}
+
+// SessionContext holds the public data of a signing session.
+type SessionContext struct {
+	AggNonce []byte   // aggregate nonce, as returned by NonceAgg
+	PubKeys  [][]byte // compressed public keys of the signers
+	Tweaks   [][]byte // tweaks applied in order to the aggregate key
+	IsXOnly  []bool   // IsXOnly[i] is true if Tweaks[i] is an x-only tweak
+	Msg      []byte   // message to sign
+}
+
+// sessionValues are the values derived from a SessionContext.
+type sessionValues struct {
+	keyAgg *KeyAggContext
+	b      fr.Element // nonce coefficient
+	r      secp256k1.G1Affine
+	e      fr.Element // challenge
+}
+
+// values returns the session values.
+//
+// BIP-327, "Session Context"
+func (session *SessionContext) values() (*sessionValues, error) {
+	if len(session.Tweaks) != len(session.IsXOnly) {
+		return nil, errMismatchedTweakSize
+	}
+	if len(session.AggNonce) != SizePubNonce {
+		return nil, &InvalidContributionError{Signer: -1, Contrib: "aggnonce"}
+	}
+	keyAgg, err := KeyAgg(session.PubKeys)
+	if err != nil {
+		return nil, err
+	}
+	for i := range session.Tweaks {
+		if err := keyAgg.ApplyTweak(session.Tweaks[i], session.IsXOnly[i]); err != nil {
+			return nil, err
+		}
+	}
+	qBin := keyAgg.q.X.Bytes()
+	h := TaggedHash(tagNonceCoeff, session.AggNonce, qBin[:], session.Msg)
+	v := &sessionValues{keyAgg: keyAgg}
+	v.b.SetBytes(h[:])
+
+	R1, err := cpointExt(session.AggNonce[:SizePlainPublicKey])
+	if err != nil {
+		return nil, &InvalidContributionError{Signer: -1, Contrib: "aggnonce"}
+	}
+	R2, err := cpointExt(session.AggNonce[SizePlainPublicKey:])
+	if err != nil {
+		return nil, &InvalidContributionError{Signer: -1, Contrib: "aggnonce"}
+	}
+	// R = R1 + b ⋅ R2, or G if it is infinity
+	var R secp256k1.G1Jac
+	R.ScalarMultiplication(new(secp256k1.G1Jac).FromAffine(&R2), v.b.BigInt(new(big.Int)))
+	R.AddMixed(&R1)
+	if R.Z.IsZero() {
+		_, v.r = secp256k1.Generators()
+	} else {
+		v.r.FromJacobian(&R)
+	}
+	rBin := v.r.X.Bytes()
+	v.e = challenge(rBin[:], qBin[:], session.Msg)
+	return v, nil
+}
+
+// keyAggCoeff returns the key aggregation coefficient of the signer of
+// compressed public key pk.
+func (session *SessionContext) keyAggCoeff(pk []byte) (fr.Element, error) {
+	if !slices.ContainsFunc(session.PubKeys, func(k []byte) bool { return bytes.Equal(k, pk) }) {
+		return fr.Element{}, ErrSignerNotInSession
+	}
+	return keyAggCoeff(hashKeys(session.PubKeys), secondKey(session.PubKeys), pk), nil
+}
+
+// PartialSign computes the partial signature of the signer of secret key sk
+// in the session. The secret nonce secNonce is erased, so that it cannot be
+// reused.
+//
+// s = k1 + b ⋅ k2 + e ⋅ a ⋅ d
+//
+// BIP-327, "Signing"
+func PartialSign(secNonce, sk []byte, session *SessionContext) ([]byte, error) {
+	if len(secNonce) != SizeSecNonce || len(sk) != SizeSecretKey {
+		return nil, errWrongSize
+	}
+	v, err := session.values()
+	if err != nil {
+		return nil, err
+	}
+
+	var k1, k2 fr.Element
+	err1 := k1.SetBytesCanonical(secNonce[:sizeFr])
+	err2 := k2.SetBytesCanonical(secNonce[sizeFr : 2*sizeFr])
+	if err1 != nil || err2 != nil || k1.IsZero() || k2.IsZero() {
+		return nil, ErrInvalidSecNonce
+	}
+	pubNonce := make([]byte, SizePubNonce)
+	var R secp256k1.G1Affine
+	R.ScalarMultiplicationBase(k1.BigInt(new(big.Int)))
+	copy(pubNonce, cbytes(&R))
+	R.ScalarMultiplicationBase(k2.BigInt(new(big.Int)))
+	copy(pubNonce[SizePlainPublicKey:], cbytes(&R))
+	// erase the secret nonce
+	for i := 0; i < 2*sizeFr; i++ {
+		secNonce[i] = 0
+	}
+	if !hasEvenY(&v.r) {
+		k1.Neg(&k1)
+		k2.Neg(&k2)
+	}
+
+	var d fr.Element
+	if err := d.SetBytesCanonical(sk); err != nil || d.IsZero() {
+		return nil, ErrInvalidSecretKey
+	}
+	var P secp256k1.G1Affine
+	P.ScalarMultiplicationBase(d.BigInt(new(big.Int)))
+	pk := cbytes(&P)
+	if !bytes.Equal(pk, secNonce[2*sizeFr:]) {
+		return nil, ErrSecNonceMismatch
+	}
+	a, err := session.keyAggCoeff(pk)
+	if err != nil {
+		return nil, err
+	}
+	// d = g ⋅ gacc ⋅ d'
+	if !hasEvenY(&v.keyAgg.q) {
+		d.Neg(&d)
+	}
+	d.Mul(&d, &v.keyAgg.gacc)
+
+	var s fr.Element
+	s.Mul(&v.e, &a).Mul(&s, &d)
+	k2.Mul(&k2, &v.b)
+	s.Add(&s, &k1).Add(&s, &k2)
+	sBin := s.Bytes()
+	psig := sBin[:]
+
+	// the partial signature is checked to protect against faults in the
+	// computation
+	if ok, _ := session.partialSigVerify(v, psig, pubNonce, pk); !ok {
+		return nil, ErrInvalidSignature
+	}
+	return psig, nil
+}
+
+// PartialSigVerify checks the partial signature psig of the signer i, given
+// the public nonces and the compressed public keys of all the signers, the
+// tweaks and the message.
+//
+// BIP-327, "Partial Signature Verification"
+func PartialSigVerify(psig []byte, pubNonces, pubKeys, tweaks [][]byte, isXOnly []bool, msg []byte, i int) (bool, error) {
+	if i < 0 || i >= len(pubNonces) || i >= len(pubKeys) {
+		return false, errors.New("signer index out of range")
+	}
+	aggNonce, err := NonceAgg(pubNonces)
+	if err != nil {
+		return false, err
+	}
+	session := &SessionContext{
+		AggNonce: aggNonce,
+		PubKeys:  pubKeys,
+		Tweaks:   tweaks,
+		IsXOnly:  isXOnly,
+		Msg:      msg,
+	}
+	v, err := session.values()
+	if err != nil {
+		return false, err
+	}
+	ok, err := session.partialSigVerify(v, psig, pubNonces[i], pubKeys[i])
+	var contribErr *InvalidContributionError
+	if errors.As(err, &contribErr) {
+		contribErr.Signer = i
+	}
+	return ok, err
+}
+
+// partialSigVerify checks the partial signature psig of the signer with
+// public nonce pubNonce and compressed public key pk.
+//
+// s ⋅ G ?= ±(R1 + b ⋅ R2) + e ⋅ a ⋅ g ⋅ gacc ⋅ P
+func (session *SessionContext) partialSigVerify(v *sessionValues, psig, pubNonce, pk []byte) (bool, error) {
+	if len(psig) != SizePartialSignature {
+		return false, errWrongSize
+	}
+	var s fr.Element
+	if err := s.SetBytesCanonical(psig); err != nil {
+		return false, nil
+	}
+	if len(pubNonce) != SizePubNonce {
+		return false, &InvalidContributionError{Signer: -1, Contrib: "pubnonce"}
+	}
+	R1, err1 := cpoint(pubNonce[:SizePlainPublicKey])
+	R2, err2 := cpoint(pubNonce[SizePlainPublicKey:])
+	if err1 != nil || err2 != nil {
+		return false, &InvalidContributionError{Signer: -1, Contrib: "pubnonce"}
+	}
+	P, err := cpoint(pk)
+	if err != nil {
+		return false, &InvalidContributionError{Signer: -1, Contrib: "pubkey"}
+	}
+	a, err := session.keyAggCoeff(pk)
+	if err != nil {
+		return false, err
+	}
+
+	var g fr.Element
+	g.Set(&v.keyAgg.gacc)
+	if !hasEvenY(&v.keyAgg.q) {
+		g.Neg(&g)
+	}
+	var c fr.Element
+	c.Mul(&v.e, &a).Mul(&c, &g)
+
+	// Re = R1 + b ⋅ R2, negated if R has an odd y-coordinate
+	var Re secp256k1.G1Jac
+	Re.JointScalarMultiplication(&R1, &R2, big.NewInt(1), v.b.BigInt(new(big.Int)))
+	if !hasEvenY(&v.r) {
+		Re.Neg(&Re)
+	}
+	// s ⋅ G - e ⋅ a ⋅ g' ⋅ P
+	var lhs secp256k1.G1Jac
+	c.Neg(&c)
+	lhs.JointScalarMultiplicationBase(&P, s.BigInt(new(big.Int)), c.BigInt(new(big.Int)))
+	return lhs.Equal(&Re), nil
+}
+
+// PartialSigAgg aggregates the partial signatures of the signers into a
+// BIP-340 signature under the x-only aggregate public key.
+//
+// BIP-327, "Partial Signature Aggregation"
+func PartialSigAgg(psigs [][]byte, session *SessionContext) ([]byte, error) {
+	v, err := session.values()
+	if err != nil {
+		return nil, err
+	}
+	var s, si fr.Element
+	for i := range psigs {
+		if len(psigs[i]) != SizePartialSignature || si.SetBytesCanonical(psigs[i]) != nil {
+			return nil, &InvalidContributionError{Signer: i, Contrib: "psig"}
+		}
+		s.Add(&s, &si)
+	}
+	// s = s + e ⋅ g ⋅ tacc
+	var t fr.Element
+	t.Mul(&v.e, &v.keyAgg.tacc)
+	if !hasEvenY(&v.keyAgg.q) {
+		t.Neg(&t)
+	}
+	s.Add(&s, &t)
+
+	rBin := v.r.X.Bytes()
+	sBin := s.Bytes()
+	sig := make([]byte, SizeSignature)
+	copy(sig[:sizeFp], rBin[:])
+	copy(sig[sizeFp:], sBin[:])
+	return sig, nil
+}
+
+// hashKeys returns hash_keyagg_list(pk_1 ∥ … ∥ pk_u).
+func hashKeys(pubKeys [][]byte) [32]byte {
+	return TaggedHash(tagKeyAggList, pubKeys...)
+}
+
+// secondKey returns the first key different from pubKeys[0], or nil.
+func secondKey(pubKeys [][]byte) []byte {
+	for i := 1; i < len(pubKeys); i++ {
+		if !bytes.Equal(pubKeys[i], pubKeys[0]) {
+			return pubKeys[i]
+		}
+	}
+	return nil
+}
+
+// keyAggCoeff returns 1 if pk is the second key, hash_keyagg_coeff(L ∥ pk)
+// otherwise.
+func keyAggCoeff(L [32]byte, pk2, pk []byte) fr.Element {
+	var a fr.Element
+	if pk2 != nil && bytes.Equal(pk, pk2) {
+		return *a.SetOne()
+	}
+	h := TaggedHash(tagKeyAggCoeff, L[:], pk)
+	a.SetBytes(h[:])
+	return a
+}
+
+// cbytes returns the compressed encoding 0x02/0x03 ∥ x of p.
+func cbytes(p *secp256k1.G1Affine) []byte {
+	res := make([]byte, SizePlainPublicKey)
+	res[0] = 2
+	if !hasEvenY(p) {
+		res[0] = 3
+	}
+	x := p.X.Bytes()
+	copy(res[1:], x[:])
+	return res
+}
+
+// cbytesExt is cbytes, with 33 zero bytes for the point at infinity.
+func cbytesExt(p *secp256k1.G1Affine) []byte {
+	if p.IsInfinity() {
+		return make([]byte, SizePlainPublicKey)
+	}
+	return cbytes(p)
+}
+
+// cpoint decodes a compressed point.
+func cpoint(buf []byte) (secp256k1.G1Affine, error) {
+	if len(buf) != SizePlainPublicKey || (buf[0] != 2 && buf[0] != 3) {
+		return secp256k1.G1Affine{}, errInvalidPoint
+	}
+	p, err := liftX(buf[1:])
+	if err != nil {
+		return p, errInvalidPoint
+	}
+	if buf[0] == 3 {
+		p.Neg(&p)
+	}
+	return p, nil
+}
+
+// cpointExt is cpoint, decoding 33 zero bytes as the point at infinity.
+func cpointExt(buf []byte) (secp256k1.G1Affine, error) {
+	var p secp256k1.G1Affine
+	if bytes.Equal(buf, make([]byte, SizePlainPublicKey)) {
+		return p, nil
+	}
+	return cpoint(buf)
+}
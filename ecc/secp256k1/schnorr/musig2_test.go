@@ -0,0 +1,540 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package schnorr
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+// The BIP-327 test vectors in testdata are from
+// https://github.com/bitcoin/bips/tree/master/bip-0327/vectors
+
+type hexBytes []byte
+
+func (h *hexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*h = b
+	return nil
+}
+
+type vectorError struct {
+	Type    string `json:"type"`
+	Signer  *int   `json:"signer"`
+	Contrib string `json:"contrib"`
+}
+
+// check that err matches the expected error of a test vector.
+func (e *vectorError) check(t *testing.T, err error) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if e.Type != "invalid_contribution" {
+		return
+	}
+	var contribErr *InvalidContributionError
+	if !errors.As(err, &contribErr) {
+		t.Fatalf("expected an invalid contribution error, got %v", err)
+	}
+	signer := -1
+	if e.Signer != nil {
+		signer = *e.Signer
+	}
+	if contribErr.Signer != signer || (e.Contrib != "" && contribErr.Contrib != e.Contrib) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func loadVectors(t *testing.T, name string, v any) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func pick(values []hexBytes, indices []int) [][]byte {
+	res := make([][]byte, len(indices))
+	for i, j := range indices {
+		res[i] = values[j]
+	}
+	return res
+}
+
+func TestKeySortVectors(t *testing.T) {
+	t.Parallel()
+	var v struct {
+		PubKeys       []hexBytes `json:"pubkeys"`
+		SortedPubKeys []hexBytes `json:"sorted_pubkeys"`
+	}
+	loadVectors(t, "key_sort_vectors.json", &v)
+	sorted := KeySort(pick(v.PubKeys, []int{0, 1, 2, 3, 4}))
+	for i := range sorted {
+		if !bytes.Equal(sorted[i], v.SortedPubKeys[i]) {
+			t.Fatalf("key %d: mismatch", i)
+		}
+	}
+}
+
+func TestKeyAggVectors(t *testing.T) {
+	t.Parallel()
+	var v struct {
+		PubKeys []hexBytes `json:"pubkeys"`
+		Tweaks  []hexBytes `json:"tweaks"`
+		Valid   []struct {
+			KeyIndices []int    `json:"key_indices"`
+			Expected   hexBytes `json:"expected"`
+		} `json:"valid_test_cases"`
+		Errors []struct {
+			KeyIndices   []int       `json:"key_indices"`
+			TweakIndices []int       `json:"tweak_indices"`
+			IsXOnly      []bool      `json:"is_xonly"`
+			Error        vectorError `json:"error"`
+		} `json:"error_test_cases"`
+	}
+	loadVectors(t, "key_agg_vectors.json", &v)
+
+	for i, c := range v.Valid {
+		ctx, err := KeyAgg(pick(v.PubKeys, c.KeyIndices))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(ctx.PublicKey().Bytes(), c.Expected) {
+			t.Fatalf("valid case %d: aggregate key mismatch", i)
+		}
+	}
+	for _, c := range v.Errors {
+		ctx, err := KeyAgg(pick(v.PubKeys, c.KeyIndices))
+		for i, j := range c.TweakIndices {
+			if err != nil {
+				break
+			}
+			err = ctx.ApplyTweak(v.Tweaks[j], c.IsXOnly[i])
+		}
+		c.Error.check(t, err)
+	}
+}
+
+func TestNonceGenVectors(t *testing.T) {
+	t.Parallel()
+	var v struct {
+		TestCases []struct {
+			Rand     hexBytes  `json:"rand_"`
+			Sk       *hexBytes `json:"sk"`
+			Pk       hexBytes  `json:"pk"`
+			AggPk    *hexBytes `json:"aggpk"`
+			Msg      *hexBytes `json:"msg"`
+			ExtraIn  *hexBytes `json:"extra_in"`
+			Expected hexBytes  `json:"expected"`
+		} `json:"test_cases"`
+	}
+	loadVectors(t, "nonce_gen_vectors.json", &v)
+
+	optional := func(b *hexBytes) []byte {
+		if b == nil {
+			return nil
+		}
+		if *b == nil {
+			return []byte{}
+		}
+		return *b
+	}
+	for i, c := range v.TestCases {
+		secNonce, pubNonce, err := nonceGen(c.Rand, optional(c.Sk), c.Pk, optional(c.AggPk), optional(c.Msg), optional(c.ExtraIn))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(secNonce, c.Expected) {
+			t.Fatalf("case %d: secret nonce mismatch", i)
+		}
+		// the public nonce is the public part of the secret nonce
+		if !bytes.Equal(pubNonce, publicNonce(secNonce)) {
+			t.Fatalf("case %d: public nonce mismatch", i)
+		}
+	}
+}
+
+func TestNonceAggVectors(t *testing.T) {
+	t.Parallel()
+	var v struct {
+		PubNonces []hexBytes `json:"pnonces"`
+		Valid     []struct {
+			PubNonceIndices []int    `json:"pnonce_indices"`
+			Expected        hexBytes `json:"expected"`
+		} `json:"valid_test_cases"`
+		Errors []struct {
+			PubNonceIndices []int       `json:"pnonce_indices"`
+			Error           vectorError `json:"error"`
+		} `json:"error_test_cases"`
+	}
+	loadVectors(t, "nonce_agg_vectors.json", &v)
+
+	for i, c := range v.Valid {
+		aggNonce, err := NonceAgg(pick(v.PubNonces, c.PubNonceIndices))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(aggNonce, c.Expected) {
+			t.Fatalf("valid case %d: aggregate nonce mismatch", i)
+		}
+	}
+	for _, c := range v.Errors {
+		_, err := NonceAgg(pick(v.PubNonces, c.PubNonceIndices))
+		c.Error.check(t, err)
+	}
+}
+
+func TestSignVerifyVectors(t *testing.T) {
+	t.Parallel()
+	var v struct {
+		Sk        hexBytes   `json:"sk"`
+		PubKeys   []hexBytes `json:"pubkeys"`
+		SecNonces []hexBytes `json:"secnonces"`
+		PubNonces []hexBytes `json:"pnonces"`
+		AggNonces []hexBytes `json:"aggnonces"`
+		Msgs      []hexBytes `json:"msgs"`
+		Valid     []struct {
+			KeyIndices    []int    `json:"key_indices"`
+			NonceIndices  []int    `json:"nonce_indices"`
+			AggNonceIndex int      `json:"aggnonce_index"`
+			MsgIndex      int      `json:"msg_index"`
+			SignerIndex   int      `json:"signer_index"`
+			Expected      hexBytes `json:"expected"`
+		} `json:"valid_test_cases"`
+		SignErrors []struct {
+			KeyIndices    []int       `json:"key_indices"`
+			AggNonceIndex int         `json:"aggnonce_index"`
+			MsgIndex      int         `json:"msg_index"`
+			SecNonceIndex int         `json:"secnonce_index"`
+			Error         vectorError `json:"error"`
+		} `json:"sign_error_test_cases"`
+		VerifyFail []struct {
+			Sig          hexBytes `json:"sig"`
+			KeyIndices   []int    `json:"key_indices"`
+			NonceIndices []int    `json:"nonce_indices"`
+			MsgIndex     int      `json:"msg_index"`
+			SignerIndex  int      `json:"signer_index"`
+		} `json:"verify_fail_test_cases"`
+		VerifyErrors []struct {
+			Sig          hexBytes    `json:"sig"`
+			KeyIndices   []int       `json:"key_indices"`
+			NonceIndices []int       `json:"nonce_indices"`
+			MsgIndex     int         `json:"msg_index"`
+			SignerIndex  int         `json:"signer_index"`
+			Error        vectorError `json:"error"`
+		} `json:"verify_error_test_cases"`
+	}
+	loadVectors(t, "sign_verify_vectors.json", &v)
+
+	for i, c := range v.Valid {
+		pubNonces := pick(v.PubNonces, c.NonceIndices)
+		aggNonce, err := NonceAgg(pubNonces)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(aggNonce, v.AggNonces[c.AggNonceIndex]) {
+			t.Fatalf("valid case %d: aggregate nonce mismatch", i)
+		}
+		session := &SessionContext{
+			AggNonce: aggNonce,
+			PubKeys:  pick(v.PubKeys, c.KeyIndices),
+			Msg:      v.Msgs[c.MsgIndex],
+		}
+		secNonce := bytes.Clone(v.SecNonces[0])
+		psig, err := PartialSign(secNonce, v.Sk, session)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(psig, c.Expected) {
+			t.Fatalf("valid case %d: partial signature mismatch", i)
+		}
+		if _, err := PartialSign(secNonce, v.Sk, session); err != ErrInvalidSecNonce {
+			t.Fatalf("valid case %d: secret nonce reused", i)
+		}
+		ok, err := PartialSigVerify(psig, pubNonces, session.PubKeys, nil, nil, session.Msg, c.SignerIndex)
+		if err != nil || !ok {
+			t.Fatalf("valid case %d: partial signature rejected", i)
+		}
+	}
+	for _, c := range v.SignErrors {
+		session := &SessionContext{
+			AggNonce: v.AggNonces[c.AggNonceIndex],
+			PubKeys:  pick(v.PubKeys, c.KeyIndices),
+			Msg:      v.Msgs[c.MsgIndex],
+		}
+		_, err := PartialSign(bytes.Clone(v.SecNonces[c.SecNonceIndex]), v.Sk, session)
+		c.Error.check(t, err)
+	}
+	for i, c := range v.VerifyFail {
+		ok, err := PartialSigVerify(c.Sig, pick(v.PubNonces, c.NonceIndices), pick(v.PubKeys, c.KeyIndices), nil, nil, v.Msgs[c.MsgIndex], c.SignerIndex)
+		if err != nil || ok {
+			t.Fatalf("verify fail case %d: partial signature accepted", i)
+		}
+	}
+	for _, c := range v.VerifyErrors {
+		_, err := PartialSigVerify(c.Sig, pick(v.PubNonces, c.NonceIndices), pick(v.PubKeys, c.KeyIndices), nil, nil, v.Msgs[c.MsgIndex], c.SignerIndex)
+		c.Error.check(t, err)
+	}
+}
+
+func TestTweakVectors(t *testing.T) {
+	t.Parallel()
+	var v struct {
+		Sk        hexBytes   `json:"sk"`
+		PubKeys   []hexBytes `json:"pubkeys"`
+		SecNonce  hexBytes   `json:"secnonce"`
+		PubNonces []hexBytes `json:"pnonces"`
+		AggNonce  hexBytes   `json:"aggnonce"`
+		Tweaks    []hexBytes `json:"tweaks"`
+		Msg       hexBytes   `json:"msg"`
+		Valid     []struct {
+			KeyIndices   []int    `json:"key_indices"`
+			NonceIndices []int    `json:"nonce_indices"`
+			TweakIndices []int    `json:"tweak_indices"`
+			IsXOnly      []bool   `json:"is_xonly"`
+			SignerIndex  int      `json:"signer_index"`
+			Expected     hexBytes `json:"expected"`
+		} `json:"valid_test_cases"`
+		Errors []struct {
+			KeyIndices   []int       `json:"key_indices"`
+			NonceIndices []int       `json:"nonce_indices"`
+			TweakIndices []int       `json:"tweak_indices"`
+			IsXOnly      []bool      `json:"is_xonly"`
+			SignerIndex  int         `json:"signer_index"`
+			Error        vectorError `json:"error"`
+		} `json:"error_test_cases"`
+	}
+	loadVectors(t, "tweak_vectors.json", &v)
+
+	for i, c := range v.Valid {
+		pubNonces := pick(v.PubNonces, c.NonceIndices)
+		aggNonce, err := NonceAgg(pubNonces)
+		if err != nil {
+			t.Fatal(err)
+		}
+		session := &SessionContext{
+			AggNonce: aggNonce,
+			PubKeys:  pick(v.PubKeys, c.KeyIndices),
+			Tweaks:   pick(v.Tweaks, c.TweakIndices),
+			IsXOnly:  c.IsXOnly,
+			Msg:      v.Msg,
+		}
+		psig, err := PartialSign(bytes.Clone(v.SecNonce), v.Sk, session)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(psig, c.Expected) {
+			t.Fatalf("valid case %d: partial signature mismatch", i)
+		}
+		ok, err := PartialSigVerify(psig, pubNonces, session.PubKeys, session.Tweaks, session.IsXOnly, session.Msg, c.SignerIndex)
+		if err != nil || !ok {
+			t.Fatalf("valid case %d: partial signature rejected", i)
+		}
+	}
+	for _, c := range v.Errors {
+		session := &SessionContext{
+			AggNonce: v.AggNonce,
+			PubKeys:  pick(v.PubKeys, c.KeyIndices),
+			Tweaks:   pick(v.Tweaks, c.TweakIndices),
+			IsXOnly:  c.IsXOnly,
+			Msg:      v.Msg,
+		}
+		_, err := PartialSign(bytes.Clone(v.SecNonce), v.Sk, session)
+		c.Error.check(t, err)
+	}
+}
+
+func TestSigAggVectors(t *testing.T) {
+	t.Parallel()
+	var v struct {
+		PubKeys   []hexBytes `json:"pubkeys"`
+		PubNonces []hexBytes `json:"pnonces"`
+		Tweaks    []hexBytes `json:"tweaks"`
+		PSigs     []hexBytes `json:"psigs"`
+		Msg       hexBytes   `json:"msg"`
+		Valid     []struct {
+			AggNonce     hexBytes `json:"aggnonce"`
+			NonceIndices []int    `json:"nonce_indices"`
+			KeyIndices   []int    `json:"key_indices"`
+			TweakIndices []int    `json:"tweak_indices"`
+			IsXOnly      []bool   `json:"is_xonly"`
+			PSigIndices  []int    `json:"psig_indices"`
+			Expected     hexBytes `json:"expected"`
+		} `json:"valid_test_cases"`
+		Errors []struct {
+			AggNonce     hexBytes    `json:"aggnonce"`
+			NonceIndices []int       `json:"nonce_indices"`
+			KeyIndices   []int       `json:"key_indices"`
+			TweakIndices []int       `json:"tweak_indices"`
+			IsXOnly      []bool      `json:"is_xonly"`
+			PSigIndices  []int       `json:"psig_indices"`
+			Error        vectorError `json:"error"`
+		} `json:"error_test_cases"`
+	}
+	loadVectors(t, "sig_agg_vectors.json", &v)
+
+	for i, c := range v.Valid {
+		aggNonce, err := NonceAgg(pick(v.PubNonces, c.NonceIndices))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(aggNonce, c.AggNonce) {
+			t.Fatalf("valid case %d: aggregate nonce mismatch", i)
+		}
+		session := &SessionContext{
+			AggNonce: aggNonce,
+			PubKeys:  pick(v.PubKeys, c.KeyIndices),
+			Tweaks:   pick(v.Tweaks, c.TweakIndices),
+			IsXOnly:  c.IsXOnly,
+			Msg:      v.Msg,
+		}
+		sig, err := PartialSigAgg(pick(v.PSigs, c.PSigIndices), session)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(sig, c.Expected) {
+			t.Fatalf("valid case %d: signature mismatch", i)
+		}
+		keyAgg, err := KeyAgg(session.PubKeys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j := range session.Tweaks {
+			if err := keyAgg.ApplyTweak(session.Tweaks[j], session.IsXOnly[j]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if ok, err := keyAgg.PublicKey().Verify(sig, v.Msg, nil); err != nil || !ok {
+			t.Fatalf("valid case %d: aggregate signature rejected", i)
+		}
+	}
+	for _, c := range v.Errors {
+		session := &SessionContext{
+			AggNonce: c.AggNonce,
+			PubKeys:  pick(v.PubKeys, c.KeyIndices),
+			Tweaks:   pick(v.Tweaks, c.TweakIndices),
+			IsXOnly:  c.IsXOnly,
+			Msg:      v.Msg,
+		}
+		_, err := PartialSigAgg(pick(v.PSigs, c.PSigIndices), session)
+		c.Error.check(t, err)
+	}
+}
+
+func TestMuSig2(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[SECP256K1] MuSig2 signature verifies under the aggregate key", prop.ForAll(
+		func(n int, xOnly bool) bool {
+			msg := []byte("testing MuSig2")
+			sks := make([][]byte, n)
+			pubKeys := make([][]byte, n)
+			for i := 0; i < n; i++ {
+				privKey, _ := GenerateKey(rand.Reader)
+				sks[i] = privKey.scalar[:]
+				pubKeys[i] = plainPublicKey(sks[i])
+			}
+			keyAgg, err := KeyAgg(pubKeys)
+			if err != nil {
+				return false
+			}
+			tweak := make([]byte, 32)
+			tweak[31] = 42
+			if err := keyAgg.ApplyTweak(tweak, xOnly); err != nil {
+				return false
+			}
+			aggPk := keyAgg.PublicKey()
+
+			// first round
+			secNonces := make([][]byte, n)
+			pubNonces := make([][]byte, n)
+			for i := 0; i < n; i++ {
+				if secNonces[i], pubNonces[i], err = NonceGen(sks[i], pubKeys[i], aggPk.Bytes(), msg, nil); err != nil {
+					return false
+				}
+			}
+			aggNonce, err := NonceAgg(pubNonces)
+			if err != nil {
+				return false
+			}
+
+			// second round
+			session := &SessionContext{
+				AggNonce: aggNonce,
+				PubKeys:  pubKeys,
+				Tweaks:   [][]byte{tweak},
+				IsXOnly:  []bool{xOnly},
+				Msg:      msg,
+			}
+			psigs := make([][]byte, n)
+			for i := 0; i < n; i++ {
+				if psigs[i], err = PartialSign(secNonces[i], sks[i], session); err != nil {
+					return false
+				}
+				ok, err := PartialSigVerify(psigs[i], pubNonces, pubKeys, session.Tweaks, session.IsXOnly, msg, i)
+				if err != nil || !ok {
+					return false
+				}
+			}
+			sig, err := PartialSigAgg(psigs, session)
+			if err != nil {
+				return false
+			}
+			ok, err := aggPk.Verify(sig, msg, nil)
+			return err == nil && ok
+		},
+		gen.IntRange(1, 5),
+		gen.Bool(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+// plainPublicKey returns the compressed public key sk ⋅ G.
+func plainPublicKey(sk []byte) []byte {
+	var P secp256k1.G1Affine
+	P.ScalarMultiplicationBase(new(big.Int).SetBytes(sk))
+	return cbytes(&P)
+}
+
+// publicNonce returns the public nonce of a secret nonce.
+func publicNonce(secNonce []byte) []byte {
+	res := make([]byte, 0, SizePubNonce)
+	for i := 0; i < 2; i++ {
+		var R secp256k1.G1Affine
+		R.ScalarMultiplicationBase(new(big.Int).SetBytes(secNonce[i*sizeFr : (i+1)*sizeFr]))
+		res = append(res, cbytes(&R)...)
+	}
+	return res
+}
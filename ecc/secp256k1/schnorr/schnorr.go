@@ -0,0 +1,361 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package schnorr
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fp"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+const (
+	sizeFr = fr.Bytes
+	sizeFp = fp.Bytes
+
+	// SizePublicKey is the size in bytes of an x-only public key.
+	SizePublicKey = sizeFp
+	// SizeSecretKey is the size in bytes of a secret key.
+	SizeSecretKey = sizeFr
+	// SizeSignature is the size in bytes of a signature.
+	SizeSignature = sizeFp + sizeFr
+
+	sizePrivateKey = SizePublicKey + SizeSecretKey
+)
+
+// tags of the BIP-340 hashes
+const (
+	tagAux       = "BIP0340/aux"
+	tagNonce     = "BIP0340/nonce"
+	tagChallenge = "BIP0340/challenge"
+)
+
+var (
+	ErrInvalidSecretKey = errors.New("secret key must be a non-zero scalar smaller than the group order")
+	ErrInvalidPublicKey = errors.New("invalid public key")
+	ErrInvalidSignature = errors.New("invalid signature")
+	errNotOnCurve       = errors.New("x is not the abscissa of a point of the curve")
+	errZeroNonce        = errors.New("nonce is zero")
+	errWrongSize        = errors.New("wrong size buffer")
+)
+
+// PublicKey represents a BIP-340 x-only public key. A is the point with an
+// even y-coordinate.
+type PublicKey struct {
+	A secp256k1.G1Affine
+}
+
+// PrivateKey represents a BIP-340 private key.
+type PrivateKey struct {
+	PublicKey PublicKey
+	scalar    [sizeFr]byte // secret scalar, in big Endian
+}
+
+// TaggedHash returns the BIP-340 tagged hash of the concatenation of msgs,
+//
+//	SHA256(SHA256(tag) ∥ SHA256(tag) ∥ msgs[0] ∥ … ∥ msgs[n-1])
+func TaggedHash(tag string, msgs ...[]byte) [32]byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, m := range msgs {
+		h.Write(m)
+	}
+	var res [32]byte
+	h.Sum(res[:0])
+	return res
+}
+
+// GenerateKey generates a public and private key pair.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	var sk [SizeSecretKey]byte
+	for {
+		if _, err := io.ReadFull(rand, sk[:]); err != nil {
+			return nil, err
+		}
+		privKey, err := NewPrivateKey(sk[:])
+		if err == nil {
+			return privKey, nil
+		}
+	}
+}
+
+// NewPrivateKey returns the private key of the 32 bytes secret key sk, which
+// must be a big endian integer in [1, n-1].
+func NewPrivateKey(sk []byte) (*PrivateKey, error) {
+	if len(sk) != SizeSecretKey {
+		return nil, ErrInvalidSecretKey
+	}
+	var d fr.Element
+	if err := d.SetBytesCanonical(sk); err != nil || d.IsZero() {
+		return nil, ErrInvalidSecretKey
+	}
+	privKey := new(PrivateKey)
+	copy(privKey.scalar[:], sk)
+	var P secp256k1.G1Affine
+	P.ScalarMultiplicationBase(d.BigInt(new(big.Int)))
+	privKey.PublicKey.A = P
+	if !hasEvenY(&P) {
+		privKey.PublicKey.A.Neg(&P)
+	}
+	return privKey, nil
+}
+
+// Public returns the public key associated to the private key.
+func (privKey *PrivateKey) Public() signature.PublicKey {
+	var pub PublicKey
+	pub.A.Set(&privKey.PublicKey.A)
+	return &pub
+}
+
+// Sign performs the BIP-340 signature of the message, with auxiliary
+// randomness read from crypto/rand. If hFunc is provided, the message is
+// hashed with hFunc first.
+func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	message, err := prehash(message, hFunc)
+	if err != nil {
+		return nil, err
+	}
+	var auxRand [32]byte
+	if _, err := io.ReadFull(rand.Reader, auxRand[:]); err != nil {
+		return nil, err
+	}
+	return privKey.SignWithAuxRand(message, auxRand[:])
+}
+
+// SignWithAuxRand performs the BIP-340 signature of the message with the
+// given 32 bytes of auxiliary randomness. The auxiliary randomness only
+// protects against side channels, the nonce is derived deterministically
+// from the secret key and the message.
+//
+// d = sk if P has an even y-coordinate, n-sk otherwise
+// t = d xor hash_aux(a)
+// k = hash_nonce(t ∥ P.x ∥ m), negated if R = k ⋅ G has an odd y-coordinate
+// e = hash_challenge(R.x ∥ P.x ∥ m)
+// signature = R.x ∥ (k + e ⋅ d)
+//
+// BIP-340, "Default Signing"
+func (privKey *PrivateKey) SignWithAuxRand(message, auxRand []byte) ([]byte, error) {
+	if len(auxRand) != 32 {
+		return nil, errWrongSize
+	}
+	var d fr.Element
+	if err := d.SetBytesCanonical(privKey.scalar[:]); err != nil || d.IsZero() {
+		return nil, ErrInvalidSecretKey
+	}
+	var P secp256k1.G1Affine
+	P.ScalarMultiplicationBase(d.BigInt(new(big.Int)))
+	if !hasEvenY(&P) {
+		d.Neg(&d)
+		P.Neg(&P)
+	}
+	pBin := P.X.Bytes()
+
+	dBin := d.Bytes()
+	t := TaggedHash(tagAux, auxRand)
+	for i := range t {
+		t[i] ^= dBin[i]
+	}
+	kBin := TaggedHash(tagNonce, t[:], pBin[:], message)
+	var k fr.Element
+	k.SetBytes(kBin[:])
+	if k.IsZero() {
+		return nil, errZeroNonce
+	}
+	var R secp256k1.G1Affine
+	R.ScalarMultiplicationBase(k.BigInt(new(big.Int)))
+	if !hasEvenY(&R) {
+		k.Neg(&k)
+	}
+	rBin := R.X.Bytes()
+	e := challenge(rBin[:], pBin[:], message)
+
+	var s fr.Element
+	s.Mul(&e, &d).Add(&s, &k)
+	sBin := s.Bytes()
+
+	sig := make([]byte, SizeSignature)
+	copy(sig[:sizeFp], rBin[:])
+	copy(sig[sizeFp:], sBin[:])
+
+	// the signature is checked against the public key to protect against
+	// faults in the computation
+	if !verify(&P, message, sig) {
+		return nil, ErrInvalidSignature
+	}
+	return sig, nil
+}
+
+// Verify checks the BIP-340 signature of the message. If hFunc is provided,
+// the message is hashed with hFunc first.
+//
+// R = s ⋅ G - e ⋅ P, with e = hash_challenge(r ∥ P.x ∥ m)
+// R ?≠ ∞, R.y ?= even and R.x ?= r
+//
+// BIP-340, "Verification"
+func (publicKey *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
+	if len(sigBin) != SizeSignature {
+		return false, errWrongSize
+	}
+	message, err := prehash(message, hFunc)
+	if err != nil {
+		return false, err
+	}
+	return verify(&publicKey.A, message, sigBin), nil
+}
+
+func verify(P *secp256k1.G1Affine, message, sig []byte) bool {
+	var r fp.Element
+	if err := r.SetBytesCanonical(sig[:sizeFp]); err != nil {
+		return false
+	}
+	var s fr.Element
+	if err := s.SetBytesCanonical(sig[sizeFp:]); err != nil {
+		return false
+	}
+	pBin := P.X.Bytes()
+	e := challenge(sig[:sizeFp], pBin[:], message)
+	e.Neg(&e)
+
+	var R secp256k1.G1Jac
+	R.JointScalarMultiplicationBase(P, s.BigInt(new(big.Int)), e.BigInt(new(big.Int)))
+	if R.Z.IsZero() {
+		return false
+	}
+	var Raff secp256k1.G1Affine
+	Raff.FromJacobian(&R)
+	return hasEvenY(&Raff) && Raff.X.Equal(&r)
+}
+
+// BatchVerify checks the BIP-340 signatures sigs[i] of the messages msgs[i]
+// under the public keys publicKeys[i]. If hFunc is provided, the messages are
+// hashed with hFunc first. It returns true only if all the signatures are
+// valid.
+//
+// The equations are combined with random coefficients a_i, a_0 = 1, into a
+// single multi-scalar multiplication
+//
+// (∑ a_i ⋅ s_i) ⋅ G ?= ∑ a_i ⋅ R_i + ∑ (a_i ⋅ e_i) ⋅ P_i
+//
+// BIP-340, "Batch Verification"
+func BatchVerify(publicKeys []*PublicKey, msgs [][]byte, sigs [][]byte, hFunc hash.Hash) (bool, error) {
+	n := len(publicKeys)
+	if n != len(msgs) || n != len(sigs) {
+		return false, errors.New("inputs of different lengths")
+	}
+	if n == 0 {
+		return true, nil
+	}
+
+	_, g := secp256k1.Generators()
+	points := make([]secp256k1.G1Affine, 2*n+1)
+	scalars := make([]fr.Element, 2*n+1)
+	points[0] = g
+
+	var a, e, s fr.Element
+	a.SetOne()
+	for i := 0; i < n; i++ {
+		if len(sigs[i]) != SizeSignature {
+			return false, errWrongSize
+		}
+		message, err := prehash(msgs[i], hFunc)
+		if err != nil {
+			return false, err
+		}
+		R, err := liftX(sigs[i][:sizeFp])
+		if err != nil {
+			return false, nil
+		}
+		if err := s.SetBytesCanonical(sigs[i][sizeFp:]); err != nil {
+			return false, nil
+		}
+		pBin := publicKeys[i].A.X.Bytes()
+		e = challenge(sigs[i][:sizeFp], pBin[:], message)
+
+		if i > 0 {
+			if _, err := a.SetRandom(); err != nil {
+				return false, err
+			}
+		}
+		s.Mul(&s, &a)
+		scalars[0].Add(&scalars[0], &s)
+		points[2*i+1] = R
+		scalars[2*i+1].Neg(&a)
+		points[2*i+2] = publicKeys[i].A
+		scalars[2*i+2].Mul(&a, &e).Neg(&scalars[2*i+2])
+	}
+
+	var res secp256k1.G1Jac
+	if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		return false, err
+	}
+	return res.Z.IsZero(), nil
+}
+
+// challenge returns hash_challenge(r ∥ p ∥ m) mod n.
+func challenge(r, p, message []byte) fr.Element {
+	h := TaggedHash(tagChallenge, r, p, message)
+	var e fr.Element
+	e.SetBytes(h[:])
+	return e
+}
+
+// hasEvenY returns true if the y-coordinate of p is even.
+func hasEvenY(p *secp256k1.G1Affine) bool {
+	y := p.Y.Bytes()
+	return y[sizeFp-1]&1 == 0
+}
+
+// liftX returns the point of abscissa x with an even y-coordinate.
+//
+// BIP-340, lift_x
+func liftX(x []byte) (secp256k1.G1Affine, error) {
+	var p secp256k1.G1Affine
+	if err := p.X.SetBytesCanonical(x); err != nil {
+		return p, errNotOnCurve
+	}
+	// y² = x³ + 7
+	_, b := secp256k1.CurveCoefficients()
+	var y2 fp.Element
+	y2.Square(&p.X).Mul(&y2, &p.X).Add(&y2, &b)
+	if p.Y.Sqrt(&y2) == nil {
+		return p, errNotOnCurve
+	}
+	if !hasEvenY(&p) {
+		p.Y.Neg(&p.Y)
+	}
+	return p, nil
+}
+
+// Equal compares 2 public keys
+func (pub *PublicKey) Equal(x signature.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+	bpk := pub.Bytes()
+	bxx := xx.Bytes()
+	return subtle.ConstantTimeCompare(bpk, bxx) == 1
+}
+
+func prehash(message []byte, hFunc hash.Hash) ([]byte, error) {
+	if hFunc == nil {
+		return message, nil
+	}
+	hFunc.Reset()
+	if _, err := hFunc.Write(message); err != nil {
+		return nil, err
+	}
+	return hFunc.Sum(nil), nil
+}
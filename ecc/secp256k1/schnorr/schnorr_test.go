@@ -0,0 +1,319 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package schnorr
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+const (
+	nbFuzzShort = 2
+	nbFuzz      = 10
+)
+
+// test vectors from BIP-340
+var bip340Vectors = []struct {
+	secretKey, publicKey, auxRand, message, signature string
+	verifyResult                                      bool
+}{
+	{
+		secretKey:    "0000000000000000000000000000000000000000000000000000000000000003",
+		publicKey:    "F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		auxRand:      "0000000000000000000000000000000000000000000000000000000000000000",
+		message:      "0000000000000000000000000000000000000000000000000000000000000000",
+		signature:    "E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+		verifyResult: true,
+	},
+	{
+		secretKey:    "B7E151628AED2A6ABF7158809CF4F3C762E7160F38B4DA56A784D9045190CFEF",
+		publicKey:    "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		auxRand:      "0000000000000000000000000000000000000000000000000000000000000001",
+		message:      "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature:    "6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+		verifyResult: true,
+	},
+	{
+		secretKey:    "C90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74020BBEA63B14E5C9",
+		publicKey:    "DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EB8",
+		auxRand:      "C87AA53824B4D7AE2EB035A2B5BBBCCC080E76CDC6D1692C4B0B62D798E6D906",
+		message:      "7E2D58D8B3BCDF1ABADEC7829054F90DDA9805AAB56C77333024B9D0A508B75C",
+		signature:    "5831AAEED7B44BB74E5EAB94BA9D4294C49BCF2A60728D8B4C200F50DD313C1BAB745879A5AD954A72C45A91C3A51D3C7ADEA98D82F8481E0E1E03674A6F3FB7",
+		verifyResult: true,
+	},
+	{
+		secretKey:    "0B432B2677937381AEF05BB02A66ECD012773062CF3FA2549E44F58ED2401710",
+		publicKey:    "25D1DFF95105F5253C4022F628A996AD3A0D95FBF21D468A1B33F8C160D8F517",
+		auxRand:      "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+		message:      "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+		signature:    "7EB0509757E246F19449885651611CB965ECC1A187DD51B64FDA1EDC9637D5EC97582B9CB13DB3933705B32BA982AF5AF25FD78881EBB32771FC5922EFC66EA3",
+		verifyResult: true,
+	},
+	{
+		publicKey:    "D69C3509BB99E412E68B0FE8544E72837DFA30746D8BE2AA65975F29D22DC7B9",
+		message:      "4DF3C3F68FCC83B27E9D42C90431A72499F17875C81A599B566C9889B9696703",
+		signature:    "00000000000000000000003B78CE563F89A0ED9414F5AA28AD0D96D6795F9C6376AFB1548AF603B3EB45C9F8207DEE1060CB71C04E80F593060B07D28308D7F4",
+		verifyResult: true,
+	},
+	{
+		// public key not on the curve
+		publicKey:    "EEFDEA4CDB677750A420FEE807EACF21EB9898AE79B9768766E4FAA04A2D4A34",
+		message:      "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature:    "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E17776969E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+		verifyResult: false,
+	},
+	{
+		// has_even_y(R) is false
+		publicKey:    "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:      "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature:    "FFF97BD5755EEEA420453A14355235D382F6472F8568A18B2F057A14602975563CC27944640AC607CD107AE10923D9EF7A73C643E166BE5EBEAFA34B1AC553E2",
+		verifyResult: false,
+	},
+	{
+		// negated message
+		publicKey:    "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:      "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature:    "1FA62E331EDBC21C394792D2AB1100A7B432B013DF3F6FF4F99FCB33E0E1515F28890B3EDB6E7189B630448B515CE4F8622A954CFE545735AAEA5134FCCDB2BD",
+		verifyResult: false,
+	},
+	{
+		// negated s value
+		publicKey:    "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:      "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature:    "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769961764B3AA9B2FFCB6EF947B6887A226E8D7C93E00C5ED0C1834FF0D0C2E6DA6",
+		verifyResult: false,
+	},
+	{
+		// s ⋅ G - e ⋅ P is infinity, x(inf) defined as 0
+		publicKey:    "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:      "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature:    "0000000000000000000000000000000000000000000000000000000000000000123DDA8328AF9C23A94C1FEECFD123BA4FB73476F0D594DCB65C6425BD186051",
+		verifyResult: false,
+	},
+	{
+		// s ⋅ G - e ⋅ P is infinity, x(inf) defined as 1
+		publicKey:    "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:      "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature:    "00000000000000000000000000000000000000000000000000000000000000017615FBAF5AE28864013C099742DEADB4DBA87F11AC6754F93780D5A1837CF197",
+		verifyResult: false,
+	},
+	{
+		// r is not an x-coordinate of the curve
+		publicKey:    "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:      "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature:    "4A298DACAE57395A15D0795DDBFD1DCB564DA82B0F269BC70A74F8220429BA1D69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+		verifyResult: false,
+	},
+	{
+		// r is equal to the field size
+		publicKey:    "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:      "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature:    "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+		verifyResult: false,
+	},
+	{
+		// s is equal to the curve order
+		publicKey:    "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:      "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature:    "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+		verifyResult: false,
+	},
+	{
+		// public key is not a valid x-coordinate because it exceeds the field size
+		publicKey:    "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30",
+		message:      "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature:    "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E17776969E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+		verifyResult: false,
+	},
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestBIP340Vectors(t *testing.T) {
+	t.Parallel()
+	for i, v := range bip340Vectors {
+		pkBin, msg, sig := mustDecodeHex(t, v.publicKey), mustDecodeHex(t, v.message), mustDecodeHex(t, v.signature)
+		if v.secretKey != "" {
+			privKey, err := NewPrivateKey(mustDecodeHex(t, v.secretKey))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(privKey.PublicKey.Bytes(), pkBin) {
+				t.Fatalf("vector %d: public key mismatch", i)
+			}
+			res, err := privKey.SignWithAuxRand(msg, mustDecodeHex(t, v.auxRand))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(res, sig) {
+				t.Fatalf("vector %d: signature mismatch", i)
+			}
+		}
+		var pk PublicKey
+		if _, err := pk.SetBytes(pkBin); err != nil {
+			if v.verifyResult {
+				t.Fatalf("vector %d: %v", i, err)
+			}
+			continue
+		}
+		ok, err := pk.Verify(sig, msg, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != v.verifyResult {
+			t.Fatalf("vector %d: expected verification result %v", i, v.verifyResult)
+		}
+		if ok, _ := BatchVerify([]*PublicKey{&pk}, [][]byte{msg}, [][]byte{sig}, nil); ok != v.verifyResult {
+			t.Fatalf("vector %d: expected batch verification result %v", i, v.verifyResult)
+		}
+	}
+}
+
+func TestSchnorr(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[SECP256K1] test the signing and verification", prop.ForAll(
+		func(msg []byte) bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			sig, err := privKey.Sign(msg, nil)
+			if err != nil {
+				return false
+			}
+			ok, err := publicKey.Verify(sig, msg, nil)
+			if err != nil || !ok {
+				return false
+			}
+			ok, _ = publicKey.Verify(sig, append(msg, 0), nil)
+			return !ok
+		},
+		gen.SliceOf(gen.UInt8()),
+	))
+
+	properties.Property("[SECP256K1] test the signing and verification (pre-hashed)", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.Public()
+
+			msg := []byte("testing Schnorr")
+			hFunc := sha256.New()
+			sig, _ := privKey.Sign(msg, hFunc)
+			flag, _ := publicKey.Verify(sig, msg, hFunc)
+
+			return flag
+		},
+	))
+
+	properties.Property("[SECP256K1] test the batch verification", prop.ForAll(
+		func(n int) bool {
+			pks := make([]*PublicKey, n)
+			msgs := make([][]byte, n)
+			sigs := make([][]byte, n)
+			for i := 0; i < n; i++ {
+				privKey, _ := GenerateKey(rand.Reader)
+				pks[i] = &privKey.PublicKey
+				msgs[i] = []byte{byte(i)}
+				sigs[i], _ = privKey.Sign(msgs[i], nil)
+			}
+			if ok, err := BatchVerify(pks, msgs, sigs, nil); err != nil || !ok {
+				return false
+			}
+			// swap two signatures
+			sigs[0], sigs[n-1] = sigs[n-1], sigs[0]
+			ok, err := BatchVerify(pks, msgs, sigs, nil)
+			return err == nil && !ok
+		},
+		gen.IntRange(2, 10),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestSerialization(t *testing.T) {
+	t.Parallel()
+	privKey, _ := GenerateKey(rand.Reader)
+
+	var pk PublicKey
+	if _, err := pk.SetBytes(privKey.PublicKey.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if !pk.Equal(&privKey.PublicKey) {
+		t.Fatal("public key round trip failed")
+	}
+
+	var sk PrivateKey
+	if _, err := sk.SetBytes(privKey.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sk.Bytes(), privKey.Bytes()) {
+		t.Fatal("private key round trip failed")
+	}
+	buf := privKey.Bytes()
+	buf[0] ^= 1
+	if _, err := sk.SetBytes(buf); err == nil {
+		t.Fatal("private key with a wrong public key accepted")
+	}
+	if _, err := NewPrivateKey(make([]byte, SizeSecretKey)); err != ErrInvalidSecretKey {
+		t.Fatal("zero secret key accepted")
+	}
+}
+
+func BenchmarkSign(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	msg := []byte("benchmarking Schnorr sign()")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.Sign(msg, nil)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	msg := []byte("benchmarking Schnorr verify()")
+	sig, _ := privKey.Sign(msg, nil)
+	publicKey := privKey.PublicKey
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		publicKey.Verify(sig, msg, nil)
+	}
+}
+
+func BenchmarkBatchVerify(b *testing.B) {
+	const n = 64
+	pks := make([]*PublicKey, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		privKey, _ := GenerateKey(rand.Reader)
+		pks[i] = &privKey.PublicKey
+		msgs[i] = []byte{byte(i)}
+		sigs[i], _ = privKey.Sign(msgs[i], nil)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BatchVerify(pks, msgs, sigs, nil)
+	}
+}
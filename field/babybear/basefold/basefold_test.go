@@ -0,0 +1,240 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package basefold
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	fr "github.com/consensys/gnark-crypto/field/babybear"
+	"github.com/consensys/gnark-crypto/field/babybear/internal/iop"
+)
+
+func randomTable(nbVariables int) []fr.Element {
+	p := make([]fr.Element, 1<<nbVariables)
+	for i := range p {
+		p[i].MustSetRandom()
+	}
+	return p
+}
+
+// evalTable returns the evaluation at point of the multilinear polynomial
+// whose evaluations on the hypercube are p, binding the variables one by one
+// as polynomial.MultiLin.Fold.
+func evalTable(p []fr.Element, point []Ext) Ext {
+	t := make([]Ext, len(p))
+	for i := range p {
+		t[i] = iop.Embed(&p[i])
+	}
+	for k := range point {
+		t = foldTable(t, point[k])
+	}
+	return t[0]
+}
+
+func randomPoint(nbVariables int) []Ext {
+	point := make([]Ext, nbVariables)
+	for k := range point {
+		point[k].MustSetRandom()
+	}
+	return point
+}
+
+func TestPCS(t *testing.T) {
+	data := []byte("data")
+
+	for _, tc := range []struct {
+		nbVariables int
+		params      Parameters
+	}{
+		{8, DefaultParameters()},
+		{8, Parameters{Rate: 2, NbQueries: 10, GrindingBits: 4}},
+		{5, Parameters{Rate: 8, NbQueries: 5}},
+		{1, Parameters{Rate: 2, NbQueries: 3}},
+	} {
+		t.Run(fmt.Sprintf("%d/%+v", tc.nbVariables, tc.params), func(t *testing.T) {
+			pcs, err := NewPCS(tc.nbVariables, sha256.New(), tc.params)
+			require.NoError(t, err)
+			polynomials := make([][]fr.Element, 3)
+			for i := range polynomials {
+				polynomials[i] = randomTable(tc.nbVariables)
+			}
+			point := randomPoint(tc.nbVariables)
+
+			proverData, err := pcs.Commit(polynomials...)
+			require.NoError(t, err)
+			commitment := proverData.Commitment
+			proof, err := pcs.Open(proverData, point, data)
+			require.NoError(t, err)
+			require.NoError(t, pcs.Verify(commitment, point, &proof, data))
+
+			// claimed values
+			for i := range polynomials {
+				expected := evalTable(polynomials[i], point)
+				require.True(t, expected.Equal(&proof.ClaimedValues[i]))
+			}
+
+			// serialization
+			var buf bytes.Buffer
+			_, err = proof.WriteTo(&buf)
+			require.NoError(t, err)
+			var decoded OpeningProof
+			_, err = decoded.ReadFrom(&buf)
+			require.NoError(t, err)
+			require.Equal(t, proof, decoded)
+			require.NoError(t, pcs.Verify(commitment, point, &decoded, data))
+
+			// wrong statement
+			require.Error(t, pcs.Verify(commitment, point, &proof, []byte("wrong")))
+			require.Error(t, pcs.Verify(commitment, randomPoint(tc.nbVariables), &proof, data))
+
+			one := iop.Embed(new(fr.Element).SetOne())
+
+			// tampered claimed value
+			proof.ClaimedValues[1].Add(&proof.ClaimedValues[1], &one)
+			require.Error(t, pcs.Verify(commitment, point, &proof, data))
+			proof.ClaimedValues[1].Sub(&proof.ClaimedValues[1], &one)
+
+			// tampered round polynomial
+			last := &proof.RoundPolynomials[tc.nbVariables-1][1]
+			last.Add(last, &one)
+			require.Error(t, pcs.Verify(commitment, point, &proof, data))
+			last.Sub(last, &one)
+
+			// tampered row
+			leaf := proof.Openings[0][0].Leaf
+			saved := leaf[len(leaf)-1]
+			leaf[len(leaf)-1].SetOne()
+			require.Error(t, pcs.Verify(commitment, point, &proof, data))
+			leaf[len(leaf)-1] = saved
+
+			// tampered final value
+			proof.Final.Add(&proof.Final, &one)
+			require.Error(t, pcs.Verify(commitment, point, &proof, data))
+			proof.Final.Sub(&proof.Final, &one)
+
+			// tampered proof of work
+			if tc.params.GrindingBits > 0 {
+				proof.Nonce++
+				require.Error(t, pcs.Verify(commitment, point, &proof, data))
+				proof.Nonce--
+			}
+			require.NoError(t, pcs.Verify(commitment, point, &proof, data))
+		})
+	}
+
+	const nbVariables = 6
+	pcs, err := NewPCS(nbVariables, sha256.New(), Parameters{Rate: 2, NbQueries: 64})
+	require.NoError(t, err)
+
+	// a table which is not the encoding of a multilinear polynomial is
+	// rejected
+	proverData, err := pcs.Commit(randomTable(nbVariables))
+	require.NoError(t, err)
+	codeword := proverData.codewords[0]
+	for j := len(codeword) / 2; j < len(codeword); j++ {
+		codeword[j].MustSetRandom()
+	}
+	proverData.tree = iop.NewMerkleTree(pcs.hasher, iop.FiberLeaves(proverData.codewords, 2))
+	proverData.Commitment.Root = proverData.tree.Root()
+	point := randomPoint(nbVariables)
+	proof, err := pcs.Open(proverData, point)
+	require.NoError(t, err)
+	require.Error(t, pcs.Verify(proverData.Commitment, point, &proof))
+
+	// wrong number of variables
+	_, err = pcs.Commit(randomTable(nbVariables + 1))
+	require.ErrorIs(t, err, ErrNbVariables)
+	_, err = pcs.Open(proverData, randomPoint(nbVariables-1))
+	require.ErrorIs(t, err, ErrNbVariables)
+}
+
+func TestEncode(t *testing.T) {
+	const nbVariables = 4
+	pcs, err := NewPCS(nbVariables, sha256.New(), DefaultParameters())
+	require.NoError(t, err)
+
+	// the encoding of X₁⋅X₃ is Y⋅Y⁴ = Y⁵
+	p := make([]fr.Element, 1<<nbVariables)
+	for i := range p {
+		if i&0b1010 == 0b1010 {
+			p[i].SetOne()
+		}
+	}
+	codeword := pcs.encode(p)
+	x := pcs.domain.FrMultiplicativeGen
+	var expected fr.Element
+	for j := range codeword {
+		expected.Square(&x).Square(&expected).Mul(&expected, &x)
+		require.True(t, expected.Equal(&codeword[j]))
+		x.Mul(&x, &pcs.domain.Generator)
+	}
+}
+
+func TestParameters(t *testing.T) {
+	for _, params := range []Parameters{
+		{Rate: 3, NbQueries: 1},
+		{Rate: 2, NbQueries: 0},
+		{Rate: 2, NbQueries: 1, GrindingBits: 40},
+	} {
+		_, err := NewPCS(4, sha256.New(), params)
+		require.ErrorIs(t, err, ErrInvalidParameters)
+	}
+	_, err := NewPCS(0, sha256.New(), DefaultParameters())
+	require.ErrorIs(t, err, ErrInvalidParameters)
+}
+
+// Benchmarks
+
+func BenchmarkOpen(b *testing.B) {
+	const nbVariables = 14
+	pcs, err := NewPCS(nbVariables, sha256.New(), DefaultParameters())
+	if err != nil {
+		b.Fatal(err)
+	}
+	polynomials := make([][]fr.Element, 8)
+	for i := range polynomials {
+		polynomials[i] = randomTable(nbVariables)
+	}
+	proverData, err := pcs.Commit(polynomials...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	point := randomPoint(nbVariables)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pcs.Open(proverData, point)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	const nbVariables = 14
+	pcs, err := NewPCS(nbVariables, sha256.New(), DefaultParameters())
+	if err != nil {
+		b.Fatal(err)
+	}
+	polynomials := make([][]fr.Element, 8)
+	for i := range polynomials {
+		polynomials[i] = randomTable(nbVariables)
+	}
+	proverData, err := pcs.Commit(polynomials...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	point := randomPoint(nbVariables)
+	proof, err := pcs.Open(proverData, point)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pcs.Verify(proverData.Commitment, point, &proof)
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package basefold provides a multilinear polynomial commitment scheme over
+// babybear, based on Basefold.
+//
+// Multilinear polynomials are given by their evaluations on the boolean
+// hypercube, in the order of polynomial.MultiLin: the first variable is the
+// most significant bit of the index. They are committed to by batches, with
+// the Poseidon2 Merkle tree of their Reed-Solomon encodings on a coset of the
+// domain, the univariate polynomial encoding a multilinear polynomial having
+// its coefficients in the monomial basis.
+//
+// The evaluations of a batch at a point of Ext are proven with a sumcheck,
+// whose i-th challenge folds both the evaluation tables, binding their i-th
+// variable, and the codewords, as in FRI. The last folded codeword is the
+// constant which the verifier needs to check the last round of the sumcheck.
+//
+// See [Basefold] for the details.
+//
+// [Basefold]: https://eprint.iacr.org/2023/1705.pdf
+package basefold
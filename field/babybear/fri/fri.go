@@ -0,0 +1,351 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+
+	fr "github.com/consensys/gnark-crypto/field/babybear"
+	"github.com/consensys/gnark-crypto/field/babybear/fft"
+	"github.com/consensys/gnark-crypto/field/babybear/internal/iop"
+)
+
+var (
+	ErrLowDegree            = errors.New("the polynomial is not of the expected degree")
+	ErrProximityTestFolding = errors.New("one round of interaction failed")
+	ErrMerklePath           = iop.ErrMerklePath
+	ErrInvalidParameters    = errors.New("invalid FRI parameters")
+	ErrProofShape           = iop.ErrProofShape
+	ErrProofOfWork          = iop.ErrProofOfWork
+)
+
+// Ext is the extension of the field in which the challenges are drawn and
+// the polynomials are opened.
+type Ext = iop.Ext
+
+// Digest is a node of a Merkle tree, in particular a commitment.
+type Digest = iop.Digest
+
+// DigestSize is the size in bytes of a Digest.
+const DigestSize = iop.DigestSize
+
+// MerkleProof is the opening of a leaf of a Merkle tree.
+type MerkleProof = iop.MerkleProof
+
+// Parameters are the parameters of the FRI protocol, trading the size of the
+// proofs against the time of the prover.
+type Parameters struct {
+
+	// Rate is the blow-up factor ρ = size_code_word/size_polynomial, a power
+	// of 2 larger than 1.
+	Rate int
+
+	// NbQueries is the number of queries of the verifier, see
+	// NbQueriesForSecurity.
+	NbQueries int
+
+	// FoldingArity is the number of evaluations folded into one at each
+	// step, 2, 4, 8 or 16. The oracles are committed to with one Merkle leaf
+	// per fiber of x ↦ x^FoldingArity, so that a query opens a single Merkle
+	// path per step.
+	FoldingArity int
+
+	// FinalPolynomialSize is a power of 2: the folding stops as soon as the
+	// folded polynomial has at most FinalPolynomialSize coefficients, which
+	// are sent in the clear instead of being committed to.
+	FinalPolynomialSize int
+
+	// GrindingBits is the number of leading zero bits of the proof of work
+	// computed by the prover before the queries are sampled. Each bit of
+	// grinding adds a bit of security for the same number of queries.
+	GrindingBits int
+}
+
+// DefaultParameters returns parameters targeting 100 bits of security: ρ = 4,
+// folding by 8 down to 32 coefficients, and 16 bits of grinding.
+func DefaultParameters() Parameters {
+	return Parameters{
+		Rate:                4,
+		NbQueries:           NbQueriesForSecurity(100, 4, 16),
+		FoldingArity:        8,
+		FinalPolynomialSize: 32,
+		GrindingBits:        16,
+	}
+}
+
+// NbQueriesForSecurity returns the number of queries achieving securityBits
+// bits of security with the blow-up factor rate and grindingBits bits of
+// proof of work, ⌈(securityBits - grindingBits)/log₂(rate)⌉, under the
+// conjecture that each query adds log₂(rate) bits of security.
+func NbQueriesForSecurity(securityBits, rate, grindingBits int) int {
+	logRate := bits.TrailingZeros(uint(rate))
+	if logRate == 0 || securityBits <= grindingBits {
+		return 1
+	}
+	return (securityBits - grindingBits + logRate - 1) / logRate
+}
+
+// check returns an error if the parameters are not supported.
+func (p Parameters) check() error {
+	if p.Rate < 2 || bits.OnesCount(uint(p.Rate)) != 1 {
+		return fmt.Errorf("%w: rate %d is not a power of 2 larger than 1", ErrInvalidParameters, p.Rate)
+	}
+	if p.NbQueries < 1 {
+		return fmt.Errorf("%w: %d queries", ErrInvalidParameters, p.NbQueries)
+	}
+	switch p.FoldingArity {
+	case 2, 4, 8, 16:
+	default:
+		return fmt.Errorf("%w: folding arity %d", ErrInvalidParameters, p.FoldingArity)
+	}
+	if p.FinalPolynomialSize < 1 || bits.OnesCount(uint(p.FinalPolynomialSize)) != 1 {
+		return fmt.Errorf("%w: final polynomial size %d is not a power of 2", ErrInvalidParameters, p.FinalPolynomialSize)
+	}
+	if p.GrindingBits < 0 || p.GrindingBits > 32 {
+		return fmt.Errorf("%w: %d grinding bits", ErrInvalidParameters, p.GrindingBits)
+	}
+	return nil
+}
+
+// ProofOfProximity is the FRI proof that a function on the domain is close
+// to a polynomial of size the size of the PCS. Its first oracle is not
+// committed to in the proof: it is the DEEP combination of the committed
+// polynomials, whose values are opened in the OpeningProof.
+//
+// implements io.ReaderFrom and io.WriterTo
+type ProofOfProximity struct {
+
+	// Roots[i] is the Merkle root of the (i+1)-th oracle.
+	Roots []Digest
+
+	// Openings[q][i] is the opening of the fiber of the q-th query in the
+	// (i+1)-th oracle.
+	Openings [][]MerkleProof
+
+	// FinalPolynomial is the fully folded polynomial, in canonical form.
+	FinalPolynomial []Ext
+
+	// Nonce is the proof of work of the prover.
+	Nonce uint64
+}
+
+// interpolate returns the coefficients of the polynomial whose evaluations on
+// the coset shift⋅⟨g⟩ of size len(evaluations) are evaluations, coordinate by
+// coordinate.
+func interpolate(evaluations []Ext, shift fr.Element) []Ext {
+	n := len(evaluations)
+	domain := fft.NewDomain(uint64(n), fft.WithShift(shift))
+	var columns [iop.ExtDegree][]fr.Element
+	for c := range columns {
+		columns[c] = make([]fr.Element, n)
+	}
+	for j := range evaluations {
+		for c, v := range iop.Coordinates(&evaluations[j]) {
+			columns[c][j] = v
+		}
+	}
+	for c := range columns {
+		domain.FFTInverse(columns[c], fft.DIF, fft.OnCoset())
+		fft.BitReverse(columns[c])
+	}
+	res := make([]Ext, n)
+	var row [iop.ExtDegree]fr.Element
+	for j := range res {
+		for c := range columns {
+			row[c] = columns[c][j]
+		}
+		res[j] = iop.FromCoordinates(row[:])
+	}
+	return res
+}
+
+// friIDs returns the identifiers of the challenges of the FRI in the
+// transcript: one folding challenge per step, and the challenges of the
+// proof of work and of the queries.
+func friIDs(nbSteps int) []string {
+	ids := make([]string, nbSteps+2)
+	for i := 0; i < nbSteps; i++ {
+		ids[i] = fmt.Sprintf("x%d", i)
+	}
+	ids[nbSteps] = "grinding"
+	ids[nbSteps+1] = "queries"
+	return ids
+}
+
+// proveProximity runs FRI on the evaluations of a polynomial on the domain,
+// in natural order, the transcript fs having the challenges of friIDs. The
+// first oracle is not committed to: the openings of its fibers at the
+// returned positions are left to the caller.
+func (pcs *PCS) proveProximity(fs *fiatshamir.Transcript, evaluations []Ext) (ProofOfProximity, []uint64, error) {
+	ids := friIDs(len(pcs.arities))
+	var proof ProofOfProximity
+	trees := make([]*iop.MerkleTree, len(pcs.arities))
+
+	// the domain of the current oracle is shift⋅⟨g⟩
+	shift, shiftInv := pcs.domain.FrMultiplicativeGen, pcs.domain.FrMultiplicativeGenInv
+	gInv := pcs.domain.GeneratorInv
+
+	// commit phase: fold the polynomial using the xᵢ
+	for i, k := range pcs.arities {
+		if i > 0 {
+			trees[i] = iop.NewMerkleTree(pcs.hasher, iop.ExtFiberLeaves(evaluations, k))
+			root := trees[i].Root()
+			proof.Roots = append(proof.Roots, root)
+			if err := fs.Bind(ids[i], root[:]); err != nil {
+				return proof, nil, err
+			}
+		}
+		xi, err := iop.Challenge(fs, ids[i])
+		if err != nil {
+			return proof, nil, err
+		}
+		if evaluations, err = iop.FoldEvaluations(evaluations, k, shiftInv, gInv, xi); err != nil {
+			return proof, nil, err
+		}
+		exp := big.NewInt(int64(k))
+		shift.Exp(shift, exp)
+		shiftInv.Exp(shiftInv, exp)
+		gInv.Exp(gInv, exp)
+	}
+
+	// the final polynomial is interpolated from its evaluations on the last
+	// domain, of size ρ⋅finalSize
+	proof.FinalPolynomial = interpolate(evaluations, shift)[:pcs.finalSize]
+
+	// query phase: derive the queries after the proof of work
+	positions, err := pcs.sampler().Positions(fs, ids[len(ids)-2], ids[len(ids)-1], proof.FinalPolynomial, &proof.Nonce, true)
+	if err != nil {
+		return proof, nil, err
+	}
+	proof.Openings = make([][]MerkleProof, len(positions))
+	for q, pos := range positions {
+		n := pcs.domain.Cardinality
+		for i, k := range pcs.arities {
+			m := n / uint64(k)
+			j := pos % m
+			if i > 0 {
+				proof.Openings[q] = append(proof.Openings[q], trees[i].Prove(int(j)))
+			}
+			pos, n = j, m
+		}
+	}
+
+	return proof, positions, nil
+}
+
+// verifyProximity verifies a proof of proximity built by proveProximity with
+// the same transcript. firstFiber returns the evaluations of the first
+// oracle on the fiber of its leaf j for the q-th query, after checking their
+// openings.
+func (pcs *PCS) verifyProximity(fs *fiatshamir.Transcript, proof *ProofOfProximity, firstFiber func(q int, j uint64) ([]Ext, error)) error {
+	ids := friIDs(len(pcs.arities))
+	nbCommitted := len(pcs.arities) - 1
+	if len(proof.Roots) != nbCommitted || len(proof.Openings) != pcs.params.NbQueries {
+		return ErrProofShape
+	}
+	if len(proof.FinalPolynomial) != pcs.finalSize {
+		return ErrLowDegree
+	}
+
+	// Fiat Shamir transcript to derive the challenges
+	xi := make([]Ext, len(pcs.arities))
+	var err error
+	for i := range pcs.arities {
+		if i > 0 {
+			if err = fs.Bind(ids[i], proof.Roots[i-1][:]); err != nil {
+				return err
+			}
+		}
+		if xi[i], err = iop.Challenge(fs, ids[i]); err != nil {
+			return err
+		}
+	}
+	nonce := proof.Nonce
+	positions, err := pcs.sampler().Positions(fs, ids[len(ids)-2], ids[len(ids)-1], proof.FinalPolynomial, &nonce, false)
+	if err != nil {
+		return err
+	}
+
+	type foldParams struct {
+		zetaInv []fr.Element
+		kInv    fr.Element
+	}
+	params := make([]foldParams, len(pcs.arities))
+	for i, k := range pcs.arities {
+		if params[i].zetaInv, params[i].kInv, err = iop.FoldParameters(k); err != nil {
+			return err
+		}
+	}
+
+	for q, pos := range positions {
+		if len(proof.Openings[q]) != nbCommitted {
+			return ErrProofShape
+		}
+
+		// shift⋅⟨g⟩ domain of the current oracle, of size n
+		shift, g := pcs.domain.FrMultiplicativeGen, pcs.domain.Generator
+		n := pcs.domain.Cardinality
+		var folded Ext
+		for i, k := range pcs.arities {
+			m := n / uint64(k)
+			j, slot := pos%m, pos/m
+
+			var e []Ext
+			if i == 0 {
+				if e, err = firstFiber(q, j); err != nil {
+					return err
+				}
+			} else {
+				opening := &proof.Openings[q][i-1]
+				if err = pcs.hasher.Verify(&proof.Roots[i-1], opening, j, m); err != nil {
+					return err
+				}
+				if e, err = iop.ParseExtLeaf(opening.Leaf, k); err != nil {
+					return err
+				}
+
+				// correctness of the folding of the previous oracle
+				if !e[slot].Equal(&folded) {
+					return ErrProximityTestFolding
+				}
+			}
+
+			// fold the fiber of shift⋅gʲ
+			var xInv fr.Element
+			xInv.Exp(g, big.NewInt(int64(j))).Mul(&xInv, &shift).Inverse(&xInv)
+			folded = iop.FoldFiber(e, params[i].zetaInv, &xInv, &xi[i], &params[i].kInv)
+
+			exp := big.NewInt(int64(k))
+			shift.Exp(shift, exp)
+			g.Exp(g, exp)
+			pos, n = j, m
+		}
+
+		// Last step: the folded value should be the evaluation of the final
+		// polynomial.
+		var x fr.Element
+		x.Exp(g, big.NewInt(int64(pos))).Mul(&x, &shift)
+		if y := evalAtBase(proof.FinalPolynomial, &x); !y.Equal(&folded) {
+			return ErrProximityTestFolding
+		}
+	}
+
+	return nil
+}
+
+// evalAtBase returns p(x), p being in canonical form.
+func evalAtBase(p []Ext, x *fr.Element) Ext {
+	var res Ext
+	for i := len(p) - 1; i >= 0; i-- {
+		res.MulByElement(&res, x).Add(&res, &p[i])
+	}
+	return res
+}
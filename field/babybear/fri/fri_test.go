@@ -0,0 +1,212 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	fr "github.com/consensys/gnark-crypto/field/babybear"
+	"github.com/consensys/gnark-crypto/field/babybear/internal/iop"
+)
+
+func randomPolynomial(size int) []fr.Element {
+	p := make([]fr.Element, size)
+	for i := range p {
+		p[i].MustSetRandom()
+	}
+	return p
+}
+
+func TestPCS(t *testing.T) {
+	const size = 256
+
+	// two commitments to polynomials of different sizes
+	sizes := [][]int{
+		{size, 3, size - 5},
+		{1, size / 2},
+	}
+	polynomials := make([][][]fr.Element, len(sizes))
+	for m := range sizes {
+		polynomials[m] = make([][]fr.Element, len(sizes[m]))
+		for i, d := range sizes[m] {
+			polynomials[m][i] = randomPolynomial(d)
+		}
+	}
+	var z, zg Ext
+	z.MustSetRandom()
+	data := []byte("data")
+
+	for _, params := range []Parameters{
+		DefaultParameters(),
+		{Rate: 2, NbQueries: 10, FoldingArity: 2, FinalPolynomialSize: 1, GrindingBits: 4},
+		{Rate: 4, NbQueries: 5, FoldingArity: 16, FinalPolynomialSize: 2},
+		{Rate: 2, NbQueries: 3, FoldingArity: 8, FinalPolynomialSize: size},
+	} {
+		t.Run(fmt.Sprintf("%+v", params), func(t *testing.T) {
+			pcs, err := NewPCS(size, sha256.New(), params)
+			require.NoError(t, err)
+			g, err := fr.Generator(size)
+			require.NoError(t, err)
+			zg.MulByElement(&z, &g)
+			points := []Ext{z, zg}
+
+			proverData := make([]*ProverData, len(polynomials))
+			commitments := make([]Commitment, len(polynomials))
+			for m := range polynomials {
+				proverData[m], err = pcs.Commit(polynomials[m]...)
+				require.NoError(t, err)
+				commitments[m] = proverData[m].Commitment
+			}
+
+			proof, err := pcs.Open(proverData, points, data)
+			require.NoError(t, err)
+			require.NoError(t, pcs.Verify(commitments, points, &proof, data))
+
+			// claimed values
+			for m := range polynomials {
+				for i := range polynomials[m] {
+					for s := range points {
+						expected := eval(polynomials[m][i], &points[s])
+						require.True(t, expected.Equal(&proof.ClaimedValues[m][i][s]))
+					}
+				}
+			}
+
+			// serialization
+			var buf bytes.Buffer
+			_, err = proof.WriteTo(&buf)
+			require.NoError(t, err)
+			var decoded OpeningProof
+			_, err = decoded.ReadFrom(&buf)
+			require.NoError(t, err)
+			require.Equal(t, proof, decoded)
+			require.NoError(t, pcs.Verify(commitments, points, &decoded, data))
+
+			// wrong statement
+			require.Error(t, pcs.Verify(commitments, points, &proof, []byte("wrong")))
+			require.Error(t, pcs.Verify(commitments, []Ext{zg, z}, &proof, data))
+
+			// tampered claimed value
+			one := iop.Embed(new(fr.Element).SetOne())
+			proof.ClaimedValues[1][0][1].Add(&proof.ClaimedValues[1][0][1], &one)
+			require.Error(t, pcs.Verify(commitments, points, &proof, data))
+			proof.ClaimedValues[1][0][1].Sub(&proof.ClaimedValues[1][0][1], &one)
+
+			// tampered row
+			leaf := proof.Rows[0][1].Leaf
+			saved := leaf[len(leaf)-1]
+			leaf[len(leaf)-1].SetOne()
+			require.Error(t, pcs.Verify(commitments, points, &proof, data))
+			leaf[len(leaf)-1] = saved
+
+			// tampered final polynomial
+			finalPolynomial := proof.ProofOfProximity.FinalPolynomial
+			finalPolynomial[0].Add(&finalPolynomial[0], &one)
+			require.Error(t, pcs.Verify(commitments, points, &proof, data))
+			finalPolynomial[0].Sub(&finalPolynomial[0], &one)
+
+			// tampered proof of work
+			if params.GrindingBits > 0 {
+				proof.ProofOfProximity.Nonce++
+				require.Error(t, pcs.Verify(commitments, points, &proof, data))
+				proof.ProofOfProximity.Nonce--
+			}
+			require.NoError(t, pcs.Verify(commitments, points, &proof, data))
+		})
+	}
+
+	params := Parameters{Rate: 2, NbQueries: 64, FoldingArity: 4, FinalPolynomialSize: 1}
+	pcs, err := NewPCS(size, sha256.New(), params)
+	require.NoError(t, err)
+
+	// a polynomial larger than its declared size is rejected
+	proverData, err := pcs.Commit(polynomials[0]...)
+	require.NoError(t, err)
+	proverData.Commitment.Sizes[0] = size / 2
+	proof, err := pcs.Open([]*ProverData{proverData}, []Ext{z})
+	require.NoError(t, err)
+	require.Error(t, pcs.Verify([]Commitment{proverData.Commitment}, []Ext{z}, &proof))
+
+	// polynomials too large for the PCS are rejected
+	_, err = pcs.Commit(randomPolynomial(size + 1))
+	require.ErrorIs(t, err, ErrLowDegree)
+
+	// points in the domain are rejected
+	x := pcs.domain.FrMultiplicativeGen
+	x.Mul(&x, &pcs.domain.Generator)
+	_, err = pcs.Open([]*ProverData{proverData}, []Ext{iop.Embed(&x)})
+	require.ErrorIs(t, err, ErrOutOfDomainPoint)
+}
+
+func TestParameters(t *testing.T) {
+	for _, params := range []Parameters{
+		{Rate: 3, NbQueries: 1, FoldingArity: 2, FinalPolynomialSize: 1},
+		{Rate: 2, NbQueries: 0, FoldingArity: 2, FinalPolynomialSize: 1},
+		{Rate: 2, NbQueries: 1, FoldingArity: 32, FinalPolynomialSize: 1},
+		{Rate: 2, NbQueries: 1, FoldingArity: 2, FinalPolynomialSize: 3},
+		{Rate: 2, NbQueries: 1, FoldingArity: 2, FinalPolynomialSize: 1, GrindingBits: 40},
+	} {
+		_, err := NewPCS(16, sha256.New(), params)
+		require.ErrorIs(t, err, ErrInvalidParameters)
+	}
+	require.Equal(t, 42, DefaultParameters().NbQueries)
+}
+
+// Benchmarks
+
+func BenchmarkOpen(b *testing.B) {
+	const size = 1 << 14
+	pcs, err := NewPCS(size, sha256.New(), DefaultParameters())
+	if err != nil {
+		b.Fatal(err)
+	}
+	polynomials := make([][]fr.Element, 8)
+	for i := range polynomials {
+		polynomials[i] = randomPolynomial(size)
+	}
+	proverData, err := pcs.Commit(polynomials...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	var z Ext
+	z.MustSetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pcs.Open([]*ProverData{proverData}, []Ext{z})
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	const size = 1 << 14
+	pcs, err := NewPCS(size, sha256.New(), DefaultParameters())
+	if err != nil {
+		b.Fatal(err)
+	}
+	polynomials := make([][]fr.Element, 8)
+	for i := range polynomials {
+		polynomials[i] = randomPolynomial(size)
+	}
+	proverData, err := pcs.Commit(polynomials...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	var z Ext
+	z.MustSetRandom()
+	proof, err := pcs.Open([]*ProverData{proverData}, []Ext{z})
+	if err != nil {
+		b.Fatal(err)
+	}
+	commitments := []Commitment{proverData.Commitment}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pcs.Verify(commitments, []Ext{z}, &proof)
+	}
+}
@@ -0,0 +1,449 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+
+	fr "github.com/consensys/gnark-crypto/field/babybear"
+	"github.com/consensys/gnark-crypto/field/babybear/fft"
+	"github.com/consensys/gnark-crypto/field/babybear/internal/iop"
+)
+
+var (
+	ErrEmptyCommitment  = errors.New("a commitment must contain at least one polynomial of size at least 1")
+	ErrNoPoint          = errors.New("at least one opening point is needed")
+	ErrOutOfDomainPoint = errors.New("the opening point is in the evaluation domain")
+	ErrNbCommitments    = errors.New("the number of commitments does not match the opening proof")
+)
+
+// PCS is a polynomial commitment scheme based on FRI. Polynomials are
+// committed to by batches, with the Merkle tree of their evaluations on a
+// coset of size Rate times the size of the PCS. They are opened at points
+// of Ext out of the domain, the evaluations of all the polynomials of all the
+// commitments at all the points being proven with a single FRI on their DEEP
+// combination.
+type PCS struct {
+
+	// h is the hash function of the Fiat Shamir transcript and of the proof
+	// of work. Its digests should have at least 2⋅iop.ExtDegree⋅fr.Bytes bytes.
+	h hash.Hash
+
+	// hasher hashes the nodes of the Merkle trees.
+	hasher iop.MerkleHasher
+
+	params Parameters
+
+	// arities[i] is the folding arity of the i-th step. The last arity may be
+	// smaller than params.FoldingArity to stop at the final size, and it is 1
+	// when the polynomial is not folded at all.
+	arities []int
+
+	// finalSize size of the final polynomial
+	finalSize int
+
+	// domain is the coset on which the polynomials are evaluated, of size
+	// ρ⋅size.
+	domain *fft.Domain
+}
+
+// NewPCS returns a PCS for polynomials of size up to maxSize, rounded up to a
+// power of 2. h is the hash function of the Fiat Shamir transcript.
+func NewPCS(maxSize uint64, h hash.Hash, params Parameters) (*PCS, error) {
+	if err := params.check(); err != nil {
+		return nil, err
+	}
+	if h.Size() < 2*iop.ExtDegree*fr.Bytes {
+		return nil, ErrInvalidParameters
+	}
+
+	res := &PCS{h: h, hasher: iop.NewMerkleHasher(), params: params}
+
+	// computing the arities of the steps
+	n := int(ecc.NextPowerOfTwo(maxSize))
+	d := n
+	for d > params.FinalPolynomialSize {
+		k := min(params.FoldingArity, d/params.FinalPolynomialSize)
+		res.arities = append(res.arities, k)
+		d /= k
+	}
+	if len(res.arities) == 0 {
+		res.arities = []int{1}
+	}
+	res.finalSize = d
+
+	if _, err := fr.Generator(uint64(n * params.Rate)); err != nil {
+		return nil, err
+	}
+	res.domain = fft.NewDomain(uint64(n * params.Rate))
+
+	return res, nil
+}
+
+// sampler returns the sampler of the queries in the domain.
+func (pcs *PCS) sampler() iop.QuerySampler {
+	return iop.QuerySampler{
+		H:            pcs.h,
+		GrindingBits: pcs.params.GrindingBits,
+		NbQueries:    pcs.params.NbQueries,
+		DomainSize:   pcs.domain.Cardinality,
+	}
+}
+
+// Size returns the maximal size of the committed polynomials.
+func (pcs *PCS) Size() int {
+	return int(pcs.domain.Cardinality) / pcs.params.Rate
+}
+
+// Commitment is the commitment to a batch of polynomials: the root of the
+// Merkle tree of the rows of their evaluations on the domain, and their
+// sizes, which are the degree bounds proven by the openings.
+type Commitment struct {
+	Root  Digest
+	Sizes []int
+}
+
+// ProverData is the data of the prover on a batch of polynomials committed
+// to with Commit.
+type ProverData struct {
+	Commitment Commitment
+
+	polynomials [][]fr.Element
+	codewords   [][]fr.Element
+	tree        *iop.MerkleTree
+}
+
+// Commit commits to polynomials in canonical form, of possibly different
+// sizes not larger than the size of the PCS, with a single Merkle tree. The
+// leaves are the rows of their evaluations on the fibers of the first
+// folding of the FRI, so that a query opens a single Merkle path per
+// commitment.
+func (pcs *PCS) Commit(polynomials ...[]fr.Element) (*ProverData, error) {
+	if len(polynomials) == 0 {
+		return nil, ErrEmptyCommitment
+	}
+	res := &ProverData{
+		Commitment:  Commitment{Sizes: make([]int, len(polynomials))},
+		polynomials: polynomials,
+		codewords:   make([][]fr.Element, len(polynomials)),
+	}
+	for i, p := range polynomials {
+		if len(p) == 0 {
+			return nil, ErrEmptyCommitment
+		}
+		if len(p) > pcs.Size() {
+			return nil, ErrLowDegree
+		}
+		res.Commitment.Sizes[i] = len(p)
+	}
+	parallel.Execute(len(polynomials), func(start, end int) {
+		for i := start; i < end; i++ {
+			res.codewords[i] = make([]fr.Element, pcs.domain.Cardinality)
+			copy(res.codewords[i], polynomials[i])
+			pcs.domain.FFT(res.codewords[i], fft.DIF, fft.OnCoset())
+			fft.BitReverse(res.codewords[i])
+		}
+	}, 1)
+	res.tree = iop.NewMerkleTree(pcs.hasher, iop.FiberLeaves(res.codewords, pcs.arities[0]))
+	res.Commitment.Root = res.tree.Root()
+	return res, nil
+}
+
+// OpeningProof is the proof of the evaluations of the polynomials of several
+// commitments at several points.
+//
+// implements io.ReaderFrom and io.WriterTo
+type OpeningProof struct {
+
+	// ClaimedValues[m][i][s] is the evaluation of the i-th polynomial of the
+	// m-th commitment at the s-th point.
+	ClaimedValues [][][]Ext
+
+	// Rows[q][m] is the opening of the rows of the m-th commitment on the
+	// fiber of the q-th query, the first oracle of the FRI.
+	Rows [][]MerkleProof
+
+	// ProofOfProximity is the FRI proof of the DEEP combination.
+	ProofOfProximity ProofOfProximity
+}
+
+// transcript returns the Fiat Shamir transcript of an opening, with the
+// challenges of the DEEP combination and of the FRI, after binding the
+// statement: dataTranscript, the commitments and the points.
+func (pcs *PCS) transcript(commitments []Commitment, points []Ext, dataTranscript [][]byte) (*fiatshamir.Transcript, error) {
+	fs := fiatshamir.NewTranscript(pcs.h, append([]string{"gamma"}, friIDs(len(pcs.arities))...)...)
+	for _, data := range dataTranscript {
+		if err := fs.Bind("gamma", data); err != nil {
+			return nil, err
+		}
+	}
+	var buf [4]byte
+	for _, c := range commitments {
+		if err := fs.Bind("gamma", c.Root[:]); err != nil {
+			return nil, err
+		}
+		for _, size := range c.Sizes {
+			binary.BigEndian.PutUint32(buf[:], uint32(size))
+			if err := fs.Bind("gamma", buf[:]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := iop.BindExt(fs, "gamma", points...); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+// combinationChallenge binds the claimed values and derives the challenge γ of
+// the DEEP combination.
+func combinationChallenge(fs *fiatshamir.Transcript, claimedValues [][][]Ext) (Ext, error) {
+	for _, m := range claimedValues {
+		for _, p := range m {
+			if err := iop.BindExt(fs, "gamma", p...); err != nil {
+				return Ext{}, err
+			}
+		}
+	}
+	return iop.Challenge(fs, "gamma")
+}
+
+// checkPoints returns an error if there is no point or if a point is in the
+// domain shift⋅⟨g⟩ of size n, that is if zⁿ = shiftⁿ.
+func (pcs *PCS) checkPoints(points []Ext) error {
+	if len(points) == 0 {
+		return ErrNoPoint
+	}
+	n := new(big.Int).SetUint64(pcs.domain.Cardinality)
+	var shiftN fr.Element
+	shiftN.Exp(pcs.domain.FrMultiplicativeGen, n)
+	eShiftN := iop.Embed(&shiftN)
+	var zN Ext
+	for i := range points {
+		if zN.Exp(points[i], n); zN.Equal(&eShiftN) {
+			return ErrOutOfDomainPoint
+		}
+	}
+	return nil
+}
+
+// Open proves the evaluations of all the polynomials of the commitments at
+// the points, which must be out of the domain. The points and dataTranscript
+// are bound to the Fiat Shamir transcript.
+//
+// The polynomials pᵢ of sizes dᵢ are batched into the DEEP combination
+//
+//	F = ∑ γᵏ⋅X^{D+1-dᵢ}⋅(pᵢ - pᵢ(zₛ))/(X - zₛ)
+//
+// over the polynomials and the points zₛ, where D is the size of the PCS.
+// Each term has size D exactly when pᵢ has size dᵢ, so that a single FRI on
+// F proves the degree bounds and the evaluations of all the polynomials. The
+// first oracle of the FRI is F, whose evaluations the verifier computes from
+// the openings of the rows of the commitments.
+func (pcs *PCS) Open(data []*ProverData, points []Ext, dataTranscript ...[]byte) (OpeningProof, error) {
+	var proof OpeningProof
+	if err := pcs.checkPoints(points); err != nil {
+		return proof, err
+	}
+	commitments := make([]Commitment, len(data))
+	for m := range data {
+		commitments[m] = data[m].Commitment
+	}
+	fs, err := pcs.transcript(commitments, points, dataTranscript)
+	if err != nil {
+		return proof, err
+	}
+
+	// claimed values
+	proof.ClaimedValues = make([][][]Ext, len(data))
+	for m, d := range data {
+		proof.ClaimedValues[m] = make([][]Ext, len(d.polynomials))
+		parallel.Execute(len(d.polynomials), func(start, end int) {
+			for i := start; i < end; i++ {
+				proof.ClaimedValues[m][i] = make([]Ext, len(points))
+				for s := range points {
+					proof.ClaimedValues[m][i][s] = eval(d.polynomials[i], &points[s])
+				}
+			}
+		})
+	}
+	gamma, err := combinationChallenge(fs, proof.ClaimedValues)
+	if err != nil {
+		return proof, err
+	}
+
+	// evaluations of F on the domain
+	n := int(pcs.domain.Cardinality)
+	invDiffs := make([][]Ext, len(points))
+	parallel.Execute(len(points), func(start, end int) {
+		for s := start; s < end; s++ {
+			invDiffs[s] = make([]Ext, n)
+			x := pcs.domain.FrMultiplicativeGen
+			for j := range invDiffs[s] {
+				invDiffs[s][j] = iop.Embed(&x)
+				invDiffs[s][j].Sub(&invDiffs[s][j], &points[s])
+				x.Mul(&x, &pcs.domain.Generator)
+			}
+			invDiffs[s] = iop.BatchInvert(invDiffs[s])
+		}
+	})
+	f := make([]Ext, n)
+	var gammaK Ext
+	gammaK.SetOne()
+	for m, d := range data {
+		for i, codeword := range d.codewords {
+			// xʲ^{D+1-dᵢ} = shift^{D+1-dᵢ}⋅(g^{D+1-dᵢ})ʲ
+			exp := big.NewInt(int64(pcs.Size() + 1 - d.Commitment.Sizes[i]))
+			var gE, shiftE fr.Element
+			gE.Exp(pcs.domain.Generator, exp)
+			shiftE.Exp(pcs.domain.FrMultiplicativeGen, exp)
+			coeffs := make([]Ext, len(points))
+			for s := range points {
+				coeffs[s].Set(&gammaK)
+				gammaK.Mul(&gammaK, &gamma)
+			}
+			values := proof.ClaimedValues[m][i]
+			parallel.Execute(n, func(start, end int) {
+				var xE fr.Element
+				xE.Exp(gE, big.NewInt(int64(start))).Mul(&xE, &shiftE)
+				var term, diff Ext
+				for j := start; j < end; j++ {
+					term.SetZero()
+					for s := range points {
+						diff = iop.Embed(&codeword[j])
+						diff.Sub(&diff, &values[s]).Mul(&diff, &invDiffs[s][j]).Mul(&diff, &coeffs[s])
+						term.Add(&term, &diff)
+					}
+					term.MulByElement(&term, &xE)
+					f[j].Add(&f[j], &term)
+					xE.Mul(&xE, &gE)
+				}
+			})
+		}
+	}
+
+	// FRI on F
+	var positions []uint64
+	if proof.ProofOfProximity, positions, err = pcs.proveProximity(fs, f); err != nil {
+		return proof, err
+	}
+	proof.Rows = make([][]MerkleProof, len(positions))
+	nbLeaves := pcs.domain.Cardinality / uint64(pcs.arities[0])
+	for q, pos := range positions {
+		proof.Rows[q] = make([]MerkleProof, len(data))
+		for m, d := range data {
+			proof.Rows[q][m] = d.tree.Prove(int(pos % nbLeaves))
+		}
+	}
+	return proof, nil
+}
+
+// Verify verifies a proof of Open against the commitments, the points and
+// dataTranscript. The claimed evaluations are proof.ClaimedValues.
+func (pcs *PCS) Verify(commitments []Commitment, points []Ext, proof *OpeningProof, dataTranscript ...[]byte) error {
+	if len(proof.ClaimedValues) != len(commitments) {
+		return ErrNbCommitments
+	}
+	if len(proof.Rows) != pcs.params.NbQueries {
+		return ErrProofShape
+	}
+	for m, c := range commitments {
+		if len(c.Sizes) == 0 || len(proof.ClaimedValues[m]) != len(c.Sizes) {
+			return ErrEmptyCommitment
+		}
+		for i, size := range c.Sizes {
+			if size < 1 || size > pcs.Size() {
+				return ErrLowDegree
+			}
+			if len(proof.ClaimedValues[m][i]) != len(points) {
+				return ErrProofShape
+			}
+		}
+	}
+	if err := pcs.checkPoints(points); err != nil {
+		return err
+	}
+	fs, err := pcs.transcript(commitments, points, dataTranscript)
+	if err != nil {
+		return err
+	}
+	gamma, err := combinationChallenge(fs, proof.ClaimedValues)
+	if err != nil {
+		return err
+	}
+
+	// F on the fiber of the leaf j, from the rows of the commitments
+	k := pcs.arities[0]
+	nbLeaves := pcs.domain.Cardinality / uint64(k)
+	var step fr.Element
+	step.Exp(pcs.domain.Generator, new(big.Int).SetUint64(nbLeaves))
+	firstFiber := func(q int, j uint64) ([]Ext, error) {
+		if len(proof.Rows[q]) != len(commitments) {
+			return nil, ErrProofShape
+		}
+		for m, c := range commitments {
+			row := &proof.Rows[q][m]
+			if len(row.Leaf) != k*len(c.Sizes) {
+				return nil, ErrProofShape
+			}
+			if err := pcs.hasher.Verify(&c.Root, row, j, nbLeaves); err != nil {
+				return nil, err
+			}
+		}
+
+		res := make([]Ext, k)
+		var x fr.Element
+		x.Exp(pcs.domain.Generator, new(big.Int).SetUint64(j)).Mul(&x, &pcs.domain.FrMultiplicativeGen)
+		invDiffs := make([]Ext, len(points))
+		for t := range res {
+			for s := range points {
+				invDiffs[s] = iop.Embed(&x)
+				invDiffs[s].Sub(&invDiffs[s], &points[s])
+			}
+			invDiffs = iop.BatchInvert(invDiffs)
+
+			var gammaK, term, diff Ext
+			var xE fr.Element
+			gammaK.SetOne()
+			for m, c := range commitments {
+				for i, size := range c.Sizes {
+					term.SetZero()
+					value := iop.Embed(&proof.Rows[q][m].Leaf[t*len(c.Sizes)+i])
+					for s := range points {
+						diff.Sub(&value, &proof.ClaimedValues[m][i][s]).Mul(&diff, &invDiffs[s]).Mul(&diff, &gammaK)
+						term.Add(&term, &diff)
+						gammaK.Mul(&gammaK, &gamma)
+					}
+					xE.Exp(x, big.NewInt(int64(pcs.Size()+1-size)))
+					term.MulByElement(&term, &xE)
+					res[t].Add(&res[t], &term)
+				}
+			}
+			x.Mul(&x, &step)
+		}
+		return res, nil
+	}
+
+	return pcs.verifyProximity(fs, &proof.ProofOfProximity, firstFiber)
+}
+
+// eval returns p(z), p being in canonical form.
+func eval(p []fr.Element, z *Ext) Ext {
+	var res Ext
+	for i := len(p) - 1; i >= 0; i-- {
+		res.Mul(&res, z)
+		c := iop.Embed(&p[i])
+		res.Add(&res, &c)
+	}
+	return res
+}
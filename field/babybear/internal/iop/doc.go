@@ -0,0 +1,11 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package iop provides the building blocks shared by the hash-based
+// polynomial commitment schemes and proof systems over babybear: the
+// extension in which the challenges are drawn, the Fiat-Shamir helpers, the
+// sampling of the queries, the folding of Reed-Solomon codewords, the
+// Poseidon2 Merkle trees and the binary encoding of the proofs.
+package iop
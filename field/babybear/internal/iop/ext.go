@@ -0,0 +1,51 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package iop
+
+import (
+	fr "github.com/consensys/gnark-crypto/field/babybear"
+	"github.com/consensys/gnark-crypto/field/babybear/extensions"
+)
+
+// Ext is the extension of the field in which the challenges are drawn and
+// the polynomials are opened, of about 124 bits.
+type Ext = extensions.E4
+
+// ExtDegree is the degree of Ext over fr.
+const ExtDegree = 4
+
+// Embed returns x as an element of Ext.
+func Embed(x *fr.Element) Ext {
+	return Ext{B0: extensions.E2{A0: *x}}
+}
+
+// Coordinates returns the coordinates of z over fr.
+func Coordinates(z *Ext) [ExtDegree]fr.Element {
+	return [ExtDegree]fr.Element{z.B0.A0, z.B0.A1, z.B1.A0, z.B1.A1}
+}
+
+// FromCoordinates returns the element of Ext of coordinates c over fr.
+func FromCoordinates(c []fr.Element) Ext {
+	return Ext{
+		B0: extensions.E2{A0: c[0], A1: c[1]},
+		B1: extensions.E2{A0: c[2], A1: c[3]},
+	}
+}
+
+// BatchInvert inverts the elements of a slice of Ext.
+var BatchInvert = extensions.BatchInvertE4
+
+// extFromBytes returns the element of Ext whose coordinates are the chunks of
+// b, reduced modulo q. Its distribution is close to uniform when b is uniform
+// and has at least 2⋅fr.Bytes bytes per coordinate.
+func extFromBytes(b []byte) Ext {
+	var c [ExtDegree]fr.Element
+	l := len(b) / ExtDegree
+	for i := range c {
+		c[i].SetBytes(b[i*l : (i+1)*l])
+	}
+	return FromCoordinates(c[:])
+}
@@ -0,0 +1,131 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package iop
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+
+	fr "github.com/consensys/gnark-crypto/field/babybear"
+)
+
+// FiberLeaves returns the leaves of the Merkle tree of the evaluations of
+// the columns on a domain of size n, the leaf j being the concatenation of
+// the rows of evaluations on the fiber {x⋅gᵗⁿᐟᵏ, t < k} of xᵏ, where x is the
+// j-th point of the domain.
+func FiberLeaves(columns [][]fr.Element, k int) [][]fr.Element {
+	m := len(columns[0]) / k
+	leaves := make([][]fr.Element, m)
+	parallel.Execute(m, func(start, end int) {
+		for j := start; j < end; j++ {
+			leaves[j] = make([]fr.Element, 0, k*len(columns))
+			for t := 0; t < k; t++ {
+				for _, c := range columns {
+					leaves[j] = append(leaves[j], c[j+t*m])
+				}
+			}
+		}
+	})
+	return leaves
+}
+
+// ExtFiberLeaves returns the leaves of the Merkle tree of evaluations in Ext,
+// the leaf j being the coordinates of the evaluations on the fiber of the
+// j-th point of the domain.
+func ExtFiberLeaves(evaluations []Ext, k int) [][]fr.Element {
+	m := len(evaluations) / k
+	leaves := make([][]fr.Element, m)
+	parallel.Execute(m, func(start, end int) {
+		for j := start; j < end; j++ {
+			leaves[j] = make([]fr.Element, 0, k*ExtDegree)
+			for t := 0; t < k; t++ {
+				c := Coordinates(&evaluations[j+t*m])
+				leaves[j] = append(leaves[j], c[:]...)
+			}
+		}
+	})
+	return leaves
+}
+
+// ParseExtLeaf returns the evaluations in Ext of a leaf of ExtFiberLeaves.
+func ParseExtLeaf(leaf []fr.Element, k int) ([]Ext, error) {
+	if len(leaf) != k*ExtDegree {
+		return nil, ErrProofShape
+	}
+	res := make([]Ext, k)
+	for t := range res {
+		res[t] = FromCoordinates(leaf[t*ExtDegree : (t+1)*ExtDegree])
+	}
+	return res, nil
+}
+
+// FoldFiber returns ∑ₛ βˢ⋅pₛ(xᵏ), where p = ∑ₛ Xˢ⋅pₛ(Xᵏ) is the polynomial
+// whose evaluations on the fiber {x⋅ζᵗ} of xᵏ are e, with k = len(e) and ζ a
+// primitive k-th root of unity. As pₛ(xᵏ)⋅xˢ = 1/k ∑ₜ ζ^{-st}⋅eₜ, it is an
+// inverse DFT of size k followed by an evaluation at β/x.
+//
+// * zetaInv are the powers ζ⁻ᵗ, t < k
+// * xInv is x⁻¹
+func FoldFiber(e []Ext, zetaInv []fr.Element, xInv *fr.Element, beta *Ext, kInv *fr.Element) Ext {
+	k := len(e)
+	var r, c, t, res Ext
+	r.MulByElement(beta, xInv)
+	for s := k - 1; s >= 0; s-- {
+		c.SetZero()
+		for i := range e {
+			t.MulByElement(&e[i], &zetaInv[(i*s)%k])
+			c.Add(&c, &t)
+		}
+		res.Mul(&res, &r).Add(&res, &c)
+	}
+	res.MulByElement(&res, kInv)
+	return res
+}
+
+// FoldParameters returns the powers ζ⁻ᵗ of the inverse of a primitive k-th
+// root of unity and 1/k.
+func FoldParameters(k int) ([]fr.Element, fr.Element, error) {
+	zeta, err := fr.Generator(uint64(k))
+	if err != nil {
+		return nil, fr.Element{}, err
+	}
+	zetaInv := make([]fr.Element, k)
+	zetaInv[0].SetOne()
+	if k > 1 {
+		zeta.Inverse(&zeta)
+		for t := 1; t < k; t++ {
+			zetaInv[t].Mul(&zetaInv[t-1], &zeta)
+		}
+	}
+	var kInv fr.Element
+	kInv.SetUint64(uint64(k)).Inverse(&kInv)
+	return zetaInv, kInv, nil
+}
+
+// FoldEvaluations folds the evaluations of a polynomial on the coset
+// shift⋅⟨g⟩ into its folding with β on the coset shiftᵏ⋅⟨gᵏ⟩.
+func FoldEvaluations(evaluations []Ext, k int, shiftInv, gInv fr.Element, beta Ext) ([]Ext, error) {
+	zetaInv, kInv, err := FoldParameters(k)
+	if err != nil {
+		return nil, err
+	}
+	m := len(evaluations) / k
+	res := make([]Ext, m)
+	parallel.Execute(m, func(start, end int) {
+		var xInv fr.Element
+		xInv.Exp(gInv, big.NewInt(int64(start))).Mul(&xInv, &shiftInv)
+		e := make([]Ext, k)
+		for j := start; j < end; j++ {
+			for t := range e {
+				e[t] = evaluations[j+t*m]
+			}
+			res[j] = FoldFiber(e, zetaInv, &xInv, &beta, &kInv)
+			xInv.Mul(&xInv, &gInv)
+		}
+	})
+	return res, nil
+}
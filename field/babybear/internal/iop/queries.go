@@ -0,0 +1,90 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package iop
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math/big"
+
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// ErrProofOfWork is returned when the nonce of the proof of work is invalid.
+var ErrProofOfWork = errors.New("the proof of work is invalid")
+
+// QuerySampler derives the positions of the queries of the verifier in a
+// domain, after a proof of work of the prover.
+type QuerySampler struct {
+	H            hash.Hash
+	GrindingBits int
+	NbQueries    int
+	DomainSize   uint64
+}
+
+// proofOfWork returns true if H(seed ∥ nonce) starts with grindingBits zero
+// bits.
+func (qs QuerySampler) proofOfWork(seed []byte, nonce uint64) bool {
+	var bNonce [8]byte
+	binary.BigEndian.PutUint64(bNonce[:], nonce)
+	qs.H.Reset()
+	qs.H.Write(seed)
+	qs.H.Write(bNonce[:])
+	digest := qs.H.Sum(nil)
+	for i := 0; i < qs.GrindingBits; i++ {
+		if digest[i/8]&(0x80>>(i%8)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Positions derives the positions of the queries from the transcript, after
+// binding the last messages of the prover to idGrinding and the proof of
+// work to idQueries. If grind is set, the nonce is computed, otherwise it is
+// checked.
+func (qs QuerySampler) Positions(fs *fiatshamir.Transcript, idGrinding, idQueries string, last []Ext, nonce *uint64, grind bool) ([]uint64, error) {
+	if err := BindExt(fs, idGrinding, last...); err != nil {
+		return nil, err
+	}
+	seed, err := fs.ComputeChallenge(idGrinding)
+	if err != nil {
+		return nil, err
+	}
+	if grind {
+		*nonce = 0
+		for !qs.proofOfWork(seed, *nonce) {
+			*nonce++
+		}
+	} else if !qs.proofOfWork(seed, *nonce) {
+		return nil, ErrProofOfWork
+	}
+	var bNonce [8]byte
+	binary.BigEndian.PutUint64(bNonce[:], *nonce)
+	if err = fs.Bind(idQueries, bNonce[:]); err != nil {
+		return nil, err
+	}
+	seed, err = fs.ComputeChallenge(idQueries)
+	if err != nil {
+		return nil, err
+	}
+
+	// the q-th position is H(seed ∥ q) mod domainSize
+	res := make([]uint64, qs.NbQueries)
+	var bPos, bSize big.Int
+	bSize.SetUint64(qs.DomainSize)
+	for q := range res {
+		var bq [8]byte
+		binary.BigEndian.PutUint64(bq[:], uint64(q))
+		qs.H.Reset()
+		qs.H.Write(seed)
+		qs.H.Write(bq[:])
+		bPos.SetBytes(qs.H.Sum(nil))
+		res[q] = bPos.Mod(&bPos, &bSize).Uint64()
+	}
+	return res, nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package stark
+
+import (
+	"io"
+)
+
+// WriteTo writes the binary encoding of the proof.
+func (proof *Proof) WriteTo(w io.Writer) (int64, error) {
+	var n int64
+	for _, root := range [][]byte{proof.TraceRoot[:], proof.QuotientRoot[:]} {
+		m, err := w.Write(root)
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+	}
+	m, err := proof.Opening.WriteTo(w)
+	return n + m, err
+}
+
+// ReadFrom decodes a proof written by WriteTo.
+func (proof *Proof) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+	for _, root := range [][]byte{proof.TraceRoot[:], proof.QuotientRoot[:]} {
+		m, err := io.ReadFull(r, root)
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+	}
+	m, err := proof.Opening.ReadFrom(r)
+	return n + m, err
+}
@@ -0,0 +1,472 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package stark
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+
+	fr "github.com/consensys/gnark-crypto/field/babybear"
+	"github.com/consensys/gnark-crypto/field/babybear/fft"
+	"github.com/consensys/gnark-crypto/field/babybear/fri"
+	"github.com/consensys/gnark-crypto/field/babybear/internal/iop"
+)
+
+var (
+	ErrEmptyAIR         = errors.New("an AIR must have at least one column and one constraint")
+	ErrUnknownColumn    = errors.New("the constraint refers to a column which is not in the AIR")
+	ErrNbRows           = errors.New("the number of rows must be a power of 2 larger than 1")
+	ErrBoundaryRow      = errors.New("the row of the boundary constraint is out of the trace")
+	ErrTraceShape       = errors.New("the trace does not match the AIR")
+	ErrUnsatisfied      = errors.New("the trace does not satisfy the constraints")
+	ErrOutOfDomainPoint = errors.New("the evaluation point is in the domain of the trace")
+	ErrQuotient         = errors.New("the quotient does not match the constraints")
+)
+
+// Ext is the extension of the field in which the challenges are drawn and
+// the constraints are checked out of the domain.
+type Ext = iop.Ext
+
+// System proves and verifies that traces of a fixed number of rows satisfy
+// an AIR.
+type System struct {
+	air    *AIR
+	nbRows int
+
+	// h is the hash function of the Fiat Shamir transcript.
+	h hash.Hash
+
+	// pcs commits to the columns of the trace and to the pieces of the
+	// quotient, all of size nbRows.
+	pcs *fri.PCS
+
+	// domain is the domain ⟨ω⟩ of the trace.
+	domain *fft.Domain
+
+	// nbQuotients is the number of pieces of size nbRows of the quotient.
+	nbQuotients int
+
+	// lde is the coset on which the quotient is computed, of size at least
+	// nbQuotients⋅nbRows.
+	lde *fft.Domain
+
+	// boundaryRows[i] is the row of the i-th boundary constraint, counted
+	// from the start of the trace.
+	boundaryRows []int
+}
+
+// Proof is a proof that a trace satisfies an AIR.
+//
+// implements io.ReaderFrom and io.WriterTo
+type Proof struct {
+
+	// TraceRoot is the commitment to the columns of the trace.
+	TraceRoot fri.Digest
+
+	// QuotientRoot is the commitment to the coordinates of the pieces of the
+	// quotient.
+	QuotientRoot fri.Digest
+
+	// Opening proves the evaluations of the columns of the trace at ζ and
+	// ζ⋅ω, and of the quotient at ζ.
+	Opening fri.OpeningProof
+}
+
+// New returns a System for the traces of nbRows rows satisfying air. h is
+// the hash function of the Fiat Shamir transcript, and params are the
+// parameters of the FRI polynomial commitment scheme.
+func New(air *AIR, nbRows int, h hash.Hash, params fri.Parameters) (*System, error) {
+	if err := air.check(); err != nil {
+		return nil, err
+	}
+	if nbRows < 2 || nbRows&(nbRows-1) != 0 {
+		return nil, ErrNbRows
+	}
+	s := &System{
+		air:          air,
+		nbRows:       nbRows,
+		h:            h,
+		nbQuotients:  max(air.maxDegree()-1, 1),
+		boundaryRows: make([]int, len(air.boundaries)),
+	}
+	for i, b := range air.boundaries {
+		row := b.row
+		if row < 0 {
+			row += nbRows
+		}
+		if row < 0 || row >= nbRows {
+			return nil, fmt.Errorf("%w: boundary constraint %d", ErrBoundaryRow, i)
+		}
+		s.boundaryRows[i] = row
+	}
+
+	var err error
+	if s.pcs, err = fri.NewPCS(uint64(nbRows), h, params); err != nil {
+		return nil, err
+	}
+	ldeSize := ecc.NextPowerOfTwo(uint64(s.nbQuotients)) * uint64(nbRows)
+	if _, err = fr.Generator(ldeSize); err != nil {
+		return nil, err
+	}
+	s.domain = fft.NewDomain(uint64(nbRows))
+	s.lde = fft.NewDomain(ldeSize)
+	return s, nil
+}
+
+// Prove returns a proof that the trace, given by its columns in the order of
+// their declaration in the AIR, satisfies the AIR.
+//
+// Let Tⱼ be the interpolations of the columns on ⟨ω⟩, Cᵢ the transition
+// constraints and (cᵢ, rᵢ, vᵢ) the boundary constraints. The quotient
+//
+//	Q = ∑ αⁱ⋅Cᵢ(T(X), T(ω⋅X))⋅(X - ωⁿ⁻¹)/(Xⁿ - 1) + ∑ αᵗ⁺ⁱ⋅(T_{cᵢ} - vᵢ)/(X - ω^{rᵢ})
+//
+// where t is the number of transition constraints, is a polynomial exactly
+// when the trace satisfies the constraints. It is computed on the coset lde
+// and committed to by pieces Qₖ of size n, Q = ∑ Xᵏⁿ⋅Qₖ, each piece as its
+// coordinates over fr.
+func (s *System) Prove(trace [][]fr.Element) (Proof, error) {
+	var proof Proof
+	if err := s.checkTrace(trace); err != nil {
+		return proof, err
+	}
+
+	// commit to the interpolations of the columns
+	columns := make([][]fr.Element, len(trace))
+	parallel.Execute(len(trace), func(start, end int) {
+		for c := start; c < end; c++ {
+			columns[c] = make([]fr.Element, s.nbRows)
+			copy(columns[c], trace[c])
+			s.domain.FFTInverse(columns[c], fft.DIF)
+			fft.BitReverse(columns[c])
+		}
+	}, 1)
+	traceData, err := s.pcs.Commit(columns...)
+	if err != nil {
+		return proof, err
+	}
+	proof.TraceRoot = traceData.Commitment.Root
+	fs := fiatshamir.NewTranscript(s.h, "alpha", "zeta")
+	alpha, err := s.compositionChallenge(fs, &proof.TraceRoot)
+	if err != nil {
+		return proof, err
+	}
+
+	// commit to the pieces of the quotient
+	quotient := s.quotient(columns, alpha)
+	pieces := make([][]fr.Element, 0, s.nbQuotients*iop.ExtDegree)
+	for k := 0; k < s.nbQuotients; k++ {
+		for c := range quotient {
+			pieces = append(pieces, quotient[c][k*s.nbRows:(k+1)*s.nbRows])
+		}
+	}
+	quotientData, err := s.pcs.Commit(pieces...)
+	if err != nil {
+		return proof, err
+	}
+	proof.QuotientRoot = quotientData.Commitment.Root
+
+	// open at ζ and ζ⋅ω
+	points, err := s.points(fs, &proof.QuotientRoot)
+	if err != nil {
+		return proof, err
+	}
+	proof.Opening, err = s.pcs.Open([]*fri.ProverData{traceData, quotientData}, points)
+	return proof, err
+}
+
+// Verify verifies a proof of Prove.
+func (s *System) Verify(proof *Proof) error {
+	fs := fiatshamir.NewTranscript(s.h, "alpha", "zeta")
+	alpha, err := s.compositionChallenge(fs, &proof.TraceRoot)
+	if err != nil {
+		return err
+	}
+	points, err := s.points(fs, &proof.QuotientRoot)
+	if err != nil {
+		return err
+	}
+	if err = s.pcs.Verify(s.commitments(proof), points, &proof.Opening); err != nil {
+		return err
+	}
+
+	// values of the columns at ζ and ζ⋅ω
+	claimedValues := proof.Opening.ClaimedValues
+	nbColumns := s.air.NbColumns()
+	curr, next := make([]Ext, nbColumns), make([]Ext, nbColumns)
+	for c := range curr {
+		curr[c] = claimedValues[0][c][0]
+		next[c] = claimedValues[0][c][1]
+	}
+
+	// inverses of the vanishing polynomials at ζ: ζⁿ - 1 and the ζ - ω^{rᵢ}
+	zeta := points[0]
+	var one, zetaN, t Ext
+	one.SetOne()
+	zetaN.Exp(zeta, big.NewInt(int64(s.nbRows)))
+	denominators := make([]Ext, 1+len(s.boundaryRows))
+	denominators[0].Sub(&zetaN, &one)
+	for i, row := range s.boundaryRows {
+		omegaR := s.omegaPow(row)
+		denominators[1+i].Sub(&zeta, &omegaR)
+	}
+	denominators = iop.BatchInvert(denominators)
+
+	// Q(ζ) from the constraints
+	alphas := powers(alpha, len(s.air.transitions)+len(s.air.boundaries))
+	var transitions, expected Ext
+	for i, e := range s.air.transitions {
+		v := e.evaluateExt(curr, next)
+		t.Mul(&v, &alphas[i])
+		transitions.Add(&transitions, &t)
+	}
+	lastRow := s.omegaPow(s.nbRows - 1)
+	t.Sub(&zeta, &lastRow).Mul(&t, &denominators[0])
+	expected.Mul(&transitions, &t)
+	for i, b := range s.air.boundaries {
+		v := iop.Embed(&b.value)
+		t.Sub(&curr[b.column], &v).Mul(&t, &denominators[1+i]).Mul(&t, &alphas[len(s.air.transitions)+i])
+		expected.Add(&expected, &t)
+	}
+
+	// Q(ζ) = ∑ ζᵏⁿ⋅Qₖ(ζ) from the pieces
+	var basis [iop.ExtDegree]Ext
+	for c := range basis {
+		var unit [iop.ExtDegree]fr.Element
+		unit[c].SetOne()
+		basis[c] = iop.FromCoordinates(unit[:])
+	}
+	var q, qk, zetaKN Ext
+	zetaKN.SetOne()
+	for k := 0; k < s.nbQuotients; k++ {
+		qk.SetZero()
+		for c := range basis {
+			t.Mul(&basis[c], &claimedValues[1][k*iop.ExtDegree+c][0])
+			qk.Add(&qk, &t)
+		}
+		qk.Mul(&qk, &zetaKN)
+		q.Add(&q, &qk)
+		zetaKN.Mul(&zetaKN, &zetaN)
+	}
+
+	if !q.Equal(&expected) {
+		return ErrQuotient
+	}
+	return nil
+}
+
+// checkTrace returns an error if the trace does not have the shape of the
+// AIR or does not satisfy its constraints.
+func (s *System) checkTrace(trace [][]fr.Element) error {
+	if len(trace) != s.air.NbColumns() {
+		return ErrTraceShape
+	}
+	for _, column := range trace {
+		if len(column) != s.nbRows {
+			return ErrTraceShape
+		}
+	}
+	for i, b := range s.air.boundaries {
+		if !trace[b.column][s.boundaryRows[i]].Equal(&b.value) {
+			return fmt.Errorf("%w: boundary constraint %d", ErrUnsatisfied, i)
+		}
+	}
+	curr, next := make([]fr.Element, len(trace)), make([]fr.Element, len(trace))
+	for row := 0; row < s.nbRows-1; row++ {
+		for c := range trace {
+			curr[c], next[c] = trace[c][row], trace[c][row+1]
+		}
+		for i, e := range s.air.transitions {
+			if v := e.evaluate(curr, next); !v.IsZero() {
+				return fmt.Errorf("%w: transition constraint %d on row %d", ErrUnsatisfied, i, row)
+			}
+		}
+	}
+	return nil
+}
+
+// quotient returns the coordinates over fr of the quotient Q, in canonical
+// form, the columns of the trace being in canonical form.
+func (s *System) quotient(columns [][]fr.Element, alpha Ext) [iop.ExtDegree][]fr.Element {
+	size := int(s.lde.Cardinality)
+	blowUp := size / s.nbRows
+
+	// evaluations of the columns on the coset x⋅⟨g⟩, where gᵇˡᵒʷᵁᵖ = ω, so
+	// that the next row of the j-th evaluation is the (j+blowUp)-th one
+	evaluations := make([][]fr.Element, len(columns))
+	parallel.Execute(len(columns), func(start, end int) {
+		for c := start; c < end; c++ {
+			evaluations[c] = make([]fr.Element, size)
+			copy(evaluations[c], columns[c])
+			s.lde.FFT(evaluations[c], fft.DIF, fft.OnCoset())
+			fft.BitReverse(evaluations[c])
+		}
+	}, 1)
+
+	// (xⁿ - 1)⁻¹ takes blowUp values on the coset
+	n := big.NewInt(int64(s.nbRows))
+	vanishingInv := make([]fr.Element, blowUp)
+	var xN, gN, one fr.Element
+	one.SetOne()
+	xN.Exp(s.lde.FrMultiplicativeGen, n)
+	gN.Exp(s.lde.Generator, n)
+	for j := range vanishingInv {
+		vanishingInv[j].Sub(&xN, &one)
+		xN.Mul(&xN, &gN)
+	}
+	vanishingInv = fr.BatchInvert(vanishingInv)
+
+	// (x - ω^{rᵢ})⁻¹ for the boundary constraints
+	boundaryInv := make([][]fr.Element, len(s.boundaryRows))
+	parallel.Execute(len(s.boundaryRows), func(start, end int) {
+		for i := start; i < end; i++ {
+			omegaR := s.domain.Generator
+			omegaR.Exp(omegaR, big.NewInt(int64(s.boundaryRows[i])))
+			boundaryInv[i] = make([]fr.Element, size)
+			x := s.lde.FrMultiplicativeGen
+			for j := range boundaryInv[i] {
+				boundaryInv[i][j].Sub(&x, &omegaR)
+				x.Mul(&x, &s.lde.Generator)
+			}
+			boundaryInv[i] = fr.BatchInvert(boundaryInv[i])
+		}
+	})
+
+	alphas := powers(alpha, len(s.air.transitions)+len(s.air.boundaries))
+	var res [iop.ExtDegree][]fr.Element
+	for c := range res {
+		res[c] = make([]fr.Element, size)
+	}
+	lastRow := s.domain.Generator
+	lastRow.Exp(lastRow, big.NewInt(int64(s.nbRows-1)))
+	parallel.Execute(size, func(start, end int) {
+		curr, next := make([]fr.Element, len(columns)), make([]fr.Element, len(columns))
+		var x, factor, v fr.Element
+		var q, t Ext
+		x.Exp(s.lde.Generator, big.NewInt(int64(start))).Mul(&x, &s.lde.FrMultiplicativeGen)
+		for j := start; j < end; j++ {
+			for c := range evaluations {
+				curr[c] = evaluations[c][j]
+				next[c] = evaluations[c][(j+blowUp)%size]
+			}
+			q.SetZero()
+			for i, e := range s.air.transitions {
+				v = e.evaluate(curr, next)
+				t.MulByElement(&alphas[i], &v)
+				q.Add(&q, &t)
+			}
+			factor.Sub(&x, &lastRow).Mul(&factor, &vanishingInv[j%blowUp])
+			q.MulByElement(&q, &factor)
+			for i, b := range s.air.boundaries {
+				v.Sub(&curr[b.column], &b.value).Mul(&v, &boundaryInv[i][j])
+				t.MulByElement(&alphas[len(s.air.transitions)+i], &v)
+				q.Add(&q, &t)
+			}
+			for c, qc := range iop.Coordinates(&q) {
+				res[c][j] = qc
+			}
+			x.Mul(&x, &s.lde.Generator)
+		}
+	})
+
+	parallel.Execute(len(res), func(start, end int) {
+		for c := start; c < end; c++ {
+			s.lde.FFTInverse(res[c], fft.DIF, fft.OnCoset())
+			fft.BitReverse(res[c])
+		}
+	}, 1)
+	return res
+}
+
+// compositionChallenge binds the statement and the commitment to the trace,
+// and derives the challenge α of the combination of the constraints.
+func (s *System) compositionChallenge(fs *fiatshamir.Transcript, traceRoot *fri.Digest) (Ext, error) {
+	var buf [4]byte
+	bindUint32 := func(v int) error {
+		binary.BigEndian.PutUint32(buf[:], uint32(v))
+		return fs.Bind("alpha", buf[:])
+	}
+	if err := bindUint32(s.nbRows); err != nil {
+		return Ext{}, err
+	}
+	for i, b := range s.air.boundaries {
+		if err := bindUint32(b.column); err != nil {
+			return Ext{}, err
+		}
+		if err := bindUint32(s.boundaryRows[i]); err != nil {
+			return Ext{}, err
+		}
+		if err := fs.Bind("alpha", b.value.Marshal()); err != nil {
+			return Ext{}, err
+		}
+	}
+	if err := fs.Bind("alpha", traceRoot[:]); err != nil {
+		return Ext{}, err
+	}
+	return iop.Challenge(fs, "alpha")
+}
+
+// points binds the commitment to the quotient, and derives the opening
+// points ζ and ζ⋅ω.
+func (s *System) points(fs *fiatshamir.Transcript, quotientRoot *fri.Digest) ([]Ext, error) {
+	if err := fs.Bind("zeta", quotientRoot[:]); err != nil {
+		return nil, err
+	}
+	zeta, err := iop.Challenge(fs, "zeta")
+	if err != nil {
+		return nil, err
+	}
+	var zetaN, one Ext
+	one.SetOne()
+	if zetaN.Exp(zeta, big.NewInt(int64(s.nbRows))); zetaN.Equal(&one) {
+		return nil, ErrOutOfDomainPoint
+	}
+	var zetaOmega Ext
+	zetaOmega.MulByElement(&zeta, &s.domain.Generator)
+	return []Ext{zeta, zetaOmega}, nil
+}
+
+// commitments returns the commitments to the trace and to the quotient of a
+// proof, with the sizes expected by the verifier.
+func (s *System) commitments(proof *Proof) []fri.Commitment {
+	sizes := func(nb int) []int {
+		res := make([]int, nb)
+		for i := range res {
+			res[i] = s.nbRows
+		}
+		return res
+	}
+	return []fri.Commitment{
+		{Root: proof.TraceRoot, Sizes: sizes(s.air.NbColumns())},
+		{Root: proof.QuotientRoot, Sizes: sizes(s.nbQuotients * iop.ExtDegree)},
+	}
+}
+
+// omegaPow returns ωᵏ as an element of Ext.
+func (s *System) omegaPow(k int) Ext {
+	var res fr.Element
+	res.Exp(s.domain.Generator, big.NewInt(int64(k)))
+	return iop.Embed(&res)
+}
+
+// powers returns 1, α, …, αⁿ⁻¹.
+func powers(alpha Ext, n int) []Ext {
+	res := make([]Ext, n)
+	if n > 0 {
+		res[0].SetOne()
+	}
+	for i := 1; i < n; i++ {
+		res[i].Mul(&res[i-1], &alpha)
+	}
+	return res
+}
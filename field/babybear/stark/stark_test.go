@@ -0,0 +1,215 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package stark
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	fr "github.com/consensys/gnark-crypto/field/babybear"
+	"github.com/consensys/gnark-crypto/field/babybear/fri"
+	"github.com/consensys/gnark-crypto/field/babybear/internal/iop"
+)
+
+// fibonacci returns the AIR of the Fibonacci sequence, with columns a and b,
+// (a, b)' = (b, a+b), starting from (1, 1), and a trace satisfying it.
+func fibonacci(nbRows int) (*AIR, [][]fr.Element) {
+	trace := [][]fr.Element{make([]fr.Element, nbRows), make([]fr.Element, nbRows)}
+	trace[0][0].SetOne()
+	trace[1][0].SetOne()
+	for i := 0; i < nbRows-1; i++ {
+		trace[0][i+1] = trace[1][i]
+		trace[1][i+1].Add(&trace[0][i], &trace[1][i])
+	}
+
+	air := NewAIR()
+	a, b := air.NewColumn("a"), air.NewColumn("b")
+	air.AssertTransition(Sub(a.Next(), b.Curr()))
+	air.AssertTransition(Sub(b.Next(), Add(a.Curr(), b.Curr())))
+	air.AssertBoundary(a, 0, trace[0][0])
+	air.AssertBoundary(b, 0, trace[1][0])
+	air.AssertBoundary(b, -1, trace[1][nbRows-1])
+	return air, trace
+}
+
+// power returns the AIR x' = xᵈ + y, y' = y + 1, starting from (2, 0), and a
+// trace satisfying it.
+func power(nbRows, d int) (*AIR, [][]fr.Element) {
+	trace := [][]fr.Element{make([]fr.Element, nbRows), make([]fr.Element, nbRows)}
+	trace[0][0].SetUint64(2)
+	for i := 0; i < nbRows-1; i++ {
+		trace[0][i+1].SetOne()
+		for k := 0; k < d; k++ {
+			trace[0][i+1].Mul(&trace[0][i+1], &trace[0][i])
+		}
+		trace[0][i+1].Add(&trace[0][i+1], &trace[1][i])
+		trace[1][i+1].SetUint64(uint64(i + 1))
+	}
+
+	air := NewAIR()
+	x, y := air.NewColumn("x"), air.NewColumn("y")
+	factors := make([]Expression, d)
+	for k := range factors {
+		factors[k] = x.Curr()
+	}
+	var xd Expression = x.Curr()
+	if d > 1 {
+		xd = Mul(factors[0], factors[1], factors[2:]...)
+	}
+	var one fr.Element
+	one.SetOne()
+	air.AssertTransition(Sub(x.Next(), Add(xd, y.Curr())))
+	air.AssertTransition(Sub(y.Next(), Add(y.Curr(), Constant(one))))
+	air.AssertBoundary(x, 0, trace[0][0])
+	air.AssertBoundary(y, 0, trace[1][0])
+	return air, trace
+}
+
+func TestFibonacci(t *testing.T) {
+	const nbRows = 64
+	air, trace := fibonacci(nbRows)
+	system, err := New(air, nbRows, sha256.New(), fri.DefaultParameters())
+	require.NoError(t, err)
+	proof, err := system.Prove(trace)
+	require.NoError(t, err)
+	require.NoError(t, system.Verify(&proof))
+
+	// serialization
+	var buf bytes.Buffer
+	_, err = proof.WriteTo(&buf)
+	require.NoError(t, err)
+	var decoded Proof
+	_, err = decoded.ReadFrom(&buf)
+	require.NoError(t, err)
+	require.Equal(t, proof, decoded)
+	require.NoError(t, system.Verify(&decoded))
+
+	// tampered proof
+	one := iop.Embed(new(fr.Element).SetOne())
+	for _, v := range []*Ext{
+		&proof.Opening.ClaimedValues[0][1][1],
+		&proof.Opening.ClaimedValues[1][2][0],
+	} {
+		v.Add(v, &one)
+		require.Error(t, system.Verify(&proof))
+		v.Sub(v, &one)
+	}
+	proof.QuotientRoot[0] ^= 1
+	require.Error(t, system.Verify(&proof))
+	proof.QuotientRoot[0] ^= 1
+	require.NoError(t, system.Verify(&proof))
+
+	// wrong statement
+	wrongAIR, _ := fibonacci(nbRows)
+	wrongAIR.boundaries[2].value.SetOne()
+	wrongSystem, err := New(wrongAIR, nbRows, sha256.New(), fri.DefaultParameters())
+	require.NoError(t, err)
+	require.Error(t, wrongSystem.Verify(&proof))
+
+	// wrong traces
+	_, err = wrongSystem.Prove(trace)
+	require.ErrorIs(t, err, ErrUnsatisfied)
+	trace[0][3].SetOne()
+	_, err = system.Prove(trace)
+	require.ErrorIs(t, err, ErrUnsatisfied)
+	_, err = system.Prove(trace[:1])
+	require.ErrorIs(t, err, ErrTraceShape)
+}
+
+func TestDegree(t *testing.T) {
+	const nbRows = 32
+	params := fri.Parameters{Rate: 2, NbQueries: 8, FoldingArity: 4, FinalPolynomialSize: 4}
+	for d := 1; d <= 5; d++ {
+		t.Run(fmt.Sprintf("degree=%d", d), func(t *testing.T) {
+			air, trace := power(nbRows, d)
+			system, err := New(air, nbRows, sha256.New(), params)
+			require.NoError(t, err)
+			require.Equal(t, max(d-1, 1), system.nbQuotients)
+			proof, err := system.Prove(trace)
+			require.NoError(t, err)
+			require.NoError(t, system.Verify(&proof))
+
+			// the constraints are not bound to the transcript, the proof
+			// does not match them
+			other, _ := power(nbRows, d)
+			other.transitions[1] = Sub(other.transitions[1], Constant(fr.One()))
+			otherSystem, err := New(other, nbRows, sha256.New(), params)
+			require.NoError(t, err)
+			require.ErrorIs(t, otherSystem.Verify(&proof), ErrQuotient)
+		})
+	}
+}
+
+func TestAIR(t *testing.T) {
+	_, err := New(NewAIR(), 8, sha256.New(), fri.DefaultParameters())
+	require.ErrorIs(t, err, ErrEmptyAIR)
+
+	air := NewAIR()
+	a := air.NewColumn("a")
+	other := NewAIR()
+	other.NewColumn("x")
+	c := other.NewColumn("c")
+	air.AssertTransition(Sub(a.Next(), c.Curr()))
+	_, err = New(air, 8, sha256.New(), fri.DefaultParameters())
+	require.ErrorIs(t, err, ErrUnknownColumn)
+
+	air = NewAIR()
+	a = air.NewColumn("a")
+	air.AssertTransition(Mul(a.Curr(), Add(a.Next(), Mul(a.Curr(), a.Next()))))
+	require.Equal(t, 3, air.maxDegree())
+	air.AssertBoundary(a, -9, fr.One())
+	_, err = New(air, 8, sha256.New(), fri.DefaultParameters())
+	require.ErrorIs(t, err, ErrBoundaryRow)
+	_, err = New(air, 12, sha256.New(), fri.DefaultParameters())
+	require.ErrorIs(t, err, ErrNbRows)
+
+	// the expressions have the same values in fr and in Ext
+	var curr, next [1]fr.Element
+	curr[0].MustSetRandom()
+	next[0].MustSetRandom()
+	e := air.transitions[0]
+	v := e.evaluate(curr[:], next[:])
+	expected := iop.Embed(&v)
+	currExt, nextExt := []Ext{iop.Embed(&curr[0])}, []Ext{iop.Embed(&next[0])}
+	vExt := e.evaluateExt(currExt, nextExt)
+	require.True(t, expected.Equal(&vExt))
+}
+
+// Benchmarks
+
+func BenchmarkProve(b *testing.B) {
+	const nbRows = 1 << 14
+	air, trace := power(nbRows, 3)
+	system, err := New(air, nbRows, sha256.New(), fri.DefaultParameters())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		system.Prove(trace)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	const nbRows = 1 << 14
+	air, trace := power(nbRows, 3)
+	system, err := New(air, nbRows, sha256.New(), fri.DefaultParameters())
+	if err != nil {
+		b.Fatal(err)
+	}
+	proof, err := system.Prove(trace)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		system.Verify(&proof)
+	}
+}
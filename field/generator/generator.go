@@ -74,6 +74,36 @@ func GenerateFF(F *config.Field, outputDir string, options ...Option) error {
 		}
 	}
 
+	// generate the building blocks shared by FRI, Basefold and the STARK
+	if cfg.HasFRI() || cfg.HasBasefold() {
+		if err := generateIOP(F, outputDir); err != nil {
+			return err
+		}
+	}
+
+	// generate FRI
+	if cfg.HasFRI() {
+		if err := generateFRI(F, outputDir); err != nil {
+			return err
+		}
+	}
+
+	// generate Basefold, whose challenges are drawn in the degree 4 extension
+	// of the 31 bits fields
+	if cfg.HasBasefold() && F.F31 {
+		if err := generateBasefold(F, outputDir); err != nil {
+			return err
+		}
+	}
+
+	// generate the STARK, whose challenges are drawn in the degree 4 extension
+	// of the 31 bits fields
+	if cfg.HasSTARK() && F.F31 {
+		if err := generateSTARK(F, outputDir); err != nil {
+			return err
+		}
+	}
+
 	return runFormatters(outputDir)
 }
 
@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/field/generator/config"
+)
+
+func generateBasefold(F *config.Field, outputDir string) error {
+
+	fieldImportPath, err := getImportPath(outputDir)
+	if err != nil {
+		return err
+	}
+
+	outputDir = filepath.Join(outputDir, "basefold")
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(outputDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(outputDir, "basefold.go"), Templates: []string{"basefold.go.tmpl"}},
+		{File: filepath.Join(outputDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(outputDir, "basefold_test.go"), Templates: []string{"basefold.test.go.tmpl"}},
+	}
+
+	type basefoldTemplateData struct {
+		FF               string
+		FieldPackagePath string
+	}
+
+	data := &basefoldTemplateData{
+		FF:               F.PackageName,
+		FieldPackagePath: fieldImportPath,
+	}
+
+	bgen := bavard.NewBatchGenerator("Consensys Software Inc.", 2020, "consensys/gnark-crypto")
+
+	templatesRootDir, err := findTemplatesRootDir()
+	if err != nil {
+		return err
+	}
+
+	if err := bgen.GenerateWithOptions(data, "basefold", filepath.Join(templatesRootDir, "basefold"), nil, entries...); err != nil {
+		return err
+	}
+
+	return runFormatters(outputDir)
+}
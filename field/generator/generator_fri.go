@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/field/generator/config"
+)
+
+func generateFRI(F *config.Field, outputDir string) error {
+
+	fieldImportPath, err := getImportPath(outputDir)
+	if err != nil {
+		return err
+	}
+
+	outputDir = filepath.Join(outputDir, "fri")
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(outputDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(outputDir, "fri.go"), Templates: []string{"fri.go.tmpl"}},
+		{File: filepath.Join(outputDir, "pcs.go"), Templates: []string{"pcs.go.tmpl"}},
+		{File: filepath.Join(outputDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(outputDir, "fri_test.go"), Templates: []string{"fri.test.go.tmpl"}},
+	}
+
+	type friTemplateData struct {
+		FF               string
+		FieldPackagePath string
+	}
+
+	data := &friTemplateData{
+		FF:               F.PackageName,
+		FieldPackagePath: fieldImportPath,
+	}
+
+	bgen := bavard.NewBatchGenerator("Consensys Software Inc.", 2020, "consensys/gnark-crypto")
+
+	friTemplatesRootDir, err := findTemplatesRootDir()
+	if err != nil {
+		return err
+	}
+	friTemplatesRootDir = filepath.Join(friTemplatesRootDir, "fri")
+
+	if err := bgen.GenerateWithOptions(data, "fri", friTemplatesRootDir, nil, entries...); err != nil {
+		return err
+	}
+
+	return runFormatters(outputDir)
+}
+
+// generateIOP generates the internal package of the building blocks shared
+// by the hash-based commitment schemes: the extension of the challenges, the
+// Fiat-Shamir helpers, the sampling of the queries, the folding of
+// Reed-Solomon codewords, the Poseidon2 Merkle trees and the binary encoding
+// of the proofs.
+func generateIOP(F *config.Field, outputDir string) error {
+
+	fieldImportPath, err := getImportPath(outputDir)
+	if err != nil {
+		return err
+	}
+
+	outputDir = filepath.Join(outputDir, "internal", "iop")
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(outputDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(outputDir, "ext.go"), Templates: []string{"ext.go.tmpl"}},
+		{File: filepath.Join(outputDir, "transcript.go"), Templates: []string{"transcript.go.tmpl"}},
+		{File: filepath.Join(outputDir, "queries.go"), Templates: []string{"queries.go.tmpl"}},
+		{File: filepath.Join(outputDir, "fold.go"), Templates: []string{"fold.go.tmpl"}},
+		{File: filepath.Join(outputDir, "merkle.go"), Templates: []string{"merkle.go.tmpl"}},
+		{File: filepath.Join(outputDir, "encoding.go"), Templates: []string{"encoding.go.tmpl"}},
+		{File: filepath.Join(outputDir, "iop_test.go"), Templates: []string{"iop.test.go.tmpl"}},
+	}
+
+	type iopTemplateData struct {
+		FF               string
+		FieldPackagePath string
+	}
+
+	data := &iopTemplateData{
+		FF:               F.PackageName,
+		FieldPackagePath: fieldImportPath,
+	}
+
+	bgen := bavard.NewBatchGenerator("Consensys Software Inc.", 2020, "consensys/gnark-crypto")
+
+	templatesRootDir, err := findTemplatesRootDir()
+	if err != nil {
+		return err
+	}
+
+	if err := bgen.GenerateWithOptions(data, "iop", filepath.Join(templatesRootDir, "iop"), nil, entries...); err != nil {
+		return err
+	}
+
+	return runFormatters(outputDir)
+}
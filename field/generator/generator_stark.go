@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/field/generator/config"
+)
+
+func generateSTARK(F *config.Field, outputDir string) error {
+
+	fieldImportPath, err := getImportPath(outputDir)
+	if err != nil {
+		return err
+	}
+
+	outputDir = filepath.Join(outputDir, "stark")
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(outputDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(outputDir, "air.go"), Templates: []string{"air.go.tmpl"}},
+		{File: filepath.Join(outputDir, "stark.go"), Templates: []string{"stark.go.tmpl"}},
+		{File: filepath.Join(outputDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(outputDir, "stark_test.go"), Templates: []string{"stark.test.go.tmpl"}},
+	}
+
+	type starkTemplateData struct {
+		FF               string
+		FieldPackagePath string
+	}
+
+	data := &starkTemplateData{
+		FF:               F.PackageName,
+		FieldPackagePath: fieldImportPath,
+	}
+
+	bgen := bavard.NewBatchGenerator("Consensys Software Inc.", 2020, "consensys/gnark-crypto")
+
+	templatesRootDir, err := findTemplatesRootDir()
+	if err != nil {
+		return err
+	}
+
+	if err := bgen.GenerateWithOptions(data, "stark", filepath.Join(templatesRootDir, "stark"), nil, entries...); err != nil {
+		return err
+	}
+
+	return runFormatters(outputDir)
+}
@@ -12,6 +12,9 @@ type generatorConfig struct {
 	withSIS        bool
 	withPoseidon2  bool
 	withExtensions bool
+	withFRI        bool
+	withBasefold   bool
+	withSTARK      bool
 }
 
 func (cfg *generatorConfig) HasExtensions() bool {
@@ -22,6 +25,24 @@ func (cfg *generatorConfig) HasPoseidon2() bool {
 	return cfg.withPoseidon2
 }
 
+// HasFRI returns true if the FRI polynomial commitment scheme is generated. It
+// requires the FFT, the extensions and Poseidon2.
+func (cfg *generatorConfig) HasFRI() bool {
+	return cfg.withFRI && cfg.HasFFT() && cfg.withExtensions && cfg.withPoseidon2
+}
+
+// HasBasefold returns true if the Basefold multilinear polynomial commitment
+// scheme is generated. It has the same requirements as FRI.
+func (cfg *generatorConfig) HasBasefold() bool {
+	return cfg.withBasefold && cfg.HasFFT() && cfg.withExtensions && cfg.withPoseidon2
+}
+
+// HasSTARK returns true if the STARK prover and verifier are generated. They
+// are built on FRI.
+func (cfg *generatorConfig) HasSTARK() bool {
+	return cfg.withSTARK && cfg.HasFRI()
+}
+
 func (cfg *generatorConfig) HasSIS() bool {
 	return cfg.withSIS
 }
@@ -56,6 +77,24 @@ func WithExtensions() Option {
 	}
 }
 
+func WithFRI() Option {
+	return func(opt *generatorConfig) {
+		opt.withFRI = true
+	}
+}
+
+func WithBasefold() Option {
+	return func(opt *generatorConfig) {
+		opt.withBasefold = true
+	}
+}
+
+func WithSTARK() Option {
+	return func(opt *generatorConfig) {
+		opt.withSTARK = true
+	}
+}
+
 func WithFFT(cfg *config.FFT) Option {
 	return func(opt *generatorConfig) {
 		opt.fftConfig = cfg
@@ -0,0 +1,76 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"io"
+
+	"github.com/consensys/gnark-crypto/field/goldilocks/internal/iop"
+)
+
+// WriteTo writes the binary encoding of the proof.
+func (proof *ProofOfProximity) WriteTo(w io.Writer) (int64, error) {
+	enc := iop.NewEncoder(w)
+	enc.WriteUint32(len(proof.Roots))
+	for i := range proof.Roots {
+		enc.Write(proof.Roots[i][:])
+	}
+	enc.WriteOpenings(proof.Openings)
+	enc.WriteExt(proof.FinalPolynomial)
+	enc.Write(proof.Nonce)
+	return enc.N(), enc.Err()
+}
+
+// ReadFrom decodes a proof written by WriteTo.
+func (proof *ProofOfProximity) ReadFrom(r io.Reader) (int64, error) {
+	dec := iop.NewDecoder(r)
+	if nbRoots := dec.ReadUint32(); nbRoots > 0 {
+		proof.Roots = make([]Digest, nbRoots)
+	}
+	for i := range proof.Roots {
+		dec.ReadFull(proof.Roots[i][:])
+	}
+	proof.Openings = dec.ReadOpenings()
+	proof.FinalPolynomial = dec.ReadExt()
+	proof.Nonce = dec.ReadUint64()
+	return dec.N(), dec.Err()
+}
+
+// WriteTo writes the binary encoding of the proof.
+func (proof *OpeningProof) WriteTo(w io.Writer) (int64, error) {
+	enc := iop.NewEncoder(w)
+	enc.WriteUint32(len(proof.ClaimedValues))
+	for _, m := range proof.ClaimedValues {
+		enc.WriteUint32(len(m))
+		for _, p := range m {
+			enc.WriteExt(p)
+		}
+	}
+	enc.WriteOpenings(proof.Rows)
+	if enc.Err() != nil {
+		return enc.N(), enc.Err()
+	}
+	n, err := proof.ProofOfProximity.WriteTo(w)
+	return enc.N() + n, err
+}
+
+// ReadFrom decodes a proof written by WriteTo.
+func (proof *OpeningProof) ReadFrom(r io.Reader) (int64, error) {
+	dec := iop.NewDecoder(r)
+	proof.ClaimedValues = make([][][]Ext, dec.ReadUint32())
+	for m := range proof.ClaimedValues {
+		proof.ClaimedValues[m] = make([][]Ext, dec.ReadUint32())
+		for i := range proof.ClaimedValues[m] {
+			proof.ClaimedValues[m][i] = dec.ReadExt()
+		}
+	}
+	proof.Rows = dec.ReadOpenings()
+	if dec.Err() != nil {
+		return dec.N(), dec.Err()
+	}
+	n, err := proof.ProofOfProximity.ReadFrom(r)
+	return dec.N() + n, err
+}
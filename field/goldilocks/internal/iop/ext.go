@@ -0,0 +1,48 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package iop
+
+import (
+	fr "github.com/consensys/gnark-crypto/field/goldilocks"
+	"github.com/consensys/gnark-crypto/field/goldilocks/extensions"
+)
+
+// Ext is the extension of the field in which the challenges are drawn and
+// the polynomials are opened, of about 128 bits.
+type Ext = extensions.E2
+
+// ExtDegree is the degree of Ext over fr.
+const ExtDegree = 2
+
+// Embed returns x as an element of Ext.
+func Embed(x *fr.Element) Ext {
+	return Ext{A0: *x}
+}
+
+// Coordinates returns the coordinates of z over fr.
+func Coordinates(z *Ext) [ExtDegree]fr.Element {
+	return [ExtDegree]fr.Element{z.A0, z.A1}
+}
+
+// FromCoordinates returns the element of Ext of coordinates c over fr.
+func FromCoordinates(c []fr.Element) Ext {
+	return Ext{A0: c[0], A1: c[1]}
+}
+
+// BatchInvert inverts the elements of a slice of Ext.
+var BatchInvert = extensions.BatchInvertE2
+
+// extFromBytes returns the element of Ext whose coordinates are the chunks of
+// b, reduced modulo q. Its distribution is close to uniform when b is uniform
+// and has at least 2⋅fr.Bytes bytes per coordinate.
+func extFromBytes(b []byte) Ext {
+	var c [ExtDegree]fr.Element
+	l := len(b) / ExtDegree
+	for i := range c {
+		c[i].SetBytes(b[i*l : (i+1)*l])
+	}
+	return FromCoordinates(c[:])
+}
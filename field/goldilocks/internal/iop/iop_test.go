@@ -0,0 +1,68 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package iop
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	fr "github.com/consensys/gnark-crypto/field/goldilocks"
+)
+
+func TestFoldFiber(t *testing.T) {
+	const k = 8
+
+	// p = ∑ₛ Xˢ⋅pₛ(Xᵏ) with the pₛ of degree 1
+	var ps [k][2]Ext
+	for s := range ps {
+		ps[s][0].MustSetRandom()
+		ps[s][1].MustSetRandom()
+	}
+	evalP := func(x fr.Element) Ext {
+		var xk, xs fr.Element
+		var res, t Ext
+		xk.Exp(x, big.NewInt(k))
+		xs.SetOne()
+		for s := range ps {
+			t.MulByElement(&ps[s][1], &xk).Add(&t, &ps[s][0]).MulByElement(&t, &xs)
+			res.Add(&res, &t)
+			xs.Mul(&xs, &x)
+		}
+		return res
+	}
+
+	var x fr.Element
+	var beta Ext
+	x.MustSetRandom()
+	beta.MustSetRandom()
+	zetaInv, kInv, err := FoldParameters(k)
+	require.NoError(t, err)
+	zeta, err := fr.Generator(k)
+	require.NoError(t, err)
+	e := make([]Ext, k)
+	xzt := x
+	for i := range e {
+		e[i] = evalP(xzt)
+		xzt.Mul(&xzt, &zeta)
+	}
+	var xInv fr.Element
+	xInv.Inverse(&x)
+	folded := FoldFiber(e, zetaInv, &xInv, &beta, &kInv)
+
+	// ∑ₛ βˢ⋅pₛ(xᵏ)
+	var xk fr.Element
+	var betaS, expected, tmp Ext
+	xk.Exp(x, big.NewInt(k))
+	betaS.SetOne()
+	for s := range ps {
+		tmp.MulByElement(&ps[s][1], &xk).Add(&tmp, &ps[s][0]).Mul(&tmp, &betaS)
+		expected.Add(&expected, &tmp)
+		betaS.Mul(&betaS, &beta)
+	}
+	require.True(t, expected.Equal(&folded))
+}
@@ -0,0 +1,159 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package iop
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+
+	fr "github.com/consensys/gnark-crypto/field/goldilocks"
+	"github.com/consensys/gnark-crypto/field/goldilocks/poseidon2"
+)
+
+// ErrMerklePath is returned when a Merkle proof does not open a leaf.
+var ErrMerklePath = errors.New("merkle path proof is wrong")
+
+// DigestSize is the size in bytes of the nodes of the Merkle trees, half the
+// state of the Poseidon2 compression function.
+const DigestSize = 32
+
+// Digest is a node of a Merkle tree, in particular a commitment.
+type Digest [DigestSize]byte
+
+// MerkleProof is the opening of a leaf of a Merkle tree.
+type MerkleProof struct {
+
+	// Leaf is the content of the leaf, the evaluations of the committed
+	// codewords on the fiber of a query.
+	Leaf []fr.Element
+
+	// Path are the siblings of the nodes from the leaf to the root.
+	Path []Digest
+}
+
+// MerkleHasher hashes the nodes of the Merkle trees with the Poseidon2
+// compression function with the default parameters. A node is the
+// compression of its children, and a leaf is hashed with the Merkle-Damgård
+// construction over the compression function, from the zero digest, the leaf
+// being padded with zeros to a multiple of DigestSize bytes.
+type MerkleHasher struct {
+	perm *poseidon2.Permutation
+}
+
+// NewMerkleHasher returns a MerkleHasher.
+func NewMerkleHasher() MerkleHasher {
+	p := poseidon2.GetDefaultParameters()
+	if p.Width*fr.Bytes != 2*DigestSize {
+		panic("the state of the Poseidon2 permutation should contain two digests")
+	}
+	return MerkleHasher{perm: poseidon2.NewPermutation(p.Width, p.NbFullRounds, p.NbPartialRounds)}
+}
+
+// compress returns the parent of the nodes left and right. It returns an
+// error if they are not canonical encodings of field elements.
+func (h MerkleHasher) compress(left, right *Digest) (Digest, error) {
+	var res Digest
+	b, err := h.perm.Compress(left[:], right[:])
+	if err != nil {
+		return res, err
+	}
+	copy(res[:], b)
+	return res, nil
+}
+
+// hashLeaf returns the hash of the content of a leaf.
+func (h MerkleHasher) hashLeaf(leaf []fr.Element) (Digest, error) {
+	const blockLen = DigestSize / fr.Bytes
+	var state, block Digest
+	var err error
+	for len(leaf) > 0 {
+		block = Digest{}
+		n := min(len(leaf), blockLen)
+		for i := 0; i < n; i++ {
+			fr.BigEndian.PutElement((*[fr.Bytes]byte)(block[i*fr.Bytes:(i+1)*fr.Bytes]), leaf[i])
+		}
+		if state, err = h.compress(&state, &block); err != nil {
+			return state, err
+		}
+		leaf = leaf[n:]
+	}
+	return state, nil
+}
+
+// MerkleTree is a complete Merkle tree keeping all its nodes, to prove many
+// leaves.
+type MerkleTree struct {
+	leaves [][]fr.Element
+
+	// nodes[1] is the root, and the children of nodes[i] are nodes[2i] and
+	// nodes[2i+1], the hashes of the leaves being the last len(leaves) nodes
+	nodes []Digest
+}
+
+// NewMerkleTree returns the Merkle tree of the leaves, whose number must be a
+// power of 2.
+func NewMerkleTree(h MerkleHasher, leaves [][]fr.Element) *MerkleTree {
+	m := len(leaves)
+	t := &MerkleTree{leaves: leaves, nodes: make([]Digest, 2*m)}
+
+	// the nodes are canonical, so that hashing them cannot fail
+	parallel.Execute(m, func(start, end int) {
+		for j := start; j < end; j++ {
+			t.nodes[m+j], _ = h.hashLeaf(leaves[j])
+		}
+	})
+	for level := m / 2; level > 0; level /= 2 {
+		parallel.Execute(level, func(start, end int) {
+			for i := level + start; i < level+end; i++ {
+				t.nodes[i], _ = h.compress(&t.nodes[2*i], &t.nodes[2*i+1])
+			}
+		})
+	}
+	return t
+}
+
+// Root returns the root of the tree.
+func (t *MerkleTree) Root() Digest {
+	return t.nodes[1]
+}
+
+// Prove returns the opening of the leaf j.
+func (t *MerkleTree) Prove(j int) MerkleProof {
+	res := MerkleProof{Leaf: t.leaves[j]}
+	for i := len(t.leaves) + j; i > 1; i >>= 1 {
+		res.Path = append(res.Path, t.nodes[i^1])
+	}
+	return res
+}
+
+// Verify checks that proof opens the leaf j of the tree of nbLeaves leaves
+// whose root is root.
+func (h MerkleHasher) Verify(root *Digest, proof *MerkleProof, j, nbLeaves uint64) error {
+	if j >= nbLeaves || len(proof.Path) != bits.TrailingZeros64(nbLeaves) {
+		return ErrMerklePath
+	}
+	node, err := h.hashLeaf(proof.Leaf)
+	if err != nil {
+		return err
+	}
+	for i := range proof.Path {
+		if j&1 == 0 {
+			node, err = h.compress(&node, &proof.Path[i])
+		} else {
+			node, err = h.compress(&proof.Path[i], &node)
+		}
+		if err != nil {
+			return err
+		}
+		j >>= 1
+	}
+	if node != *root {
+		return ErrMerklePath
+	}
+	return nil
+}
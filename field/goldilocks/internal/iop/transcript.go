@@ -0,0 +1,32 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package iop
+
+import (
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// Challenge derives the challenge id from the transcript, as an element of
+// Ext.
+func Challenge(fs *fiatshamir.Transcript, id string) (Ext, error) {
+	b, err := fs.ComputeChallenge(id)
+	if err != nil {
+		return Ext{}, err
+	}
+	return extFromBytes(b), nil
+}
+
+// BindExt binds the elements of Ext to the challenge id.
+func BindExt(fs *fiatshamir.Transcript, id string, values ...Ext) error {
+	for i := range values {
+		for _, c := range Coordinates(&values[i]) {
+			if err := fs.Bind(id, c.Marshal()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -38,6 +38,9 @@ func main() {
 			generator.WithSIS(),
 			generator.WithPoseidon2(),
 			generator.WithExtensions(),
+			generator.WithFRI(),
+			generator.WithBasefold(),
+			generator.WithSTARK(),
 		); err != nil {
 			panic(err)
 		}
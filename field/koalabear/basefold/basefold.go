@@ -0,0 +1,630 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package basefold
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"math/bits"
+	"sync"
+
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+
+	fr "github.com/consensys/gnark-crypto/field/koalabear"
+	"github.com/consensys/gnark-crypto/field/koalabear/fft"
+	"github.com/consensys/gnark-crypto/field/koalabear/internal/iop"
+)
+
+var (
+	ErrInvalidParameters = errors.New("invalid Basefold parameters")
+	ErrNbVariables       = errors.New("the number of variables does not match the PCS")
+	ErrEmptyCommitment   = errors.New("a commitment must contain at least one polynomial")
+	ErrSumcheck          = errors.New("the sumcheck does not match the claimed values")
+	ErrFolding           = errors.New("the folding of the codeword is wrong")
+	ErrMerklePath        = iop.ErrMerklePath
+	ErrProofShape        = iop.ErrProofShape
+	ErrProofOfWork       = iop.ErrProofOfWork
+)
+
+// Ext is the extension of the field in which the challenges are drawn and
+// the polynomials are opened.
+type Ext = iop.Ext
+
+// Digest is a node of a Merkle tree, in particular a commitment.
+type Digest = iop.Digest
+
+// DigestSize is the size in bytes of a Digest.
+const DigestSize = iop.DigestSize
+
+// MerkleProof is the opening of a leaf of a Merkle tree.
+type MerkleProof = iop.MerkleProof
+
+// Parameters are the parameters of the Basefold protocol, trading the size of
+// the proofs against the time of the prover.
+type Parameters struct {
+
+	// Rate is the blow-up factor ρ = size_code_word/size_polynomial, a power
+	// of 2 larger than 1.
+	Rate int
+
+	// NbQueries is the number of queries of the verifier. Under the
+	// conjecture that each query adds log₂(ρ) bits of security, it should be
+	// ⌈(securityBits - GrindingBits)/log₂(ρ)⌉.
+	NbQueries int
+
+	// GrindingBits is the number of leading zero bits of the proof of work
+	// computed by the prover before the queries are sampled.
+	GrindingBits int
+}
+
+// DefaultParameters returns parameters targeting 100 bits of security: ρ = 4,
+// 42 queries and 16 bits of grinding.
+func DefaultParameters() Parameters {
+	return Parameters{
+		Rate:         4,
+		NbQueries:    42,
+		GrindingBits: 16,
+	}
+}
+
+// check returns an error if the parameters are not supported.
+func (p Parameters) check() error {
+	if p.Rate < 2 || bits.OnesCount(uint(p.Rate)) != 1 {
+		return fmt.Errorf("%w: rate %d is not a power of 2 larger than 1", ErrInvalidParameters, p.Rate)
+	}
+	if p.NbQueries < 1 {
+		return fmt.Errorf("%w: %d queries", ErrInvalidParameters, p.NbQueries)
+	}
+	if p.GrindingBits < 0 || p.GrindingBits > 32 {
+		return fmt.Errorf("%w: %d grinding bits", ErrInvalidParameters, p.GrindingBits)
+	}
+	return nil
+}
+
+// PCS is a commitment scheme for multilinear polynomials in a fixed number of
+// variables n, given by their 2ⁿ evaluations on the boolean hypercube.
+type PCS struct {
+
+	// h is the hash function of the Fiat Shamir transcript and of the proof
+	// of work. Its digests should have at least 2⋅iop.ExtDegree⋅fr.Bytes bytes.
+	h hash.Hash
+
+	// hasher hashes the nodes of the Merkle trees.
+	hasher iop.MerkleHasher
+
+	params Parameters
+
+	nbVariables int
+
+	// domain is the coset of size ρ⋅2ⁿ on which the polynomials are encoded.
+	domain *fft.Domain
+}
+
+// NewPCS returns a PCS for multilinear polynomials in nbVariables variables.
+// h is the hash function of the Fiat Shamir transcript.
+func NewPCS(nbVariables int, h hash.Hash, params Parameters) (*PCS, error) {
+	if err := params.check(); err != nil {
+		return nil, err
+	}
+	if nbVariables < 1 || nbVariables > 32 {
+		return nil, fmt.Errorf("%w: %d variables", ErrInvalidParameters, nbVariables)
+	}
+	if h.Size() < 2*iop.ExtDegree*fr.Bytes {
+		return nil, ErrInvalidParameters
+	}
+	n := uint64(params.Rate) << nbVariables
+	if _, err := fr.Generator(n); err != nil {
+		return nil, err
+	}
+	return &PCS{
+		h:           h,
+		hasher:      iop.NewMerkleHasher(),
+		params:      params,
+		nbVariables: nbVariables,
+		domain:      fft.NewDomain(n),
+	}, nil
+}
+
+// NbVariables returns the number of variables of the committed polynomials.
+func (pcs *PCS) NbVariables() int {
+	return pcs.nbVariables
+}
+
+// sampler returns the sampler of the queries in the domain.
+func (pcs *PCS) sampler() iop.QuerySampler {
+	return iop.QuerySampler{
+		H:            pcs.h,
+		GrindingBits: pcs.params.GrindingBits,
+		NbQueries:    pcs.params.NbQueries,
+		DomainSize:   pcs.domain.Cardinality,
+	}
+}
+
+// Commitment is the commitment to a batch of multilinear polynomials: the
+// root of the Merkle tree of the rows of their encodings, and their number.
+type Commitment struct {
+	Root          Digest
+	NbPolynomials int
+}
+
+// ProverData is the data of the prover on a batch of polynomials committed
+// to with Commit.
+type ProverData struct {
+	Commitment Commitment
+
+	polynomials [][]fr.Element
+	codewords   [][]fr.Element
+	tree        *iop.MerkleTree
+}
+
+// Commit commits to multilinear polynomials given by their evaluations on the
+// boolean hypercube, in the order of polynomial.MultiLin, with a single Merkle
+// tree. The leaves are the rows of their encodings on the fibers {x, -x} of
+// the first folding, so that a query opens a single Merkle path.
+func (pcs *PCS) Commit(polynomials ...[]fr.Element) (*ProverData, error) {
+	if len(polynomials) == 0 {
+		return nil, ErrEmptyCommitment
+	}
+	for _, p := range polynomials {
+		if len(p) != 1<<pcs.nbVariables {
+			return nil, ErrNbVariables
+		}
+	}
+	res := &ProverData{
+		Commitment:  Commitment{NbPolynomials: len(polynomials)},
+		polynomials: polynomials,
+		codewords:   make([][]fr.Element, len(polynomials)),
+	}
+	parallel.Execute(len(polynomials), func(start, end int) {
+		for i := start; i < end; i++ {
+			res.codewords[i] = pcs.encode(polynomials[i])
+		}
+	}, 1)
+	res.tree = iop.NewMerkleTree(pcs.hasher, iop.FiberLeaves(res.codewords, 2))
+	res.Commitment.Root = res.tree.Root()
+	return res, nil
+}
+
+// encode returns the evaluations on the domain, in natural order, of the
+// univariate polynomial P = ∑ⱼ aⱼ⋅Xʲ encoding the multilinear polynomial
+// whose evaluations on the hypercube are p. aⱼ is the coefficient of the
+// monomial ∏ Xₖ over the variables Xₖ such that the bit k-1 of j is set, so
+// that folding P with r as in FRI binds the first variable to r.
+func (pcs *PCS) encode(p []fr.Element) []fr.Element {
+	res := make([]fr.Element, pcs.domain.Cardinality)
+	copy(res, p)
+	coefficients := res[:len(p)]
+	moebius(coefficients)
+
+	// the coefficients are indexed like the evaluations, with the first
+	// variable as the most significant bit
+	fft.BitReverse(coefficients)
+	pcs.domain.FFT(res, fft.DIF, fft.OnCoset())
+	fft.BitReverse(res)
+	return res
+}
+
+// moebius transforms in place the evaluations of a multilinear polynomial on
+// the hypercube into its coefficients in the monomial basis, indexed the same
+// way.
+func moebius(t []fr.Element) {
+	for h := len(t) / 2; h > 0; h /= 2 {
+		for start := 0; start < len(t); start += 2 * h {
+			for i := start; i < start+h; i++ {
+				t[i+h].Sub(&t[i+h], &t[i])
+			}
+		}
+	}
+}
+
+// OpeningProof is the proof of the evaluations of a batch of polynomials at a
+// point.
+//
+// implements io.ReaderFrom and io.WriterTo
+type OpeningProof struct {
+
+	// ClaimedValues[i] is the evaluation of the i-th polynomial at the point.
+	ClaimedValues []Ext
+
+	// RoundPolynomials[k] are the evaluations at 0 and 2 of the polynomial of
+	// degree 2 sent in the k-th round of the sumcheck, its evaluation at 1
+	// being deduced from the claim.
+	RoundPolynomials [][2]Ext
+
+	// Roots[k] is the Merkle root of the codeword folded k+1 times.
+	Roots []Digest
+
+	// Final is the value of the fully folded polynomial, the constant
+	// codeword.
+	Final Ext
+
+	// Nonce is the proof of work of the prover.
+	Nonce uint64
+
+	// Openings[q][k] is the opening of the fiber of the q-th query in the
+	// codeword folded k times, the openings of the rows of the commitment
+	// being Openings[q][0].
+	Openings [][]MerkleProof
+}
+
+// ids returns the identifiers of the challenges of the transcript: the
+// challenge of the batching, one challenge per round of the sumcheck, and
+// the challenges of the proof of work and of the queries.
+func (pcs *PCS) ids() []string {
+	ids := make([]string, pcs.nbVariables+3)
+	ids[0] = "alpha"
+	for k := 0; k < pcs.nbVariables; k++ {
+		ids[k+1] = fmt.Sprintf("r%d", k)
+	}
+	ids[pcs.nbVariables+1] = "grinding"
+	ids[pcs.nbVariables+2] = "queries"
+	return ids
+}
+
+// transcript returns the Fiat Shamir transcript of an opening, after binding
+// the statement: dataTranscript, the commitment, the point and the claimed
+// values. It returns the challenge α of the batching.
+func (pcs *PCS) transcript(commitment Commitment, point, claimedValues []Ext, dataTranscript [][]byte) (*fiatshamir.Transcript, Ext, error) {
+	fs := fiatshamir.NewTranscript(pcs.h, pcs.ids()...)
+	for _, data := range dataTranscript {
+		if err := fs.Bind("alpha", data); err != nil {
+			return nil, Ext{}, err
+		}
+	}
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(commitment.NbPolynomials))
+	if err := fs.Bind("alpha", commitment.Root[:]); err != nil {
+		return nil, Ext{}, err
+	}
+	if err := fs.Bind("alpha", buf[:]); err != nil {
+		return nil, Ext{}, err
+	}
+	if err := iop.BindExt(fs, "alpha", point...); err != nil {
+		return nil, Ext{}, err
+	}
+	if err := iop.BindExt(fs, "alpha", claimedValues...); err != nil {
+		return nil, Ext{}, err
+	}
+	alpha, err := iop.Challenge(fs, "alpha")
+	return fs, alpha, err
+}
+
+// Open proves the evaluations of the committed polynomials fᵢ at point. The
+// point and dataTranscript are bound to the Fiat Shamir transcript.
+//
+// The polynomials are batched into g = ∑ αⁱ⋅fᵢ, and the sumcheck proves
+// ∑_b g(b)⋅eq(point, b) = ∑ αⁱ⋅fᵢ(point) over the hypercube. Its k-th
+// challenge rₖ binds the k-th variable of the tables of g and eq, and folds
+// the codeword of g, committed to for the next round, so that after the last
+// round the codeword is the constant g(r).
+func (pcs *PCS) Open(data *ProverData, point []Ext, dataTranscript ...[]byte) (OpeningProof, error) {
+	var proof OpeningProof
+	if len(point) != pcs.nbVariables {
+		return proof, ErrNbVariables
+	}
+
+	// claimed values
+	eq := eqTable(point)
+	proof.ClaimedValues = make([]Ext, len(data.polynomials))
+	parallel.Execute(len(data.polynomials), func(start, end int) {
+		var t Ext
+		for i := start; i < end; i++ {
+			for j := range data.polynomials[i] {
+				t.MulByElement(&eq[j], &data.polynomials[i][j])
+				proof.ClaimedValues[i].Add(&proof.ClaimedValues[i], &t)
+			}
+		}
+	})
+	fs, alpha, err := pcs.transcript(data.Commitment, point, proof.ClaimedValues, dataTranscript)
+	if err != nil {
+		return proof, err
+	}
+	ids := pcs.ids()
+
+	// evaluations and codeword of g
+	alphas := powers(alpha, len(data.polynomials))
+	table := combine(data.polynomials, alphas)
+	codeword := combine(data.codewords, alphas)
+
+	// sumcheck, folding the codeword
+	shiftInv, gInv := pcs.domain.FrMultiplicativeGenInv, pcs.domain.GeneratorInv
+	trees := make([]*iop.MerkleTree, pcs.nbVariables)
+	trees[0] = data.tree
+	for k := 0; k < pcs.nbVariables; k++ {
+		if k > 0 {
+			trees[k] = iop.NewMerkleTree(pcs.hasher, iop.ExtFiberLeaves(codeword, 2))
+			root := trees[k].Root()
+			proof.Roots = append(proof.Roots, root)
+			if err = fs.Bind(ids[k+1], root[:]); err != nil {
+				return proof, err
+			}
+		}
+		s := roundPolynomial(table, eq)
+		proof.RoundPolynomials = append(proof.RoundPolynomials, s)
+		if err = iop.BindExt(fs, ids[k+1], s[:]...); err != nil {
+			return proof, err
+		}
+		r, err := iop.Challenge(fs, ids[k+1])
+		if err != nil {
+			return proof, err
+		}
+		table, eq = foldTable(table, r), foldTable(eq, r)
+		if codeword, err = iop.FoldEvaluations(codeword, 2, shiftInv, gInv, r); err != nil {
+			return proof, err
+		}
+		shiftInv.Square(&shiftInv)
+		gInv.Square(&gInv)
+	}
+	proof.Final = table[0]
+
+	// query phase: derive the queries after the proof of work
+	positions, err := pcs.sampler().Positions(fs, ids[len(ids)-2], ids[len(ids)-1], []Ext{proof.Final}, &proof.Nonce, true)
+	if err != nil {
+		return proof, err
+	}
+	proof.Openings = make([][]MerkleProof, len(positions))
+	for q, pos := range positions {
+		proof.Openings[q] = make([]MerkleProof, pcs.nbVariables)
+		n := pcs.domain.Cardinality
+		for k := range trees {
+			m := n / 2
+			j := pos % m
+			proof.Openings[q][k] = trees[k].Prove(int(j))
+			pos, n = j, m
+		}
+	}
+	return proof, nil
+}
+
+// Verify verifies a proof of Open against the commitment, the point and
+// dataTranscript. The claimed evaluations are proof.ClaimedValues.
+func (pcs *PCS) Verify(commitment Commitment, point []Ext, proof *OpeningProof, dataTranscript ...[]byte) error {
+	n := pcs.nbVariables
+	if len(point) != n {
+		return ErrNbVariables
+	}
+	if commitment.NbPolynomials < 1 {
+		return ErrEmptyCommitment
+	}
+	if len(proof.ClaimedValues) != commitment.NbPolynomials ||
+		len(proof.RoundPolynomials) != n ||
+		len(proof.Roots) != n-1 ||
+		len(proof.Openings) != pcs.params.NbQueries {
+		return ErrProofShape
+	}
+	fs, alpha, err := pcs.transcript(commitment, point, proof.ClaimedValues, dataTranscript)
+	if err != nil {
+		return err
+	}
+	ids := pcs.ids()
+	alphas := powers(alpha, commitment.NbPolynomials)
+
+	// sumcheck
+	var claim, t Ext
+	for i := range alphas {
+		t.Mul(&alphas[i], &proof.ClaimedValues[i])
+		claim.Add(&claim, &t)
+	}
+	r := make([]Ext, n)
+	for k := range r {
+		if k > 0 {
+			if err = fs.Bind(ids[k+1], proof.Roots[k-1][:]); err != nil {
+				return err
+			}
+		}
+		if err = iop.BindExt(fs, ids[k+1], proof.RoundPolynomials[k][:]...); err != nil {
+			return err
+		}
+		if r[k], err = iop.Challenge(fs, ids[k+1]); err != nil {
+			return err
+		}
+		claim = evalRoundPolynomial(&proof.RoundPolynomials[k], &claim, &r[k])
+	}
+
+	// the last claim is g(r)⋅eq(point, r), g(r) being the constant codeword
+	e := eval(point, r)
+	if t.Mul(&proof.Final, &e); !t.Equal(&claim) {
+		return ErrSumcheck
+	}
+
+	nonce := proof.Nonce
+	positions, err := pcs.sampler().Positions(fs, ids[len(ids)-2], ids[len(ids)-1], []Ext{proof.Final}, &nonce, false)
+	if err != nil {
+		return err
+	}
+	zetaInv, kInv, err := iop.FoldParameters(2)
+	if err != nil {
+		return err
+	}
+
+	for q, pos := range positions {
+		if len(proof.Openings[q]) != n {
+			return ErrProofShape
+		}
+
+		// shift⋅⟨g⟩ domain of the current codeword, of size size
+		shift, g := pcs.domain.FrMultiplicativeGen, pcs.domain.Generator
+		size := pcs.domain.Cardinality
+		var folded Ext
+		for k := 0; k < n; k++ {
+			m := size / 2
+			j, slot := pos%m, pos/m
+			opening := &proof.Openings[q][k]
+
+			var e []Ext
+			if k == 0 {
+				if len(opening.Leaf) != 2*commitment.NbPolynomials {
+					return ErrProofShape
+				}
+				if err = pcs.hasher.Verify(&commitment.Root, opening, j, m); err != nil {
+					return err
+				}
+				e = combineRows(opening.Leaf, alphas)
+			} else {
+				if err = pcs.hasher.Verify(&proof.Roots[k-1], opening, j, m); err != nil {
+					return err
+				}
+				if e, err = iop.ParseExtLeaf(opening.Leaf, 2); err != nil {
+					return err
+				}
+
+				// correctness of the folding of the previous codeword
+				if !e[slot].Equal(&folded) {
+					return ErrFolding
+				}
+			}
+
+			// fold the fiber of shift⋅gʲ
+			var xInv fr.Element
+			xInv.Exp(g, big.NewInt(int64(j))).Mul(&xInv, &shift).Inverse(&xInv)
+			folded = iop.FoldFiber(e, zetaInv, &xInv, &r[k], &kInv)
+
+			shift.Square(&shift)
+			g.Square(&g)
+			pos, size = j, m
+		}
+
+		// the fully folded codeword is the constant Final
+		if !folded.Equal(&proof.Final) {
+			return ErrFolding
+		}
+	}
+
+	return nil
+}
+
+// powers returns 1, α, …, αⁿ⁻¹.
+func powers(alpha Ext, n int) []Ext {
+	res := make([]Ext, n)
+	res[0].SetOne()
+	for i := 1; i < n; i++ {
+		res[i].Mul(&res[i-1], &alpha)
+	}
+	return res
+}
+
+// combine returns ∑ αᵢ⋅vᵢ.
+func combine(vectors [][]fr.Element, alphas []Ext) []Ext {
+	res := make([]Ext, len(vectors[0]))
+	parallel.Execute(len(res), func(start, end int) {
+		var t Ext
+		for j := start; j < end; j++ {
+			for i := range vectors {
+				t.MulByElement(&alphas[i], &vectors[i][j])
+				res[j].Add(&res[j], &t)
+			}
+		}
+	})
+	return res
+}
+
+// combineRows returns the values of ∑ αᵢ⋅vᵢ on the fiber of a leaf of
+// fiberLeaves.
+func combineRows(leaf []fr.Element, alphas []Ext) []Ext {
+	res := make([]Ext, len(leaf)/len(alphas))
+	var t Ext
+	for s := range res {
+		for i := range alphas {
+			t.MulByElement(&alphas[i], &leaf[s*len(alphas)+i])
+			res[s].Add(&res[s], &t)
+		}
+	}
+	return res
+}
+
+// eqTable returns the evaluations of eq(point, ·) on the hypercube, in the
+// order of polynomial.MultiLin, eq(z, b) being ∏ (zₖ⋅bₖ + (1-zₖ)⋅(1-bₖ)).
+func eqTable(point []Ext) []Ext {
+	res := make([]Ext, 1, 1<<len(point))
+	res[0].SetOne()
+	for k := range point {
+		res = res[:2*len(res)]
+		for i := len(res)/2 - 1; i >= 0; i-- {
+			res[2*i+1].Mul(&res[i], &point[k])
+			res[2*i].Sub(&res[i], &res[2*i+1])
+		}
+	}
+	return res
+}
+
+// eval returns eq(z, r).
+func eval(z, r []Ext) Ext {
+	var res, t, one Ext
+	res.SetOne()
+	one.SetOne()
+
+	// zₖ⋅rₖ + (1-zₖ)⋅(1-rₖ) = 1 - zₖ - rₖ + 2⋅zₖ⋅rₖ
+	for k := range z {
+		t.Mul(&z[k], &r[k]).Double(&t).Sub(&t, &z[k]).Sub(&t, &r[k]).Add(&t, &one)
+		res.Mul(&res, &t)
+	}
+	return res
+}
+
+// roundPolynomial returns the evaluations at 0 and 2 of
+// s(X) = ∑_b g(X, b)⋅eq(X, b), where g and eq are given by their tables.
+func roundPolynomial(g, eq []Ext) [2]Ext {
+	h := len(g) / 2
+	var res [2]Ext
+	var lock sync.Mutex
+	parallel.Execute(h, func(start, end int) {
+		var s0, s2, t, g2, eq2 Ext
+		for i := start; i < end; i++ {
+			t.Mul(&g[i], &eq[i])
+			s0.Add(&s0, &t)
+
+			// g(2, b) = 2⋅g(1, b) - g(0, b)
+			g2.Double(&g[h+i]).Sub(&g2, &g[i])
+			eq2.Double(&eq[h+i]).Sub(&eq2, &eq[i])
+			t.Mul(&g2, &eq2)
+			s2.Add(&s2, &t)
+		}
+		lock.Lock()
+		res[0].Add(&res[0], &s0)
+		res[1].Add(&res[1], &s2)
+		lock.Unlock()
+	})
+	return res
+}
+
+// evalRoundPolynomial returns s(r), s being the polynomial of degree 2 such
+// that s(0) = values[0], s(2) = values[1] and s(0) + s(1) = claim.
+func evalRoundPolynomial(values *[2]Ext, claim, r *Ext) Ext {
+	var s1, one, two, rm1, rm2, t, res Ext
+	s1.Sub(claim, &values[0])
+	one.SetOne()
+	two.Double(&one)
+	rm1.Sub(r, &one)
+	rm2.Sub(r, &two)
+
+	// Lagrange interpolation on {0, 1, 2}:
+	// s(r) = s(0)⋅(r-1)(r-2)/2 - s(1)⋅r(r-2) + s(2)⋅r(r-1)/2
+	res.Mul(&rm1, &rm2).Mul(&res, &values[0])
+	t.Mul(r, &rm1).Mul(&t, &values[1])
+	res.Add(&res, &t)
+	res.Halve()
+	t.Mul(r, &rm2).Mul(&t, &s1)
+	res.Sub(&res, &t)
+	return res
+}
+
+// foldTable binds the first variable of the table of a multilinear
+// polynomial to r, in place, and returns the folded table.
+func foldTable(t []Ext, r Ext) []Ext {
+	h := len(t) / 2
+	parallel.Execute(h, func(start, end int) {
+		var d Ext
+		for i := start; i < end; i++ {
+			d.Sub(&t[h+i], &t[i]).Mul(&d, &r)
+			t[i].Add(&t[i], &d)
+		}
+	})
+	return t[:h]
+}
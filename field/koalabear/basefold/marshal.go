@@ -0,0 +1,65 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package basefold
+
+import (
+	"io"
+
+	"github.com/consensys/gnark-crypto/field/koalabear/internal/iop"
+)
+
+// WriteTo writes the binary encoding of the proof.
+func (proof *OpeningProof) WriteTo(w io.Writer) (int64, error) {
+	enc := iop.NewEncoder(w)
+	enc.WriteExt(proof.ClaimedValues)
+	roundPolynomials := make([]Ext, 0, 2*len(proof.RoundPolynomials))
+	for k := range proof.RoundPolynomials {
+		roundPolynomials = append(roundPolynomials, proof.RoundPolynomials[k][:]...)
+	}
+	enc.WriteExt(roundPolynomials)
+	enc.WriteUint32(len(proof.Roots))
+	for i := range proof.Roots {
+		enc.Write(proof.Roots[i][:])
+	}
+	enc.WriteExt([]Ext{proof.Final})
+	enc.Write(proof.Nonce)
+	enc.WriteOpenings(proof.Openings)
+	return enc.N(), enc.Err()
+}
+
+// ReadFrom decodes a proof written by WriteTo.
+func (proof *OpeningProof) ReadFrom(r io.Reader) (int64, error) {
+	dec := iop.NewDecoder(r)
+	proof.ClaimedValues = dec.ReadExt()
+	roundPolynomials := dec.ReadExt()
+	if len(roundPolynomials)%2 != 0 {
+		return dec.N(), ErrProofShape
+	}
+	proof.RoundPolynomials = nil
+	if len(roundPolynomials) > 0 {
+		proof.RoundPolynomials = make([][2]Ext, len(roundPolynomials)/2)
+	}
+	for k := range proof.RoundPolynomials {
+		proof.RoundPolynomials[k] = [2]Ext{roundPolynomials[2*k], roundPolynomials[2*k+1]}
+	}
+	proof.Roots = nil
+	if nbRoots := dec.ReadUint32(); nbRoots > 0 {
+		proof.Roots = make([]Digest, nbRoots)
+	}
+	for i := range proof.Roots {
+		dec.ReadFull(proof.Roots[i][:])
+	}
+	final := dec.ReadExt()
+	if dec.Err() == nil && len(final) != 1 {
+		return dec.N(), ErrProofShape
+	}
+	if dec.Err() == nil {
+		proof.Final = final[0]
+	}
+	proof.Nonce = dec.ReadUint64()
+	proof.Openings = dec.ReadOpenings()
+	return dec.N(), dec.Err()
+}
@@ -0,0 +1,18 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package fri provides a polynomial commitment scheme over koalabear,
+// based on FRI.
+//
+// Polynomials are committed to by batches, with the Poseidon2 Merkle tree of
+// their evaluations on a coset of the domain. The openings at points of the
+// extension Ext, out of the domain, are proven with a single FRI on the DEEP
+// combination of all the polynomials, whose challenges are drawn in Ext.
+//
+// See [DEEP-FRI] and [ethSTARK] for the details.
+//
+// [DEEP-FRI]: https://eprint.iacr.org/2019/336.pdf
+// [ethSTARK]: https://eprint.iacr.org/2021/582.pdf
+package fri
@@ -0,0 +1,200 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package iop
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	fr "github.com/consensys/gnark-crypto/field/koalabear"
+)
+
+// maxSliceLen bounds the lengths read by a Decoder, to avoid allocating
+// arbitrary amounts of memory on malformed inputs.
+const maxSliceLen = 1 << 24
+
+var errSliceTooLong = errors.New("encoded slice too long")
+
+// ErrProofShape is returned when a proof does not have the expected shape.
+var ErrProofShape = errors.New("the proof does not have the expected shape")
+
+// Encoder writes big-endian encodings, and keeps the first error.
+type Encoder struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+// NewEncoder returns an Encoder writing to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// N returns the number of bytes written.
+func (enc *Encoder) N() int64 {
+	return enc.n
+}
+
+// Err returns the first error encountered while writing.
+func (enc *Encoder) Err() error {
+	return enc.err
+}
+
+// Write writes the big-endian encoding of v, of fixed size.
+func (enc *Encoder) Write(v interface{}) {
+	if enc.err != nil {
+		return
+	}
+	if enc.err = binary.Write(enc.w, binary.BigEndian, v); enc.err == nil {
+		enc.n += int64(binary.Size(v))
+	}
+}
+
+// WriteUint32 writes v as a uint32.
+func (enc *Encoder) WriteUint32(v int) {
+	enc.Write(uint32(v))
+}
+
+// WriteElements writes the length of v followed by its elements.
+func (enc *Encoder) WriteElements(v []fr.Element) {
+	if enc.err != nil {
+		return
+	}
+	vector := fr.Vector(v)
+	m, err := vector.WriteTo(enc.w)
+	enc.n += m
+	enc.err = err
+}
+
+// WriteExt writes the coordinates of the elements of v.
+func (enc *Encoder) WriteExt(v []Ext) {
+	c := make([]fr.Element, 0, ExtDegree*len(v))
+	for i := range v {
+		ci := Coordinates(&v[i])
+		c = append(c, ci[:]...)
+	}
+	enc.WriteElements(c)
+}
+
+// WriteOpenings writes the Merkle proofs openings[q][i].
+func (enc *Encoder) WriteOpenings(openings [][]MerkleProof) {
+	enc.WriteUint32(len(openings))
+	for _, o := range openings {
+		enc.WriteUint32(len(o))
+		for i := range o {
+			enc.WriteElements(o[i].Leaf)
+			enc.WriteUint32(len(o[i].Path))
+			for j := range o[i].Path {
+				enc.Write(o[i].Path[j][:])
+			}
+		}
+	}
+}
+
+// Decoder reads big-endian encodings, and keeps the first error.
+type Decoder struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+// NewDecoder returns a Decoder reading from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// N returns the number of bytes read.
+func (dec *Decoder) N() int64 {
+	return dec.n
+}
+
+// Err returns the first error encountered while reading.
+func (dec *Decoder) Err() error {
+	return dec.err
+}
+
+// ReadFull fills b.
+func (dec *Decoder) ReadFull(b []byte) {
+	if dec.err != nil {
+		return
+	}
+	m, err := io.ReadFull(dec.r, b)
+	dec.n += int64(m)
+	dec.err = err
+}
+
+// ReadUint32 reads a length written by WriteUint32.
+func (dec *Decoder) ReadUint32() int {
+	var buf [4]byte
+	dec.ReadFull(buf[:])
+	if dec.err != nil {
+		return 0
+	}
+	v := binary.BigEndian.Uint32(buf[:])
+	if v > maxSliceLen {
+		dec.err = errSliceTooLong
+		return 0
+	}
+	return int(v)
+}
+
+// ReadUint64 reads a uint64.
+func (dec *Decoder) ReadUint64() uint64 {
+	var buf [8]byte
+	dec.ReadFull(buf[:])
+	return binary.BigEndian.Uint64(buf[:])
+}
+
+// ReadElements reads a vector written by WriteElements.
+func (dec *Decoder) ReadElements() []fr.Element {
+	if dec.err != nil {
+		return nil
+	}
+	var vector fr.Vector
+	m, err := vector.ReadFrom(dec.r)
+	dec.n += m
+	dec.err = err
+	return vector
+}
+
+// ReadExt reads elements of Ext written by WriteExt.
+func (dec *Decoder) ReadExt() []Ext {
+	c := dec.ReadElements()
+	if dec.err != nil {
+		return nil
+	}
+	if len(c)%ExtDegree != 0 {
+		dec.err = ErrProofShape
+		return nil
+	}
+	res := make([]Ext, len(c)/ExtDegree)
+	for i := range res {
+		res[i] = FromCoordinates(c[i*ExtDegree : (i+1)*ExtDegree])
+	}
+	return res
+}
+
+// ReadOpenings reads Merkle proofs written by WriteOpenings.
+func (dec *Decoder) ReadOpenings() [][]MerkleProof {
+	res := make([][]MerkleProof, dec.ReadUint32())
+	for q := range res {
+		if nbProofs := dec.ReadUint32(); nbProofs > 0 {
+			res[q] = make([]MerkleProof, nbProofs)
+		}
+		for i := range res[q] {
+			res[q][i].Leaf = dec.ReadElements()
+			res[q][i].Path = make([]Digest, dec.ReadUint32())
+			for j := range res[q][i].Path {
+				dec.ReadFull(res[q][i].Path[j][:])
+			}
+			if dec.err != nil {
+				return nil
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,289 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package stark
+
+import (
+	"fmt"
+
+	fr "github.com/consensys/gnark-crypto/field/koalabear"
+	"github.com/consensys/gnark-crypto/field/koalabear/internal/iop"
+)
+
+// AIR is an algebraic intermediate representation: the columns of a trace
+// and the constraints on them.
+type AIR struct {
+	columns     []string
+	transitions []Expression
+	boundaries  []boundary
+}
+
+// boundary is the constraint column[row] = value, the row being counted from
+// the end of the trace when it is negative.
+type boundary struct {
+	column int
+	row    int
+	value  fr.Element
+}
+
+// Column is a column of the trace of an AIR.
+type Column struct {
+	id int
+}
+
+// NewAIR returns an AIR without columns nor constraints.
+func NewAIR() *AIR {
+	return &AIR{}
+}
+
+// NewColumn adds a column to the trace. The columns of the trace given to the
+// prover are in the order of their declaration.
+func (air *AIR) NewColumn(name string) Column {
+	air.columns = append(air.columns, name)
+	return Column{id: len(air.columns) - 1}
+}
+
+// NbColumns returns the number of columns of the trace.
+func (air *AIR) NbColumns() int {
+	return len(air.columns)
+}
+
+// AssertTransition adds the constraint e = 0 on every row of the trace but
+// the last, the next row of e being the following one.
+func (air *AIR) AssertTransition(e Expression) {
+	air.transitions = append(air.transitions, e)
+}
+
+// AssertBoundary adds the constraint that the value of the column c on the
+// row is value. A negative row is counted from the end of the trace, -1 being
+// the last row.
+func (air *AIR) AssertBoundary(c Column, row int, value fr.Element) {
+	air.boundaries = append(air.boundaries, boundary{column: c.id, row: row, value: value})
+}
+
+// Curr returns the value of the column on the current row.
+func (c Column) Curr() Expression {
+	return variable{column: c.id}
+}
+
+// Next returns the value of the column on the next row.
+func (c Column) Next() Expression {
+	return variable{column: c.id, next: true}
+}
+
+// Expression is a polynomial in the values of the columns of a trace on a
+// row, the current one, and on the next one.
+type Expression interface {
+
+	// Degree returns the total degree of the expression.
+	Degree() int
+
+	// evaluate returns the value of the expression on a row.
+	evaluate(curr, next []fr.Element) fr.Element
+
+	// evaluateExt returns the value of the expression at values of the
+	// columns in Ext.
+	evaluateExt(curr, next []Ext) Ext
+
+	// maxColumn returns the largest column in the expression, or -1.
+	maxColumn() int
+}
+
+// Constant returns the expression of value v.
+func Constant(v fr.Element) Expression {
+	return constant{value: v}
+}
+
+// Add returns the sum of the terms.
+func Add(a, b Expression, terms ...Expression) Expression {
+	return sum{terms: append([]Expression{a, b}, terms...)}
+}
+
+// Sub returns a - b.
+func Sub(a, b Expression) Expression {
+	return difference{a: a, b: b}
+}
+
+// Mul returns the product of the factors.
+func Mul(a, b Expression, factors ...Expression) Expression {
+	return product{factors: append([]Expression{a, b}, factors...)}
+}
+
+type constant struct {
+	value fr.Element
+}
+
+func (c constant) Degree() int {
+	return 0
+}
+
+func (c constant) evaluate(_, _ []fr.Element) fr.Element {
+	return c.value
+}
+
+func (c constant) evaluateExt(_, _ []Ext) Ext {
+	return iop.Embed(&c.value)
+}
+
+func (c constant) maxColumn() int {
+	return -1
+}
+
+type variable struct {
+	column int
+	next   bool
+}
+
+func (v variable) Degree() int {
+	return 1
+}
+
+func (v variable) evaluate(curr, next []fr.Element) fr.Element {
+	if v.next {
+		return next[v.column]
+	}
+	return curr[v.column]
+}
+
+func (v variable) evaluateExt(curr, next []Ext) Ext {
+	if v.next {
+		return next[v.column]
+	}
+	return curr[v.column]
+}
+
+func (v variable) maxColumn() int {
+	return v.column
+}
+
+type sum struct {
+	terms []Expression
+}
+
+func (s sum) Degree() int {
+	res := 0
+	for _, t := range s.terms {
+		res = max(res, t.Degree())
+	}
+	return res
+}
+
+func (s sum) evaluate(curr, next []fr.Element) fr.Element {
+	var res fr.Element
+	for _, t := range s.terms {
+		v := t.evaluate(curr, next)
+		res.Add(&res, &v)
+	}
+	return res
+}
+
+func (s sum) evaluateExt(curr, next []Ext) Ext {
+	var res Ext
+	for _, t := range s.terms {
+		v := t.evaluateExt(curr, next)
+		res.Add(&res, &v)
+	}
+	return res
+}
+
+func (s sum) maxColumn() int {
+	res := -1
+	for _, t := range s.terms {
+		res = max(res, t.maxColumn())
+	}
+	return res
+}
+
+type difference struct {
+	a, b Expression
+}
+
+func (d difference) Degree() int {
+	return max(d.a.Degree(), d.b.Degree())
+}
+
+func (d difference) evaluate(curr, next []fr.Element) fr.Element {
+	a, b := d.a.evaluate(curr, next), d.b.evaluate(curr, next)
+	a.Sub(&a, &b)
+	return a
+}
+
+func (d difference) evaluateExt(curr, next []Ext) Ext {
+	a, b := d.a.evaluateExt(curr, next), d.b.evaluateExt(curr, next)
+	a.Sub(&a, &b)
+	return a
+}
+
+func (d difference) maxColumn() int {
+	return max(d.a.maxColumn(), d.b.maxColumn())
+}
+
+type product struct {
+	factors []Expression
+}
+
+func (p product) Degree() int {
+	res := 0
+	for _, f := range p.factors {
+		res += f.Degree()
+	}
+	return res
+}
+
+func (p product) evaluate(curr, next []fr.Element) fr.Element {
+	var res fr.Element
+	res.SetOne()
+	for _, f := range p.factors {
+		v := f.evaluate(curr, next)
+		res.Mul(&res, &v)
+	}
+	return res
+}
+
+func (p product) evaluateExt(curr, next []Ext) Ext {
+	var res Ext
+	res.SetOne()
+	for _, f := range p.factors {
+		v := f.evaluateExt(curr, next)
+		res.Mul(&res, &v)
+	}
+	return res
+}
+
+func (p product) maxColumn() int {
+	res := -1
+	for _, f := range p.factors {
+		res = max(res, f.maxColumn())
+	}
+	return res
+}
+
+// check returns an error if the constraints refer to columns which are not
+// in the AIR, or if it has no constraint.
+func (air *AIR) check() error {
+	if len(air.columns) == 0 || len(air.transitions)+len(air.boundaries) == 0 {
+		return ErrEmptyAIR
+	}
+	for i, e := range air.transitions {
+		if e.maxColumn() >= len(air.columns) {
+			return fmt.Errorf("%w: transition constraint %d", ErrUnknownColumn, i)
+		}
+	}
+	for i, b := range air.boundaries {
+		if b.column >= len(air.columns) {
+			return fmt.Errorf("%w: boundary constraint %d", ErrUnknownColumn, i)
+		}
+	}
+	return nil
+}
+
+// maxDegree returns the largest degree of the transition constraints.
+func (air *AIR) maxDegree() int {
+	res := 0
+	for _, e := range air.transitions {
+		res = max(res, e.Degree())
+	}
+	return res
+}
@@ -0,0 +1,26 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package stark provides a STARK prover and verifier over koalabear, for
+// computations described by an algebraic intermediate representation (AIR).
+//
+// An AIR declares the columns of an execution trace of 2ⁿ rows, and the
+// constraints that the trace satisfies: transition constraints, polynomial
+// expressions in the values of the columns on a row and on the next one,
+// which vanish on every row but the last, and boundary constraints, which fix
+// the value of a column on a given row.
+//
+// The prover commits to the interpolations of the columns on the domain of
+// the trace with the FRI polynomial commitment scheme, and to the quotient of
+// a random combination of the constraints by their vanishing polynomials,
+// computed on a low-degree extension of the trace. The verifier checks the
+// quotient at a random point ζ of the extension out of the domain, from the
+// evaluations of the columns at ζ and ζ⋅ω, which are proven together with the
+// evaluations of the quotient by a single batched DEEP-FRI opening.
+//
+// See [ethSTARK] for the details.
+//
+// [ethSTARK]: https://eprint.iacr.org/2021/582.pdf
+package stark
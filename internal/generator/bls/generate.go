@@ -0,0 +1,41 @@
+package bls
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// bls
+	conf.Package = "bls"
+	baseDir = filepath.Join(baseDir, conf.Package)
+
+	data := struct {
+		config.Curve
+		DST string // domain separation tag used to hash messages to G2
+	}{conf, dst(conf)}
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "bls.go"), Templates: []string{"bls.go.tmpl"}},
+		{File: filepath.Join(baseDir, "bls_test.go"), Templates: []string{"bls.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal_test.go"), Templates: []string{"marshal.test.go.tmpl"}},
+	}
+	return bgen.Generate(data, conf.Package, "./bls/template", entries...)
+
+}
+
+// dst returns the ciphersuite identifier, built as in the IETF BLS signature
+// draft: BLS_SIG_ ∥ hash-to-curve suite ∥ _NUL_
+func dst(conf config.Curve) string {
+	mapping := "SVDW"
+	if _, ok := conf.HashE2.(*config.HashSuiteSswu); ok {
+		mapping = "SSWU"
+	}
+	curve := strings.ToUpper(strings.ReplaceAll(conf.Name, "-", ""))
+	return "BLS_SIG_" + curve + "G2_XMD:SHA-256_" + mapping + "_RO_NUL_"
+}
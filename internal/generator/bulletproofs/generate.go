@@ -0,0 +1,21 @@
+package bulletproofs
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// range proofs on G1
+	conf.Package = "bulletproofs"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "bulletproofs.go"), Templates: []string{"bulletproofs.go.tmpl"}},
+		{File: filepath.Join(baseDir, "inner_product.go"), Templates: []string{"inner_product.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "bulletproofs_test.go"), Templates: []string{"bulletproofs.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./bulletproofs/template/", entries...)
+}
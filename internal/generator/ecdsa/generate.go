@@ -18,6 +18,35 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "ecdsa_test.go"), Templates: []string{"ecdsa.test.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal_test.go"), Templates: []string{"marshal.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "rfc6979.go"), Templates: []string{"rfc6979.go.tmpl"}},
+		{File: filepath.Join(baseDir, "rfc6979_test.go"), Templates: []string{"rfc6979.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "encoding.go"), Templates: []string{"encoding.go.tmpl"}},
+		{File: filepath.Join(baseDir, "encoding_test.go"), Templates: []string{"encoding.test.go.tmpl"}},
+	}
+	if conf.Equal(config.SECP256K1) {
+		// Ethereum signatures and addresses
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "ethereum.go"), Templates: []string{"ethereum.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "ethereum_test.go"), Templates: []string{"ethereum.test.go.tmpl"}},
+		)
+	}
+	// The recovery information v of SignForRecover encodes the parity of the y
+	// coordinate of R and the quotient ⌊x/n⌋ of its x coordinate by the order n,
+	// which must fit in a uint on 32-bit platforms. This is the case on
+	// secp256k1, bn254, stark-curve and grumpkin, but not on the BLS12, BLS24
+	// and BW6 curves, whose base field is 62 to 384 bits larger than their
+	// scalar field: R cannot be recovered there, so that neither the public key
+	// recovery nor the batch verification are generated for them.
+	//
+	// On stark-curve, recovering R takes a square root in a base field of
+	// 2-adicity 192, which alone costs more than a Verify: the batch
+	// verification would be slower than verifying the signatures one by one.
+	if conf.Equal(config.SECP256K1) || conf.Equal(config.BN254) || conf.Equal(config.GRUMPKIN) {
+		// batch verification with the recovered R points
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "batch.go"), Templates: []string{"batch.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "batch_test.go"), Templates: []string{"batch.test.go.tmpl"}},
+		)
 	}
 	return bgen.Generate(conf, conf.Package, "./ecdsa/template", entries...)
 
@@ -0,0 +1,43 @@
+package ecvrf
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+// templateData is the curve configuration, with the name of the package of
+// the twisted Edwards curve since conf.Package is the one of the scheme.
+type templateData struct {
+	config.TwistedEdwardsCurve
+	CurvePackage string
+	ScalarBytes  int // size in bytes of the order of the subgroup
+	LogCofactor  int
+}
+
+func Generate(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// ecvrf
+	data := templateData{TwistedEdwardsCurve: conf, CurvePackage: conf.Package}
+	data.Package = "ecvrf"
+	order, _ := new(big.Int).SetString(conf.Order, 10)
+	data.ScalarBytes = (order.BitLen() + 7) / 8
+	cofactor, _ := new(big.Int).SetString(conf.Cofactor, 10)
+	if !cofactor.IsUint64() || bits.OnesCount64(cofactor.Uint64()) != 1 {
+		return fmt.Errorf("%s: the cofactor must be a power of 2", conf.Name)
+	}
+	data.LogCofactor = bits.TrailingZeros64(cofactor.Uint64())
+	baseDir = filepath.Join(baseDir, data.Package)
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecvrf.go"), Templates: []string{"ecvrf.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecvrf_test.go"), Templates: []string{"ecvrf.test.go.tmpl"}},
+	}
+	return bgen.Generate(data, data.Package, "./edwards/ecvrf/template", entries...)
+
+}
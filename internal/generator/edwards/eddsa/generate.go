@@ -7,10 +7,18 @@ import (
 	"github.com/consensys/gnark-crypto/internal/generator/config"
 )
 
+// templateData is the curve configuration, with the name of the package of
+// the twisted Edwards curve since conf.Package is the one of the scheme.
+type templateData struct {
+	config.TwistedEdwardsCurve
+	CurvePackage string
+}
+
 func Generate(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.BatchGenerator) error {
 	// eddsa
-	conf.Package = "eddsa"
-	baseDir = filepath.Join(baseDir, conf.Package)
+	data := templateData{TwistedEdwardsCurve: conf, CurvePackage: conf.Package}
+	data.Package = "eddsa"
+	baseDir = filepath.Join(baseDir, data.Package)
 
 	entries := []bavard.Entry{
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
@@ -18,6 +26,6 @@ func Generate(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.Batc
 		{File: filepath.Join(baseDir, "eddsa_test.go"), Templates: []string{"eddsa.test.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 	}
-	return bgen.Generate(conf, conf.Package, "./edwards/eddsa/template", entries...)
+	return bgen.Generate(data, data.Package, "./edwards/eddsa/template", entries...)
 
 }
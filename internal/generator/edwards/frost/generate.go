@@ -0,0 +1,31 @@
+package frost
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+// templateData is the curve configuration, with the name of the package of
+// the twisted Edwards curve since conf.Package is the one of the scheme.
+type templateData struct {
+	config.TwistedEdwardsCurve
+	CurvePackage string
+}
+
+func Generate(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// frost
+	data := templateData{TwistedEdwardsCurve: conf, CurvePackage: conf.Package}
+	data.Package = "frost"
+	baseDir = filepath.Join(baseDir, data.Package)
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "frost.go"), Templates: []string{"frost.go.tmpl"}},
+		{File: filepath.Join(baseDir, "keygen.go"), Templates: []string{"keygen.go.tmpl"}},
+		{File: filepath.Join(baseDir, "frost_test.go"), Templates: []string{"frost.test.go.tmpl"}},
+	}
+	return bgen.Generate(data, data.Package, "./edwards/frost/template", entries...)
+
+}
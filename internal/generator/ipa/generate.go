@@ -0,0 +1,59 @@
+package ipa
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+// templateData abstracts the group of the commitments, which is either the G1
+// of a short Weierstrass curve or a twisted Edwards curve.
+type templateData struct {
+	Package        string
+	Curve          string // name of the curve, for the documentation
+	CurvePackage   string // import path of the group
+	FrPackage      string // import path of the scalar field of the group
+	FpPackage      string // import path of the base field of a twisted Edwards curve
+	Point          string // type of the points, in affine coordinates
+	TwistedEdwards bool
+}
+
+// GenerateWeierstrass generates the inner product argument on the G1 of a curve.
+func GenerateWeierstrass(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	data := templateData{
+		Package:      "ipa",
+		Curve:        conf.Name,
+		CurvePackage: "github.com/consensys/gnark-crypto/ecc/" + conf.Name,
+		FrPackage:    "github.com/consensys/gnark-crypto/ecc/" + conf.Name + "/fr",
+		Point:        "curve.G1Affine",
+	}
+	return generate(data, baseDir, bgen)
+}
+
+// GenerateTwistedEdwards generates the inner product argument on a twisted
+// Edwards curve, whose scalar field package is generated in baseDir/../fr.
+func GenerateTwistedEdwards(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.BatchGenerator) error {
+	curvePackage := "github.com/consensys/gnark-crypto/ecc/" + conf.Name + "/" + conf.Package
+	data := templateData{
+		Package:        "ipa",
+		Curve:          conf.Package,
+		CurvePackage:   curvePackage,
+		FrPackage:      curvePackage + "/fr",
+		FpPackage:      "github.com/consensys/gnark-crypto/ecc/" + conf.Name + "/fr",
+		Point:          "curve.PointAffine",
+		TwistedEdwards: true,
+	}
+	return generate(data, baseDir, bgen)
+}
+
+func generate(data templateData, baseDir string, bgen *bavard.BatchGenerator) error {
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ipa.go"), Templates: []string{"ipa.go.tmpl"}},
+		{File: filepath.Join(baseDir, "group.go"), Templates: []string{"group.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ipa_test.go"), Templates: []string{"ipa.test.go.tmpl"}},
+	}
+	return bgen.Generate(data, data.Package, "./ipa/template/", entries...)
+}
@@ -16,6 +16,10 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "kzg_test.go"), Templates: []string{"kzg.test.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 		{File: filepath.Join(baseDir, "utils.go"), Templates: []string{"utils.go.tmpl"}},
+		{File: filepath.Join(baseDir, "fk20.go"), Templates: []string{"fk20.go.tmpl"}},
+		{File: filepath.Join(baseDir, "lagrange.go"), Templates: []string{"lagrange.go.tmpl"}},
+		{File: filepath.Join(baseDir, "vector.go"), Templates: []string{"vector.go.tmpl"}},
+		{File: filepath.Join(baseDir, "hiding.go"), Templates: []string{"hiding.go.tmpl"}},
 		{File: filepath.Join(baseDir, "mpcsetup.go"), Templates: []string{"mpcsetup.go.tmpl"}},
 	}
 	return bgen.Generate(conf, conf.Package, "./kzg/template/", entries...)
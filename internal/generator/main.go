@@ -12,28 +12,35 @@ import (
 	"github.com/consensys/bavard"
 	"github.com/consensys/gnark-crypto/field/generator"
 	fieldConfig "github.com/consensys/gnark-crypto/field/generator/config"
+	"github.com/consensys/gnark-crypto/internal/generator/bls"
+	"github.com/consensys/gnark-crypto/internal/generator/bulletproofs"
 	"github.com/consensys/gnark-crypto/internal/generator/config"
 	"github.com/consensys/gnark-crypto/internal/generator/crypto/hash/mimc"
 	"github.com/consensys/gnark-crypto/internal/generator/crypto/hash/poseidon2"
 	"github.com/consensys/gnark-crypto/internal/generator/ecc"
 	"github.com/consensys/gnark-crypto/internal/generator/ecdsa"
 	"github.com/consensys/gnark-crypto/internal/generator/edwards"
+	"github.com/consensys/gnark-crypto/internal/generator/edwards/ecvrf"
 	"github.com/consensys/gnark-crypto/internal/generator/edwards/eddsa"
+	"github.com/consensys/gnark-crypto/internal/generator/edwards/frost"
 	"github.com/consensys/gnark-crypto/internal/generator/fflonk"
 	fri "github.com/consensys/gnark-crypto/internal/generator/fri/template"
 	"github.com/consensys/gnark-crypto/internal/generator/gkr"
 	"github.com/consensys/gnark-crypto/internal/generator/hash_to_field"
 	"github.com/consensys/gnark-crypto/internal/generator/iop"
+	"github.com/consensys/gnark-crypto/internal/generator/ipa"
 	"github.com/consensys/gnark-crypto/internal/generator/kzg"
 	"github.com/consensys/gnark-crypto/internal/generator/pairing"
 	"github.com/consensys/gnark-crypto/internal/generator/pedersen"
 	"github.com/consensys/gnark-crypto/internal/generator/permutation"
 	"github.com/consensys/gnark-crypto/internal/generator/plookup"
 	"github.com/consensys/gnark-crypto/internal/generator/polynomial"
+	"github.com/consensys/gnark-crypto/internal/generator/pst"
 	"github.com/consensys/gnark-crypto/internal/generator/shplonk"
 	"github.com/consensys/gnark-crypto/internal/generator/sumcheck"
 	"github.com/consensys/gnark-crypto/internal/generator/test_vector_utils"
 	"github.com/consensys/gnark-crypto/internal/generator/tower"
+	"github.com/consensys/gnark-crypto/internal/generator/zeromorph"
 )
 
 const (
@@ -96,6 +103,9 @@ func main() {
 			// generate G1, G2, multiExp, ...
 			assertNoError(ecc.Generate(conf, curveDir, bgen))
 
+			// generate bulletproofs range proofs on G1
+			assertNoError(bulletproofs.Generate(conf, filepath.Join(curveDir, "bulletproofs"), bgen))
+
 			if conf.Equal(config.SECP256K1) {
 				return
 			}
@@ -142,6 +152,8 @@ func main() {
 			assertNoError(hash_to_field.Generate(fpInfo, filepath.Join(curveDir, "fp", "hash_to_field"), bgen))
 
 			if conf.Equal(config.GRUMPKIN) {
+				// generate the inner product argument on grumpkin
+				assertNoError(ipa.GenerateWeierstrass(conf, filepath.Join(curveDir, "ipa"), bgen))
 				return
 			}
 
@@ -154,6 +166,12 @@ func main() {
 			// generate pairing tests
 			assertNoError(pairing.Generate(conf, curveDir, bgen))
 
+			// generate bls signatures
+			// (bls12-381 has the IETF ciphersuites in a hand-written package)
+			if !conf.Equal(config.BLS12_381) {
+				assertNoError(bls.Generate(conf, curveDir, bgen))
+			}
+
 			// generate fri on fr
 			assertNoError(fri.Generate(conf, filepath.Join(curveDir, "fr", "fri"), bgen))
 
@@ -163,9 +181,15 @@ func main() {
 			// generate kzg on fr
 			assertNoError(kzg.Generate(conf, filepath.Join(curveDir, "kzg"), bgen))
 
+			// generate multilinear kzg on fr
+			assertNoError(pst.Generate(conf, filepath.Join(curveDir, "pst"), bgen))
+
 			// generate shplonk on fr
 			assertNoError(shplonk.Generate(conf, filepath.Join(curveDir, "shplonk"), bgen))
 
+			// generate zeromorph on fr
+			assertNoError(zeromorph.Generate(conf, filepath.Join(curveDir, "zeromorph"), bgen))
+
 			// generate fflonk on fr
 			assertNoError(fflonk.Generate(conf, filepath.Join(curveDir, "fflonk"), bgen))
 
@@ -197,6 +221,24 @@ func main() {
 
 			// generate eddsa on companion curves
 			assertNoError(eddsa.Generate(conf, curveDir, bgen))
+
+			// generate frost on companion curves
+			assertNoError(frost.Generate(conf, curveDir, bgen))
+
+			// generate ecvrf on companion curves
+			assertNoError(ecvrf.Generate(conf, curveDir, bgen))
+
+			if conf.Package == "bandersnatch" {
+				// generate the scalar field of bandersnatch, one directory deeper
+				// than the fields of the curves
+				frConf, err := fieldConfig.NewFieldConfig("fr", "Element", conf.Order, true)
+				assertNoError(err)
+				frAsmConfig := &fieldConfig.Assembly{BuildDir: asmDirBuildPath, IncludeDir: filepath.Join(asmDirIncludePath, "..")}
+				assertNoError(generator.GenerateFF(frConf, filepath.Join(curveDir, "fr"), generator.WithASM(frAsmConfig)))
+
+				// generate the inner product argument on bandersnatch
+				assertNoError(ipa.GenerateTwistedEdwards(conf, filepath.Join(curveDir, "ipa"), bgen))
+			}
 		}(conf)
 
 	}
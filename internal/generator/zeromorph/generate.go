@@ -0,0 +1,21 @@
+package zeromorph
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// zeromorph reduction of multilinear openings to kzg
+	conf.Package = "zeromorph"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "zeromorph.go"), Templates: []string{"zeromorph.go.tmpl"}},
+		{File: filepath.Join(baseDir, "zeromorph_test.go"), Templates: []string{"zeromorph.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./zeromorph/template/", entries...)
+
+}
@@ -0,0 +1,101 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Package rfc6979 implements the HMAC_DRBG of RFC 6979, which derives
+// deterministic nonces from a private key and a message hash. It is shared
+// by the ECDSA and ECVRF packages.
+package rfc6979
+
+import (
+	"crypto/hmac"
+	"hash"
+	"math/big"
+)
+
+// Generator is the HMAC_DRBG generating the nonces of RFC 6979 in [1, q-1].
+type Generator struct {
+	newHash func() hash.Hash
+	q       *big.Int
+	k, v    []byte
+	started bool
+}
+
+// New seeds the generator of the nonces modulo q with the private key x and
+// the message hash h1, instantiating HMAC with newHash.
+//
+// RFC 6979, Section 3.2, steps a. to f.
+func New(newHash func() hash.Hash, q, x *big.Int, h1 []byte) *Generator {
+	hLen := newHash().Size()
+	g := &Generator{
+		newHash: newHash,
+		q:       q,
+		k:       make([]byte, hLen),
+		v:       make([]byte, hLen),
+	}
+	for i := range g.v {
+		g.v[i] = 0x01
+	}
+	rLen := g.rLen()
+	bx := make([]byte, rLen)
+	x.FillBytes(bx)
+	// bits2octets(h1) = int2octets(bits2int(h1) mod q)
+	bh := make([]byte, rLen)
+	z := bits2int(h1, q.BitLen())
+	z.Mod(z, q).FillBytes(bh)
+
+	g.k = g.mac(g.k, g.v, []byte{0x00}, bx, bh)
+	g.v = g.mac(g.k, g.v)
+	g.k = g.mac(g.k, g.v, []byte{0x01}, bx, bh)
+	g.v = g.mac(g.k, g.v)
+	return g
+}
+
+// Next returns the next candidate nonce in [1, q-1]. Calling it again means
+// that the previous nonce was rejected.
+//
+// RFC 6979, Section 3.2, step h.
+func (g *Generator) Next() *big.Int {
+	rLen := g.rLen()
+	for {
+		if g.started {
+			g.k = g.mac(g.k, g.v, []byte{0x00})
+			g.v = g.mac(g.k, g.v)
+		}
+		g.started = true
+
+		t := make([]byte, 0, rLen+len(g.v))
+		for len(t) < rLen {
+			g.v = g.mac(g.k, g.v)
+			t = append(t, g.v...)
+		}
+		k := bits2int(t[:rLen], g.q.BitLen())
+		if k.Sign() > 0 && k.Cmp(g.q) < 0 {
+			return k
+		}
+	}
+}
+
+// rLen returns the size in bytes rlen/8 = ceil(qlen/8) of the integers.
+func (g *Generator) rLen() int {
+	return (g.q.BitLen() + 7) / 8
+}
+
+// mac returns HMAC_K(data[0] || … || data[n-1]).
+func (g *Generator) mac(key []byte, data ...[]byte) []byte {
+	h := hmac.New(g.newHash, key)
+	for _, b := range data {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+// bits2int returns the integer made of the qLen leftmost bits of b.
+//
+// RFC 6979, Section 2.3.2
+func bits2int(b []byte, qLen int) *big.Int {
+	res := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - qLen; excess > 0 {
+		res.Rsh(res, uint(excess))
+	}
+	return res
+}
@@ -0,0 +1,62 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package rfc6979
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"math/big"
+	"testing"
+)
+
+func TestVectors(t *testing.T) {
+	t.Parallel()
+	const (
+		// RFC 6979, Appendix A.1
+		qA1 = "4000000000000000000020108a2e0cc0d99f8a5ef"
+		// P-192 and P-256, RFC 6979, Appendices A.2.3 and A.2.5
+		qP192 = "ffffffffffffffffffffffff99def836146bc9b1b4d22831"
+		qP256 = "ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551"
+		// secp256k1, from Trezor, https://github.com/trezor/trezor-crypto
+		qSecp256k1 = "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"
+	)
+	vectors := []struct {
+		q, key, msg, nonce string
+		newHash            func() hash.Hash
+	}{
+		{qA1, "09a4d6792295a7f730fc3f2b49cbc0f62e862272f", "sample", "23af4074c90a02b3fe61d286d5c87f425e6bdd81b", sha256.New},
+		{qP192, "6fab034934e4c0fc9ae67f5b5659a9d7d1fefd187ee09fd4", "sample", "32b1b6d7d42a05cb449065727a84804fb1a3e34d8f261496", sha256.New},
+		{qP256, "c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721", "sample", "a6e3c57dd01abe90086538398355dd4c3b17aa873382b0f24d6129493d8aad60", sha256.New},
+		{qP256, "c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721", "test", "d16b6ae827f17175e040871a1c7ec3500192c4c92677336ec2537acaee0008e0", sha256.New},
+		{qP256, "c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721", "sample", "5fa81c63109badb88c1f367b47da606da28cad69aa22c4fe6ad7df73a7173aa5", sha512.New},
+		{qSecp256k1, "cca9fbcc1b41e5a95d369eaa6ddcff73b61a4efaa279cfc6567e8daa39cbaf50", "sample", "2df40ca70e639d89528a6b670d9d48d9165fdc0febc0974056bdce192b8e16a3", sha256.New},
+		{qSecp256k1, "0000000000000000000000000000000000000000000000000000000000000001", "Satoshi Nakamoto", "8f8a276c19f4149656b280621e358cce24f5f52542772691ee69063b74f15d15", sha256.New},
+		{qSecp256k1, "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140", "Satoshi Nakamoto", "33a19b60e25fb6f4435af53a3d42d493644827367e6453928554f43e49aa6f90", sha256.New},
+		{qSecp256k1, "f8b8af8ce3c7cca5e300d33939540c10d45ce001b8f252bfbc57ba0342904181", "Alan Turing", "525a82b70e67874398067543fd84c83d30c175fdc45fdeee082fe13b1d7cfdf1", sha256.New},
+		{qSecp256k1, "0000000000000000000000000000000000000000000000000000000000000001", "All those moments will be lost in time, like tears in rain. Time to die...", "38aa22d72376b4dbc472e06c3ba403ee0a394da63fc58d88686c611aba98d6b3", sha256.New},
+	}
+	for _, v := range vectors {
+		q, _ := new(big.Int).SetString(v.q, 16)
+		x, _ := new(big.Int).SetString(v.key, 16)
+		h := v.newHash()
+		h.Write([]byte(v.msg))
+		k := New(v.newHash, q, x, h.Sum(nil)).Next()
+		if k.Text(16) != new(big.Int).SetBytes(mustDecode(v.nonce)).Text(16) {
+			t.Fatalf("nonce mismatch for %q modulo %s", v.msg, v.q)
+		}
+	}
+}
+
+func mustDecode(s string) []byte {
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
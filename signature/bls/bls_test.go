@@ -0,0 +1,85 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package bls
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+func TestSigner(t *testing.T) {
+	curves := []ecc.ID{
+		ecc.BN254,
+		ecc.BLS12_381,
+		ecc.BLS12_377,
+		ecc.BW6_761,
+		ecc.BLS24_315,
+		ecc.BLS24_317,
+		ecc.BW6_633,
+	}
+	for _, curve := range curves {
+		t.Run(curve.String(), func(t *testing.T) {
+			signer, err := New(curve, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			msg := []byte("testing BLS")
+			hFunc := sha256.New()
+			sig, err := signer.Sign(msg, hFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok, err := signer.Public().Verify(sig, msg, hFunc); err != nil || !ok {
+				t.Fatal("signature should verify", err)
+			}
+			if ok, _ := signer.Public().Verify(sig, []byte("testing BLS?"), hFunc); ok {
+				t.Fatal("signature should not verify for another message")
+			}
+
+			// the serialized keys are read back by keys of another pair
+			other, err := New(curve, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if other.Public().Equal(signer.Public()) {
+				t.Fatal("two random keys should differ")
+			}
+			pk := other.Public()
+			n, err := pk.SetBytes(signer.Public().Bytes())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(signer.Public().Bytes()) {
+				t.Fatal("wrong number of bytes read for the public key")
+			}
+			if !pk.Equal(signer.Public()) {
+				t.Fatal("deserialized public key differs")
+			}
+			if ok, err := pk.Verify(sig, msg, hFunc); err != nil || !ok {
+				t.Fatal("signature should verify with the deserialized public key", err)
+			}
+
+			n, err = other.SetBytes(signer.Bytes())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(signer.Bytes()) {
+				t.Fatal("wrong number of bytes read for the private key")
+			}
+			if !other.Public().Equal(signer.Public()) {
+				t.Fatal("deserialized private key has another public key")
+			}
+			sig2, err := other.Sign(msg, hFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok, err := signer.Public().Verify(sig2, msg, hFunc); err != nil || !ok {
+				t.Fatal("signature of the deserialized private key should verify", err)
+			}
+		})
+	}
+}